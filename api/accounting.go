@@ -0,0 +1,182 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/toml5566/go_pos_backend/internal/accounting"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func (server *Server) getAccountMapping(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	mapping, err := server.accountMapping(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapping)
+}
+
+// the configured accounts of the shop or the defaults
+func (server *Server) accountMapping(ctx *gin.Context, shopName string) (db.AccountMapping, error) {
+	mapping, err := server.store.GetAccountMapping(ctx, shopName)
+	if err == sql.ErrNoRows {
+		return accounting.DefaultMapping(shopName), nil
+	}
+	return mapping, err
+}
+
+type setAccountMappingRequest struct {
+	CashClearing   string            `json:"cash_clearing" binding:"required"`
+	CardClearing   string            `json:"card_clearing" binding:"required"`
+	OtherClearing  string            `json:"other_clearing" binding:"required"`
+	Sales          string            `json:"sales" binding:"required"`
+	SalesByTaxRate map[string]string `json:"sales_by_tax_rate" binding:"dive,keys,required,endkeys,required"`
+	TaxPayable     string            `json:"tax_payable" binding:"required"`
+	RefundClearing string            `json:"refund_clearing" binding:"required"`
+	Receivable     string            `json:"receivable" binding:"required"`
+}
+
+func (server *Server) setAccountMapping(ctx *gin.Context) {
+	var req setAccountMappingRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	// keys must match tax rates as stored on orders, e.g. "5.00"
+	salesByTaxRate := map[string]string{}
+	for rate, account := range req.SalesByTaxRate {
+		value, err := strconv.ParseFloat(rate, 64)
+		if err != nil || utils.FormottedDecimalToString(value) != rate {
+			err := fmt.Errorf("tax rate %q must be written with two decimals, e.g. 5.00", rate)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		salesByTaxRate[rate] = account
+	}
+
+	rates, err := json.Marshal(salesByTaxRate)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.UpsertAccountMappingParams{
+		ShopName:       shop.Name,
+		CashClearing:   req.CashClearing,
+		CardClearing:   req.CardClearing,
+		OtherClearing:  req.OtherClearing,
+		Sales:          req.Sales,
+		SalesByTaxRate: rates,
+		TaxPayable:     req.TaxPayable,
+		RefundClearing: req.RefundClearing,
+		Receivable:     req.Receivable,
+	}
+
+	mapping, err := server.store.UpsertAccountMapping(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapping)
+}
+
+type journalQuery struct {
+	FromDate string `form:"from_date" binding:"required,datetime=2006-01-02"`
+	ToDate   string `form:"to_date" binding:"required,datetime=2006-01-02"`
+	Format   string `form:"format" binding:"omitempty,oneof=csv iif"`
+}
+
+// journal entries of the closed business days in the range, days that are
+// still open are left out because their figures can change
+func (server *Server) exportJournal(ctx *gin.Context) {
+	var query journalQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if query.ToDate < query.FromDate {
+		err := errors.New("to_date must not be before from_date")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if query.Format == "" {
+		query.Format = "csv"
+	}
+
+	shop := currentShop(ctx)
+
+	mapping, err := server.accountMapping(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	reports, err := server.store.ListZReportsByBusinessDay(ctx, db.ListZReportsByBusinessDayParams{
+		ShopName: shop.Name,
+		FromDay:  query.FromDate,
+		ToDay:    query.ToDate,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	entries := []accounting.Entry{}
+	for _, report := range reports {
+		day := accounting.Day{Report: report}
+
+		day.TaxRates, err = server.store.GetDailySalesByTaxRate(ctx, db.GetDailySalesByTaxRateParams{
+			ShopName: shop.Name,
+			OrderDay: report.BusinessDay,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		day.Tenders, err = server.store.GetDailyTenders(ctx, db.GetDailyTendersParams{
+			ShopName: shop.Name,
+			OrderDay: report.BusinessDay,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		dayEntries, err := accounting.BuildEntries(day, mapping)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		entries = append(entries, dayEntries...)
+	}
+
+	filename := fmt.Sprintf("journal_%s_%s.%s", query.FromDate, query.ToDate, query.Format)
+	if query.Format == "iif" {
+		sendAttachmentHeaders(ctx, "application/octet-stream", filename)
+		err = accounting.WriteIIF(ctx.Writer, entries)
+	} else {
+		sendAttachmentHeaders(ctx, "text/csv; charset=utf-8", filename)
+		err = accounting.WriteCSV(ctx.Writer, entries)
+	}
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+	}
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/internal/accounting"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAccountMappingDefaults(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		GetAccountMapping(gomock.Any(), gomock.Eq(shop.Name)).
+		Times(1).
+		Return(db.AccountMapping{}, sql.ErrNoRows)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/shops/%v/accounting/accounts", shop.ID)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+	server.router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res db.AccountMapping
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+	require.Equal(t, accounting.DefaultMapping(shop.Name).CashClearing, res.CashClearing)
+}
+
+func TestSetAccountMapping(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	body := func(rates gin.H) gin.H {
+		return gin.H{
+			"cash_clearing":     "1010 Cash",
+			"card_clearing":     "1020 Card",
+			"other_clearing":    "1030 Other",
+			"sales":             "4000 Sales",
+			"sales_by_tax_rate": rates,
+			"tax_payable":       "2200 VAT",
+			"refund_clearing":   "1010 Cash",
+			"receivable":        "1100 Receivable",
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body(gin.H{"5.00": "4010 Taxable Sales"}),
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertAccountMapping(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpsertAccountMappingParams) (db.AccountMapping, error) {
+						require.Equal(t, shop.Name, arg.ShopName)
+						require.JSONEq(t, `{"5.00": "4010 Taxable Sales"}`, string(arg.SalesByTaxRate))
+						return db.AccountMapping{ShopName: arg.ShopName, SalesByTaxRate: arg.SalesByTaxRate}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidTaxRate",
+			body: body(gin.H{"5%": "4010 Taxable Sales"}),
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertAccountMapping(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MissingAccount",
+			body: gin.H{"cash_clearing": "1010 Cash"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertAccountMapping(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/accounting/accounts", shop.ID)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestExportJournal(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	report := db.ZReport{ShopName: shop.Name, Number: 3, BusinessDay: "2024-01-02"}
+
+	buildStub := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetAccountMapping(gomock.Any(), gomock.Eq(shop.Name)).
+			Times(1).
+			Return(db.AccountMapping{}, sql.ErrNoRows)
+		store.EXPECT().
+			ListZReportsByBusinessDay(gomock.Any(), gomock.Eq(db.ListZReportsByBusinessDayParams{
+				ShopName: shop.Name,
+				FromDay:  "2024-01-01",
+				ToDay:    "2024-01-31",
+			})).
+			Times(1).
+			Return([]db.ZReport{report}, nil)
+		store.EXPECT().
+			GetDailySalesByTaxRate(gomock.Any(), gomock.Eq(db.GetDailySalesByTaxRateParams{ShopName: shop.Name, OrderDay: report.BusinessDay})).
+			Times(1).
+			Return([]db.GetDailySalesByTaxRateRow{
+				{TaxRate: "10.00", Sales: "50.00", Refunds: "0", Tax: "5.00", RefundedTax: "0"},
+			}, nil)
+		store.EXPECT().
+			GetDailyTenders(gomock.Any(), gomock.Eq(db.GetDailyTendersParams{ShopName: shop.Name, OrderDay: report.BusinessDay})).
+			Times(1).
+			Return([]db.GetDailyTendersRow{{Method: "cash", Amount: "55.00", Payments: 1}}, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		format        string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "CSV",
+			format: "csv",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `attachment; filename="journal_2024-01-01_2024-01-31.csv"`, recorder.Header().Get("Content-Disposition"))
+				require.Contains(t, recorder.Body.String(), "2024-01-02,Z3,Sales 2024-01-02,Cash Clearing,55.00,,cash tenders")
+			},
+		},
+		{
+			name:   "IIF",
+			format: "iif",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `attachment; filename="journal_2024-01-01_2024-01-31.iif"`, recorder.Header().Get("Content-Disposition"))
+				require.True(t, strings.HasPrefix(recorder.Body.String(), "!TRNS"))
+				require.Contains(t, recorder.Body.String(), "SPL\t\tGENERAL JOURNAL\t01/02/2024\tSales Tax Payable\t-5.00\tZ3")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			buildStub(store)
+
+			recorder := serveExport(t, store, user, shop, "journal", "from_date=2024-01-01&to_date=2024-01-31&format="+tc.format)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
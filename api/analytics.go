@@ -0,0 +1,222 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// from_date and to_date are business days of the shop
+type analyticsQuery struct {
+	FromDate string `form:"from_date" binding:"required,datetime=2006-01-02"`
+	ToDate   string `form:"to_date" binding:"required,datetime=2006-01-02"`
+}
+
+// bind the range of business days, orders are counted on the business day
+// they belong to, so a scheduled order falls on the day of its slot. writes
+// the error response and returns false when the request cannot be served
+func bindAnalyticsRange(ctx *gin.Context, query *analyticsQuery) bool {
+	if err := ctx.ShouldBindQuery(query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return false
+	}
+	if query.ToDate < query.FromDate {
+		err := errors.New("to_date must not be before from_date")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return false
+	}
+	return true
+}
+
+type productMixQuery struct {
+	analyticsQuery
+	GroupBy string `form:"group_by" binding:"omitempty,oneof=product category order_type"`
+}
+
+// quantity, revenue and revenue share of every product, menu category or
+// order type sold in the range, refunded items and fee and discount lines
+// are left out
+func (server *Server) getProductMix(ctx *gin.Context) {
+	var query productMixQuery
+
+	if !bindAnalyticsRange(ctx, &query.analyticsQuery) {
+		return
+	}
+	shop := currentShop(ctx)
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if query.GroupBy == "order_type" {
+		arg := db.GetOrderTypeMixParams{
+			ShopName: shop.Name,
+			FromDay:  query.FromDate,
+			ToDay:    query.ToDate,
+		}
+
+		mix, err := server.store.GetOrderTypeMix(ctx, arg)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, mix)
+		return
+	}
+
+	if query.GroupBy == "category" {
+		arg := db.GetCategoryMixParams{
+			ShopName: shop.Name,
+			FromDay:  query.FromDate,
+			ToDay:    query.ToDate,
+		}
+
+		mix, err := server.store.GetCategoryMix(ctx, arg)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, mix)
+		return
+	}
+
+	arg := db.GetProductMixParams{
+		ShopName: shop.Name,
+		FromDay:  query.FromDate,
+		ToDay:    query.ToDate,
+	}
+
+	mix, err := server.store.GetProductMix(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mix)
+}
+
+// sales by weekday of the business day (ISO 8601, 1 is Monday) and hour of
+// day in the shop timezone, scheduled orders count at the hour of their
+// slot. cells without sales are omitted
+func (server *Server) getSalesHeatmap(ctx *gin.Context) {
+	var query analyticsQuery
+
+	if !bindAnalyticsRange(ctx, &query) {
+		return
+	}
+	shop := currentShop(ctx)
+
+	clock, err := newShopClock(shop)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.GetSalesHeatmapParams{
+		Timezone: clock.loc.String(),
+		ShopName: shop.Name,
+		FromDay:  query.FromDate,
+		ToDay:    query.ToDate,
+	}
+
+	heatmap, err := server.store.GetSalesHeatmap(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, heatmap)
+}
+
+type salesPeriod struct {
+	FromDate   string `json:"from_date"`
+	ToDate     string `json:"to_date"`
+	Revenue    string `json:"revenue"`
+	Quantity   int64  `json:"quantity"`
+	OrderCount int64  `json:"order_count"`
+}
+
+type salesComparisonResponse struct {
+	Current  salesPeriod `json:"current"`
+	Previous salesPeriod `json:"previous"`
+	// percentage change of revenue, null when the previous period had no sales
+	RevenueChange *string `json:"revenue_change"`
+}
+
+// compare the range with the period of the same length right before it
+func (server *Server) getSalesComparison(ctx *gin.Context) {
+	var query analyticsQuery
+
+	if !bindAnalyticsRange(ctx, &query) {
+		return
+	}
+	shop := currentShop(ctx)
+
+	from, err := time.Parse("2006-01-02", query.FromDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	to, err := time.Parse("2006-01-02", query.ToDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	days := int(to.Sub(from).Hours()/24) + 1
+	previousFrom := from.AddDate(0, 0, -days)
+
+	arg := db.GetSalesComparisonParams{
+		FromDay:         query.FromDate,
+		ShopName:        shop.Name,
+		PreviousFromDay: previousFrom.Format("2006-01-02"),
+		ToDay:           query.ToDate,
+	}
+
+	comparison, err := server.store.GetSalesComparison(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := salesComparisonResponse{
+		Current: salesPeriod{
+			FromDate:   query.FromDate,
+			ToDate:     query.ToDate,
+			Revenue:    comparison.CurrentRevenue,
+			Quantity:   comparison.CurrentQuantity,
+			OrderCount: comparison.CurrentOrderCount,
+		},
+		Previous: salesPeriod{
+			FromDate:   previousFrom.Format("2006-01-02"),
+			ToDate:     from.AddDate(0, 0, -1).Format("2006-01-02"),
+			Revenue:    comparison.PreviousRevenue,
+			Quantity:   comparison.PreviousQuantity,
+			OrderCount: comparison.PreviousOrderCount,
+		},
+	}
+
+	current, err := strconv.ParseFloat(comparison.CurrentRevenue, 64)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	previous, err := strconv.ParseFloat(comparison.PreviousRevenue, 64)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if previous != 0 {
+		change := utils.FormottedDecimalToString((current - previous) / previous * 100)
+		res.RevenueChange = &change
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
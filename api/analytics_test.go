@@ -0,0 +1,282 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+// the shop is 8 hours ahead of UTC, so its days start at 16:00 UTC the day before
+func hongKongShop(user db.User) db.Shop {
+	shop := randomShop(user)
+	shop.Timezone = "Asia/Hong_Kong"
+	return shop
+}
+
+func serveAnalytics(t *testing.T, store *mockdb.MockStore, user db.User, url string) *httptest.ResponseRecorder {
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+	server.router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestGetProductMix(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := hongKongShop(user)
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "from_date=2024-03-02&to_date=2024-03-03",
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.GetProductMixParams{
+					ShopName: shop.Name,
+					FromDay:  "2024-03-02",
+					ToDay:    "2024-03-03",
+				}
+				store.EXPECT().
+					GetProductMix(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.GetProductMixRow{
+						{ProductName: "latte", Category: "drinks", Quantity: 3, Revenue: "60.00", Share: "0.7500"},
+						{ProductName: "toast", Category: "breakfast", Quantity: 1, Revenue: "20.00", Share: "0.2500"},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []db.GetProductMixRow
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Len(t, res, 2)
+				require.Equal(t, "0.7500", res[0].Share)
+			},
+		},
+		{
+			name:  "ByCategory",
+			query: "from_date=2024-03-02&to_date=2024-03-03&group_by=category",
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.GetCategoryMixParams{
+					ShopName: shop.Name,
+					FromDay:  "2024-03-02",
+					ToDay:    "2024-03-03",
+				}
+				store.EXPECT().
+					GetCategoryMix(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.GetCategoryMixRow{}, nil)
+				store.EXPECT().
+					GetProductMix(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "ByOrderType",
+			query: "from_date=2024-03-02&to_date=2024-03-03&group_by=order_type",
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.GetOrderTypeMixParams{
+					ShopName: shop.Name,
+					FromDay:  "2024-03-02",
+					ToDay:    "2024-03-03",
+				}
+				store.EXPECT().
+					GetOrderTypeMix(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.GetOrderTypeMixRow{
+						{OrderType: utils.OrderDelivery, OrderCount: 2, Quantity: 5, Revenue: "60.00", Share: "0.6000"},
+						{OrderType: utils.OrderDineIn, OrderCount: 1, Quantity: 2, Revenue: "40.00", Share: "0.4000"},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []db.GetOrderTypeMixRow
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res, 2)
+				require.Equal(t, utils.OrderDelivery, res[0].OrderType)
+			},
+		},
+		{
+			name:  "InvalidGroupBy",
+			query: "from_date=2024-03-02&to_date=2024-03-03&group_by=supplier",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProductMix(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "ToBeforeFrom",
+			query: "from_date=2024-03-03&to_date=2024-03-02",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProductMix(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidDate",
+			query: "from_date=03/02/2024&to_date=2024-03-03",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProductMix(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%v/reports/product-mix?%v", shop.ID, tc.query)
+			tc.checkResponse(t, serveAnalytics(t, store, user, url))
+		})
+	}
+}
+
+func TestGetSalesHeatmap(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := hongKongShop(user)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+
+	arg := db.GetSalesHeatmapParams{
+		Timezone: "Asia/Hong_Kong",
+		ShopName: shop.Name,
+		FromDay:  "2024-03-02",
+		ToDay:    "2024-03-02",
+	}
+	cells := []db.GetSalesHeatmapRow{
+		{DayOfWeek: 6, Hour: 8, OrderCount: 2, Quantity: 3, Revenue: "45.00"},
+	}
+	store.EXPECT().
+		GetSalesHeatmap(gomock.Any(), gomock.Eq(arg)).
+		Times(1).
+		Return(cells, nil)
+
+	url := fmt.Sprintf("/shops/%v/reports/heatmap?from_date=2024-03-02&to_date=2024-03-02", shop.ID)
+	recorder := serveAnalytics(t, store, user, url)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res []db.GetSalesHeatmapRow
+	err := json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+	require.Equal(t, cells, res)
+}
+
+func TestGetSalesComparison(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := hongKongShop(user)
+
+	arg := db.GetSalesComparisonParams{
+		FromDay:         "2024-03-10",
+		ShopName:        shop.Name,
+		PreviousFromDay: "2024-03-03",
+		ToDay:           "2024-03-16",
+	}
+
+	testCases := []struct {
+		name          string
+		row           db.GetSalesComparisonRow
+		checkResponse func(t *testing.T, res salesComparisonResponse)
+	}{
+		{
+			name: "OK",
+			row: db.GetSalesComparisonRow{
+				CurrentRevenue:     "150.00",
+				CurrentQuantity:    10,
+				CurrentOrderCount:  6,
+				PreviousRevenue:    "120.00",
+				PreviousQuantity:   8,
+				PreviousOrderCount: 5,
+			},
+			checkResponse: func(t *testing.T, res salesComparisonResponse) {
+				require.Equal(t, "2024-03-03", res.Previous.FromDate)
+				require.Equal(t, "2024-03-09", res.Previous.ToDate)
+				require.Equal(t, "150.00", res.Current.Revenue)
+				require.Equal(t, int64(5), res.Previous.OrderCount)
+				require.NotNil(t, res.RevenueChange)
+				require.Equal(t, "25.00", *res.RevenueChange)
+			},
+		},
+		{
+			name: "NoPreviousSales",
+			row: db.GetSalesComparisonRow{
+				CurrentRevenue:  "150.00",
+				PreviousRevenue: "0",
+			},
+			checkResponse: func(t *testing.T, res salesComparisonResponse) {
+				require.Nil(t, res.RevenueChange)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			store.EXPECT().
+				GetSalesComparison(gomock.Any(), gomock.Eq(arg)).
+				Times(1).
+				Return(tc.row, nil)
+
+			url := fmt.Sprintf("/shops/%v/reports/comparison?from_date=2024-03-10&to_date=2024-03-16", shop.ID)
+			recorder := serveAnalytics(t, store, user, url)
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			var res salesComparisonResponse
+			err := json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			tc.checkResponse(t, res)
+		})
+	}
+}
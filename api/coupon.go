@@ -0,0 +1,296 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+var (
+	errCouponCode   = errors.New("code must not be blank")
+	errCouponExpiry = errors.New("expires_at is in the past")
+	errCouponBatch  = errors.New("no coupon batch with that id")
+)
+
+type promotionCouponsUri struct {
+	PromotionID string `uri:"promotion_id" binding:"required,uuid"`
+}
+
+// max_redemptions and max_per_customer of 0 are unlimited, a code never
+// expires without expires_at
+type createCouponRequest struct {
+	Code           string     `json:"code" binding:"required,max=32"`
+	MaxRedemptions int32      `json:"max_redemptions" binding:"min=0"`
+	MaxPerCustomer int32      `json:"max_per_customer" binding:"min=0"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+}
+
+// writes the error response and returns false when the code would already
+// be expired
+func couponExpiry(ctx *gin.Context, expiresAt *time.Time) (sql.NullTime, bool) {
+	if expiresAt == nil {
+		return sql.NullTime{}, true
+	}
+	if !expiresAt.After(time.Now()) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errCouponExpiry))
+		return sql.NullTime{}, false
+	}
+	return sql.NullTime{Time: expiresAt.UTC(), Valid: true}, true
+}
+
+func (server *Server) createCoupon(ctx *gin.Context) {
+	var uri promotionCouponsUri
+	var req createCouponRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	code := utils.NormalizeCouponCode(req.Code)
+	if code == "" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errCouponCode))
+		return
+	}
+	expiresAt, ok := couponExpiry(ctx, req.ExpiresAt)
+	if !ok {
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	promotion, err := server.store.GetPromotion(ctx, db.GetPromotionParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.PromotionID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	coupon, err := server.store.CreateCoupon(ctx, db.CreateCouponParams{
+		ID:             uuid.New(),
+		ShopName:       shop.Name,
+		PromotionID:    promotion.ID,
+		Code:           code,
+		MaxRedemptions: req.MaxRedemptions,
+		MaxPerCustomer: req.MaxPerCustomer,
+		ExpiresAt:      expiresAt,
+	})
+	if err != nil {
+		// the insert skips codes that are taken
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(db.ErrCouponCodeTaken))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, coupon)
+}
+
+// count single use codes starting with prefix, e.g. for flyers, at most
+// 1000 at a time
+type createCouponBatchRequest struct {
+	Count          int        `json:"count" binding:"required,min=1,max=1000"`
+	Prefix         string     `json:"prefix" binding:"max=16"`
+	MaxPerCustomer int32      `json:"max_per_customer" binding:"min=0"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+}
+
+func (server *Server) createCouponBatch(ctx *gin.Context) {
+	var uri promotionCouponsUri
+	var req createCouponBatchRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	expiresAt, ok := couponExpiry(ctx, req.ExpiresAt)
+	if !ok {
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	result, err := server.store.CreateCouponBatchTx(ctx, db.CreateCouponBatchTxParams{
+		ShopName:       shop.Name,
+		PromotionID:    uuid.MustParse(uri.PromotionID),
+		Prefix:         req.Prefix,
+		Count:          req.Count,
+		MaxPerCustomer: req.MaxPerCustomer,
+		ExpiresAt:      expiresAt,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrCouponCodeTaken) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (server *Server) getCoupons(ctx *gin.Context) {
+	var uri promotionCouponsUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	coupons, err := server.store.ListCoupons(ctx, db.ListCouponsParams{
+		ShopName:    shop.Name,
+		PromotionID: uuid.MustParse(uri.PromotionID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, coupons)
+}
+
+// redemptions of the codes of a promotion, discounts is what the redeeming
+// orders were discounted by the promotion less refunds
+type couponStatsResponse struct {
+	Codes         int64  `json:"codes"`
+	ActiveCodes   int64  `json:"active_codes"`
+	RedeemedCodes int64  `json:"redeemed_codes"`
+	Redemptions   int64  `json:"redemptions"`
+	Customers     int64  `json:"customers"`
+	Discounts     string `json:"discounts"`
+}
+
+func (server *Server) getCouponStats(ctx *gin.Context) {
+	var uri promotionCouponsUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	stats, err := server.store.GetCouponStats(ctx, db.GetCouponStatsParams{
+		ShopName:    shop.Name,
+		PromotionID: uuid.MustParse(uri.PromotionID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, couponStatsResponse(stats))
+}
+
+type couponUri struct {
+	CouponID string `uri:"coupon_id" binding:"required,uuid"`
+}
+
+type setCouponActiveRequest struct {
+	Active *bool `json:"active" binding:"required"`
+}
+
+// deactivate or reactivate a single code
+func (server *Server) setCouponActive(ctx *gin.Context) {
+	var uri couponUri
+	var req setCouponActiveRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	coupon, err := server.store.SetCouponActive(ctx, db.SetCouponActiveParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.CouponID),
+		Active:   *req.Active,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, coupon)
+}
+
+type couponBatchUri struct {
+	BatchID string `uri:"batch_id" binding:"required,uuid"`
+}
+
+type couponBatchResponse struct {
+	BatchID uuid.UUID `json:"batch_id"`
+	Coupons int64     `json:"coupons"`
+	Active  bool      `json:"active"`
+}
+
+// deactivate or reactivate every code of a batch at once
+func (server *Server) setCouponBatchActive(ctx *gin.Context) {
+	var uri couponBatchUri
+	var req setCouponActiveRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	batchID := uuid.MustParse(uri.BatchID)
+	n, err := server.store.SetCouponBatchActive(ctx, db.SetCouponBatchActiveParams{
+		ShopName: shop.Name,
+		BatchID:  uuid.NullUUID{UUID: batchID, Valid: true},
+		Active:   *req.Active,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if n == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(errCouponBatch))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, couponBatchResponse{BatchID: batchID, Coupons: n, Active: *req.Active})
+}
@@ -0,0 +1,302 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateCoupon(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	promotion := db.Promotion{ID: uuid.New(), ShopName: shop.Name}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"code": " welcome10 ", "max_redemptions": 500, "max_per_customer": 1},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPromotion(gomock.Any(), gomock.Eq(db.GetPromotionParams{ShopName: shop.Name, ID: promotion.ID})).
+					Times(1).
+					Return(promotion, nil)
+				store.EXPECT().
+					CreateCoupon(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateCouponParams) (db.Coupon, error) {
+						// codes are stored upper case without surrounding spaces
+						require.Equal(t, "WELCOME10", arg.Code)
+						require.Equal(t, promotion.ID, arg.PromotionID)
+						require.Equal(t, int32(500), arg.MaxRedemptions)
+						require.Equal(t, int32(1), arg.MaxPerCustomer)
+						require.False(t, arg.BatchID.Valid)
+						require.False(t, arg.ExpiresAt.Valid)
+						return db.Coupon{ID: arg.ID, Code: arg.Code, Active: true}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "CodeTaken",
+			body: gin.H{"code": "WELCOME10"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPromotion(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(promotion, nil)
+				store.EXPECT().
+					CreateCoupon(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Coupon{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "PromotionNotFound",
+			body: gin.H{"code": "WELCOME10"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPromotion(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Promotion{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreateCoupon(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyExpired",
+			body: gin.H{"code": "WELCOME10", "expires_at": time.Now().Add(-time.Hour)},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCoupon(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BlankCode",
+			body: gin.H{"code": "   "},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCoupon(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/promotions/%s/coupons", shop.ID, promotion.ID)
+			recorder := serveKitchen(t, store, user, http.MethodPost, url, tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreateCouponBatch(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	promotionID := uuid.New()
+	expiresAt := time.Now().Add(30 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"count": 2, "prefix": "FLYER", "max_per_customer": 1, "expires_at": expiresAt},
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.CreateCouponBatchTxParams{
+					ShopName:       shop.Name,
+					PromotionID:    promotionID,
+					Prefix:         "FLYER",
+					Count:          2,
+					MaxPerCustomer: 1,
+					ExpiresAt:      sql.NullTime{Time: expiresAt, Valid: true},
+				}
+				batchID := uuid.New()
+				store.EXPECT().
+					CreateCouponBatchTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.CouponBatchTxResult{
+						BatchID: batchID,
+						Coupons: []db.Coupon{
+							{ID: uuid.New(), Code: "FLYER7K2M9QXA", BatchID: uuid.NullUUID{UUID: batchID, Valid: true}, MaxRedemptions: 1},
+							{ID: uuid.New(), Code: "FLYERC4PW8ZTR", BatchID: uuid.NullUUID{UUID: batchID, Valid: true}, MaxRedemptions: 1},
+						},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.CouponBatchTxResult
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.Coupons, 2)
+			},
+		},
+		{
+			name: "TooMany",
+			body: gin.H{"count": 5000},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCouponBatchTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "PromotionNotFound",
+			body: gin.H{"count": 10},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCouponBatchTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CouponBatchTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/promotions/%s/coupons/batch", shop.ID, promotionID)
+			recorder := serveKitchen(t, store, user, http.MethodPost, url, tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetCouponStats(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	promotionID := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		GetCouponStats(gomock.Any(), gomock.Eq(db.GetCouponStatsParams{ShopName: shop.Name, PromotionID: promotionID})).
+		Times(1).
+		Return(db.GetCouponStatsRow{Codes: 100, ActiveCodes: 90, RedeemedCodes: 12, Redemptions: 12, Customers: 11, Discounts: "36.00"}, nil)
+
+	url := fmt.Sprintf("/shops/%s/promotions/%s/coupons/stats", shop.ID, promotionID)
+	recorder := serveKitchen(t, store, user, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res couponStatsResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	require.Equal(t, int64(12), res.RedeemedCodes)
+	require.Equal(t, "36.00", res.Discounts)
+}
+
+func TestSetCouponBatchActive(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	batchID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		updated       int64
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			updated: 250,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res couponBatchResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, int64(250), res.Coupons)
+				require.False(t, res.Active)
+			},
+		},
+		{
+			name: "NotFound",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			arg := db.SetCouponBatchActiveParams{
+				ShopName: shop.Name,
+				BatchID:  uuid.NullUUID{UUID: batchID, Valid: true},
+				Active:   false,
+			}
+			store.EXPECT().
+				SetCouponBatchActive(gomock.Any(), gomock.Eq(arg)).
+				Times(1).
+				Return(tc.updated, nil)
+
+			url := fmt.Sprintf("/shops/%s/coupon-batches/%s/active", shop.ID, batchID)
+			recorder := serveKitchen(t, store, user, http.MethodPatch, url, gin.H{"active": false})
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
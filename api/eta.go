@@ -0,0 +1,146 @@
+package api
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/eta"
+	"github.com/toml5566/go_pos_backend/internal/event"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type orderETA struct {
+	OrderID     uuid.UUID `json:"order_id"`
+	ReadyAt     time.Time `json:"ready_at"`
+	WaitSeconds int64     `json:"wait_seconds"`
+}
+
+func newOrderETA(orderID uuid.UUID, readyAt, now time.Time) orderETA {
+	return orderETA{
+		OrderID:     orderID,
+		ReadyAt:     readyAt,
+		WaitSeconds: int64(readyAt.Sub(now).Round(time.Second) / time.Second),
+	}
+}
+
+// estimate when every order with open kitchen tickets will be ready, from the
+// prep times of their items and the queue at each station
+func (server *Server) estimateOrders(ctx *gin.Context, shopName string, now time.Time) (map[uuid.UUID]time.Time, error) {
+	rows, err := server.store.ListOpenKitchenTicketPrep(ctx, shopName)
+	if err != nil {
+		return nil, err
+	}
+
+	return eta.Estimate(now, eta.NewTickets(rows)), nil
+}
+
+// tell order tracking screens the new estimates, soonest first
+func (server *Server) publishOrderETAs(shopName string, ready map[uuid.UUID]time.Time, now time.Time) {
+	etas := make([]orderETA, 0, len(ready))
+	for orderID, readyAt := range ready {
+		etas = append(etas, newOrderETA(orderID, readyAt, now))
+	}
+	sort.Slice(etas, func(i, j int) bool {
+		return etas[i].ReadyAt.Before(etas[j].ReadyAt)
+	})
+
+	server.hub.Publish(event.Event{
+		Type:     event.TypeETAUpdated,
+		ShopName: shopName,
+		Data:     etas,
+	})
+}
+
+// the queue changed, recompute every open order. the change itself is
+// already saved, so a failure only leaves the screens with older estimates
+func (server *Server) refreshOrderETAs(ctx *gin.Context, shopName string) {
+	now := time.Now()
+	ready, err := server.estimateOrders(ctx, shopName, now)
+	if err != nil {
+		log.Println("cannot estimate orders:", err)
+		return
+	}
+
+	server.publishOrderETAs(shopName, ready, now)
+}
+
+type orderTrackingUri struct {
+	ShopName string `uri:"shop_name" binding:"required,alphanum,min=1"`
+	OrderID  string `uri:"order_id" binding:"required,uuid"`
+}
+
+// readiness is only known in shops with kitchen stations, elsewhere an order
+// is never ready and has no estimate
+type orderTrackingResponse struct {
+	OrderID uuid.UUID `json:"order_id"`
+	Ready   bool      `json:"ready"`
+	ETA     *orderETA `json:"eta"`
+}
+
+// public view for the customer waiting on their order
+func (server *Server) getOrderTracking(ctx *gin.Context) {
+	var uri orderTrackingUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	orderID := uuid.MustParse(uri.OrderID)
+	res := orderTrackingResponse{OrderID: orderID}
+
+	tickets, err := server.store.ListKitchenTicketsByOrderID(ctx, db.ListKitchenTicketsByOrderIDParams{
+		ShopName: uri.ShopName,
+		OrderID:  orderID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if len(tickets) == 0 {
+		items, err := server.store.GetOrdersByOrderID(ctx, db.GetOrdersByOrderIDParams{
+			ShopName: uri.ShopName,
+			OrderID:  orderID,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if len(items) == 0 {
+			ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
+	res.Ready = true
+	for _, ticket := range tickets {
+		if ticket.Status != utils.TicketBumped {
+			res.Ready = false
+		}
+	}
+
+	if !res.Ready {
+		now := time.Now()
+		ready, err := server.estimateOrders(ctx, uri.ShopName, now)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if readyAt, ok := ready[orderID]; ok {
+			e := newOrderETA(orderID, readyAt, now)
+			res.ETA = &e
+		}
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
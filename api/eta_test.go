@@ -0,0 +1,151 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetOrderTracking(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	bar := randomStation(shop, false)
+	grill := randomStation(shop, true)
+	orderID := uuid.New()
+
+	barTicket := randomKitchenTicket(bar, orderID, utils.TicketBumped)
+	grillTicket := randomKitchenTicket(grill, orderID, utils.TicketOpen)
+
+	expectTickets := func(store *mockdb.MockStore, tickets ...db.KitchenTicket) {
+		store.EXPECT().
+			ListKitchenTicketsByOrderID(gomock.Any(), gomock.Eq(db.ListKitchenTicketsByOrderIDParams{ShopName: shop.Name, OrderID: orderID})).
+			Times(1).
+			Return(tickets, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Preparing",
+			buildStub: func(store *mockdb.MockStore) {
+				expectTickets(store, barTicket, grillTicket)
+				// another order is ahead at the grill
+				store.EXPECT().
+					ListOpenKitchenTicketPrep(gomock.Any(), gomock.Eq(shop.Name)).
+					Times(1).
+					Return([]db.ListOpenKitchenTicketPrepRow{
+						{ID: uuid.New(), OrderID: uuid.New(), StationID: grill.ID, ReleaseAt: time.Now(), PrepSeconds: 120},
+						{ID: grillTicket.ID, OrderID: orderID, StationID: grill.ID, ReleaseAt: time.Now(), PrepSeconds: 180},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res orderTrackingResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.False(t, res.Ready)
+				require.NotNil(t, res.ETA)
+				require.InDelta(t, 300, res.ETA.WaitSeconds, 1)
+			},
+		},
+		{
+			name: "Ready",
+			buildStub: func(store *mockdb.MockStore) {
+				bumped := grillTicket
+				bumped.Status = utils.TicketBumped
+				expectTickets(store, barTicket, bumped)
+				store.EXPECT().
+					ListOpenKitchenTicketPrep(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res orderTrackingResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.True(t, res.Ready)
+				require.Nil(t, res.ETA)
+			},
+		},
+		{
+			name: "NoKitchen",
+			buildStub: func(store *mockdb.MockStore) {
+				expectTickets(store)
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Order{{OrderID: orderID}}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res orderTrackingResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.False(t, res.Ready)
+				require.Nil(t, res.ETA)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStub: func(store *mockdb.MockStore) {
+				expectTickets(store)
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Order{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListKitchenTicketsByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// public, no authorization header
+			url := fmt.Sprintf("/%v/order/%v/tracking", shop.Name, orderID)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/toml5566/go_pos_backend/internal/event"
+	"github.com/toml5566/go_pos_backend/internal/schedule"
+)
+
+// a station's screen only follows the tickets of that station
+type eventQuery struct {
+	StationID string `form:"station_id" binding:"omitempty,uuid"`
+}
+
+// stream the shop's events to the client as server-sent events
+func (server *Server) streamEvents(ctx *gin.Context) {
+	var query eventQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var stationID uuid.UUID
+	if query.StationID != "" {
+		stationID = uuid.MustParse(query.StationID)
+	}
+
+	shop := currentShop(ctx)
+
+	events, unsubscribe := server.hub.Subscribe(shop.Name)
+	defer unsubscribe()
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case e, ok := <-events:
+			if !ok {
+				return false
+			}
+			if stationID != uuid.Nil && !forStation(e, stationID) {
+				return true
+			}
+			ctx.SSEvent(e.Type, e)
+			return true
+		}
+	})
+}
+
+func forStation(e event.Event, stationID uuid.UUID) bool {
+	switch ticket := e.Data.(type) {
+	case kitchenTicketResponse:
+		return ticket.StationID == stationID
+	case schedule.ReleasedTicket:
+		return ticket.StationID == stationID
+	}
+	return false
+}
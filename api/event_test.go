@@ -0,0 +1,123 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/internal/event"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStreamEvents(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+
+	server := newTestServer(t, store)
+	httpServer := httptest.NewServer(server.router)
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	url := fmt.Sprintf("%v/shops/%v/events", httpServer.URL, shop.ID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	// the subscription exists once the response headers are sent
+	server.hub.Publish(event.Event{Type: "other", ShopName: "otherShop"})
+	server.hub.Publish(event.Event{Type: event.TypeStockLow, ShopName: shop.Name})
+
+	scanner := bufio.NewScanner(res.Body)
+	require.True(t, scanner.Scan())
+	require.Equal(t, "event:"+event.TypeStockLow, scanner.Text())
+	require.True(t, scanner.Scan())
+	require.True(t, strings.HasPrefix(scanner.Text(), "data:"))
+	require.Contains(t, scanner.Text(), shop.Name)
+}
+
+func TestStreamEventsUnauthorized(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/shops/%v/events", shop.ID)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, "unauthorizatedUser", time.Minute)
+
+	server.router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestStreamEventsStation(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	bar, grill := uuid.New(), uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+
+	server := newTestServer(t, store)
+	httpServer := httptest.NewServer(server.router)
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	url := fmt.Sprintf("%v/shops/%v/events?station_id=%v", httpServer.URL, shop.ID, bar)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	// only tickets of the bar reach the bar's screen
+	server.hub.Publish(event.Event{Type: event.TypeStockLow, ShopName: shop.Name})
+	server.publishKitchenTickets(shop.Name, []db.StationTicket{
+		{KitchenTicket: db.KitchenTicket{ID: uuid.New(), StationID: grill, Released: true}},
+		{KitchenTicket: db.KitchenTicket{ID: uuid.New(), StationID: bar, Released: true}},
+	})
+
+	scanner := bufio.NewScanner(res.Body)
+	require.True(t, scanner.Scan())
+	require.Equal(t, "event:"+event.TypeTicketCreated, scanner.Text())
+	require.True(t, scanner.Scan())
+	require.Contains(t, scanner.Text(), bar.String())
+	require.NotContains(t, scanner.Text(), grill.String())
+}
@@ -0,0 +1,318 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/export"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// rows fetched per query while streaming order lines
+const exportPageSize = 500
+
+type exportQuery struct {
+	orderHistoryFilter
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+}
+
+// bind the request and return the shop for its locale and timezone, writes
+// the error response and returns false on failure
+func bindExport(ctx *gin.Context, query *exportQuery) (db.Shop, bool) {
+	if err := ctx.ShouldBindQuery(query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Shop{}, false
+	}
+	if query.Format == "" {
+		query.Format = export.FormatCSV
+	}
+
+	return currentShop(ctx), true
+}
+
+// write the download headers and open the file writer on the response body,
+// once this returns the status is sent and errors can only abort the stream
+func startExport(ctx *gin.Context, shop db.Shop, name string, query exportQuery) (export.Writer, error) {
+	locale := utils.LookupLocale(shop.Locale)
+	filename := fmt.Sprintf("%s_%s_%s.%s", name, query.FromDate, query.ToDate, query.Format)
+
+	var writer export.Writer
+	var err error
+	if query.Format == export.FormatXLSX {
+		writer, err = export.NewXLSXWriter(ctx.Writer, name, locale)
+	} else {
+		writer = export.NewCSVWriter(ctx.Writer, locale)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sendAttachmentHeaders(ctx, writer.ContentType(), filename)
+	return writer, nil
+}
+
+func sendAttachmentHeaders(ctx *gin.Context, contentType, filename string) {
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Status(http.StatusOK)
+}
+
+// the status is already sent, the error is only recorded and the download
+// ends early, an xlsx file is then unreadable because its zip is incomplete
+func abortExport(ctx *gin.Context, err error) {
+	_ = ctx.Error(err)
+	ctx.Abort()
+}
+
+// stream every order line matching the order history filters, oldest first
+func (server *Server) exportOrders(ctx *gin.Context) {
+	var query exportQuery
+
+	shop, ok := bindExport(ctx, &query)
+	if !ok {
+		return
+	}
+
+	arg, err := query.params(shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	arg.SortBy = db.OrderHistorySortCreatedAt
+	arg.PageSize = exportPageSize
+
+	loc, err := time.LoadLocation(shop.Timezone)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the first page is read before the headers so a failing query still
+	// gets a proper error response
+	orders, err := server.store.ListOrderHistory(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	writer, err := startExport(ctx, shop, "orders", query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = writer.WriteRow(
+		export.Text("Business Day"), export.Text("Created At"), export.Text("Order ID"),
+		export.Text("Item ID"), export.Text("Product"), export.Text("Unit Price"),
+		export.Text("Quantity"), export.Text("Line Total"), export.Text("Tax Rate"),
+		export.Text("Status"),
+	)
+	if err != nil {
+		abortExport(ctx, err)
+		return
+	}
+
+	for len(orders) > 0 {
+		for _, order := range orders {
+			if err := writeOrderRow(writer, order, loc); err != nil {
+				abortExport(ctx, err)
+				return
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			abortExport(ctx, err)
+			return
+		}
+
+		if len(orders) < exportPageSize {
+			break
+		}
+
+		last := orders[len(orders)-1]
+		arg.Cursor = &db.OrderHistoryCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+
+		orders, err = server.store.ListOrderHistory(ctx, arg)
+		if err != nil {
+			abortExport(ctx, err)
+			return
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		abortExport(ctx, err)
+		return
+	}
+}
+
+func writeOrderRow(writer export.Writer, order db.Order, loc *time.Location) error {
+	day, err := time.Parse("2006-01-02", order.OrderDay)
+	if err != nil {
+		return err
+	}
+	price, err := strconv.ParseFloat(order.ProductPrice, 64)
+	if err != nil {
+		return err
+	}
+
+	return writer.WriteRow(
+		export.Date(day),
+		export.DateTime(order.CreatedAt.In(loc)),
+		export.Text(order.OrderID.String()),
+		export.Text(order.ID.String()),
+		export.Text(order.ProductName),
+		export.Decimal(order.ProductPrice),
+		export.Int(int64(order.Amount)),
+		export.Decimal(utils.FormottedDecimalToString(price*float64(order.Amount))),
+		export.Decimal(order.TaxRate),
+		export.Text(order.Status),
+	)
+}
+
+// one row per business day in the range, only the date filters apply
+func (server *Server) exportDailySales(ctx *gin.Context) {
+	var query exportQuery
+
+	shop, ok := bindExport(ctx, &query)
+	if !ok {
+		return
+	}
+	if query.ToDate < query.FromDate {
+		err := errors.New("to_date must not be before from_date")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListDailySalesParams{
+		ShopName: shop.Name,
+		FromDay:  query.FromDate,
+		ToDay:    query.ToDate,
+	}
+
+	days, err := server.store.ListDailySales(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	writer, err := startExport(ctx, shop, "daily_sales", query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = writer.WriteRow(
+		export.Text("Business Day"), export.Text("Gross Sales"), export.Text("Discounts"),
+		export.Text("Refunds"), export.Text("Net Sales"), export.Text("Tax"),
+		export.Text("Orders"), export.Text("Average Ticket"),
+	)
+	if err != nil {
+		abortExport(ctx, err)
+		return
+	}
+
+	for _, row := range days {
+		summary, err := db.SummarizeDailySales(shop.Name, row.OrderDay, db.GetDailySalesRow{
+			GrossSales: row.GrossSales,
+			Discounts:  row.Discounts,
+			Refunds:    row.Refunds,
+			Tax:        row.Tax,
+			OrderCount: row.OrderCount,
+		})
+		if err != nil {
+			abortExport(ctx, err)
+			return
+		}
+		day, err := time.Parse("2006-01-02", row.OrderDay)
+		if err != nil {
+			abortExport(ctx, err)
+			return
+		}
+
+		err = writer.WriteRow(
+			export.Date(day),
+			export.Decimal(summary.GrossSales),
+			export.Decimal(summary.Discounts),
+			export.Decimal(summary.Refunds),
+			export.Decimal(summary.NetSales),
+			export.Decimal(summary.Tax),
+			export.Int(summary.OrderCount),
+			export.Decimal(summary.AverageTicket),
+		)
+		if err != nil {
+			abortExport(ctx, err)
+			return
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		abortExport(ctx, err)
+		return
+	}
+}
+
+// product mix of the business days in the range, only the date filters apply
+func (server *Server) exportProductMix(ctx *gin.Context) {
+	var query exportQuery
+
+	shop, ok := bindExport(ctx, &query)
+	if !ok {
+		return
+	}
+
+	if query.ToDate < query.FromDate {
+		err := errors.New("to_date must not be before from_date")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.GetProductMixParams{
+		ShopName: shop.Name,
+		FromDay:  query.FromDate,
+		ToDay:    query.ToDate,
+	}
+
+	mix, err := server.store.GetProductMix(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	writer, err := startExport(ctx, shop, "product_mix", query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = writer.WriteRow(
+		export.Text("Product"), export.Text("Category"), export.Text("Quantity"),
+		export.Text("Revenue"), export.Text("Share"),
+	)
+	if err != nil {
+		abortExport(ctx, err)
+		return
+	}
+
+	for _, row := range mix {
+		err := writer.WriteRow(
+			export.Text(row.ProductName),
+			export.Text(row.Category),
+			export.Int(row.Quantity),
+			export.Decimal(row.Revenue),
+			export.Decimal(row.Share),
+		)
+		if err != nil {
+			abortExport(ctx, err)
+			return
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		abortExport(ctx, err)
+		return
+	}
+}
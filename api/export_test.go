@@ -0,0 +1,196 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func serveExport(t *testing.T, store *mockdb.MockStore, user db.User, shop db.Shop, path, query string) *httptest.ResponseRecorder {
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/shops/%v/exports/%v?%v", shop.ID, path, query)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+	server.router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestExportOrders(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	orders := randomOrderHistory(shop, exportPageSize+1)
+
+	arg := db.OrderHistoryParams{
+		ShopName:  shop.Name,
+		FromDay:   "2022-01-01",
+		ToDay:     "2022-01-31",
+		Status:    "Pending",
+		MaxAmount: math.MaxInt32,
+		SortBy:    db.OrderHistorySortCreatedAt,
+		PageSize:  exportPageSize,
+	}
+
+	testCases := []struct {
+		name          string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStub: func(store *mockdb.MockStore) {
+
+				last := orders[exportPageSize-1]
+				next := arg
+				next.Cursor = &db.OrderHistoryCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+
+				gomock.InOrder(
+					store.EXPECT().
+						ListOrderHistory(gomock.Any(), gomock.Eq(arg)).
+						Times(1).
+						Return(orders[:exportPageSize], nil),
+					store.EXPECT().
+						ListOrderHistory(gomock.Any(), gomock.Eq(next)).
+						Times(1).
+						Return(orders[exportPageSize:], nil),
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				require.Equal(t, `attachment; filename="orders_2022-01-01_2022-01-31.csv"`, recorder.Header().Get("Content-Disposition"))
+
+				records, err := csv.NewReader(recorder.Body).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, records, len(orders)+1)
+				require.Equal(t, "Business Day", records[0][0])
+
+				first := records[1]
+				require.Equal(t, "01/01/2022", first[0])
+				require.Equal(t, orders[0].ID.String(), first[3])
+				require.Equal(t, orders[0].ProductPrice, first[5])
+			},
+		},
+		{
+			name: "InternalError",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListOrderHistory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Empty(t, recorder.Header().Get("Content-Disposition"))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			recorder := serveExport(t, store, user, shop, "orders", "from_date=2022-01-01&to_date=2022-01-31&status=Pending")
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestExportDailySalesXLSX(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+
+	arg := db.ListDailySalesParams{
+		ShopName: shop.Name,
+		FromDay:  "2024-01-01",
+		ToDay:    "2024-01-31",
+	}
+	store.EXPECT().
+		ListDailySales(gomock.Any(), gomock.Eq(arg)).
+		Times(1).
+		Return([]db.ListDailySalesRow{
+			{OrderDay: "2024-01-02", GrossSales: "120.00", Discounts: "0.00", Refunds: "20.00", Tax: "5.00", OrderCount: 4},
+		}, nil)
+
+	recorder := serveExport(t, store, user, shop, "daily-sales", "from_date=2024-01-01&to_date=2024-01-31&format=xlsx")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", recorder.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="daily_sales_2024-01-01_2024-01-31.xlsx"`, recorder.Header().Get("Content-Disposition"))
+
+	body := recorder.Body.Bytes()
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(t, err)
+
+	var sheet bytes.Buffer
+	for _, f := range archive.File {
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		r, err := f.Open()
+		require.NoError(t, err)
+		_, err = sheet.ReadFrom(r)
+		require.NoError(t, err)
+		r.Close()
+	}
+
+	// net sales and average ticket are derived from the aggregate row
+	require.Contains(t, sheet.String(), "<v>100.00</v>")
+	require.Contains(t, sheet.String(), "<v>25.00</v>")
+}
+
+func TestExportProductMixLocale(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := hongKongShop(user)
+	shop.Locale = "de-DE"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+
+	arg := db.GetProductMixParams{
+		ShopName: shop.Name,
+		FromDay:  "2024-03-02",
+		ToDay:    "2024-03-02",
+	}
+	store.EXPECT().
+		GetProductMix(gomock.Any(), gomock.Eq(arg)).
+		Times(1).
+		Return([]db.GetProductMixRow{
+			{ProductName: "latte", Category: "drinks", Quantity: 3, Revenue: "60.00", Share: "1.0000"},
+		}, nil)
+
+	recorder := serveExport(t, store, user, shop, "product-mix", "from_date=2024-03-02&to_date=2024-03-02")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "Product;Category;Quantity;Revenue;Share\nlatte;drinks;3;60,00;1,0000\n", recorder.Body.String())
+}
@@ -0,0 +1,245 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/event"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type fulfilmentUri struct {
+	OrderID string `uri:"order_id" binding:"required,uuid"`
+}
+
+// next_statuses are the statuses the order can be moved to from its current one
+type fulfilmentResponse struct {
+	db.OrderFulfilment
+	NextStatuses []string `json:"next_statuses"`
+}
+
+func newFulfilmentResponse(fulfilment db.OrderFulfilment) fulfilmentResponse {
+	next := utils.NextFulfilmentStatuses(fulfilment.OrderType, fulfilment.Status)
+	if next == nil {
+		next = []string{}
+	}
+	return fulfilmentResponse{OrderFulfilment: fulfilment, NextStatuses: next}
+}
+
+func (server *Server) getOrderFulfilment(ctx *gin.Context) {
+	var uri fulfilmentUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	fulfilment, err := server.store.GetOrderFulfilment(ctx, db.GetOrderFulfilmentParams{
+		ShopName: shop.Name,
+		OrderID:  uuid.MustParse(uri.OrderID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newFulfilmentResponse(fulfilment))
+}
+
+type setFulfilmentStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+var errFulfilmentMoved = errors.New("order status was changed in the meantime")
+
+// move an order along the status flow of its type, e.g. a delivery order
+// goes out for delivery once it is ready
+func (server *Server) setFulfilmentStatus(ctx *gin.Context) {
+	var uri fulfilmentUri
+	var req setFulfilmentStatusRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	orderID := uuid.MustParse(uri.OrderID)
+	fulfilment, err := server.store.GetOrderFulfilment(ctx, db.GetOrderFulfilmentParams{
+		ShopName: shop.Name,
+		OrderID:  orderID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !utils.CanMoveFulfilment(fulfilment.OrderType, fulfilment.Status, req.Status) {
+		err := fmt.Errorf("a %s order cannot move from %s to %s", fulfilment.OrderType, fulfilment.Status, req.Status)
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+		return
+	}
+
+	fulfilment, err = server.store.SetOrderFulfilmentStatus(ctx, db.SetOrderFulfilmentStatusParams{
+		Status:     req.Status,
+		ShopName:   shop.Name,
+		OrderID:    orderID,
+		FromStatus: fulfilment.Status,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(errFulfilmentMoved))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := newFulfilmentResponse(fulfilment)
+	server.hub.Publish(event.Event{
+		Type:     event.TypeOrderStatus,
+		ShopName: shop.Name,
+		Data:     res,
+	})
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type listFulfilmentsQuery struct {
+	OrderType string `form:"order_type" binding:"omitempty,oneof=dine_in takeaway pickup delivery"`
+}
+
+// orders not yet served, collected or delivered, pickups in the order they
+// are collected
+func (server *Server) getActiveFulfilments(ctx *gin.Context) {
+	var query listFulfilmentsQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	fulfilments, err := server.store.ListActiveFulfilments(ctx, db.ListActiveFulfilmentsParams{
+		ShopName:  shop.Name,
+		OrderType: query.OrderType,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]fulfilmentResponse, 0, len(fulfilments))
+	for _, fulfilment := range fulfilments {
+		res = append(res, newFulfilmentResponse(fulfilment))
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// amount is a price for flat fees and a percentage of the round for percent
+// fees, negative amounts are discounts
+type createOrderTypeFeeRequest struct {
+	OrderType string  `json:"order_type" binding:"required,oneof=dine_in takeaway pickup delivery"`
+	Name      string  `json:"name" binding:"required"`
+	Kind      string  `json:"kind" binding:"required,oneof=flat percent"`
+	Amount    float64 `json:"amount" binding:"required"`
+	TaxRate   float64 `json:"tax_rate" binding:"min=0,max=100"`
+}
+
+var errFeePercent = errors.New("a percent fee must be between -100 and 100")
+
+func (server *Server) createOrderTypeFee(ctx *gin.Context) {
+	var req createOrderTypeFeeRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Kind == utils.FeePercent && (req.Amount < -100 || req.Amount > 100) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errFeePercent))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	fee, err := server.store.CreateOrderTypeFee(ctx, db.CreateOrderTypeFeeParams{
+		ID:        uuid.New(),
+		ShopName:  shop.Name,
+		OrderType: req.OrderType,
+		Name:      req.Name,
+		Kind:      req.Kind,
+		Amount:    utils.FormottedDecimalToString(req.Amount),
+		TaxRate:   utils.FormottedDecimalToString(req.TaxRate),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, fee)
+}
+
+func (server *Server) getOrderTypeFees(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	fees, err := server.store.ListOrderTypeFees(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, fees)
+}
+
+type orderTypeFeeUri struct {
+	FeeID string `uri:"fee_id" binding:"required,uuid"`
+}
+
+func (server *Server) deleteOrderTypeFee(ctx *gin.Context) {
+	var uri orderTypeFeeUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	err := server.store.DeleteOrderTypeFee(ctx, db.DeleteOrderTypeFeeParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.FeeID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, textResponse("delete successfully"))
+}
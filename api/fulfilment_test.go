@@ -0,0 +1,297 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func randomFulfilment(shop db.Shop, orderType, status string) db.OrderFulfilment {
+	return db.OrderFulfilment{
+		ID:        uuid.New(),
+		ShopName:  shop.Name,
+		OrderID:   uuid.New(),
+		OrderType: orderType,
+		Status:    status,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func TestGetOrderFulfilment(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	fulfilment := randomFulfilment(shop, utils.OrderDelivery, utils.FulfilmentReady)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		GetOrderFulfilment(gomock.Any(), gomock.Eq(db.GetOrderFulfilmentParams{ShopName: shop.Name, OrderID: fulfilment.OrderID})).
+		Times(1).
+		Return(fulfilment, nil)
+
+	url := fmt.Sprintf("/shops/%s/orders/%s/fulfilment", shop.ID, fulfilment.OrderID)
+	recorder := serveKitchen(t, store, user, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res fulfilmentResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	require.Equal(t, fulfilment.OrderID, res.OrderID)
+	require.Equal(t, []string{utils.FulfilmentOutForDelivery, utils.FulfilmentDelivered, utils.FulfilmentCancelled}, res.NextStatuses)
+}
+
+func TestSetFulfilmentStatus(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	testCases := []struct {
+		name          string
+		fulfilment    db.OrderFulfilment
+		status        string
+		buildStub     func(store *mockdb.MockStore, fulfilment db.OrderFulfilment)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OutForDelivery",
+			fulfilment: randomFulfilment(shop, utils.OrderDelivery, utils.FulfilmentReady),
+			status:     utils.FulfilmentOutForDelivery,
+			buildStub: func(store *mockdb.MockStore, fulfilment db.OrderFulfilment) {
+				store.EXPECT().
+					GetOrderFulfilment(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(fulfilment, nil)
+				moved := fulfilment
+				moved.Status = utils.FulfilmentOutForDelivery
+				store.EXPECT().
+					SetOrderFulfilmentStatus(gomock.Any(), gomock.Eq(db.SetOrderFulfilmentStatusParams{
+						Status:     utils.FulfilmentOutForDelivery,
+						ShopName:   shop.Name,
+						OrderID:    fulfilment.OrderID,
+						FromStatus: utils.FulfilmentReady,
+					})).
+					Times(1).
+					Return(moved, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res fulfilmentResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, utils.FulfilmentOutForDelivery, res.Status)
+				require.Equal(t, []string{utils.FulfilmentDelivered, utils.FulfilmentCancelled}, res.NextStatuses)
+			},
+		},
+		{
+			name:       "NotInFlow",
+			fulfilment: randomFulfilment(shop, utils.OrderPickup, utils.FulfilmentReady),
+			status:     utils.FulfilmentOutForDelivery,
+			buildStub: func(store *mockdb.MockStore, fulfilment db.OrderFulfilment) {
+				store.EXPECT().
+					GetOrderFulfilment(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(fulfilment, nil)
+				store.EXPECT().
+					SetOrderFulfilmentStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:       "MovedMeanwhile",
+			fulfilment: randomFulfilment(shop, utils.OrderTakeaway, utils.FulfilmentPreparing),
+			status:     utils.FulfilmentReady,
+			buildStub: func(store *mockdb.MockStore, fulfilment db.OrderFulfilment) {
+				store.EXPECT().
+					GetOrderFulfilment(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(fulfilment, nil)
+				store.EXPECT().
+					SetOrderFulfilmentStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OrderFulfilment{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:       "NotFound",
+			fulfilment: randomFulfilment(shop, utils.OrderTakeaway, utils.FulfilmentPreparing),
+			status:     utils.FulfilmentReady,
+			buildStub: func(store *mockdb.MockStore, fulfilment db.OrderFulfilment) {
+				store.EXPECT().
+					GetOrderFulfilment(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OrderFulfilment{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store, tc.fulfilment)
+
+			url := fmt.Sprintf("/shops/%s/orders/%s/fulfilment/status", shop.ID, tc.fulfilment.OrderID)
+			recorder := serveKitchen(t, store, user, http.MethodPut, url, gin.H{"status": tc.status})
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetActiveFulfilments(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	fulfilment := randomFulfilment(shop, utils.OrderPickup, utils.FulfilmentPreparing)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		ListActiveFulfilments(gomock.Any(), gomock.Eq(db.ListActiveFulfilmentsParams{ShopName: shop.Name, OrderType: utils.OrderPickup})).
+		Times(1).
+		Return([]db.OrderFulfilment{fulfilment}, nil)
+
+	url := fmt.Sprintf("/shops/%s/fulfilments?order_type=pickup", shop.ID)
+	recorder := serveKitchen(t, store, user, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res []fulfilmentResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	require.Len(t, res, 1)
+	require.Equal(t, fulfilment.ID, res[0].ID)
+}
+
+func TestCreateOrderTypeFee(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"order_type": utils.OrderTakeaway, "name": "Packaging", "kind": utils.FeeFlat, "amount": 0.5},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTypeFee(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateOrderTypeFeeParams) (db.OrderTypeFee, error) {
+						require.Equal(t, shop.Name, arg.ShopName)
+						require.Equal(t, "0.50", arg.Amount)
+						require.Equal(t, "0.00", arg.TaxRate)
+						return db.OrderTypeFee{ID: arg.ID, ShopName: arg.ShopName, OrderType: arg.OrderType, Name: arg.Name, Kind: arg.Kind, Amount: arg.Amount}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "PercentOutOfRange",
+			body: gin.H{"order_type": utils.OrderDelivery, "name": "Service", "kind": utils.FeePercent, "amount": 120},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTypeFee(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownOrderType",
+			body: gin.H{"order_type": "drive_through", "name": "Lane", "kind": utils.FeeFlat, "amount": 1},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTypeFee(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateName",
+			body: gin.H{"order_type": utils.OrderTakeaway, "name": "Packaging", "kind": utils.FeeFlat, "amount": 0.5},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTypeFee(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OrderTypeFee{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/order-type-fees", shop.ID)
+			recorder := serveKitchen(t, store, user, http.MethodPost, url, tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteOrderTypeFee(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	feeID := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		DeleteOrderTypeFee(gomock.Any(), gomock.Eq(db.DeleteOrderTypeFeeParams{ShopName: shop.Name, ID: feeID})).
+		Times(1).
+		Return(nil)
+
+	url := fmt.Sprintf("/shops/%s/order-type-fees/%s", shop.ID, feeID)
+	recorder := serveKitchen(t, store, user, http.MethodDelete, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
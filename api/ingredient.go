@@ -0,0 +1,206 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type ingredientUri struct {
+	IngredientID string `uri:"ingredient_id" binding:"required,uuid"`
+}
+
+type createIngredientRequest struct {
+	Name string `json:"name" binding:"required"`
+	Unit string `json:"unit" binding:"required"`
+}
+
+func (server *Server) createIngredient(ctx *gin.Context) {
+	var req createIngredientRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !utils.IsValidUnit(req.Unit) {
+		err := fmt.Errorf("unsupported unit: %s", req.Unit)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.CreateIngredientParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		Name:     req.Name,
+		Unit:     req.Unit,
+	}
+
+	ingredient, err := server.store.CreateIngredient(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ingredient)
+}
+
+func (server *Server) getIngredients(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	ingredients, err := server.store.ListIngredients(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ingredients)
+}
+
+type createIngredientMovementRequest struct {
+	MovementType string  `json:"movement_type" binding:"required"`
+	Quantity     float64 `json:"quantity"`
+	Unit         string  `json:"unit"` // optional, defaults to the ingredient's unit
+	Note         string  `json:"note"`
+}
+
+func (server *Server) createIngredientMovement(ctx *gin.Context) {
+	var uri ingredientUri
+	var req createIngredientMovementRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := validateStockMovement(req.MovementType, req.Quantity); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	ingredient, err := server.store.GetIngredient(ctx, db.GetIngredientParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.IngredientID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	quantity := req.Quantity
+	if req.Unit != "" {
+		quantity, err = utils.ConvertUnit(req.Quantity, req.Unit, ingredient.Unit)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	arg := db.IngredientMovementTxParams{
+		ShopName:     shop.Name,
+		IngredientID: ingredient.ID,
+		MovementType: req.MovementType,
+		Quantity:     quantity,
+		Note:         req.Note,
+	}
+
+	result, err := server.store.IngredientMovementTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (server *Server) getIngredientMovements(ctx *gin.Context) {
+	var uri ingredientUri
+	var query pageQuery
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.ListIngredientMovementsParams{
+		ShopName:     shop.Name,
+		IngredientID: uuid.MustParse(uri.IngredientID),
+		Limit:        query.PageSize,
+		Offset:       (query.PageID - 1) * query.PageSize,
+	}
+
+	movements, err := server.store.ListIngredientMovements(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, movements)
+}
+
+type dateRangeQuery struct {
+	FromDate time.Time `form:"from_date" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	ToDate   time.Time `form:"to_date" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+}
+
+// compare recipe depletion with the usage implied by stock counts, waste
+// and adjustments between the business days from_date and to_date (both
+// inclusive) of the shop
+func (server *Server) getIngredientUsageReport(ctx *gin.Context) {
+	var query analyticsQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	clock, err := newShopClock(shop)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	from, to, err := clock.dayRange(query.FromDate, query.ToDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.GetIngredientUsageReportParams{
+		FromTime: from,
+		ToTime:   to,
+		ShopName: shop.Name,
+	}
+
+	report, err := server.store.GetIngredientUsageReport(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,368 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/token"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func randomIngredient(shop db.Shop, unit string) db.Ingredient {
+	return db.Ingredient{
+		ID:        uuid.New(),
+		ShopName:  shop.Name,
+		Name:      utils.RandString(6),
+		Unit:      unit,
+		OnHand:    "1000.000",
+		CreatedAt: time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCreateIngredient(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	ingredient := randomIngredient(shop, utils.UnitGram)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"name": ingredient.Name,
+				"unit": ingredient.Unit,
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIngredient(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(ingredient, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.Ingredient
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, ingredient, res)
+			},
+		},
+		{
+			name: "UnsupportedUnit",
+			body: gin.H{
+				"name": ingredient.Name,
+				"unit": "oz",
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIngredient(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/ingredients", shop.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(jsonData))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreateIngredientMovement(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	ingredient := randomIngredient(shop, utils.UnitGram)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "ConvertsUnit",
+			body: gin.H{
+				"movement_type": utils.MovementReceive,
+				"quantity":      2.5,
+				"unit":          utils.UnitKilogram,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIngredient(gomock.Any(), gomock.Eq(db.GetIngredientParams{ShopName: shop.Name, ID: ingredient.ID})).
+					Times(1).
+					Return(ingredient, nil)
+
+				arg := db.IngredientMovementTxParams{
+					ShopName:     shop.Name,
+					IngredientID: ingredient.ID,
+					MovementType: utils.MovementReceive,
+					Quantity:     2500,
+				}
+				store.EXPECT().
+					IngredientMovementTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientMovementTxResult{Ingredient: ingredient}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "IncompatibleUnit",
+			body: gin.H{
+				"movement_type": utils.MovementReceive,
+				"quantity":      1,
+				"unit":          utils.UnitLiter,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIngredient(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(ingredient, nil)
+				store.EXPECT().
+					IngredientMovementTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "IngredientNotFound",
+			body: gin.H{
+				"movement_type": utils.MovementWaste,
+				"quantity":      10,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIngredient(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Ingredient{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizatedUser",
+			body: gin.H{
+				"movement_type": utils.MovementWaste,
+				"quantity":      10,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorizatedUser", time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					IngredientMovementTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/ingredients/%v/movements", shop.ID, ingredient.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(jsonData))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetIngredientUsageReport(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	ingredient := randomIngredient(shop, utils.UnitMilliliter)
+
+	report := []db.GetIngredientUsageReportRow{
+		{
+			IngredientID:     ingredient.ID,
+			Name:             ingredient.Name,
+			Unit:             ingredient.Unit,
+			TheoreticalUsage: "1200.000",
+			ActualUsage:      "1350.000",
+			Variance:         "150.000",
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "from_date=2022-01-01&to_date=2022-01-31",
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.GetIngredientUsageReportParams{
+					FromTime: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC),
+					ToTime:   time.Date(2022, time.February, 1, 0, 0, 0, 0, time.UTC),
+					ShopName: shop.Name,
+				}
+				store.EXPECT().
+					GetIngredientUsageReport(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(report, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []db.GetIngredientUsageReportRow
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, report, res)
+			},
+		},
+		{
+			name:  "InvertedRange",
+			query: "from_date=2022-02-01&to_date=2022-01-01",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIngredientUsageReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingDates",
+			query: "",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIngredientUsageReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shops/%v/reports/ingredient-usage?%v", shop.ID, tc.query)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetIngredientUsageReportBusinessDay(t *testing.T) {
+	// a bar whose business day runs from 04:00 to 04:00 the next morning
+	user, _ := randomUser(t)
+	shop := hongKongShop(user)
+	shop.BusinessDayCutoff = 240
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+
+	arg := db.GetIngredientUsageReportParams{
+		FromTime: time.Date(2021, time.December, 31, 20, 0, 0, 0, time.UTC),
+		ToTime:   time.Date(2022, time.January, 31, 20, 0, 0, 0, time.UTC),
+		ShopName: shop.Name,
+	}
+	store.EXPECT().
+		GetIngredientUsageReport(gomock.Any(), gomock.Eq(arg)).
+		Times(1).
+		Return([]db.GetIngredientUsageReportRow{}, nil)
+
+	url := fmt.Sprintf("/shops/%v/reports/ingredient-usage?from_date=2022-01-01&to_date=2022-01-31", shop.ID)
+	recorder := serveAnalytics(t, store, user, url)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
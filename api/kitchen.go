@@ -0,0 +1,429 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/event"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type createStationRequest struct {
+	Name      string `json:"name" binding:"required"`
+	IsDefault bool   `json:"is_default"` // receives items no route matches, one per shop
+}
+
+func (server *Server) createStation(ctx *gin.Context) {
+	var req createStationRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	station, err := server.store.CreateStation(ctx, db.CreateStationParams{
+		ID:        uuid.New(),
+		ShopName:  shop.Name,
+		Name:      req.Name,
+		IsDefault: req.IsDefault,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, station)
+}
+
+func (server *Server) getStations(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	stations, err := server.store.ListStations(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stations)
+}
+
+type stationUri struct {
+	StationID string `uri:"station_id" binding:"required,uuid"`
+}
+
+// deleting a station drops its routes and tickets
+func (server *Server) deleteStation(ctx *gin.Context) {
+	var uri stationUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	deleted, err := server.store.DeleteStation(ctx, db.DeleteStationParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.StationID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, textResponse("delete successfully"))
+}
+
+// route either a menu catalog or a single product to a station
+type setStationRouteRequest struct {
+	StationID uuid.UUID `json:"station_id" binding:"required"`
+	Catalog   string    `json:"catalog" binding:"required_without=ProductID,excluded_with=ProductID"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+func (server *Server) setStationRoute(ctx *gin.Context) {
+	var req setStationRouteRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	_, err := server.store.GetStation(ctx, db.GetStationParams{
+		ShopName: shop.Name,
+		ID:       req.StationID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var route db.StationRoute
+	if req.Catalog != "" {
+		route, err = server.store.UpsertCatalogRoute(ctx, db.UpsertCatalogRouteParams{
+			ID:        uuid.New(),
+			ShopName:  shop.Name,
+			StationID: req.StationID,
+			Catalog:   sql.NullString{String: req.Catalog, Valid: true},
+		})
+	} else {
+		route, err = server.store.UpsertProductRoute(ctx, db.UpsertProductRouteParams{
+			ID:        uuid.New(),
+			ShopName:  shop.Name,
+			StationID: req.StationID,
+			ProductID: uuid.NullUUID{UUID: req.ProductID, Valid: true},
+		})
+	}
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, route)
+}
+
+func (server *Server) getStationRoutes(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	routes, err := server.store.ListStationRoutes(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, routes)
+}
+
+type stationRouteUri struct {
+	RouteID string `uri:"route_id" binding:"required,uuid"`
+}
+
+func (server *Server) deleteStationRoute(ctx *gin.Context) {
+	var uri stationRouteUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	deleted, err := server.store.DeleteStationRoute(ctx, db.DeleteStationRouteParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.RouteID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, textResponse("delete successfully"))
+}
+
+type kitchenTicketItem struct {
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	ProductName string    `json:"product_name"`
+	Amount      int32     `json:"amount"`
+	Status      string    `json:"status"`
+}
+
+type kitchenTicketResponse struct {
+	db.KitchenTicket
+	Items []kitchenTicketItem `json:"items"`
+}
+
+func newKitchenTicketResponse(ticket db.StationTicket) kitchenTicketResponse {
+	res := kitchenTicketResponse{KitchenTicket: ticket.KitchenTicket, Items: []kitchenTicketItem{}}
+	for _, orderItem := range ticket.Items {
+		res.Items = append(res.Items, kitchenTicketItem{
+			OrderItemID: orderItem.ID,
+			ProductName: orderItem.ProductName,
+			Amount:      orderItem.Amount,
+			Status:      orderItem.Status,
+		})
+	}
+	return res
+}
+
+// attach the items of every ticket, tickets keep their order
+func (server *Server) kitchenTicketResponses(ctx *gin.Context, tickets []db.KitchenTicket) ([]kitchenTicketResponse, error) {
+	res := make([]kitchenTicketResponse, len(tickets))
+	index := make(map[uuid.UUID]int, len(tickets))
+	ids := make([]uuid.UUID, len(tickets))
+
+	for i, ticket := range tickets {
+		res[i] = kitchenTicketResponse{KitchenTicket: ticket, Items: []kitchenTicketItem{}}
+		index[ticket.ID] = i
+		ids[i] = ticket.ID
+	}
+	if len(tickets) == 0 {
+		return res, nil
+	}
+
+	items, err := server.store.ListKitchenTicketItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		i := index[item.TicketID]
+		res[i].Items = append(res[i].Items, kitchenTicketItem{
+			OrderItemID: item.OrderItemID,
+			ProductName: item.ProductName,
+			Amount:      item.Amount,
+			Status:      item.Status,
+		})
+	}
+
+	return res, nil
+}
+
+// the station view of the kitchen, oldest tickets first
+type kitchenTicketsQuery struct {
+	StationID string `form:"station_id" binding:"omitempty,uuid"`
+	Status    string `form:"status,default=open" binding:"oneof=open bumped"`
+	PageSize  int32  `form:"page_size,default=50" binding:"min=1,max=200"`
+}
+
+func (server *Server) getKitchenTickets(ctx *gin.Context) {
+	var query kitchenTicketsQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.ListKitchenTicketsParams{
+		ShopName: shop.Name,
+		Status:   query.Status,
+		PageSize: query.PageSize,
+	}
+	if query.StationID != "" {
+		arg.StationID = uuid.MustParse(query.StationID)
+	}
+
+	tickets, err := server.store.ListKitchenTickets(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res, err := server.kitchenTicketResponses(ctx, tickets)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type kitchenOrderUri struct {
+	OrderID string `uri:"order_id" binding:"required,uuid"`
+}
+
+type kitchenOrderResponse struct {
+	OrderID uuid.UUID               `json:"order_id"`
+	Ready   bool                    `json:"ready"` // every station has bumped its ticket
+	Tickets []kitchenTicketResponse `json:"tickets"`
+}
+
+func (server *Server) getKitchenOrder(ctx *gin.Context) {
+	var uri kitchenOrderUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	orderID := uuid.MustParse(uri.OrderID)
+	tickets, err := server.store.ListKitchenTicketsByOrderID(ctx, db.ListKitchenTicketsByOrderIDParams{
+		ShopName: shop.Name,
+		OrderID:  orderID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if len(tickets) == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	res := kitchenOrderResponse{OrderID: orderID, Ready: true}
+	for _, ticket := range tickets {
+		if ticket.Status != utils.TicketBumped {
+			res.Ready = false
+		}
+	}
+
+	res.Tickets, err = server.kitchenTicketResponses(ctx, tickets)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type kitchenTicketUri struct {
+	TicketID string `uri:"ticket_id" binding:"required,uuid"`
+}
+
+type bumpKitchenTicketResponse struct {
+	Ticket     db.KitchenTicket `json:"ticket"`
+	OrderReady bool             `json:"order_ready"`
+}
+
+// mark the ticket done at its station, the order is ready once the last of
+// its tickets is bumped
+func (server *Server) bumpKitchenTicket(ctx *gin.Context) {
+	server.setKitchenTicketStatus(ctx, utils.TicketBumped)
+}
+
+// bring a bumped ticket back to the station's open tickets
+func (server *Server) recallKitchenTicket(ctx *gin.Context) {
+	server.setKitchenTicketStatus(ctx, utils.TicketOpen)
+}
+
+func (server *Server) setKitchenTicketStatus(ctx *gin.Context, status string) {
+	var uri kitchenTicketUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	ticket, err := server.store.SetKitchenTicketStatus(ctx, db.SetKitchenTicketStatusParams{
+		Status:   status,
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.TicketID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	open, err := server.store.CountOpenKitchenTickets(ctx, db.CountOpenKitchenTicketsParams{
+		ShopName: shop.Name,
+		OrderID:  ticket.OrderID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	eventType := event.TypeTicketBumped
+	if status == utils.TicketOpen {
+		eventType = event.TypeTicketRecalled
+	}
+	server.hub.Publish(event.Event{
+		Type:     eventType,
+		ShopName: shop.Name,
+		Data:     kitchenTicketResponse{KitchenTicket: ticket},
+	})
+
+	res := bumpKitchenTicketResponse{Ticket: ticket, OrderReady: open == 0}
+	if res.OrderReady {
+		server.hub.Publish(event.Event{
+			Type:     event.TypeOrderReady,
+			ShopName: shop.Name,
+			Data:     kitchenOrderResponse{OrderID: ticket.OrderID, Ready: true},
+		})
+	}
+	server.refreshOrderETAs(ctx, shop.Name)
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// tell the kitchen feed about the tickets of a new order, tickets of a
+// scheduled order are announced when they are released
+func (server *Server) publishKitchenTickets(shopName string, tickets []db.StationTicket) {
+	for _, ticket := range tickets {
+		if !ticket.Released {
+			continue
+		}
+		server.hub.Publish(event.Event{
+			Type:     event.TypeTicketCreated,
+			ShopName: shopName,
+			Data:     newKitchenTicketResponse(ticket),
+		})
+	}
+}
@@ -0,0 +1,526 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func randomStation(shop db.Shop, isDefault bool) db.Station {
+	return db.Station{
+		ID:        uuid.New(),
+		ShopName:  shop.Name,
+		Name:      utils.RandString(6),
+		IsDefault: isDefault,
+		CreatedAt: time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func randomKitchenTicket(station db.Station, orderID uuid.UUID, status string) db.KitchenTicket {
+	return db.KitchenTicket{
+		ID:        uuid.New(),
+		ShopName:  station.ShopName,
+		OrderID:   orderID,
+		StationID: station.ID,
+		Status:    status,
+		CreatedAt: time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+		ReleaseAt: time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+		Released:  true,
+	}
+}
+
+func serveKitchen(t *testing.T, store *mockdb.MockStore, user db.User, method, url string, body gin.H) *httptest.ResponseRecorder {
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+	server.router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestCreateStation(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	station := randomStation(shop, true)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": station.Name, "is_default": true},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateStation(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateStationParams) (db.Station, error) {
+						require.Equal(t, shop.Name, arg.ShopName)
+						require.Equal(t, station.Name, arg.Name)
+						require.True(t, arg.IsDefault)
+						return station, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.Station
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, station, res)
+			},
+		},
+		{
+			name: "SecondDefault",
+			body: gin.H{"name": station.Name, "is_default": true},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateStation(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Station{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "MissingName",
+			body: gin.H{"is_default": true},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateStation(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%v/stations", shop.ID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPost, url, tc.body))
+		})
+	}
+}
+
+func TestSetStationRoute(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	station := randomStation(shop, false)
+	productID := uuid.New()
+
+	expectStation := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetStation(gomock.Any(), gomock.Eq(db.GetStationParams{ShopName: shop.Name, ID: station.ID})).
+			Times(1).
+			Return(station, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Catalog",
+			body: gin.H{"station_id": station.ID, "catalog": "drinks"},
+			buildStub: func(store *mockdb.MockStore) {
+				expectStation(store)
+				store.EXPECT().
+					UpsertCatalogRoute(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpsertCatalogRouteParams) (db.StationRoute, error) {
+						require.Equal(t, station.ID, arg.StationID)
+						require.Equal(t, sql.NullString{String: "drinks", Valid: true}, arg.Catalog)
+						return db.StationRoute{ID: arg.ID, ShopName: arg.ShopName, StationID: arg.StationID, Catalog: arg.Catalog}, nil
+					})
+				store.EXPECT().
+					UpsertProductRoute(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Product",
+			body: gin.H{"station_id": station.ID, "product_id": productID},
+			buildStub: func(store *mockdb.MockStore) {
+				expectStation(store)
+				store.EXPECT().
+					UpsertProductRoute(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpsertProductRouteParams) (db.StationRoute, error) {
+						require.Equal(t, uuid.NullUUID{UUID: productID, Valid: true}, arg.ProductID)
+						return db.StationRoute{ID: arg.ID, ShopName: arg.ShopName, StationID: arg.StationID, ProductID: arg.ProductID}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "CatalogAndProduct",
+			body: gin.H{"station_id": station.ID, "catalog": "drinks", "product_id": productID},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetStation(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NeitherCatalogNorProduct",
+			body: gin.H{"station_id": station.ID},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetStation(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "StationOfOtherShop",
+			body: gin.H{"station_id": station.ID, "catalog": "drinks"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetStation(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Station{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpsertCatalogRoute(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%v/station-routes", shop.ID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPut, url, tc.body))
+		})
+	}
+}
+
+func TestGetKitchenTickets(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	bar := randomStation(shop, false)
+	orderID := uuid.New()
+	tickets := []db.KitchenTicket{
+		randomKitchenTicket(bar, orderID, utils.TicketOpen),
+		randomKitchenTicket(bar, uuid.New(), utils.TicketOpen),
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Station",
+			query: "station_id=" + bar.ID.String(),
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.ListKitchenTicketsParams{
+					ShopName:  shop.Name,
+					Status:    utils.TicketOpen,
+					StationID: bar.ID,
+					PageSize:  50,
+				}
+				store.EXPECT().
+					ListKitchenTickets(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(tickets, nil)
+				store.EXPECT().
+					ListKitchenTicketItems(gomock.Any(), gomock.Eq([]uuid.UUID{tickets[0].ID, tickets[1].ID})).
+					Times(1).
+					Return([]db.ListKitchenTicketItemsRow{
+						{TicketID: tickets[1].ID, OrderItemID: uuid.New(), ProductName: "latte", Amount: 2, Status: "Pending"},
+						{TicketID: tickets[0].ID, OrderItemID: uuid.New(), ProductName: "mocha", Amount: 1, Status: "Pending"},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []kitchenTicketResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res, 2)
+				require.Equal(t, tickets[0].ID, res[0].ID)
+				require.Equal(t, "mocha", res[0].Items[0].ProductName)
+				require.Equal(t, "latte", res[1].Items[0].ProductName)
+			},
+		},
+		{
+			name:  "AllStationsBumped",
+			query: "status=bumped",
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.ListKitchenTicketsParams{
+					ShopName: shop.Name,
+					Status:   utils.TicketBumped,
+					PageSize: 50,
+				}
+				store.EXPECT().
+					ListKitchenTickets(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.KitchenTicket{}, nil)
+				store.EXPECT().
+					ListKitchenTicketItems(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "[]", recorder.Body.String())
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			query: "status=cooking",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListKitchenTickets(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%v/kitchen/tickets?%v", shop.ID, tc.query)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodGet, url, nil))
+		})
+	}
+}
+
+func TestBumpKitchenTicket(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	station := randomStation(shop, true)
+	ticket := randomKitchenTicket(station, uuid.New(), utils.TicketOpen)
+
+	bumped := ticket
+	bumped.Status = utils.TicketBumped
+	bumped.BumpedAt = sql.NullTime{Time: time.Date(2022, time.January, 1, 12, 5, 0, 0, time.UTC), Valid: true}
+
+	testCases := []struct {
+		name          string
+		action        string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "LastTicket",
+			action: "bump",
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.SetKitchenTicketStatusParams{
+					Status:   utils.TicketBumped,
+					ShopName: shop.Name,
+					ID:       ticket.ID,
+				}
+				store.EXPECT().
+					SetKitchenTicketStatus(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(bumped, nil)
+				store.EXPECT().
+					CountOpenKitchenTickets(gomock.Any(), gomock.Eq(db.CountOpenKitchenTicketsParams{ShopName: shop.Name, OrderID: ticket.OrderID})).
+					Times(1).
+					Return(int64(0), nil)
+				store.EXPECT().
+					ListOpenKitchenTicketPrep(gomock.Any(), gomock.Eq(shop.Name)).
+					Times(1).
+					Return([]db.ListOpenKitchenTicketPrepRow{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res bumpKitchenTicketResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, utils.TicketBumped, res.Ticket.Status)
+				require.True(t, res.OrderReady)
+			},
+		},
+		{
+			name:   "OtherStationsOpen",
+			action: "bump",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetKitchenTicketStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(bumped, nil)
+				store.EXPECT().
+					CountOpenKitchenTickets(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
+				store.EXPECT().
+					ListOpenKitchenTicketPrep(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListOpenKitchenTicketPrepRow{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res bumpKitchenTicketResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.False(t, res.OrderReady)
+			},
+		},
+		{
+			name:   "Recall",
+			action: "recall",
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.SetKitchenTicketStatusParams{
+					Status:   utils.TicketOpen,
+					ShopName: shop.Name,
+					ID:       ticket.ID,
+				}
+				store.EXPECT().
+					SetKitchenTicketStatus(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(ticket, nil)
+				store.EXPECT().
+					CountOpenKitchenTickets(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
+				store.EXPECT().
+					ListOpenKitchenTicketPrep(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListOpenKitchenTicketPrepRow{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "NotFound",
+			action: "bump",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetKitchenTicketStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.KitchenTicket{}, sql.ErrNoRows)
+				store.EXPECT().
+					CountOpenKitchenTickets(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%v/kitchen/tickets/%v/%v", shop.ID, ticket.ID, tc.action)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPost, url, nil))
+		})
+	}
+}
+
+func TestGetKitchenOrder(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	bar := randomStation(shop, false)
+	grill := randomStation(shop, true)
+	orderID := uuid.New()
+
+	tickets := []db.KitchenTicket{
+		randomKitchenTicket(bar, orderID, utils.TicketBumped),
+		randomKitchenTicket(grill, orderID, utils.TicketOpen),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		ListKitchenTicketsByOrderID(gomock.Any(), gomock.Eq(db.ListKitchenTicketsByOrderIDParams{ShopName: shop.Name, OrderID: orderID})).
+		Times(1).
+		Return(tickets, nil)
+	store.EXPECT().
+		ListKitchenTicketItems(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.ListKitchenTicketItemsRow{}, nil)
+
+	url := fmt.Sprintf("/shops/%v/kitchen/orders/%v", shop.ID, orderID)
+	recorder := serveKitchen(t, store, user, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res kitchenOrderResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	require.Equal(t, orderID, res.OrderID)
+	// the grill has not bumped its ticket yet
+	require.False(t, res.Ready)
+	require.Len(t, res.Tickets, 2)
+}
@@ -1,22 +1,21 @@
 package api
 
 import (
-	"errors"
+	"database/sql"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	db "github.com/toml5566/go_pos_backend/internal/database"
-	"github.com/toml5566/go_pos_backend/token"
 	"github.com/toml5566/go_pos_backend/utils"
 )
 
+// the product comes from the organisation catalogue, its name and price are
+// copied onto the menu unless the shop overrides them
 type addMenuItemRequest struct {
-	UserID       uuid.UUID `json:"user_id" binding:"required"`
-	ShopName     string    `json:"shop_name" binding:"required"`
 	ProductID    uuid.UUID `json:"product_id" binding:"required"`
-	ProductName  string    `json:"product_name" binding:"required"`
-	ProductPrice float64   `json:"product_price" binding:"required"`
+	ProductName  string    `json:"product_name"`
+	ProductPrice *float64  `json:"product_price" binding:"omitempty,min=0"`
 	Catalog      string    `json:"catalog" binding:"required"`
 	Description  string    `json:"description" binding:"required"`
 }
@@ -29,23 +28,36 @@ func (server *Server) addMenuItem(ctx *gin.Context) {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if authPayload.Username != req.ShopName {
-		err := errors.New("unauthorizated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	shop := currentShop(ctx)
+
+	product, err := server.store.GetProduct(ctx, db.GetProductParams{
+		OrganisationID: shop.OrganisationID,
+		ID:             req.ProductID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
 	arg := db.AddMenuItemParams{
 		ID:           uuid.New(),
-		UserID:       req.UserID,
-		ShopName:     req.ShopName,
-		ProductID:    req.ProductID,
-		ProductName:  req.ProductName,
-		ProductPrice: utils.FormottedDecimalToString(req.ProductPrice),
+		ShopName:     shop.Name,
+		ProductID:    product.ID,
+		ProductName:  product.Name,
+		ProductPrice: product.Price,
 		Catalog:      req.Catalog,
 		Description:  req.Description,
 	}
+	if req.ProductName != "" {
+		arg.ProductName = req.ProductName
+	}
+	if req.ProductPrice != nil {
+		arg.ProductPrice = utils.FormottedDecimalToString(*req.ProductPrice)
+	}
 
 	menuItem, err := server.store.AddMenuItem(ctx, arg)
 	if err != nil {
@@ -56,34 +68,35 @@ func (server *Server) addMenuItem(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, menuItem)
 }
 
+type menuItemUri struct {
+	MenuItemID string `uri:"menu_item_id" binding:"required,uuid"`
+}
+
 type updateMenuItemRequest struct {
-	ID           uuid.UUID `json:"id" binding:"required"`
-	UserID       uuid.UUID `json:"user_id" binding:"required"`
-	ShopName     string    `json:"shop_name" binding:"required"`
-	ProductName  string    `json:"product_name" binding:"required"`
-	ProductPrice float64   `json:"product_price" binding:"required"`
-	Catalog      string    `json:"catalog" binding:"required"`
-	Description  string    `json:"description" binding:"required"`
+	ProductName  string  `json:"product_name" binding:"required"`
+	ProductPrice float64 `json:"product_price" binding:"required"`
+	Catalog      string  `json:"catalog" binding:"required"`
+	Description  string  `json:"description" binding:"required"`
 }
 
 func (server *Server) updateMenuItem(ctx *gin.Context) {
+	var uri menuItemUri
 	var req updateMenuItemRequest
 
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+	if err := ctx.ShouldBindUri(&uri); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if authPayload.Username != req.ShopName {
-		err := errors.New("unauthorizated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	shop := currentShop(ctx)
+
 	arg := db.UpdateMenuItemParams{
-		UserID:       req.UserID,
-		ID:           req.ID,
+		ShopName:     shop.Name,
+		ID:           uuid.MustParse(uri.MenuItemID),
 		ProductName:  req.ProductName,
 		ProductPrice: utils.FormottedDecimalToString(req.ProductPrice),
 		Catalog:      req.Catalog,
@@ -92,6 +105,10 @@ func (server *Server) updateMenuItem(ctx *gin.Context) {
 
 	updatedItem, err := server.store.UpdateMenuItem(ctx, arg)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -100,30 +117,19 @@ func (server *Server) updateMenuItem(ctx *gin.Context) {
 
 }
 
-type deleteMenuItemRequest struct {
-	ID       uuid.UUID `json:"id" binding:"required"`
-	UserID   uuid.UUID `json:"user_id" binding:"required"`
-	ShopName string    `json:"shop_name" binding:"required"`
-}
-
 func (server *Server) deleteMenuItem(ctx *gin.Context) {
-	var req deleteMenuItemRequest
+	var uri menuItemUri
 
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+	if err := ctx.ShouldBindUri(&uri); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if authPayload.Username != req.ShopName {
-		err := errors.New("unauthorizated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
+	shop := currentShop(ctx)
 
 	arg := db.DeleteMenuItemParams{
-		UserID: req.UserID,
-		ID:     req.ID,
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.MenuItemID),
 	}
 
 	err := server.store.DeleteMenuItem(ctx, arg)
@@ -135,6 +141,44 @@ func (server *Server) deleteMenuItem(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, textResponse("delete successfully"))
 }
 
+type setMenuItemAvailabilityRequest struct {
+	Available *bool `json:"available" binding:"required"`
+}
+
+func (server *Server) setMenuItemAvailability(ctx *gin.Context) {
+	var uri menuItemUri
+	var req setMenuItemAvailabilityRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.SetMenuItemAvailabilityParams{
+		ShopName:  shop.Name,
+		ID:        uuid.MustParse(uri.MenuItemID),
+		Available: *req.Available,
+	}
+
+	menuItem, err := server.store.SetMenuItemAvailability(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, menuItem)
+}
+
 type getAllMenuItemsUri struct {
 	ShopName string `uri:"shop_name" binding:"required"`
 }
@@ -23,17 +23,17 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
-func createMenuItem(user db.User, product db.Product, catalog string) db.Menu {
+func createMenuItem(shop db.Shop, product db.Product, catalog string) db.Menu {
 	return db.Menu{
 		ID:           uuid.New(),
-		UserID:       user.ID,
-		ShopName:     user.Username,
+		ShopName:     shop.Name,
 		ProductID:    product.ID,
 		ProductName:  product.Name,
 		ProductPrice: product.Price,
 		Catalog:      catalog,
 		Description:  product.Description,
 		CreatedAt:    time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+		Available:    true,
 	}
 }
 
@@ -68,27 +68,27 @@ func requireBodyMatchMenuItem(t *testing.T, body *bytes.Buffer, menuItem db.Menu
 	err = json.Unmarshal(data, &resMenuItem)
 	require.NoError(t, err)
 	require.Equal(t, resMenuItem.ID, menuItem.ID)
-	require.Equal(t, resMenuItem.UserID, menuItem.UserID)
 	require.Equal(t, resMenuItem.ShopName, menuItem.ShopName)
 	require.Equal(t, resMenuItem.ProductID, menuItem.ProductID)
 	require.Equal(t, resMenuItem.ProductName, menuItem.ProductName)
 	require.Equal(t, resMenuItem.ProductPrice, menuItem.ProductPrice)
 	require.Equal(t, resMenuItem.Catalog, menuItem.Catalog)
 	require.Equal(t, resMenuItem.Description, menuItem.Description)
+	require.Equal(t, resMenuItem.Available, menuItem.Available)
 }
 
 func TestAddMenuItem(t *testing.T) {
 	user, _ := randomUser(t)
-	product := randomProduct(user)
+	shop := randomShop(user)
+	product := randomProduct(shop)
 	catalog := "breakfast"
-	menuItem := createMenuItem(user, product, catalog)
+	menuItem := createMenuItem(shop, product, catalog)
 
 	floatPrice, err := strconv.ParseFloat(product.Price, 64)
 	require.NoError(t, err)
 
 	testCases := []struct {
 		name          string
-		user          db.User
 		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		buildStub     func(store *mockdb.MockStore)
@@ -96,10 +96,7 @@ func TestAddMenuItem(t *testing.T) {
 	}{
 		{
 			name: "OK",
-			user: user,
 			body: gin.H{
-				"user_id":       user.ID,
-				"shop_name":     user.Username,
 				"product_id":    product.ID,
 				"product_name":  product.Name,
 				"product_price": floatPrice,
@@ -110,9 +107,13 @@ func TestAddMenuItem(t *testing.T) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(db.GetProductParams{OrganisationID: shop.OrganisationID, ID: product.ID})).
+					Times(1).
+					Return(product, nil)
+
 				arg := db.AddMenuItemParams{
 					ID:           menuItem.ID,
-					UserID:       menuItem.UserID,
 					ShopName:     menuItem.ShopName,
 					ProductID:    menuItem.ProductID,
 					ProductName:  menuItem.ProductName,
@@ -130,12 +131,67 @@ func TestAddMenuItem(t *testing.T) {
 				requireBodyMatchMenuItem(t, recorder.Body, menuItem)
 			},
 		},
+		{
+			name: "PriceOverride",
+			body: gin.H{
+				"product_id":    product.ID,
+				"product_price": 4.5,
+				"catalog":       catalog,
+				"description":   product.Description,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(db.GetProductParams{OrganisationID: shop.OrganisationID, ID: product.ID})).
+					Times(1).
+					Return(product, nil)
+
+				// the name comes from the catalogue, the price is the shop's own
+				arg := db.AddMenuItemParams{
+					ShopName:     shop.Name,
+					ProductID:    product.ID,
+					ProductName:  product.Name,
+					ProductPrice: "4.50",
+					Catalog:      catalog,
+					Description:  product.Description,
+				}
+				store.EXPECT().
+					AddMenuItem(gomock.Any(), eqAddMenuItemParams(arg)).
+					Times(1).
+					Return(menuItem, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ProductNotFound",
+			body: gin.H{
+				"product_id":  product.ID,
+				"catalog":     catalog,
+				"description": product.Description,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Product{}, sql.ErrNoRows)
+				store.EXPECT().
+					AddMenuItem(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 		{
 			name: "InternalServerError",
-			user: user,
 			body: gin.H{
-				"user_id":       user.ID,
-				"shop_name":     user.Username,
 				"product_id":    product.ID,
 				"product_name":  product.Name,
 				"product_price": floatPrice,
@@ -146,6 +202,10 @@ func TestAddMenuItem(t *testing.T) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(db.GetProductParams{OrganisationID: shop.OrganisationID, ID: product.ID})).
+					Times(1).
+					Return(product, nil)
 				store.EXPECT().
 					AddMenuItem(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -157,7 +217,6 @@ func TestAddMenuItem(t *testing.T) {
 		},
 		{
 			name: "MissingJSONData",
-			user: user,
 			body: gin.H{},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
@@ -173,10 +232,7 @@ func TestAddMenuItem(t *testing.T) {
 		},
 		{
 			name: "UnauthorizatedUser",
-			user: user,
 			body: gin.H{
-				"user_id":       user.ID,
-				"shop_name":     user.Username,
 				"product_id":    product.ID,
 				"product_name":  product.Name,
 				"product_price": floatPrice,
@@ -205,6 +261,7 @@ func TestAddMenuItem(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
 			tc.buildStub(store)
 
 			server := newTestServer(t, store)
@@ -214,7 +271,7 @@ func TestAddMenuItem(t *testing.T) {
 			require.NoError(t, err)
 			jsonReader := bytes.NewReader(jsonData)
 
-			url := fmt.Sprintf("/users/%v/menus", tc.user.Username)
+			url := fmt.Sprintf("/shops/%v/menus", shop.ID)
 			req, err := http.NewRequest(http.MethodPost, url, jsonReader)
 			require.NoError(t, err)
 
@@ -228,14 +285,14 @@ func TestAddMenuItem(t *testing.T) {
 
 func TestUpdateMenuItem(t *testing.T) {
 	user, _ := randomUser(t)
-	product := randomProduct(user)
+	shop := randomShop(user)
+	product := randomProduct(shop)
 	catalog := "breakfast"
-	menuItem := createMenuItem(user, product, catalog)
+	menuItem := createMenuItem(shop, product, catalog)
 
 	updatedPrice := 100000.00
 	updatedMenuItem := db.Menu{
 		ID:           menuItem.ID,
-		UserID:       menuItem.UserID,
 		ShopName:     menuItem.ShopName,
 		ProductID:    menuItem.ProductID,
 		ProductName:  "updated",
@@ -246,7 +303,6 @@ func TestUpdateMenuItem(t *testing.T) {
 
 	testCases := []struct {
 		name          string
-		user          db.User
 		menuItem      db.Menu
 		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
@@ -255,12 +311,8 @@ func TestUpdateMenuItem(t *testing.T) {
 	}{
 		{
 			name:     "OK",
-			user:     user,
 			menuItem: menuItem,
 			body: gin.H{
-				"id":            menuItem.ID,
-				"user_id":       menuItem.UserID,
-				"shop_name":     menuItem.ShopName,
 				"product_name":  updatedMenuItem.ProductName,
 				"product_price": updatedPrice,
 				"catalog":       updatedMenuItem.Catalog,
@@ -271,8 +323,8 @@ func TestUpdateMenuItem(t *testing.T) {
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				arg := db.UpdateMenuItemParams{
+					ShopName:     menuItem.ShopName,
 					ID:           menuItem.ID,
-					UserID:       menuItem.UserID,
 					ProductName:  updatedMenuItem.ProductName,
 					ProductPrice: updatedMenuItem.ProductPrice,
 					Catalog:      updatedMenuItem.Catalog,
@@ -290,12 +342,8 @@ func TestUpdateMenuItem(t *testing.T) {
 		},
 		{
 			name:     "InternalError",
-			user:     user,
 			menuItem: menuItem,
 			body: gin.H{
-				"id":            menuItem.ID,
-				"user_id":       menuItem.UserID,
-				"shop_name":     menuItem.ShopName,
 				"product_name":  updatedMenuItem.ProductName,
 				"product_price": updatedPrice,
 				"catalog":       updatedMenuItem.Catalog,
@@ -316,7 +364,6 @@ func TestUpdateMenuItem(t *testing.T) {
 		},
 		{
 			name:     "MissingJSONData",
-			user:     user,
 			menuItem: menuItem,
 			body:     gin.H{},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -333,12 +380,8 @@ func TestUpdateMenuItem(t *testing.T) {
 		},
 		{
 			name:     "UnauthorizatedUser",
-			user:     user,
 			menuItem: menuItem,
 			body: gin.H{
-				"id":            menuItem.ID,
-				"user_id":       menuItem.UserID,
-				"shop_name":     menuItem.ShopName,
 				"product_name":  updatedMenuItem.ProductName,
 				"product_price": updatedPrice,
 				"catalog":       updatedMenuItem.Catalog,
@@ -366,6 +409,7 @@ func TestUpdateMenuItem(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
 			tc.buildStub(store)
 
 			server := newTestServer(t, store)
@@ -375,7 +419,7 @@ func TestUpdateMenuItem(t *testing.T) {
 			require.NoError(t, err)
 			jsonReader := bytes.NewReader(jsonData)
 
-			url := fmt.Sprintf("/users/%v/menus/%v", tc.user.Username, tc.menuItem.ID)
+			url := fmt.Sprintf("/shops/%v/menus/%v", shop.ID, tc.menuItem.ID)
 			req, err := http.NewRequest(http.MethodPatch, url, jsonReader)
 			require.NoError(t, err)
 
@@ -389,35 +433,28 @@ func TestUpdateMenuItem(t *testing.T) {
 
 func TestDeleteMenuItem(t *testing.T) {
 	user, _ := randomUser(t)
-	product := randomProduct(user)
+	shop := randomShop(user)
+	product := randomProduct(shop)
 	catalog := "breakfast"
-	menuItem := createMenuItem(user, product, catalog)
+	menuItem := createMenuItem(shop, product, catalog)
 
 	testCases := []struct {
 		name          string
-		user          db.User
 		menuItem      db.Menu
-		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		buildStub     func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
 			name:     "OK",
-			user:     user,
 			menuItem: menuItem,
-			body: gin.H{
-				"id":        menuItem.ID,
-				"user_id":   menuItem.UserID,
-				"shop_name": menuItem.ShopName,
-			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				arg := db.DeleteMenuItemParams{
-					ID:     menuItem.ID,
-					UserID: menuItem.UserID,
+					ShopName: menuItem.ShopName,
+					ID:       menuItem.ID,
 				}
 				store.EXPECT().
 					DeleteMenuItem(gomock.Any(), gomock.Eq(arg)).
@@ -430,13 +467,7 @@ func TestDeleteMenuItem(t *testing.T) {
 		},
 		{
 			name:     "InternalError",
-			user:     user,
 			menuItem: menuItem,
-			body: gin.H{
-				"id":        menuItem.ID,
-				"user_id":   menuItem.UserID,
-				"shop_name": menuItem.ShopName,
-			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
@@ -450,32 +481,9 @@ func TestDeleteMenuItem(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
-		{
-			name:     "MissingJSONData",
-			user:     user,
-			menuItem: menuItem,
-			body:     gin.H{},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStub: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					DeleteMenuItem(gomock.Any(), gomock.Any()).
-					Times(0)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
 		{
 			name:     "UnauthorizatedUser",
-			user:     user,
 			menuItem: menuItem,
-			body: gin.H{
-				"id":        menuItem.ID,
-				"user_id":   menuItem.UserID,
-				"shop_name": menuItem.ShopName,
-			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "UnauthorizatedUser", time.Minute)
 			},
@@ -498,17 +506,14 @@ func TestDeleteMenuItem(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
 			tc.buildStub(store)
 
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			jsonData, err := json.Marshal(tc.body)
-			require.NoError(t, err)
-			jsonReader := bytes.NewReader(jsonData)
-
-			url := fmt.Sprintf("/users/%v/menus/%v", tc.user.Username, tc.menuItem.ID)
-			req, err := http.NewRequest(http.MethodDelete, url, jsonReader)
+			url := fmt.Sprintf("/shops/%v/menus/%v", shop.ID, tc.menuItem.ID)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
 			require.NoError(t, err)
 
 			tc.setupAuth(t, req, server.tokenMaker)
@@ -521,9 +526,10 @@ func TestDeleteMenuItem(t *testing.T) {
 
 func TestGetAllMenuItems(t *testing.T) {
 	user, _ := randomUser(t)
-	product := randomProduct(user)
+	shop := randomShop(user)
+	product := randomProduct(shop)
 	catalog := "breakfast"
-	menuItem := createMenuItem(user, product, catalog)
+	menuItem := createMenuItem(shop, product, catalog)
 
 	testCases := []struct {
 		name          string
@@ -544,6 +550,27 @@ func TestGetAllMenuItems(t *testing.T) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name:     "SoldOutItemListed",
+			shopName: menuItem.ShopName,
+			buildStub: func(store *mockdb.MockStore) {
+				soldOut := menuItem
+				soldOut.Available = false
+				store.EXPECT().
+					GetAllMenuItems(gomock.Any(), gomock.Eq(menuItem.ShopName)).
+					Times(1).
+					Return([]db.Menu{soldOut}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []db.Menu
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Len(t, res, 1)
+				require.False(t, res[0].Available)
+			},
+		},
 		{
 			name:     "InternalError",
 			shopName: menuItem.ShopName,
@@ -594,3 +621,123 @@ func TestGetAllMenuItems(t *testing.T) {
 		})
 	}
 }
+
+func TestSetMenuItemAvailability(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	menuItem := createMenuItem(shop, product, "breakfast")
+
+	soldOut := menuItem
+	soldOut.Available = false
+
+	testCases := []struct {
+		name          string
+		menuItemID    string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			menuItemID: menuItem.ID.String(),
+			body:       gin.H{"available": false},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.SetMenuItemAvailabilityParams{
+					ShopName:  shop.Name,
+					ID:        menuItem.ID,
+					Available: false,
+				}
+				store.EXPECT().
+					SetMenuItemAvailability(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(soldOut, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchMenuItem(t, recorder.Body, soldOut)
+			},
+		},
+		{
+			name:       "MissingAvailable",
+			menuItemID: menuItem.ID.String(),
+			body:       gin.H{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetMenuItemAvailability(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "NotFound",
+			menuItemID: menuItem.ID.String(),
+			body:       gin.H{"available": true},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetMenuItemAvailability(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Menu{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "UnauthorizatedUser",
+			menuItemID: menuItem.ID.String(),
+			body:       gin.H{"available": true},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorizatedUser", time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetMenuItemAvailability(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/menus/%v/availability", shop.ID, tc.menuItemID)
+			req, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(jsonData))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -1,19 +1,24 @@
 package api
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
 	"github.com/toml5566/go_pos_backend/token"
+	"github.com/toml5566/go_pos_backend/utils"
 )
 
 const (
 	authorizationHeaderKey  = "authorization"
 	authorizationTypeBearer = "bearer"
 	authorizationPayloadKey = "authorization_payload"
+	shopKey                 = "shop"
 )
 
 func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
@@ -50,3 +55,67 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+type shopUri struct {
+	ShopID string `uri:"shop_id" binding:"required,uuid"`
+}
+
+// load the shop of a /shops/:shop_id route, only its members get through.
+// it runs after authMiddleware
+func shopMiddleware(store db.Store) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var uri shopUri
+		if err := ctx.ShouldBindUri(&uri); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		shop, err := store.GetMemberShop(ctx, db.GetMemberShopParams{
+			ShopID:   uuid.MustParse(uri.ShopID),
+			Username: authPayload.Username,
+		})
+		if err != nil {
+			// a shop the user does not work at looks the same as a missing one
+			if err == sql.ErrNoRows {
+				err := errors.New("unauthorizated user")
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.Set(shopKey, shop)
+		ctx.Next()
+	}
+}
+
+// only owners and managers get through to the settings and the money of a
+// shop, staff run its day to day. it runs after shopMiddleware
+func managerMiddleware(store db.Store) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		shop := currentShop(ctx)
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		member, err := store.GetOrganisationMember(ctx, db.GetOrganisationMemberParams{
+			OrganisationID: shop.OrganisationID,
+			Username:       authPayload.Username,
+		})
+		if err != nil && err != sql.ErrNoRows {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if err == sql.ErrNoRows || member.Role == utils.RoleStaff {
+			err := errors.New("unauthorizated user")
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// the shop loaded by shopMiddleware
+func currentShop(ctx *gin.Context) db.Shop {
+	return ctx.MustGet(shopKey).(db.Shop)
+}
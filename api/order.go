@@ -1,68 +1,368 @@
 package api
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	db "github.com/toml5566/go_pos_backend/internal/database"
-	"github.com/toml5566/go_pos_backend/token"
 	"github.com/toml5566/go_pos_backend/utils"
 )
 
+// order_day is derived by the server from the shop's business day, a value
+// sent by older clients is ignored. items are taxed at the rate of their
+// product, tax_rate is only taken for open items rung up by staff.
 type createOrderItemRequest struct {
-	ShopName     string  `json:"shop_name" binding:"required"`
-	OrderDay     string  `json:"order_day" binding:"required"`
-	ProductName  string  `json:"product_name" binding:"required"`
-	ProductPrice float64 `json:"product_price" binding:"required"`
-	Amount       int32   `json:"amount" binding:"required"`
-	Status       string  `json:"status" binding:"required"`
+	ShopName     string    `json:"shop_name" binding:"required"`
+	ProductID    uuid.UUID `json:"product_id"` // optional, links the item to tracked stock
+	ProductName  string    `json:"product_name" binding:"required"`
+	ProductPrice float64   `json:"product_price" binding:"required"`
+	Amount       int32     `json:"amount" binding:"required,min=1,max=1000"`
+	Status       string    `json:"status" binding:"required"`
+	TaxRate      float64   `json:"tax_rate" binding:"min=0,max=100"` // percentage charged on an open item rung up by staff
+	Seat         int32     `json:"seat" binding:"min=0"`             // guest who ordered the item, 0 when shared
 }
 
+// counter orders carry their own order id, a round for a table or an open
+// tab is added to the order of the tab. customers ordering from the QR code
+// of their table send its table token, table_id and tab_id are only taken
+// from staff on the shop's own order route.
+// orders are dine-in unless order_type says otherwise, pickup orders need the
+// time they are collected and delivery orders an address and phone number.
+// scheduled_for books a pre-order into a 15 minute slot, a scheduled pickup
+// is collected at its slot unless pickup_at says otherwise.
+// channel is where the order is placed and picks the price lists that apply,
+// pos unless given. orders from a table token are always qr.
+// coupon_code redeems a coupon for the order, customer is who redeems it and
+// defaults to the contact phone.
 type createOrderRequest struct {
-	OrderID uuid.UUID                `json:"order_id" binding:"required"`
-	Orders  []createOrderItemRequest `json:"orders" binding:"required"`
+	OrderID         uuid.UUID                `json:"order_id" binding:"required_without_all=TableID TabID TableToken"`
+	TableID         uuid.UUID                `json:"table_id"` // opens a tab on the table unless one is open already
+	TabID           uuid.UUID                `json:"tab_id"`
+	TableToken      string                   `json:"table_token"`
+	OrderType       string                   `json:"order_type" binding:"omitempty,oneof=dine_in takeaway pickup delivery"`
+	Channel         string                   `json:"channel" binding:"omitempty,oneof=pos online"`
+	PickupAt        *time.Time               `json:"pickup_at"`
+	ScheduledFor    *time.Time               `json:"scheduled_for"`
+	DeliveryAddress string                   `json:"delivery_address" binding:"required_if=OrderType delivery"`
+	ContactName     string                   `json:"contact_name"`
+	ContactPhone    string                   `json:"contact_phone" binding:"required_if=OrderType delivery"`
+	CouponCode      string                   `json:"coupon_code"`
+	Customer        string                   `json:"customer"`
+	Orders          []createOrderItemRequest `json:"orders" binding:"required,dive"`
 }
 
+var (
+	errTableOrderType = errors.New("only dine-in orders are placed on a table")
+	errPickupInPast   = errors.New("pickup time is in the past")
+	errPickupTime     = errors.New("a pickup order needs pickup_at or scheduled_for")
+	errTableSchedule  = errors.New("orders on a table cannot be scheduled")
+	errShopClosed     = errors.New("the shop is closed, orders can be scheduled for when it opens")
+	errTableStaff     = errors.New("orders are placed on a table through its table token, table_id and tab_id are for staff")
+	errTableToken     = errors.New("table tokens are for customers at their table, staff send the table_id or tab_id")
+)
+
+// eta is null when the shop has no kitchen stations to estimate from,
+// fees and discounts list the fee and promotion lines that were added to
+// orders, coupon is the coupon redeemed for the order
+type createOrderResponse struct {
+	Orders     []db.Order         `json:"orders"`
+	Fees       []db.Order         `json:"fees"`
+	Discounts  []db.Order         `json:"discounts"`
+	Fulfilment db.OrderFulfilment `json:"fulfilment"`
+	ETA        *orderETA          `json:"eta"`
+	Tab        *db.Tab            `json:"tab,omitempty"`
+	Coupon     *db.Coupon         `json:"coupon,omitempty"`
+}
+
+// refuse customer orders for now while the shop is closed, staff still
+// ring up orders outside opening hours
+func (server *Server) checkShopOpen(ctx *gin.Context, shopName string, clock shopClock) bool {
+	now := time.Now()
+	hours, err := server.openingHours(ctx, shopName, clock, now, now)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	if !hours.IsOpen(now, clock.loc) {
+		ctx.JSON(http.StatusConflict, errorResponse(errShopClosed))
+		return false
+	}
+	return true
+}
+
+type createOrderUri struct {
+	ShopName string `uri:"shop_name" binding:"required,alphanum,min=1"`
+}
+
+// orders placed by customers, at the counter, online or on their table
+// through its table token
 func (server *Server) createOrders(ctx *gin.Context) {
+	var uri createOrderUri
 	var orderReq createOrderRequest
-	var orders []db.Order
 
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 	if err := ctx.ShouldBindJSON(&orderReq); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	if orderReq.TableID != uuid.Nil || orderReq.TabID != uuid.Nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errTableStaff))
+		return
+	}
+
+	if !checkOrderRequest(ctx, uri.ShopName, orderReq) {
+		return
+	}
+
+	if orderReq.TableToken != "" {
+		table, ok := server.tokenTable(ctx, uri.ShopName, orderReq.TableToken)
+		if !ok {
+			return
+		}
+		// the token only opens the tab of its own table
+		orderReq.TableID = table.ID
+	}
+
+	shop, err := server.store.GetShopByName(ctx, uri.ShopName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.placeOrder(ctx, shop, orderReq, false)
+}
+
+// orders placed by staff of the shop, who may send rounds to a table or tab
+func (server *Server) createShopOrder(ctx *gin.Context) {
+	var orderReq createOrderRequest
+
+	if err := ctx.ShouldBindJSON(&orderReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if orderReq.TableToken != "" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errTableToken))
+		return
+	}
+
+	shop := currentShop(ctx)
+	if !checkOrderRequest(ctx, shop.Name, orderReq) {
+		return
+	}
+
+	// staff may ring up items that are not on the menu
+	server.placeOrder(ctx, shop, orderReq, true)
+}
+
+// refuse order requests that can never be placed, before touching the store
+func checkOrderRequest(ctx *gin.Context, shopName string, orderReq createOrderRequest) bool {
+	for _, req := range orderReq.Orders {
+		if req.ShopName != shopName {
+			err := fmt.Errorf("order item belongs to shop %q, not %q", req.ShopName, shopName)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return false
+		}
+		if req.Status == utils.StatusRefunded {
+			ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrRefundStatus))
+			return false
+		}
+	}
+
+	atTable := orderReq.TableID != uuid.Nil || orderReq.TabID != uuid.Nil || orderReq.TableToken != ""
+	if atTable && orderReq.OrderType != "" && orderReq.OrderType != utils.OrderDineIn {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errTableOrderType))
+		return false
+	}
+
+	if atTable && orderReq.ScheduledFor != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errTableSchedule))
+		return false
+	}
+
+	if orderReq.OrderType == utils.OrderPickup && orderReq.PickupAt == nil && orderReq.ScheduledFor == nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errPickupTime))
+		return false
+	}
+
+	if orderReq.PickupAt != nil && orderReq.PickupAt.Before(time.Now()) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errPickupInPast))
+		return false
+	}
+
+	return true
+}
+
+// price the order, create it and answer with its estimate. the table of a
+// table token is already resolved into table_id. items are priced by the
+// shop, open items keep the price they are ordered at.
+func (server *Server) placeOrder(ctx *gin.Context, shop db.Shop, orderReq createOrderRequest, openItems bool) {
+	channel := orderReq.Channel
+	if channel == "" {
+		channel = utils.ChannelPOS
+	}
+	if orderReq.TableToken != "" {
+		channel = utils.ChannelQR
+	}
+
+	clock, err := newShopClock(shop)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	// a scheduled order belongs to the business day of its slot
+	orderDay := clock.businessDay(time.Now())
+	if orderReq.ScheduledFor != nil {
+		orderDay = clock.businessDay(*orderReq.ScheduledFor)
+	}
+
+	if orderReq.ScheduledFor != nil {
+		if !server.checkScheduledSlot(ctx, shop.Name, clock, *orderReq.ScheduledFor) {
+			return
+		}
+	} else if !openItems && !server.checkShopOpen(ctx, shop.Name, clock) {
+		return
+	}
+
+	arg := db.CreateOrderTxParams{
+		TableID:   orderReq.TableID,
+		TabID:     orderReq.TabID,
+		OrderType: orderReq.OrderType,
+		Fulfilment: db.OrderFulfilmentParams{
+			DeliveryAddress: orderReq.DeliveryAddress,
+			ContactName:     orderReq.ContactName,
+			ContactPhone:    orderReq.ContactPhone,
+		},
+		CouponCode: orderReq.CouponCode,
+		Customer:   orderReq.Customer,
+		OpenItems:  openItems,
+	}
+	if arg.CouponCode != "" && arg.Customer == "" {
+		arg.Customer = orderReq.ContactPhone
+	}
+	if orderReq.ScheduledFor != nil {
+		arg.Fulfilment.ScheduledFor = sql.NullTime{Time: orderReq.ScheduledFor.UTC(), Valid: true}
+		if orderReq.OrderType == utils.OrderPickup && orderReq.PickupAt == nil {
+			orderReq.PickupAt = orderReq.ScheduledFor
+		}
+	}
+	if orderReq.PickupAt != nil {
+		arg.Fulfilment.PickupAt = sql.NullTime{Time: orderReq.PickupAt.UTC(), Valid: true}
+	}
+
+	// a scheduled order is priced at its slot
+	pricedAt := time.Now()
+	if orderReq.ScheduledFor != nil {
+		pricedAt = *orderReq.ScheduledFor
+	}
+	arg.PricedAt = pricedAt.UTC()
+	orderType := orderReq.OrderType
+	if orderType == "" {
+		orderType = utils.OrderDineIn
+	}
+	arg.PriceListIDs, err = server.orderPriceLists(ctx, shop.Name, clock, channel, orderType, pricedAt)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	for _, req := range orderReq.Orders {
-		arg := db.CreateOrderItemParams{
+		// the store taxes every item at the rate of its product, customers
+		// never pick their own rate
+		taxRate := 0.0
+		if openItems {
+			taxRate = req.TaxRate
+		}
+		arg.Items = append(arg.Items, db.CreateOrderItemParams{
 			ID:           uuid.New(),
 			ShopName:     req.ShopName,
 			OrderID:      orderReq.OrderID,
-			OrderDay:     req.OrderDay,
+			OrderDay:     orderDay,
 			ProductName:  req.ProductName,
 			ProductPrice: utils.FormottedDecimalToString(req.ProductPrice),
 			Amount:       req.Amount,
 			Status:       req.Status,
-		}
+			ProductID:    uuid.NullUUID{UUID: req.ProductID, Valid: req.ProductID != uuid.Nil},
+			TaxRate:      utils.FormottedDecimalToString(taxRate),
+			Seat:         req.Seat,
+		})
+	}
 
-		orderItem, err := server.store.CreateOrderItem(ctx, arg)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	result, err := server.store.CreateOrderTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrItemUnavailable) || errors.Is(err, db.ErrTabNotOpen) || errors.Is(err, db.ErrOrderTypeMismatch) ||
+			errors.Is(err, db.ErrSlotFull) || errors.Is(err, db.ErrCouponExpired) || errors.Is(err, db.ErrCouponUsedUp) ||
+			errors.Is(err, db.ErrCouponCustomerLimit) || errors.Is(err, db.ErrCouponRedeemed) || errors.Is(err, db.ErrCouponNotApplicable) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
 			return
 		}
+		if errors.Is(err, db.ErrCouponCustomer) || errors.Is(err, db.ErrNotOnMenu) || errors.Is(err, db.ErrRefundStatus) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.publishKitchenTickets(shop.Name, result.Tickets)
+	server.printKitchenTickets(ctx, shop, result.Tickets)
 
-		orders = append(orders, orderItem)
+	orderID := orderReq.OrderID
+	res := createOrderResponse{
+		Orders:     result.Orders,
+		Fees:       result.Fees,
+		Discounts:  result.Discounts,
+		Fulfilment: result.Fulfilment,
+	}
+	if result.Tab.ID != uuid.Nil {
+		orderID = result.Tab.OrderID
+		res.Tab = &result.Tab
+	}
+	if result.Coupon.ID != uuid.Nil {
+		res.Coupon = &result.Coupon
+	}
+	if len(result.Tickets) > 0 {
+		// the order is taken, without an estimate the customer just waits for the call
+		now := time.Now()
+		ready, err := server.estimateOrders(ctx, shop.Name, now)
+		if err != nil {
+			log.Println("cannot estimate orders:", err)
+		} else {
+			if readyAt, ok := ready[orderID]; ok {
+				e := newOrderETA(orderID, readyAt, now)
+				res.ETA = &e
+			}
+			server.publishOrderETAs(shop.Name, ready, now)
+		}
 	}
 
-	ctx.JSON(http.StatusOK, orders)
+	ctx.JSON(http.StatusOK, res)
 }
 
+// a change of the amount is posted to stock, items are refunded through the
+// refund route only
 type updateOrderItemRequest struct {
-	ID       uuid.UUID `json:"id" binding:"required"`
-	ShopName string    `json:"shop_name" binding:"required"`
-	Amount   int32     `json:"amount" binding:"required"`
-	Status   string    `json:"status" binding:"required"`
+	ID     uuid.UUID `json:"id" binding:"required"`
+	Amount int32     `json:"amount" binding:"required,min=1,max=1000"`
+	Status string    `json:"status" binding:"required"`
 }
 
 func (server *Server) updateOrderItem(ctx *gin.Context) {
@@ -73,23 +373,18 @@ func (server *Server) updateOrderItem(ctx *gin.Context) {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if authPayload.Username != req.ShopName {
-		err := errors.New("unauthorizated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
+	shop := currentShop(ctx)
 
 	arg := db.UpdateOrderItemParams{
-		ShopName: req.ShopName,
+		ShopName: shop.Name,
 		ID:       req.ID,
 		Amount:   req.Amount,
 		Status:   req.Status,
 	}
 
-	updatedOrder, err := server.store.UpdateOrderItem(ctx, arg)
+	updatedOrder, err := server.store.UpdateOrderItemTx(ctx, arg)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeOrderItemError(ctx, err)
 		return
 	}
 
@@ -97,8 +392,7 @@ func (server *Server) updateOrderItem(ctx *gin.Context) {
 }
 
 type deleteOrderItemRequest struct {
-	ShopName string    `json:"shop_name" binding:"required"`
-	ID       uuid.UUID `json:"id" binding:"required"`
+	ID uuid.UUID `json:"id" binding:"required"`
 }
 
 func (server *Server) deleteOrderItem(ctx *gin.Context) {
@@ -109,26 +403,75 @@ func (server *Server) deleteOrderItem(ctx *gin.Context) {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if authPayload.Username != req.ShopName {
-		err := errors.New("unauthorizated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	shop := currentShop(ctx)
+
+	arg := db.DeleteOrderItemParams{
+		ShopName: shop.Name,
+		ID:       req.ID,
+	}
+
+	err := server.store.DeleteOrderItemTx(ctx, arg)
+	if err != nil {
+		writeOrderItemError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, textResponse("delete successfully"))
+}
+
+// items of a closed business day are frozen in its z report, refunded items
+// are final and fee and discount lines follow the items they were worked out
+// from
+func writeOrderItemError(ctx *gin.Context, err error) {
+	if err == sql.ErrNoRows {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if err == db.ErrDayClosed || err == db.ErrAlreadyRefunded || err == db.ErrRefundStatus ||
+		err == db.ErrAdjustmentLine || err == db.ErrRoundDiscounted {
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+}
+
+type refundOrderItemUri struct {
+	ID string `uri:"order_id" binding:"required,uuid"`
+}
+
+func (server *Server) refundOrderItem(ctx *gin.Context) {
+	var uri refundOrderItemUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	arg := db.DeleteOrderItemParams(req)
+	shop := currentShop(ctx)
+
+	arg := db.RefundOrderItemTxParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.ID),
+	}
 
-	err := server.store.DeleteOrderItem(ctx, arg)
+	result, err := server.store.RefundOrderItemTx(ctx, arg)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if err == db.ErrAlreadyRefunded || err == db.ErrDayClosed || err == db.ErrAdjustmentLine {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, textResponse("delete successfully"))
+	ctx.JSON(http.StatusOK, result)
 }
 
 type getOrdersByDayRequest struct {
-	ShopName string `json:"shop_name" binding:"required"`
 	OrderDay string `json:"order_day" binding:"required"`
 }
 
@@ -140,14 +483,12 @@ func (server *Server) getOrdersByDay(ctx *gin.Context) {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if authPayload.Username != req.ShopName {
-		err := errors.New("unauthorizated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
+	shop := currentShop(ctx)
 
-	arg := db.GetOrdersByDayParams(req)
+	arg := db.GetOrdersByDayParams{
+		ShopName: shop.Name,
+		OrderDay: req.OrderDay,
+	}
 
 	orders, err := server.store.GetOrdersByDay(ctx, arg)
 	if err != nil {
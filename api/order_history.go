@@ -0,0 +1,166 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+)
+
+// filters on order_day (inclusive), status, product name and amount
+type orderHistoryFilter struct {
+	FromDate    string `form:"from_date" binding:"required,datetime=2006-01-02"`
+	ToDate      string `form:"to_date" binding:"required,datetime=2006-01-02"`
+	Status      string `form:"status"`
+	ProductName string `form:"product_name"`
+	MinAmount   int32  `form:"min_amount" binding:"min=0"`
+	MaxAmount   *int32 `form:"max_amount" binding:"omitempty,min=0"`
+}
+
+func (filter orderHistoryFilter) params(shopName string) (db.OrderHistoryParams, error) {
+	if filter.ToDate < filter.FromDate {
+		return db.OrderHistoryParams{}, errors.New("to_date must not be before from_date")
+	}
+
+	arg := db.OrderHistoryParams{
+		ShopName:    shopName,
+		FromDay:     filter.FromDate,
+		ToDay:       filter.ToDate,
+		Status:      filter.Status,
+		ProductName: filter.ProductName,
+		MinAmount:   filter.MinAmount,
+		MaxAmount:   math.MaxInt32,
+	}
+	if filter.MaxAmount != nil {
+		if *filter.MaxAmount < filter.MinAmount {
+			return db.OrderHistoryParams{}, errors.New("max_amount must not be below min_amount")
+		}
+		arg.MaxAmount = *filter.MaxAmount
+	}
+
+	return arg, nil
+}
+
+type orderHistoryQuery struct {
+	orderHistoryFilter
+	Sort     string `form:"sort" binding:"omitempty,oneof=created_at amount"`
+	Order    string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Cursor   string `form:"cursor"`
+	PageSize int32  `form:"page_size" binding:"omitempty,min=5,max=100"`
+}
+
+// cursors are opaque to clients, they carry the sort so a cursor cannot be
+// replayed against a different ordering
+type orderHistoryCursor struct {
+	Sort       string `json:"sort"`
+	Descending bool   `json:"desc"`
+	db.OrderHistoryCursor
+}
+
+func encodeOrderHistoryCursor(cursor orderHistoryCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeOrderHistoryCursor(s string) (orderHistoryCursor, error) {
+	var cursor orderHistoryCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor, errors.New("invalid cursor")
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, errors.New("invalid cursor")
+	}
+
+	return cursor, nil
+}
+
+type orderHistoryResponse struct {
+	Orders     []db.Order `json:"orders"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+// list order items of a date range newest first by default, the next page is
+// requested with the next_cursor of the previous response
+func (server *Server) getOrderHistory(ctx *gin.Context) {
+	var query orderHistoryQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg, err := query.params(shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg.SortBy = db.OrderHistorySortCreatedAt
+	if query.Sort != "" {
+		arg.SortBy = query.Sort
+	}
+	arg.Descending = query.Order != "asc"
+
+	pageSize := query.PageSize
+	if pageSize == 0 {
+		pageSize = 20
+	}
+	// one extra row tells whether there is a next page
+	arg.PageSize = pageSize + 1
+
+	if query.Cursor != "" {
+		cursor, err := decodeOrderHistoryCursor(query.Cursor)
+		if err == nil && (cursor.Sort != arg.SortBy || cursor.Descending != arg.Descending) {
+			err = errors.New("cursor does not match the requested sort")
+		}
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.Cursor = &cursor.OrderHistoryCursor
+	}
+
+	orders, err := server.store.ListOrderHistory(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := orderHistoryResponse{Orders: orders}
+	if len(orders) > int(pageSize) {
+		res.Orders = orders[:pageSize]
+		last := res.Orders[pageSize-1]
+
+		cursor := orderHistoryCursor{
+			Sort:       arg.SortBy,
+			Descending: arg.Descending,
+			OrderHistoryCursor: db.OrderHistoryCursor{
+				ID: last.ID,
+			},
+		}
+		if arg.SortBy == db.OrderHistorySortAmount {
+			cursor.Amount = last.Amount
+		} else {
+			cursor.CreatedAt = last.CreatedAt
+		}
+
+		res.NextCursor, err = encodeOrderHistoryCursor(cursor)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func randomOrderHistory(shop db.Shop, n int) []db.Order {
+	product := randomProduct(shop)
+	menuItem := createMenuItem(shop, product, "breakfast")
+
+	orders := make([]db.Order, n)
+	for i := range orders {
+		orders[i] = addOrderItem(menuItem, uuid.New())
+		orders[i].Amount = int32(i + 1)
+		orders[i].CreatedAt = time.Date(2022, time.January, 1, 12, 0, n-i, 0, time.UTC)
+	}
+	return orders
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	orders := randomOrderHistory(shop, 6)
+
+	nextCursor, err := encodeOrderHistoryCursor(orderHistoryCursor{
+		Sort:       db.OrderHistorySortCreatedAt,
+		Descending: true,
+		OrderHistoryCursor: db.OrderHistoryCursor{
+			CreatedAt: orders[4].CreatedAt,
+			ID:        orders[4].ID,
+		},
+	})
+	require.NoError(t, err)
+
+	amountCursor, err := encodeOrderHistoryCursor(orderHistoryCursor{
+		Sort: db.OrderHistorySortAmount,
+		OrderHistoryCursor: db.OrderHistoryCursor{
+			Amount: 3,
+			ID:     orders[2].ID,
+		},
+	})
+	require.NoError(t, err)
+
+	baseArg := db.OrderHistoryParams{
+		ShopName:   shop.Name,
+		FromDay:    "2022-01-01",
+		ToDay:      "2022-01-31",
+		MaxAmount:  math.MaxInt32,
+		SortBy:     db.OrderHistorySortCreatedAt,
+		Descending: true,
+		PageSize:   6,
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "FirstPage",
+			query: "from_date=2022-01-01&to_date=2022-01-31&page_size=5",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListOrderHistory(gomock.Any(), gomock.Eq(baseArg)).
+					Times(1).
+					Return(orders, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res orderHistoryResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Len(t, res.Orders, 5)
+				require.Equal(t, nextCursor, res.NextCursor)
+			},
+		},
+		{
+			name:  "NextPage",
+			query: "from_date=2022-01-01&to_date=2022-01-31&page_size=5&cursor=" + nextCursor,
+			buildStub: func(store *mockdb.MockStore) {
+				arg := baseArg
+				arg.Cursor = &db.OrderHistoryCursor{CreatedAt: orders[4].CreatedAt, ID: orders[4].ID}
+				store.EXPECT().
+					ListOrderHistory(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(orders[5:], nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res orderHistoryResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Len(t, res.Orders, 1)
+				require.Empty(t, res.NextCursor)
+			},
+		},
+		{
+			name:  "FiltersAndSort",
+			query: "from_date=2022-01-01&to_date=2022-01-31&status=pending&product_name=latte&min_amount=2&max_amount=4&sort=amount&order=asc&cursor=" + amountCursor,
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.OrderHistoryParams{
+					ShopName:    shop.Name,
+					FromDay:     "2022-01-01",
+					ToDay:       "2022-01-31",
+					Status:      "pending",
+					ProductName: "latte",
+					MinAmount:   2,
+					MaxAmount:   4,
+					SortBy:      db.OrderHistorySortAmount,
+					Cursor:      &db.OrderHistoryCursor{Amount: 3, ID: orders[2].ID},
+					PageSize:    21,
+				}
+				store.EXPECT().
+					ListOrderHistory(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.Order{orders[3]}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "CursorSortMismatch",
+			query: "from_date=2022-01-01&to_date=2022-01-31&cursor=" + amountCursor,
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListOrderHistory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidCursor",
+			query: "from_date=2022-01-01&to_date=2022-01-31&cursor=not-a-cursor",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListOrderHistory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "MaxBelowMin",
+			query: "from_date=2022-01-01&to_date=2022-01-31&min_amount=5&max_amount=2",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListOrderHistory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingRange",
+			query: "status=pending",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListOrderHistory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shops/%v/orders/history?%v", shop.ID, tc.query)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -34,30 +34,52 @@ func addOrderItem(menuItem db.Menu, orderID uuid.UUID) db.Order {
 		Amount:       1,
 		Status:       "Pending",
 		CreatedAt:    time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+		ProductID:    uuid.NullUUID{UUID: menuItem.ProductID, Valid: true},
+		TaxRate:      "5.00",
 	}
 }
 
-type eqCreateOrderItemParamsMatcher struct {
-	arg db.CreateOrderItemParams
+type eqCreateOrderTxParamsMatcher struct {
+	arg db.CreateOrderTxParams
 }
 
-func (e eqCreateOrderItemParamsMatcher) Matches(x interface{}) bool {
-	arg, ok := x.(db.CreateOrderItemParams)
-	if !ok {
+func (e eqCreateOrderTxParamsMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.CreateOrderTxParams)
+	if !ok || len(arg.Items) != len(e.arg.Items) {
+		return false
+	}
+	if arg.TableID != e.arg.TableID || arg.TabID != e.arg.TabID {
+		return false
+	}
+	if arg.OrderType != e.arg.OrderType || arg.Fulfilment != e.arg.Fulfilment {
+		return false
+	}
+	if arg.CouponCode != e.arg.CouponCode || arg.Customer != e.arg.Customer || arg.OpenItems != e.arg.OpenItems {
+		return false
+	}
+	if !reflect.DeepEqual(arg.PriceListIDs, e.arg.PriceListIDs) {
+		return false
+	}
+	// orders for now are priced at the time they are placed
+	if !e.arg.PricedAt.IsZero() && !arg.PricedAt.Equal(e.arg.PricedAt) {
 		return false
 	}
 
-	e.arg.ID = arg.ID // match the random generated uuid
+	expected := make([]db.CreateOrderItemParams, len(e.arg.Items))
+	for i := range e.arg.Items {
+		expected[i] = e.arg.Items[i]
+		expected[i].ID = arg.Items[i].ID // match the random generated uuid
+	}
 
-	return reflect.DeepEqual(e.arg, arg)
+	return reflect.DeepEqual(expected, arg.Items)
 }
 
-func (e eqCreateOrderItemParamsMatcher) String() string {
+func (e eqCreateOrderTxParamsMatcher) String() string {
 	return fmt.Sprintf("matches arg %v", e.arg)
 }
 
-func eqCreateOrderItemParams(arg db.CreateOrderItemParams) gomock.Matcher {
-	return eqCreateOrderItemParamsMatcher{arg}
+func eqCreateOrderTxParams(arg db.CreateOrderTxParams) gomock.Matcher {
+	return eqCreateOrderTxParamsMatcher{arg}
 }
 
 func requireBodyMatchOrder(t *testing.T, body *bytes.Buffer, orders []db.Order) {
@@ -100,25 +122,32 @@ func requireBodyMatchOrderItem(t *testing.T, body *bytes.Buffer, orderItem db.Or
 
 func TestCreateOrderItem(t *testing.T) {
 	user, _ := randomUser(t)
-	product := randomProduct(user)
-	menuItem := createMenuItem(user, product, "breakfast")
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	menuItem := createMenuItem(shop, product, "breakfast")
 
 	orderID := uuid.New()
 	orderItem := addOrderItem(menuItem, orderID)
+	orderItem.OrderDay = utils.BusinessDay(time.Now(), time.UTC, 0)
 	orders := []db.Order{orderItem}
 
 	orderItemFloatPrice, err := strconv.ParseFloat(orderItem.ProductPrice, 64)
 	require.NoError(t, err)
 
 	orderItemReq := createOrderItemRequest{
-		orderItem.ShopName,
-		orderItem.OrderDay,
-		orderItem.ProductName,
-		orderItemFloatPrice,
-		orderItem.Amount,
-		orderItem.Status,
+		ShopName:     orderItem.ShopName,
+		ProductID:    product.ID,
+		ProductName:  orderItem.ProductName,
+		ProductPrice: orderItemFloatPrice,
+		Amount:       orderItem.Amount,
+		Status:       orderItem.Status,
+		TaxRate:      5, // ignored, customers do not pick their tax rate
 	}
 
+	tab := randomTab(shop, utils.TabOpen)
+	// a slot a couple of hours ahead, the shop has no opening hours
+	slot := utils.SlotStart(time.Now().Add(2 * time.Hour)).UTC()
+
 	testCases := []struct {
 		name          string
 		shopName      string
@@ -127,64 +156,1085 @@ func TestCreateOrderItem(t *testing.T) {
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:     "OK",
-			shopName: orderItem.ShopName,
+			name:     "OK",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id": orderID,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				expectOpeningHours(store, shop, nil, nil)
+				expectPriceLists(store, shop, nil)
+				arg := db.CreateOrderTxParams{
+					Items: []db.CreateOrderItemParams{
+						{
+							ID:           orderItem.ID,
+							ShopName:     orderItem.ShopName,
+							OrderID:      orderID,
+							OrderDay:     orderItem.OrderDay,
+							ProductName:  orderItem.ProductName,
+							ProductPrice: orderItem.ProductPrice,
+							Amount:       orderItem.Amount,
+							Status:       orderItem.Status,
+							ProductID:    orderItem.ProductID,
+							TaxRate:      "0.00", // the store taxes the item at the rate of its product
+						},
+					},
+				}
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), eqCreateOrderTxParams(arg)).
+					Times(1).
+					Return(db.CreateOrderTxResult{Orders: orders}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res createOrderResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.Orders, 1)
+				require.Equal(t, orderItem.ID, res.Orders[0].ID)
+				require.Equal(t, orderItem.OrderDay, res.Orders[0].OrderDay)
+				// no kitchen stations, nothing to estimate from
+				require.Nil(t, res.ETA)
+			},
+		},
+		{
+			name:     "PriceLists",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":         orderID,
+				"order_type":       utils.OrderDelivery,
+				"channel":          utils.ChannelOnline,
+				"delivery_address": "1 Queen's Road",
+				"contact_phone":    "5555 0000",
+				"orders":           []createOrderItemRequest{orderItemReq},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				expectOpeningHours(store, shop, nil, nil)
+				deliveryMarkup := randomPriceList(shop, 10)
+				dineIn := randomPriceList(shop, 5)
+				online := randomPriceList(shop, 1)
+				expectPriceLists(store, shop, []db.PriceListRule{
+					randomPriceListRule(deliveryMarkup, "", utils.OrderDelivery),
+					randomPriceListRule(dineIn, "", utils.OrderDineIn),
+					randomPriceListRule(online, utils.ChannelPOS, ""),
+					randomPriceListRule(online, utils.ChannelOnline, ""),
+				})
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateOrderTxParams) (db.CreateOrderTxResult, error) {
+						// every list with a matching rule, highest priority first
+						require.Equal(t, []uuid.UUID{deliveryMarkup.ID, online.ID}, arg.PriceListIDs)
+						return db.CreateOrderTxResult{Orders: orders}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "KitchenETA",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id": orderID,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				expectOpeningHours(store, shop, nil, nil)
+				expectPriceLists(store, shop, nil)
+				station := randomStation(shop, true)
+				ticket := randomKitchenTicket(station, orderID, utils.TicketOpen)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateOrderTxResult{
+						Orders:  orders,
+						Tickets: []db.StationTicket{{KitchenTicket: ticket, Items: orders}},
+					}, nil)
+				store.EXPECT().
+					ListPrinters(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Printer{}, nil)
+				store.EXPECT().
+					ListOpenKitchenTicketPrep(gomock.Any(), gomock.Eq(shop.Name)).
+					Times(1).
+					Return([]db.ListOpenKitchenTicketPrepRow{
+						{ID: ticket.ID, OrderID: orderID, StationID: station.ID, ReleaseAt: time.Now(), PrepSeconds: 300},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res createOrderResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.NotNil(t, res.ETA)
+				require.Equal(t, orderID, res.ETA.OrderID)
+				require.InDelta(t, 300, res.ETA.WaitSeconds, 1)
+			},
+		},
+		{
+			name:     "InternalError",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id": orderID,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				expectOpeningHours(store, shop, nil, nil)
+				expectPriceLists(store, shop, nil)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateOrderTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:     "ItemUnavailable",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id": orderID,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				expectOpeningHours(store, shop, nil, nil)
+				expectPriceLists(store, shop, nil)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateOrderTxResult{}, fmt.Errorf("%w: %s", db.ErrItemUnavailable, orderItem.ProductName))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), orderItem.ProductName)
+			},
+		},
+		{
+			name:     "NotOnMenu",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id": orderID,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				expectOpeningHours(store, shop, nil, nil)
+				expectPriceLists(store, shop, nil)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateOrderTxResult{}, fmt.Errorf("%w: %s", db.ErrNotOnMenu, orderItem.ProductName))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), orderItem.ProductName)
+			},
+		},
+		{
+			// items are refunded through the refund, never ordered refunded
+			name:     "RefundedStatus",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id": orderID,
+				"orders": []gin.H{
+					{
+						"shop_name":     orderItemReq.ShopName,
+						"product_id":    orderItemReq.ProductID,
+						"product_name":  orderItemReq.ProductName,
+						"product_price": orderItemReq.ProductPrice,
+						"amount":        orderItemReq.Amount,
+						"status":        utils.StatusRefunded,
+					},
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShopByName(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			// tables are only reached through their table token
+			name:     "RawTableID",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"table_id": tab.TableID.UUID,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShopByName(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "RawTabID",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"tab_id": tab.ID,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "Delivery",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":         orderID,
+				"order_type":       utils.OrderDelivery,
+				"delivery_address": "1 Harbour Road",
+				"contact_name":     "Sam",
+				"contact_phone":    "+85212345678",
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				expectOpeningHours(store, shop, nil, nil)
+				expectPriceLists(store, shop, nil)
+				arg := db.CreateOrderTxParams{
+					Items: []db.CreateOrderItemParams{
+						{
+							ShopName:     orderItem.ShopName,
+							OrderID:      orderID,
+							OrderDay:     orderItem.OrderDay,
+							ProductName:  orderItem.ProductName,
+							ProductPrice: orderItem.ProductPrice,
+							Amount:       orderItem.Amount,
+							Status:       orderItem.Status,
+							ProductID:    orderItem.ProductID,
+							TaxRate:      "0.00",
+						},
+					},
+					OrderType: utils.OrderDelivery,
+					Fulfilment: db.OrderFulfilmentParams{
+						DeliveryAddress: "1 Harbour Road",
+						ContactName:     "Sam",
+						ContactPhone:    "+85212345678",
+					},
+				}
+				fee := orderItem
+				fee.ID = uuid.New()
+				fee.ProductName = "Delivery fee"
+				fee.ProductPrice = "3.00"
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), eqCreateOrderTxParams(arg)).
+					Times(1).
+					Return(db.CreateOrderTxResult{
+						Orders:     []db.Order{orderItem, fee},
+						Fees:       []db.Order{fee},
+						Fulfilment: db.OrderFulfilment{OrderID: orderID, OrderType: utils.OrderDelivery, Status: utils.FulfilmentReceived},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res createOrderResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.Orders, 2)
+				require.Len(t, res.Fees, 1)
+				require.Equal(t, "3.00", res.Fees[0].ProductPrice)
+				require.Equal(t, utils.OrderDelivery, res.Fulfilment.OrderType)
+			},
+		},
+		{
+			name:     "Coupon",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":         orderID,
+				"order_type":       utils.OrderDelivery,
+				"delivery_address": "1 Harbour Road",
+				"contact_phone":    "+85212345678",
+				"coupon_code":      "welcome10",
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				expectOpeningHours(store, shop, nil, nil)
+				expectPriceLists(store, shop, nil)
+				arg := db.CreateOrderTxParams{
+					Items: []db.CreateOrderItemParams{
+						{
+							ShopName:     orderItem.ShopName,
+							OrderID:      orderID,
+							OrderDay:     orderItem.OrderDay,
+							ProductName:  orderItem.ProductName,
+							ProductPrice: orderItem.ProductPrice,
+							Amount:       orderItem.Amount,
+							Status:       orderItem.Status,
+							ProductID:    orderItem.ProductID,
+							TaxRate:      "0.00",
+						},
+					},
+					OrderType: utils.OrderDelivery,
+					Fulfilment: db.OrderFulfilmentParams{
+						DeliveryAddress: "1 Harbour Road",
+						ContactPhone:    "+85212345678",
+					},
+					CouponCode: "welcome10",
+					// redeemed on behalf of the contact phone
+					Customer: "+85212345678",
+				}
+				discount := orderItem
+				discount.ID = uuid.New()
+				discount.ProductPrice = "-1.00"
+				discount.PromotionID = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), eqCreateOrderTxParams(arg)).
+					Times(1).
+					Return(db.CreateOrderTxResult{
+						Orders:     []db.Order{orderItem, discount},
+						Discounts:  []db.Order{discount},
+						Fulfilment: db.OrderFulfilment{OrderID: orderID, OrderType: utils.OrderDelivery},
+						Coupon:     db.Coupon{ID: uuid.New(), Code: "WELCOME10", PromotionID: discount.PromotionID.UUID, Redemptions: 1},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res createOrderResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.Discounts, 1)
+				require.NotNil(t, res.Coupon)
+				require.Equal(t, "WELCOME10", res.Coupon.Code)
+			},
+		},
+		{
+			name:     "CouponUsedUp",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":    orderID,
+				"coupon_code": "FLYER7K2M9QX",
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				expectOpeningHours(store, shop, nil, nil)
+				expectPriceLists(store, shop, nil)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateOrderTxResult{}, db.ErrCouponUsedUp)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "DeliveryWithoutAddress",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":      orderID,
+				"order_type":    utils.OrderDelivery,
+				"contact_phone": "+85212345678",
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "PickupInPast",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":   orderID,
+				"order_type": utils.OrderPickup,
+				"pickup_at":  time.Now().Add(-time.Hour),
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "OrderTypeMismatch",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":   orderID,
+				"order_type": utils.OrderTakeaway,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				expectOpeningHours(store, shop, nil, nil)
+				expectPriceLists(store, shop, nil)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateOrderTxResult{}, db.ErrOrderTypeMismatch)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "ShopClosed",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id": orderID,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				// closed for the day
+				expectOpeningHours(store, shop, nil, []db.OpeningException{{Day: time.Now().UTC().Format("2006-01-02"), Closed: true}})
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "ScheduledPickup",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":      orderID,
+				"order_type":    utils.OrderPickup,
+				"scheduled_for": slot,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				store.EXPECT().
+					GetOrderSchedule(gomock.Any(), gomock.Eq(shop.Name)).
+					Times(1).
+					Return(db.OrderSchedule{}, sql.ErrNoRows)
+				expectOpeningHours(store, shop, nil, nil)
+				expectPriceLists(store, shop, nil)
+				arg := db.CreateOrderTxParams{
+					Items: []db.CreateOrderItemParams{
+						{
+							ShopName:     orderItem.ShopName,
+							OrderID:      orderID,
+							OrderDay:     orderItem.OrderDay,
+							ProductName:  orderItem.ProductName,
+							ProductPrice: orderItem.ProductPrice,
+							Amount:       orderItem.Amount,
+							Status:       orderItem.Status,
+							ProductID:    orderItem.ProductID,
+							TaxRate:      "0.00",
+						},
+					},
+					OrderType: utils.OrderPickup,
+					Fulfilment: db.OrderFulfilmentParams{
+						// collected at the slot
+						PickupAt:     sql.NullTime{Time: slot, Valid: true},
+						ScheduledFor: sql.NullTime{Time: slot, Valid: true},
+					},
+					// promotions apply as at the slot
+					PricedAt: slot,
+				}
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), eqCreateOrderTxParams(arg)).
+					Times(1).
+					Return(db.CreateOrderTxResult{
+						Orders: []db.Order{orderItem},
+						Fulfilment: db.OrderFulfilment{
+							OrderID:      orderID,
+							OrderType:    utils.OrderPickup,
+							ScheduledFor: sql.NullTime{Time: slot, Valid: true},
+						},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res createOrderResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.True(t, res.Fulfilment.ScheduledFor.Time.Equal(slot))
+			},
+		},
+		{
+			name:     "ScheduledTomorrow",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":      orderID,
+				"order_type":    utils.OrderTakeaway,
+				"scheduled_for": slot.AddDate(0, 0, 1),
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				store.EXPECT().
+					GetOrderSchedule(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OrderSchedule{}, sql.ErrNoRows)
+				expectOpeningHours(store, shop, nil, nil)
+				expectPriceLists(store, shop, nil)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateOrderTxParams) (db.CreateOrderTxResult, error) {
+						// booked on the business day of the slot
+						require.Equal(t, utils.BusinessDay(slot.AddDate(0, 0, 1), time.UTC, 0), arg.Items[0].OrderDay)
+						return db.CreateOrderTxResult{Orders: orders}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "SlotNotAligned",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":      orderID,
+				"order_type":    utils.OrderTakeaway,
+				"scheduled_for": slot.Add(5 * time.Minute),
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "SlotTooFarAhead",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":      orderID,
+				"order_type":    utils.OrderTakeaway,
+				"scheduled_for": slot.AddDate(0, 0, 8),
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				store.EXPECT().
+					GetOrderSchedule(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OrderSchedule{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "SlotClosed",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":      orderID,
+				"order_type":    utils.OrderTakeaway,
+				"scheduled_for": slot,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				store.EXPECT().
+					GetOrderSchedule(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.DefaultOrderSchedule(shop.Name), nil)
+				// only open the day after the slot
+				expectOpeningHours(store, shop, []db.OpeningHour{{Weekday: utils.ISOWeekday(slot)%7 + 1, OpensAt: 0, ClosesAt: 1440}}, nil)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "SlotFull",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":      orderID,
+				"order_type":    utils.OrderTakeaway,
+				"scheduled_for": slot,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectGetShop(store, shop)
+				store.EXPECT().
+					GetOrderSchedule(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OrderSchedule{}, sql.ErrNoRows)
+				expectOpeningHours(store, shop, nil, nil)
+				expectPriceLists(store, shop, nil)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateOrderTxResult{}, db.ErrSlotFull)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "PickupWithoutTime",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id":   orderID,
+				"order_type": utils.OrderPickup,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "NoOrderTarget",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			// would return stock instead of selling it
+			name:     "NegativeAmount",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id": orderID,
+				"orders": []createOrderItemRequest{
+					{
+						ShopName:     orderItem.ShopName,
+						ProductName:  orderItem.ProductName,
+						ProductPrice: orderItemFloatPrice,
+						Amount:       -5,
+						Status:       orderItem.Status,
+					},
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "TooManyUnits",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id": orderID,
+				"orders": []createOrderItemRequest{
+					{
+						ShopName:     orderItem.ShopName,
+						ProductName:  orderItem.ProductName,
+						ProductPrice: orderItemFloatPrice,
+						Amount:       1001,
+						Status:       orderItem.Status,
+					},
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "OtherShopItem",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id": orderID,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+					{
+						ShopName:     "othershop",
+						ProductName:  orderItem.ProductName,
+						ProductPrice: orderItemFloatPrice,
+						Amount:       orderItem.Amount,
+						Status:       orderItem.Status,
+					},
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShopByName(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "ShopNotFound",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id": orderID,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShopByName(gomock.Any(), gomock.Eq(shop.Name)).
+					Times(1).
+					Return(db.Shop{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "IncorrectJSONFormat",
+			shopName: orderItem.ShopName,
+			body: gin.H{
+				"order_id": orderID,
+				"orders":   "",
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			jsonReader := bytes.NewReader(jsonData)
+
+			url := fmt.Sprintf("/%v/order", tc.shopName)
+			req, err := http.NewRequest(http.MethodPost, url, jsonReader)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreateShopOrder(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	menuItem := createMenuItem(shop, product, "breakfast")
+
+	orderItem := addOrderItem(menuItem, uuid.Nil)
+	orderItem.OrderDay = utils.BusinessDay(time.Now(), time.UTC, 0)
+
+	orderItemFloatPrice, err := strconv.ParseFloat(orderItem.ProductPrice, 64)
+	require.NoError(t, err)
+
+	orderItemReq := createOrderItemRequest{
+		ShopName:     orderItem.ShopName,
+		ProductID:    product.ID,
+		ProductName:  orderItem.ProductName,
+		ProductPrice: orderItemFloatPrice,
+		Amount:       orderItem.Amount,
+		Status:       orderItem.Status,
+		TaxRate:      5,
+	}
+
+	tab := randomTab(shop, utils.TabOpen)
+	slot := utils.SlotStart(time.Now().Add(2 * time.Hour)).UTC()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "TableOrder",
+			body: gin.H{
+				"table_id": tab.TableID.UUID,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectPriceLists(store, shop, nil)
+				arg := db.CreateOrderTxParams{
+					TableID:   tab.TableID.UUID,
+					OpenItems: true,
+					Items: []db.CreateOrderItemParams{
+						{
+							ShopName:     orderItem.ShopName,
+							OrderDay:     orderItem.OrderDay,
+							ProductName:  orderItem.ProductName,
+							ProductPrice: orderItem.ProductPrice,
+							Amount:       orderItem.Amount,
+							Status:       orderItem.Status,
+							ProductID:    orderItem.ProductID,
+							TaxRate:      orderItem.TaxRate,
+						},
+					},
+				}
+				tabItem := orderItem
+				tabItem.OrderID = tab.OrderID
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), eqCreateOrderTxParams(arg)).
+					Times(1).
+					Return(db.CreateOrderTxResult{Orders: []db.Order{tabItem}, Tab: tab}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res createOrderResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.NotNil(t, res.Tab)
+				require.Equal(t, tab.ID, res.Tab.ID)
+				require.Equal(t, tab.OrderID, res.Orders[0].OrderID)
+			},
+		},
+		{
+			// staff ring up orders outside opening hours
+			name: "ShopClosed",
+			body: gin.H{
+				"order_id": uuid.New(),
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListOpeningHours(gomock.Any(), gomock.Any()).
+					Times(0)
+				expectPriceLists(store, shop, nil)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateOrderTxResult{Orders: []db.Order{orderItem}}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "RefundedStatus",
+			body: gin.H{
+				"order_id": uuid.New(),
+				"orders": []gin.H{
+					{
+						"shop_name":     orderItemReq.ShopName,
+						"product_name":  orderItemReq.ProductName,
+						"product_price": orderItemReq.ProductPrice,
+						"amount":        orderItemReq.Amount,
+						"status":        utils.StatusRefunded,
+					},
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "TabNotOpen",
+			body: gin.H{
+				"tab_id": tab.ID,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				expectPriceLists(store, shop, nil)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateOrderTxResult{}, db.ErrTabNotOpen)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "TakeawayOnTab",
+			body: gin.H{
+				"tab_id":     tab.ID,
+				"order_type": utils.OrderTakeaway,
+				"orders": []createOrderItemRequest{
+					orderItemReq,
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ScheduledOnTab",
 			body: gin.H{
-				"order_id": orderID,
+				"tab_id":        tab.ID,
+				"scheduled_for": slot,
 				"orders": []createOrderItemRequest{
 					orderItemReq,
 				},
 			},
 			buildStub: func(store *mockdb.MockStore) {
-				arg := db.CreateOrderItemParams{
-					ID:           orderItem.ID,
-					ShopName:     orderItem.ShopName,
-					OrderID:      orderID,
-					OrderDay:     orderItem.OrderDay,
-					ProductName:  orderItem.ProductName,
-					ProductPrice: orderItem.ProductPrice,
-					Amount:       orderItem.Amount,
-					Status:       orderItem.Status,
-				}
 				store.EXPECT().
-					CreateOrderItem(gomock.Any(), eqCreateOrderItemParams(arg)).
-					Times(1).
-					Return(orderItem, nil)
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchOrder(t, recorder.Body, orders)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
-			name:     "InternalError",
-			shopName: orderItem.ShopName,
+			// table tokens are for the public order route
+			name: "TableToken",
 			body: gin.H{
-				"order_id": orderID,
+				"table_token": "token",
 				"orders": []createOrderItemRequest{
 					orderItemReq,
 				},
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateOrderItem(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(db.Order{}, sql.ErrConnDone)
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
-			name:     "IncorrectJSONFormat",
-			shopName: orderItem.ShopName,
+			name: "OtherShopItem",
 			body: gin.H{
-				"order_id": orderID,
-				"orders":   "",
+				"tab_id": tab.ID,
+				"orders": []createOrderItemRequest{
+					{
+						ShopName:     "othershop",
+						ProductName:  orderItem.ProductName,
+						ProductPrice: orderItemFloatPrice,
+						Amount:       orderItem.Amount,
+						Status:       orderItem.Status,
+					},
+				},
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateOrderItem(gomock.Any(), gomock.Any()).
+					CreateOrderTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -201,29 +1251,20 @@ func TestCreateOrderItem(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
 			tc.buildStub(store)
 
-			server := newTestServer(t, store)
-			recorder := httptest.NewRecorder()
-
-			jsonData, err := json.Marshal(tc.body)
-			require.NoError(t, err)
-			jsonReader := bytes.NewReader(jsonData)
-
-			url := fmt.Sprintf("/%v/order", tc.shopName)
-			req, err := http.NewRequest(http.MethodPost, url, jsonReader)
-			require.NoError(t, err)
-
-			server.router.ServeHTTP(recorder, req)
-			tc.checkResponse(t, recorder)
+			url := fmt.Sprintf("/shops/%s/orders", shop.ID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPost, url, tc.body))
 		})
 	}
 }
 
 func TestUpdateOrderItem(t *testing.T) {
 	user, _ := randomUser(t)
-	product := randomProduct(user)
-	menuItem := createMenuItem(user, product, "breakfast")
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	menuItem := createMenuItem(shop, product, "breakfast")
 
 	orderID := uuid.New()
 	orderItem := addOrderItem(menuItem, orderID)
@@ -243,7 +1284,6 @@ func TestUpdateOrderItem(t *testing.T) {
 
 	testCases := []struct {
 		name          string
-		user          db.User
 		orderId       uuid.UUID
 		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
@@ -252,12 +1292,10 @@ func TestUpdateOrderItem(t *testing.T) {
 	}{
 		{
 			name: "OK",
-			user: user,
 			body: gin.H{
-				"id":        updatedOrderItem.ID,
-				"shop_name": updatedOrderItem.ShopName,
-				"amount":    updatedPrice,
-				"status":    updatedOrderItem.Status,
+				"id":     updatedOrderItem.ID,
+				"amount": updatedPrice,
+				"status": updatedOrderItem.Status,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
@@ -270,7 +1308,7 @@ func TestUpdateOrderItem(t *testing.T) {
 					Status:   updatedOrderItem.Status,
 				}
 				store.EXPECT().
-					UpdateOrderItem(gomock.Any(), gomock.Eq(arg)).
+					UpdateOrderItemTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(updatedOrderItem, nil)
 			},
@@ -281,19 +1319,17 @@ func TestUpdateOrderItem(t *testing.T) {
 		},
 		{
 			name: "InternalError",
-			user: user,
 			body: gin.H{
-				"id":        updatedOrderItem.ID,
-				"shop_name": updatedOrderItem.ShopName,
-				"amount":    updatedPrice,
-				"status":    updatedOrderItem.Status,
+				"id":     updatedOrderItem.ID,
+				"amount": updatedPrice,
+				"status": updatedOrderItem.Status,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					UpdateOrderItem(gomock.Any(), gomock.Any()).
+					UpdateOrderItemTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(db.Order{}, sql.ErrConnDone)
 			},
@@ -301,16 +1337,114 @@ func TestUpdateOrderItem(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "DayClosed",
+			body: gin.H{
+				"id":     updatedOrderItem.ID,
+				"amount": updatedPrice,
+				"status": updatedOrderItem.Status,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Order{}, db.ErrDayClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "RoundDiscounted",
+			body: gin.H{
+				"id":     updatedOrderItem.ID,
+				"amount": updatedPrice,
+				"status": updatedOrderItem.Status,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Order{}, db.ErrRoundDiscounted)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "SetRefunded",
+			body: gin.H{
+				"id":     updatedOrderItem.ID,
+				"amount": 1,
+				"status": utils.StatusRefunded,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Order{}, db.ErrRefundStatus)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeAmount",
+			body: gin.H{
+				"id":     updatedOrderItem.ID,
+				"amount": -3,
+				"status": updatedOrderItem.Status,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{
+				"id":     updatedOrderItem.ID,
+				"amount": updatedPrice,
+				"status": updatedOrderItem.Status,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Order{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 		{
 			name: "MissingJSONData",
-			user: user,
 			body: gin.H{},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					UpdateOrderItem(gomock.Any(), gomock.Any()).
+					UpdateOrderItemTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -319,19 +1453,17 @@ func TestUpdateOrderItem(t *testing.T) {
 		},
 		{
 			name: "UnauthorizatedUser",
-			user: user,
 			body: gin.H{
-				"id":        updatedOrderItem.ID,
-				"shop_name": updatedOrderItem.ShopName,
-				"amount":    updatedPrice,
-				"status":    updatedOrderItem.Status,
+				"id":     updatedOrderItem.ID,
+				"amount": updatedPrice,
+				"status": updatedOrderItem.Status,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "UnauthorizatedUser", time.Minute)
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					UpdateOrderItem(gomock.Any(), gomock.Any()).
+					UpdateOrderItemTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -348,6 +1480,7 @@ func TestUpdateOrderItem(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
 			tc.buildStub(store)
 
 			server := newTestServer(t, store)
@@ -357,7 +1490,7 @@ func TestUpdateOrderItem(t *testing.T) {
 			require.NoError(t, err)
 			jsonReader := bytes.NewReader(jsonData)
 
-			url := fmt.Sprintf("/users/%v/orders/%v", tc.user.Username, tc.orderId)
+			url := fmt.Sprintf("/shops/%v/orders/%v", shop.ID, tc.orderId)
 			req, err := http.NewRequest(http.MethodPatch, url, jsonReader)
 			require.NoError(t, err)
 
@@ -371,15 +1504,15 @@ func TestUpdateOrderItem(t *testing.T) {
 
 func TestDeleteOrderItem(t *testing.T) {
 	user, _ := randomUser(t)
-	product := randomProduct(user)
-	menuItem := createMenuItem(user, product, "breakfast")
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	menuItem := createMenuItem(shop, product, "breakfast")
 
 	orderID := uuid.New()
 	orderItem := addOrderItem(menuItem, orderID)
 
 	testCases := []struct {
 		name          string
-		user          db.User
 		orderId       uuid.UUID
 		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
@@ -388,10 +1521,8 @@ func TestDeleteOrderItem(t *testing.T) {
 	}{
 		{
 			name: "OK",
-			user: user,
 			body: gin.H{
-				"id":        orderItem.ID,
-				"shop_name": orderItem.ShopName,
+				"id": orderItem.ID,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
@@ -402,7 +1533,7 @@ func TestDeleteOrderItem(t *testing.T) {
 					ShopName: orderItem.ShopName,
 				}
 				store.EXPECT().
-					DeleteOrderItem(gomock.Any(), gomock.Eq(arg)).
+					DeleteOrderItemTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(nil)
 			},
@@ -412,17 +1543,15 @@ func TestDeleteOrderItem(t *testing.T) {
 		},
 		{
 			name: "InternalError",
-			user: user,
 			body: gin.H{
-				"id":        orderItem.ID,
-				"shop_name": orderItem.ShopName,
+				"id": orderItem.ID,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteOrderItem(gomock.Any(), gomock.Any()).
+					DeleteOrderItemTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(sql.ErrConnDone)
 			},
@@ -430,19 +1559,53 @@ func TestDeleteOrderItem(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "DiscountLine",
+			body: gin.H{
+				"id": orderItem.ID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ErrAdjustmentLine)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "DayClosed",
+			body: gin.H{
+				"id": orderItem.ID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ErrDayClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
 		{
 			name: "UnauthorizatedUser",
-			user: user,
 			body: gin.H{
-				"id":        orderItem.ID,
-				"shop_name": orderItem.ShopName,
+				"id": orderItem.ID,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "UnauthorizatedUser", time.Minute)
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteOrderItem(gomock.Any(), gomock.Any()).
+					DeleteOrderItemTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -451,14 +1614,13 @@ func TestDeleteOrderItem(t *testing.T) {
 		},
 		{
 			name: "MissingJSONData",
-			user: user,
 			body: gin.H{},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteOrderItem(gomock.Any(), gomock.Any()).
+					DeleteOrderItemTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -475,6 +1637,7 @@ func TestDeleteOrderItem(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
 			tc.buildStub(store)
 
 			server := newTestServer(t, store)
@@ -484,7 +1647,7 @@ func TestDeleteOrderItem(t *testing.T) {
 			require.NoError(t, err)
 			jsonReader := bytes.NewReader(jsonData)
 
-			url := fmt.Sprintf("/users/%v/orders/%v", tc.user.Username, tc.orderId)
+			url := fmt.Sprintf("/shops/%v/orders/%v", shop.ID, tc.orderId)
 			req, err := http.NewRequest(http.MethodDelete, url, jsonReader)
 			require.NoError(t, err)
 
@@ -498,8 +1661,9 @@ func TestDeleteOrderItem(t *testing.T) {
 
 func TestGetOrdersByDay(t *testing.T) {
 	user, _ := randomUser(t)
-	product := randomProduct(user)
-	menuItem := createMenuItem(user, product, "breakfast")
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	menuItem := createMenuItem(shop, product, "breakfast")
 
 	orderID := uuid.New()
 	orderItem := addOrderItem(menuItem, orderID)
@@ -508,7 +1672,6 @@ func TestGetOrdersByDay(t *testing.T) {
 
 	testCases := []struct {
 		name          string
-		user          db.User
 		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		buildStub     func(store *mockdb.MockStore)
@@ -516,9 +1679,7 @@ func TestGetOrdersByDay(t *testing.T) {
 	}{
 		{
 			name: "OK",
-			user: user,
 			body: gin.H{
-				"shop_name": orderItem.ShopName,
 				"order_day": orderItem.OrderDay,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -541,9 +1702,7 @@ func TestGetOrdersByDay(t *testing.T) {
 		},
 		{
 			name: "InternalError",
-			user: user,
 			body: gin.H{
-				"shop_name": orderItem.ShopName,
 				"order_day": orderItem.OrderDay,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -561,7 +1720,6 @@ func TestGetOrdersByDay(t *testing.T) {
 		},
 		{
 			name: "MissingJSONData",
-			user: user,
 			body: gin.H{},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
@@ -577,9 +1735,7 @@ func TestGetOrdersByDay(t *testing.T) {
 		},
 		{
 			name: "UnauthorizatedUser",
-			user: user,
 			body: gin.H{
-				"shop_name": orderItem.ShopName,
 				"order_day": orderItem.OrderDay,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -604,6 +1760,7 @@ func TestGetOrdersByDay(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
 			tc.buildStub(store)
 
 			server := newTestServer(t, store)
@@ -613,7 +1770,7 @@ func TestGetOrdersByDay(t *testing.T) {
 			require.NoError(t, err)
 			jsonReader := bytes.NewReader(jsonData)
 
-			url := fmt.Sprintf("/users/%v/orders", tc.user.Username)
+			url := fmt.Sprintf("/shops/%v/orders", shop.ID)
 			req, err := http.NewRequest(http.MethodGet, url, jsonReader)
 			require.NoError(t, err)
 
@@ -627,8 +1784,9 @@ func TestGetOrdersByDay(t *testing.T) {
 
 func TestGetOrdersByOrderID(t *testing.T) {
 	user, _ := randomUser(t)
-	product := randomProduct(user)
-	menuItem := createMenuItem(user, product, "breakfast")
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	menuItem := createMenuItem(shop, product, "breakfast")
 
 	orderID := uuid.New()
 	orderItem := addOrderItem(menuItem, orderID)
@@ -726,3 +1884,162 @@ func TestGetOrdersByOrderID(t *testing.T) {
 		})
 	}
 }
+
+func TestRefundOrderItem(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	menuItem := createMenuItem(shop, product, "breakfast")
+
+	orderItem := addOrderItem(menuItem, uuid.New())
+	refundedItem := orderItem
+	refundedItem.Status = utils.StatusRefunded
+
+	movement := db.StockMovement{
+		ID:           uuid.New(),
+		ShopName:     orderItem.ShopName,
+		ProductID:    product.ID,
+		MovementType: utils.MovementRefund,
+		Quantity:     orderItem.Amount,
+		OrderItemID:  uuid.NullUUID{UUID: orderItem.ID, Valid: true},
+	}
+
+	testCases := []struct {
+		name          string
+		orderItemID   string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			orderItemID: orderItem.ID.String(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.RefundOrderItemTxParams{
+					ShopName: shop.Name,
+					ID:       orderItem.ID,
+				}
+				store.EXPECT().
+					RefundOrderItemTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.RefundOrderItemTxResult{Order: refundedItem, Movements: []db.StockMovement{movement}}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.RefundOrderItemTxResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, utils.StatusRefunded, res.Order.Status)
+				require.Len(t, res.Movements, 1)
+				require.Equal(t, orderItem.Amount, res.Movements[0].Quantity)
+			},
+		},
+		{
+			name:        "AlreadyRefunded",
+			orderItemID: orderItem.ID.String(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RefundOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RefundOrderItemTxResult{}, db.ErrAlreadyRefunded)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:        "AdjustmentLine",
+			orderItemID: orderItem.ID.String(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RefundOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RefundOrderItemTxResult{}, db.ErrAdjustmentLine)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			orderItemID: orderItem.ID.String(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RefundOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RefundOrderItemTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:        "InvalidID",
+			orderItemID: "invalid",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RefundOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "UnauthorizatedUser",
+			orderItemID: orderItem.ID.String(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorizatedUser", time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RefundOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shops/%v/orders/%v/refund", shop.ID, tc.orderItemID)
+			req, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,386 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/token"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+const organisationMemberKey = "organisation_member"
+
+type organisationUri struct {
+	OrganisationID string `uri:"organisation_id" binding:"required,uuid"`
+}
+
+// load the caller's membership of a /organisations/:organisation_id route.
+// only owners and managers of the whole organisation get through, members
+// tied to a shop work through the shop routes
+func organisationMiddleware(store db.Store) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var uri organisationUri
+		if err := ctx.ShouldBindUri(&uri); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		member, err := store.GetOrganisationMember(ctx, db.GetOrganisationMemberParams{
+			OrganisationID: uuid.MustParse(uri.OrganisationID),
+			Username:       authPayload.Username,
+		})
+		if err != nil && err != sql.ErrNoRows {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if err == sql.ErrNoRows || member.ShopID.Valid || member.Role == utils.RoleStaff {
+			err := errors.New("unauthorizated user")
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+
+		ctx.Set(organisationMemberKey, member)
+		ctx.Next()
+	}
+}
+
+// the membership loaded by organisationMiddleware
+func currentMember(ctx *gin.Context) db.OrganisationMember {
+	return ctx.MustGet(organisationMemberKey).(db.OrganisationMember)
+}
+
+// shop names are the public handle of a shop, e.g. /harbourcafe/menus
+type createShopRequest struct {
+	Name        string `json:"name" binding:"required,alphanum,max=50"`
+	DisplayName string `json:"display_name" binding:"max=100"`
+	Currency    string `json:"currency"`
+	Timezone    string `json:"timezone"`
+	Locale      string `json:"locale"`
+}
+
+// fill in the defaults and check the settings of a new shop
+func (req *createShopRequest) params(ctx *gin.Context) (db.CreateShopParams, bool) {
+	if req.Currency == "" {
+		req.Currency = utils.DefaultCurrency
+	}
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	}
+	if req.Locale == "" {
+		req.Locale = utils.DefaultLocale
+	}
+
+	if !utils.IsValidCurrency(req.Currency) {
+		err := fmt.Errorf("unsupported currency %q", req.Currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.CreateShopParams{}, false
+	}
+	if !utils.IsValidLocale(req.Locale) {
+		err := fmt.Errorf("unsupported locale %q", req.Locale)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.CreateShopParams{}, false
+	}
+	if !checkTimezone(ctx, req.Timezone) {
+		return db.CreateShopParams{}, false
+	}
+
+	return db.CreateShopParams{
+		Name:        req.Name,
+		DisplayName: req.DisplayName,
+		Currency:    req.Currency,
+		Timezone:    req.Timezone,
+		Locale:      req.Locale,
+	}, true
+}
+
+type createOrganisationRequest struct {
+	Name string            `json:"name" binding:"required,max=100"`
+	Shop createShopRequest `json:"shop" binding:"required"`
+}
+
+type organisationResponse struct {
+	ID        uuid.UUID      `json:"id"`
+	Name      string         `json:"name"`
+	CreatedAt time.Time      `json:"created_at"`
+	Shops     []shopResponse `json:"shops"`
+}
+
+// create an organisation with its first shop, owned by the caller
+func (server *Server) createOrganisation(ctx *gin.Context) {
+	var req createOrganisationRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop, ok := req.Shop.params(ctx)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.CreateOrganisationTx(ctx, db.CreateOrganisationTxParams{
+		Name:    req.Name,
+		OwnerID: user.ID,
+		Shop:    shop,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organisationResponse{
+		ID:        result.Organisation.ID,
+		Name:      result.Organisation.Name,
+		CreatedAt: result.Organisation.CreatedAt,
+		Shops:     []shopResponse{newShopResponse(result.Shop)},
+	})
+}
+
+func (server *Server) getOrganisation(ctx *gin.Context) {
+	member := currentMember(ctx)
+
+	organisation, err := server.store.GetOrganisation(ctx, member.OrganisationID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	shops, err := server.store.ListOrganisationShops(ctx, member.OrganisationID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organisationResponse{
+		ID:        organisation.ID,
+		Name:      organisation.Name,
+		CreatedAt: organisation.CreatedAt,
+		Shops:     newShopResponses(shops),
+	})
+}
+
+// open another location of the organisation
+func (server *Server) createShop(ctx *gin.Context) {
+	var req createShopRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg, ok := req.params(ctx)
+	if !ok {
+		return
+	}
+	arg.ID = uuid.New()
+	arg.OrganisationID = currentMember(ctx).OrganisationID
+
+	shop, err := server.store.CreateShop(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newShopResponse(shop))
+}
+
+type organisationMemberResponse struct {
+	UserID    uuid.UUID  `json:"user_id"`
+	Username  string     `json:"username"`
+	ShopID    *uuid.UUID `json:"shop_id"` // null for members of every shop
+	Role      string     `json:"role"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func newOrganisationMemberResponse(member db.ListOrganisationMembersRow) organisationMemberResponse {
+	res := organisationMemberResponse{
+		UserID:    member.UserID,
+		Username:  member.Username,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+	}
+	if member.ShopID.Valid {
+		res.ShopID = &member.ShopID.UUID
+	}
+	return res
+}
+
+func (server *Server) getOrganisationMembers(ctx *gin.Context) {
+	members, err := server.store.ListOrganisationMembers(ctx, currentMember(ctx).OrganisationID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]organisationMemberResponse, len(members))
+	for i, member := range members {
+		res[i] = newOrganisationMemberResponse(member)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// only owners manage the members. a member given a shop only works there
+type addOrganisationMemberRequest struct {
+	Username string     `json:"username" binding:"required,alphanum"`
+	ShopID   *uuid.UUID `json:"shop_id"`
+	Role     string     `json:"role" binding:"required,oneof=manager staff"`
+}
+
+func (server *Server) addOrganisationMember(ctx *gin.Context) {
+	var req addOrganisationMemberRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	member := currentMember(ctx)
+	if member.Role != utils.RoleOwner {
+		err := errors.New("only owners can manage members")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	arg := db.AddOrganisationMemberParams{
+		OrganisationID: member.OrganisationID,
+		Role:           req.Role,
+	}
+
+	if req.ShopID != nil {
+		shop, err := server.store.GetShop(ctx, *req.ShopID)
+		if err != nil && err != sql.ErrNoRows {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if err == sql.ErrNoRows || shop.OrganisationID != member.OrganisationID {
+			err := errors.New("shop not found in the organisation")
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		arg.ShopID = uuid.NullUUID{UUID: shop.ID, Valid: true}
+	}
+
+	user, err := server.store.GetUser(ctx, req.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	arg.UserID = user.ID
+
+	added, err := server.store.AddOrganisationMember(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newOrganisationMemberResponse(db.ListOrganisationMembersRow{
+		UserID:    added.UserID,
+		Username:  user.Username,
+		ShopID:    added.ShopID,
+		Role:      added.Role,
+		CreatedAt: added.CreatedAt,
+	}))
+}
+
+type organisationMemberUri struct {
+	UserID string `uri:"user_id" binding:"required,uuid"`
+}
+
+// owners cannot be removed, an organisation keeps the one it was created by
+func (server *Server) deleteOrganisationMember(ctx *gin.Context) {
+	var uri organisationMemberUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	member := currentMember(ctx)
+	if member.Role != utils.RoleOwner {
+		err := errors.New("only owners can manage members")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeleteOrganisationMember(ctx, db.DeleteOrganisationMemberParams{
+		OrganisationID: member.OrganisationID,
+		UserID:         uuid.MustParse(uri.UserID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rows == 0 {
+		err := errors.New("member not found")
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, textResponse("delete successfully"))
+}
+
+// every shop the user works at, to pick one after logging in
+func (server *Server) getUserShops(ctx *gin.Context) {
+	var uri getUserRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Username != uri.Username {
+		err := errors.New("unauthorizated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	shops, err := server.store.ListMemberShops(ctx, uri.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newShopResponses(shops))
+}
@@ -0,0 +1,426 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func randomMember(user db.User, organisationID uuid.UUID, role string) db.OrganisationMember {
+	return db.OrganisationMember{
+		OrganisationID: organisationID,
+		UserID:         user.ID,
+		Role:           role,
+		CreatedAt:      user.CreatedAt,
+	}
+}
+
+// let the user through organisationMiddleware with the membership, every
+// other user looks like an outsider
+func expectOrganisationMember(store *mockdb.MockStore, member db.OrganisationMember, user db.User) {
+	arg := db.GetOrganisationMemberParams{
+		OrganisationID: member.OrganisationID,
+		Username:       user.Username,
+	}
+	store.EXPECT().
+		GetOrganisationMember(gomock.Any(), gomock.Eq(arg)).
+		AnyTimes().
+		Return(member, nil)
+	store.EXPECT().
+		GetOrganisationMember(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(db.OrganisationMember{}, sql.ErrNoRows)
+}
+
+func TestCreateOrganisation(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	organisation := db.Organisation{
+		ID:        shop.OrganisationID,
+		Name:      "Harbour Group",
+		CreatedAt: user.CreatedAt,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": organisation.Name, "shop": gin.H{"name": shop.Name}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				arg := db.CreateOrganisationTxParams{
+					Name:    organisation.Name,
+					OwnerID: user.ID,
+					Shop: db.CreateShopParams{
+						Name:     shop.Name,
+						Currency: utils.DefaultCurrency,
+						Timezone: "UTC",
+						Locale:   utils.DefaultLocale,
+					},
+				}
+				store.EXPECT().
+					CreateOrganisationTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.CreateOrganisationTxResult{
+						Organisation: organisation,
+						Shop:         shop,
+						Owner:        randomMember(user, organisation.ID, utils.RoleOwner),
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res organisationResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, organisation.ID, res.ID)
+				require.Len(t, res.Shops, 1)
+				require.Equal(t, shop.ID, res.Shops[0].ID)
+			},
+		},
+		{
+			name: "ShopNameTaken",
+			body: gin.H{"name": organisation.Name, "shop": gin.H{"name": shop.Name}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateOrganisationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateOrganisationTxResult{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownCurrency",
+			body: gin.H{"name": organisation.Name, "shop": gin.H{"name": shop.Name, "currency": "XYZ"}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOrganisationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStub(store)
+
+			recorder := serveKitchen(t, store, user, http.MethodPost, "/organisations", tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreateShop(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	branch := randomShop(user)
+	branch.OrganisationID = shop.OrganisationID
+	branch.Name = utils.RandString(6)
+
+	testCases := []struct {
+		name          string
+		member        db.OrganisationMember
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			member: randomMember(user, shop.OrganisationID, utils.RoleManager),
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateShop(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateShopParams) (db.Shop, error) {
+						require.Equal(t, shop.OrganisationID, arg.OrganisationID)
+						require.Equal(t, branch.Name, arg.Name)
+						return branch, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res shopResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, branch.ID, res.ID)
+			},
+		},
+		{
+			name:   "Staff",
+			member: randomMember(user, shop.OrganisationID, utils.RoleStaff),
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateShop(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "ShopManager",
+			member: db.OrganisationMember{
+				OrganisationID: shop.OrganisationID,
+				UserID:         user.ID,
+				ShopID:         uuid.NullUUID{UUID: shop.ID, Valid: true},
+				Role:           utils.RoleManager,
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateShop(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectOrganisationMember(store, tc.member, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/organisations/%v/shops", shop.OrganisationID)
+			recorder := serveKitchen(t, store, user, http.MethodPost, url, gin.H{"name": branch.Name})
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestAddOrganisationMember(t *testing.T) {
+	owner, _ := randomUser(t)
+	shop := randomShop(owner)
+	waiter, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		role          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: utils.RoleOwner,
+			body: gin.H{"username": waiter.Username, "shop_id": shop.ID, "role": utils.RoleStaff},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShop(gomock.Any(), gomock.Eq(shop.ID)).
+					Times(1).
+					Return(shop, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(waiter.Username)).
+					Times(1).
+					Return(waiter, nil)
+
+				arg := db.AddOrganisationMemberParams{
+					OrganisationID: shop.OrganisationID,
+					UserID:         waiter.ID,
+					ShopID:         uuid.NullUUID{UUID: shop.ID, Valid: true},
+					Role:           utils.RoleStaff,
+				}
+				store.EXPECT().
+					AddOrganisationMember(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.OrganisationMember{
+						OrganisationID: arg.OrganisationID,
+						UserID:         arg.UserID,
+						ShopID:         arg.ShopID,
+						Role:           arg.Role,
+						CreatedAt:      time.Now(),
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res organisationMemberResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, waiter.Username, res.Username)
+				require.Equal(t, shop.ID, *res.ShopID)
+			},
+		},
+		{
+			name: "Manager",
+			role: utils.RoleManager,
+			body: gin.H{"username": waiter.Username, "role": utils.RoleStaff},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AddOrganisationMember(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "ShopOfOtherOrganisation",
+			role: utils.RoleOwner,
+			body: gin.H{"username": waiter.Username, "shop_id": shop.ID, "role": utils.RoleStaff},
+			buildStub: func(store *mockdb.MockStore) {
+				other := shop
+				other.OrganisationID = uuid.New()
+				store.EXPECT().
+					GetShop(gomock.Any(), gomock.Eq(shop.ID)).
+					Times(1).
+					Return(other, nil)
+				store.EXPECT().
+					AddOrganisationMember(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "SecondOwner",
+			role: utils.RoleOwner,
+			body: gin.H{"username": waiter.Username, "role": utils.RoleOwner},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AddOrganisationMember(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectOrganisationMember(store, randomMember(owner, shop.OrganisationID, tc.role), owner)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/organisations/%v/members", shop.OrganisationID)
+			recorder := serveKitchen(t, store, owner, http.MethodPost, url, tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteOrganisationMember(t *testing.T) {
+	owner, _ := randomUser(t)
+	shop := randomShop(owner)
+
+	testCases := []struct {
+		name          string
+		userID        uuid.UUID
+		rows          int64
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			userID: uuid.New(),
+			rows:   1,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			// the query never deletes the owner
+			name:   "Owner",
+			userID: owner.ID,
+			rows:   0,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectOrganisationMember(store, randomMember(owner, shop.OrganisationID, utils.RoleOwner), owner)
+			store.EXPECT().
+				DeleteOrganisationMember(gomock.Any(), gomock.Eq(db.DeleteOrganisationMemberParams{
+					OrganisationID: shop.OrganisationID,
+					UserID:         tc.userID,
+				})).
+				Times(1).
+				Return(tc.rows, nil)
+
+			url := fmt.Sprintf("/organisations/%v/members/%v", shop.OrganisationID, tc.userID)
+			recorder := serveKitchen(t, store, owner, http.MethodDelete, url, nil)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetUserShops(t *testing.T) {
+	user, _ := randomUser(t)
+	shops := []db.Shop{randomShop(user), randomShop(user)}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListMemberShops(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(shops, nil)
+
+	url := fmt.Sprintf("/users/%v/shops", user.Username)
+	recorder := serveKitchen(t, store, user, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res []shopResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, shops[1].ID, res[1].ID)
+}
@@ -0,0 +1,365 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// when a price list applies, an empty channel or order type matches every
+// one and weekday 0 every day. times are HH:MM on the shop's clock, the
+// window defaults to the whole day
+type priceListRuleRequest struct {
+	Channel   string `json:"channel" binding:"omitempty,oneof=pos qr online"`
+	OrderType string `json:"order_type" binding:"omitempty,oneof=dine_in takeaway pickup delivery"`
+	Weekday   int32  `json:"weekday" binding:"min=0,max=7"`
+	StartsAt  string `json:"starts_at"`
+	EndsAt    string `json:"ends_at"`
+}
+
+// a list without rules never applies, active defaults to true
+type priceListRequest struct {
+	Name     string                 `json:"name" binding:"required"`
+	Priority int32                  `json:"priority"`
+	Active   *bool                  `json:"active"`
+	Rules    []priceListRuleRequest `json:"rules" binding:"dive"`
+}
+
+type priceListRuleResponse struct {
+	Channel   string `json:"channel"`
+	OrderType string `json:"order_type"`
+	Weekday   int32  `json:"weekday"`
+	StartsAt  string `json:"starts_at"`
+	EndsAt    string `json:"ends_at"`
+}
+
+type priceListResponse struct {
+	ID        uuid.UUID               `json:"id"`
+	Name      string                  `json:"name"`
+	Priority  int32                   `json:"priority"`
+	Active    bool                    `json:"active"`
+	CreatedAt time.Time               `json:"created_at"`
+	Rules     []priceListRuleResponse `json:"rules"`
+}
+
+func newPriceListResponse(priceList db.PriceList, rules []db.PriceListRule) priceListResponse {
+	res := priceListResponse{
+		ID:        priceList.ID,
+		Name:      priceList.Name,
+		Priority:  priceList.Priority,
+		Active:    priceList.Active,
+		CreatedAt: priceList.CreatedAt,
+		Rules:     []priceListRuleResponse{},
+	}
+	for _, rule := range rules {
+		if rule.PriceListID != priceList.ID {
+			continue
+		}
+		res.Rules = append(res.Rules, priceListRuleResponse{
+			Channel:   rule.Channel,
+			OrderType: rule.OrderType,
+			Weekday:   rule.Weekday,
+			StartsAt:  utils.FormatTimeOfDay(rule.StartsAt),
+			EndsAt:    utils.FormatTimeOfDay(rule.EndsAt),
+		})
+	}
+	return res
+}
+
+// build the params of a price list from the request, writes the error
+// response and returns false when a rule has an invalid time window
+func newSetPriceListTxParams(ctx *gin.Context, shopName string, req priceListRequest) (db.SetPriceListTxParams, bool) {
+	arg := db.SetPriceListTxParams{
+		ShopName: shopName,
+		Name:     req.Name,
+		Priority: req.Priority,
+		Active:   req.Active == nil || *req.Active,
+	}
+
+	for _, rule := range req.Rules {
+		startsAt, endsAt := int32(0), int32(24*60)
+		var err error
+		if rule.StartsAt != "" {
+			if startsAt, err = utils.ParseTimeOfDay(rule.StartsAt); err != nil {
+				ctx.JSON(http.StatusBadRequest, errorResponse(err))
+				return arg, false
+			}
+		}
+		if rule.EndsAt != "" {
+			if endsAt, err = utils.ParseTimeOfDay(rule.EndsAt); err != nil {
+				ctx.JSON(http.StatusBadRequest, errorResponse(err))
+				return arg, false
+			}
+		}
+		if endsAt <= startsAt {
+			err := fmt.Errorf("ends_at %s must be after starts_at %s", utils.FormatTimeOfDay(endsAt), utils.FormatTimeOfDay(startsAt))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return arg, false
+		}
+		arg.Rules = append(arg.Rules, db.PriceListRuleParams{
+			Channel:   rule.Channel,
+			OrderType: rule.OrderType,
+			Weekday:   rule.Weekday,
+			StartsAt:  startsAt,
+			EndsAt:    endsAt,
+		})
+	}
+
+	return arg, true
+}
+
+func (server *Server) createPriceList(ctx *gin.Context) {
+	var req priceListRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg, ok := newSetPriceListTxParams(ctx, shop.Name, req)
+	if !ok {
+		return
+	}
+
+	result, err := server.store.CreatePriceListTx(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPriceListResponse(result.PriceList, result.Rules))
+}
+
+// lists of the shop in priority order, highest first
+func (server *Server) getPriceLists(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	priceLists, err := server.store.ListPriceLists(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rules, err := server.store.ListPriceListRules(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]priceListResponse, 0, len(priceLists))
+	for _, priceList := range priceLists {
+		res = append(res, newPriceListResponse(priceList, rules))
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type priceListUri struct {
+	PriceListID string `uri:"price_list_id" binding:"required,uuid"`
+}
+
+// update a price list and replace its rules
+func (server *Server) updatePriceList(ctx *gin.Context) {
+	var uri priceListUri
+	var req priceListRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg, ok := newSetPriceListTxParams(ctx, shop.Name, req)
+	if !ok {
+		return
+	}
+	arg.ID = uuid.MustParse(uri.PriceListID)
+
+	result, err := server.store.UpdatePriceListTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPriceListResponse(result.PriceList, result.Rules))
+}
+
+var errPriceListUsed = errors.New("the price list priced orders, deactivate it instead")
+
+func (server *Server) deletePriceList(ctx *gin.Context) {
+	var uri priceListUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	err := server.store.DeletePriceList(ctx, db.DeletePriceListParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.PriceListID),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(errPriceListUsed))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, textResponse("delete successfully"))
+}
+
+func (server *Server) getPriceListPrices(ctx *gin.Context) {
+	var uri priceListUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	priceList, err := server.store.GetPriceList(ctx, db.GetPriceListParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.PriceListID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	prices, err := server.store.ListPriceListPrices(ctx, priceList.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prices)
+}
+
+// a price names either a product, priced wherever it is sold, or a single
+// menu item of the shop
+type priceListPriceRequest struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	MenuItemID uuid.UUID `json:"menu_item_id"`
+	Price      *float64  `json:"price" binding:"required,min=0"`
+}
+
+type setPriceListPricesRequest struct {
+	Prices []priceListPriceRequest `json:"prices" binding:"dive"`
+}
+
+var errPriceTarget = errors.New("a price names either a product_id or a menu_item_id")
+
+// replace the prices of a price list
+func (server *Server) setPriceListPrices(ctx *gin.Context) {
+	var uri priceListUri
+	var req setPriceListPricesRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.SetPriceListPricesTxParams{
+		ShopName:       shop.Name,
+		OrganisationID: shop.OrganisationID,
+		PriceListID:    uuid.MustParse(uri.PriceListID),
+	}
+	for _, p := range req.Prices {
+		if (p.ProductID == uuid.Nil) == (p.MenuItemID == uuid.Nil) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errPriceTarget))
+			return
+		}
+		arg.Prices = append(arg.Prices, db.PriceListPriceParams{
+			ProductID:  p.ProductID,
+			MenuItemID: p.MenuItemID,
+			Price:      utils.FormottedDecimalToString(*p.Price),
+		})
+	}
+
+	prices, err := server.store.SetPriceListPricesTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prices)
+}
+
+// the active price lists of the shop with a rule matching the order, highest
+// priority first. the rules of a list come together since priorities are
+// unique within a shop
+func (server *Server) orderPriceLists(ctx *gin.Context, shopName string, clock shopClock, channel, orderType string, at time.Time) ([]uuid.UUID, error) {
+	rules, err := server.store.ListActivePriceListRules(ctx, shopName)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uuid.UUID
+	for _, r := range rules {
+		if len(ids) > 0 && ids[len(ids)-1] == r.PriceListID {
+			continue
+		}
+		rule := utils.PriceRule{
+			Channel:   r.Channel,
+			OrderType: r.OrderType,
+			Weekday:   r.Weekday,
+			StartsAt:  r.StartsAt,
+			EndsAt:    r.EndsAt,
+		}
+		if rule.Matches(channel, orderType, at, clock.loc) {
+			ids = append(ids, r.PriceListID)
+		}
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,441 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func randomPriceList(shop db.Shop, priority int32) db.PriceList {
+	return db.PriceList{
+		ID:        uuid.New(),
+		ShopName:  shop.Name,
+		Name:      utils.RandString(8),
+		Priority:  priority,
+		Active:    true,
+		CreatedAt: time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func randomPriceListRule(priceList db.PriceList, channel, orderType string) db.PriceListRule {
+	return db.PriceListRule{
+		ID:          uuid.New(),
+		PriceListID: priceList.ID,
+		Channel:     channel,
+		OrderType:   orderType,
+		EndsAt:      24 * 60,
+	}
+}
+
+// rules of the active price lists, highest priority first
+func expectPriceLists(store *mockdb.MockStore, shop db.Shop, rules []db.PriceListRule) {
+	store.EXPECT().
+		ListActivePriceListRules(gomock.Any(), gomock.Eq(shop.Name)).
+		Times(1).
+		Return(rules, nil)
+}
+
+func TestCreatePriceList(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"name":     "Happy hour",
+				"priority": 10,
+				"rules": []gin.H{
+					{"weekday": 5, "starts_at": "17:00", "ends_at": "19:00"},
+					{"channel": utils.ChannelOnline, "order_type": utils.OrderDelivery},
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePriceListTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.SetPriceListTxParams) (db.PriceListTxResult, error) {
+						require.Equal(t, shop.Name, arg.ShopName)
+						require.Equal(t, int32(10), arg.Priority)
+						// active unless said otherwise
+						require.True(t, arg.Active)
+						require.Equal(t, []db.PriceListRuleParams{
+							{Weekday: 5, StartsAt: 17 * 60, EndsAt: 19 * 60},
+							// the whole day without a window
+							{Channel: utils.ChannelOnline, OrderType: utils.OrderDelivery, StartsAt: 0, EndsAt: 24 * 60},
+						}, arg.Rules)
+
+						priceList := db.PriceList{ID: uuid.New(), ShopName: arg.ShopName, Name: arg.Name, Priority: arg.Priority, Active: arg.Active}
+						rule := db.PriceListRule{ID: uuid.New(), PriceListID: priceList.ID, Weekday: 5, StartsAt: 17 * 60, EndsAt: 19 * 60}
+						return db.PriceListTxResult{PriceList: priceList, Rules: []db.PriceListRule{rule}}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res priceListResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, "Happy hour", res.Name)
+				require.Len(t, res.Rules, 1)
+				require.Equal(t, "17:00", res.Rules[0].StartsAt)
+				require.Equal(t, "19:00", res.Rules[0].EndsAt)
+			},
+		},
+		{
+			name: "Inactive",
+			body: gin.H{"name": "Winter menu", "priority": 1, "active": false},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePriceListTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.SetPriceListTxParams) (db.PriceListTxResult, error) {
+						require.False(t, arg.Active)
+						require.Empty(t, arg.Rules)
+						return db.PriceListTxResult{PriceList: db.PriceList{ID: uuid.New(), Name: arg.Name}}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "WindowEndsBeforeStart",
+			body: gin.H{"name": "Late night", "priority": 1, "rules": []gin.H{{"starts_at": "22:00", "ends_at": "02:00"}}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePriceListTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownChannel",
+			body: gin.H{"name": "Kiosk", "priority": 1, "rules": []gin.H{{"channel": "kiosk"}}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePriceListTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "PriorityTaken",
+			body: gin.H{"name": "Delivery", "priority": 1},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePriceListTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PriceListTxResult{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/price-lists", shop.ID)
+			recorder := serveKitchen(t, store, user, http.MethodPost, url, tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetPriceLists(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	happyHour := randomPriceList(shop, 10)
+	delivery := randomPriceList(shop, 1)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		ListPriceLists(gomock.Any(), gomock.Eq(shop.Name)).
+		Times(1).
+		Return([]db.PriceList{happyHour, delivery}, nil)
+	store.EXPECT().
+		ListPriceListRules(gomock.Any(), gomock.Eq(shop.Name)).
+		Times(1).
+		Return([]db.PriceListRule{randomPriceListRule(delivery, "", utils.OrderDelivery)}, nil)
+
+	url := fmt.Sprintf("/shops/%s/price-lists", shop.ID)
+	recorder := serveKitchen(t, store, user, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res []priceListResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	require.Len(t, res, 2)
+	require.Equal(t, happyHour.ID, res[0].ID)
+	require.Empty(t, res[0].Rules)
+	require.Len(t, res[1].Rules, 1)
+	require.Equal(t, utils.OrderDelivery, res[1].Rules[0].OrderType)
+	require.Equal(t, "24:00", res[1].Rules[0].EndsAt)
+}
+
+func TestUpdatePriceList(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	priceList := randomPriceList(shop, 1)
+
+	testCases := []struct {
+		name          string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdatePriceListTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.SetPriceListTxParams) (db.PriceListTxResult, error) {
+						require.Equal(t, shop.Name, arg.ShopName)
+						require.Equal(t, priceList.ID, arg.ID)
+						require.False(t, arg.Active)
+						return db.PriceListTxResult{PriceList: priceList}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdatePriceListTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PriceListTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/price-lists/%s", shop.ID, priceList.ID)
+			body := gin.H{"name": priceList.Name, "priority": 2, "active": false}
+			recorder := serveKitchen(t, store, user, http.MethodPut, url, body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeletePriceList(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	priceListID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		err           error
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			// lines priced by the list still point at it
+			name: "PricedOrders",
+			err:  &pq.Error{Code: "23503"},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			store.EXPECT().
+				DeletePriceList(gomock.Any(), gomock.Eq(db.DeletePriceListParams{ShopName: shop.Name, ID: priceListID})).
+				Times(1).
+				Return(tc.err)
+
+			url := fmt.Sprintf("/shops/%s/price-lists/%s", shop.ID, priceListID)
+			recorder := serveKitchen(t, store, user, http.MethodDelete, url, nil)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetPriceListPrices(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	priceList := randomPriceList(shop, 1)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		GetPriceList(gomock.Any(), gomock.Eq(db.GetPriceListParams{ShopName: shop.Name, ID: priceList.ID})).
+		Times(1).
+		Return(priceList, nil)
+	store.EXPECT().
+		ListPriceListPrices(gomock.Any(), gomock.Eq(priceList.ID)).
+		Times(1).
+		Return([]db.PriceListPrice{{ID: uuid.New(), PriceListID: priceList.ID, Price: "3.00"}}, nil)
+
+	url := fmt.Sprintf("/shops/%s/price-lists/%s/prices", shop.ID, priceList.ID)
+	recorder := serveKitchen(t, store, user, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res []db.PriceListPrice
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	require.Len(t, res, 1)
+	require.Equal(t, "3.00", res[0].Price)
+}
+
+func TestSetPriceListPrices(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	priceList := randomPriceList(shop, 1)
+	productID := uuid.New()
+	menuItemID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"prices": []gin.H{
+				{"product_id": productID, "price": 4},
+				{"menu_item_id": menuItemID, "price": 0},
+			}},
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.SetPriceListPricesTxParams{
+					ShopName:       shop.Name,
+					OrganisationID: shop.OrganisationID,
+					PriceListID:    priceList.ID,
+					Prices: []db.PriceListPriceParams{
+						{ProductID: productID, Price: "4.00"},
+						// a free item is a price too
+						{MenuItemID: menuItemID, Price: "0.00"},
+					},
+				}
+				store.EXPECT().
+					SetPriceListPricesTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.PriceListPrice{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ProductAndMenuItem",
+			body: gin.H{"prices": []gin.H{{"product_id": productID, "menu_item_id": menuItemID, "price": 4}}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetPriceListPricesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MissingPrice",
+			body: gin.H{"prices": []gin.H{{"product_id": productID}}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetPriceListPricesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			// the product of another organisation or a menu item of another shop
+			name: "NotFound",
+			body: gin.H{"prices": []gin.H{{"product_id": productID, "price": 4}}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetPriceListPricesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/price-lists/%s/prices", shop.ID, priceList.ID)
+			recorder := serveKitchen(t, store, user, http.MethodPut, url, tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,458 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/printing"
+	"github.com/toml5566/go_pos_backend/internal/receipt"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type createPrinterRequest struct {
+	Name           string    `json:"name" binding:"required"`
+	Address        string    `json:"address" binding:"required"`                  // host or host:port on the shop network, the port defaults to 9100
+	PaperWidth     int32     `json:"paper_width" binding:"omitempty,oneof=58 80"` // millimeters, defaults to 80
+	StationID      uuid.UUID `json:"station_id"`                                  // optional, the printer prints the station's kitchen tickets
+	PrintsReceipts bool      `json:"prints_receipts"`
+}
+
+func (server *Server) createPrinter(ctx *gin.Context) {
+	var req createPrinterRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	address := req.Address
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, printing.DefaultPort)
+	}
+	if err := printing.CheckAddress(address); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.PaperWidth == 0 {
+		req.PaperWidth = int32(receipt.Width80mm)
+	}
+
+	shop := currentShop(ctx)
+
+	if req.StationID != uuid.Nil {
+		_, err := server.store.GetStation(ctx, db.GetStationParams{
+			ShopName: shop.Name,
+			ID:       req.StationID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	printer, err := server.store.CreatePrinter(ctx, db.CreatePrinterParams{
+		ID:             uuid.New(),
+		ShopName:       shop.Name,
+		Name:           req.Name,
+		Address:        address,
+		PaperWidth:     req.PaperWidth,
+		StationID:      uuid.NullUUID{UUID: req.StationID, Valid: req.StationID != uuid.Nil},
+		PrintsReceipts: req.PrintsReceipts,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, printer)
+}
+
+func (server *Server) getPrinters(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	printers, err := server.store.ListPrinters(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, printers)
+}
+
+type printerUri struct {
+	PrinterID string `uri:"printer_id" binding:"required,uuid"`
+}
+
+// deleting a printer drops its print jobs
+func (server *Server) deletePrinter(ctx *gin.Context) {
+	var uri printerUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	deleted, err := server.store.DeletePrinter(ctx, db.DeletePrinterParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.PrinterID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, textResponse("delete successfully"))
+}
+
+// print jobs are listed without their raw ESC/POS payload
+type printJobResponse struct {
+	ID            uuid.UUID    `json:"id"`
+	ShopName      string       `json:"shop_name"`
+	PrinterID     uuid.UUID    `json:"printer_id"`
+	Kind          string       `json:"kind"`
+	ReferenceID   uuid.UUID    `json:"reference_id"`
+	Status        string       `json:"status"`
+	Attempts      int32        `json:"attempts"`
+	LastError     string       `json:"last_error"`
+	NextAttemptAt time.Time    `json:"next_attempt_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+	PrintedAt     sql.NullTime `json:"printed_at"`
+}
+
+func newPrintJobResponse(job db.PrintJob) printJobResponse {
+	return printJobResponse{
+		ID:            job.ID,
+		ShopName:      job.ShopName,
+		PrinterID:     job.PrinterID,
+		Kind:          job.Kind,
+		ReferenceID:   job.ReferenceID,
+		Status:        job.Status,
+		Attempts:      job.Attempts,
+		LastError:     job.LastError,
+		NextAttemptAt: job.NextAttemptAt,
+		CreatedAt:     job.CreatedAt,
+		PrintedAt:     job.PrintedAt,
+	}
+}
+
+// print on the given printer, or on every receipt printer of the shop
+type printReceiptRequest struct {
+	PrinterID uuid.UUID `json:"printer_id"`
+}
+
+func (server *Server) printReceipt(ctx *gin.Context) {
+	var uri receiptUri
+	var req printReceiptRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	// the body is optional
+	if err := ctx.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	var printers []db.Printer
+	if req.PrinterID != uuid.Nil {
+		printer, err := server.store.GetPrinter(ctx, db.GetPrinterParams{
+			ShopName: shop.Name,
+			ID:       req.PrinterID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		printers = append(printers, printer)
+	} else {
+		shopPrinters, err := server.store.ListPrinters(ctx, shop.Name)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		for _, printer := range shopPrinters {
+			if printer.PrintsReceipts {
+				printers = append(printers, printer)
+			}
+		}
+	}
+	if len(printers) == 0 {
+		err := errors.New("shop has no receipt printer")
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+		return
+	}
+
+	orderID := uuid.MustParse(uri.OrderID)
+	r, ok := server.loadReceipt(ctx, shop, orderID)
+	if !ok {
+		return
+	}
+
+	res := []printJobResponse{}
+	for _, printer := range printers {
+		var buf bytes.Buffer
+		if err := receipt.WriteESCPOS(&buf, r, receipt.Width(printer.PaperWidth)); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		job, err := server.store.CreatePrintJob(ctx, db.CreatePrintJobParams{
+			ID:          uuid.New(),
+			ShopName:    shop.Name,
+			PrinterID:   printer.ID,
+			Kind:        utils.PrintKindReceipt,
+			ReferenceID: orderID,
+			Payload:     buf.Bytes(),
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		res = append(res, newPrintJobResponse(job))
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// queue the kitchen tickets of a new order on the printers of their stations.
+// the order is already taken, so failures are logged and the tickets stay on
+// the kitchen screens
+func (server *Server) printKitchenTickets(ctx *gin.Context, shop db.Shop, tickets []db.StationTicket) {
+	if len(tickets) == 0 {
+		return
+	}
+
+	printers, err := server.store.ListPrinters(ctx, shop.Name)
+	if err != nil {
+		log.Println("cannot print kitchen tickets:", err)
+		return
+	}
+
+	byStation := make(map[uuid.UUID][]db.Printer)
+	for _, printer := range printers {
+		if printer.StationID.Valid {
+			byStation[printer.StationID.UUID] = append(byStation[printer.StationID.UUID], printer)
+		}
+	}
+	if len(byStation) == 0 {
+		return
+	}
+
+	stations, err := server.store.ListStations(ctx, shop.Name)
+	if err != nil {
+		log.Println("cannot print kitchen tickets:", err)
+		return
+	}
+	stationNames := make(map[uuid.UUID]string, len(stations))
+	for _, station := range stations {
+		stationNames[station.ID] = station.Name
+	}
+
+	receiptShop, err := receipt.NewShop(shop)
+	if err != nil {
+		log.Println("cannot print kitchen tickets:", err)
+		return
+	}
+
+	for _, ticket := range tickets {
+		slip := receipt.NewKitchenTicket(receiptShop, stationNames[ticket.StationID], ticket.KitchenTicket, ticket.Items)
+
+		for _, printer := range byStation[ticket.StationID] {
+			var buf bytes.Buffer
+			if err := receipt.WriteKitchenTicketESCPOS(&buf, slip, receipt.Width(printer.PaperWidth)); err != nil {
+				log.Println("cannot print kitchen tickets:", err)
+				return
+			}
+
+			arg := db.CreatePrintJobParams{
+				ID:          uuid.New(),
+				ShopName:    shop.Name,
+				PrinterID:   printer.ID,
+				Kind:        utils.PrintKindKitchenTicket,
+				ReferenceID: ticket.ID,
+				Payload:     buf.Bytes(),
+			}
+			if ticket.Released {
+				_, err = server.store.CreatePrintJob(ctx, arg)
+			} else {
+				// held tickets print when the kitchen gets them
+				_, err = server.store.CreateDelayedPrintJob(ctx, db.CreateDelayedPrintJobParams{
+					ID:            arg.ID,
+					ShopName:      arg.ShopName,
+					PrinterID:     arg.PrinterID,
+					Kind:          arg.Kind,
+					ReferenceID:   arg.ReferenceID,
+					Payload:       arg.Payload,
+					NextAttemptAt: ticket.ReleaseAt,
+				})
+			}
+			if err != nil {
+				log.Println("cannot print kitchen tickets:", err)
+				return
+			}
+		}
+	}
+}
+
+// newest jobs first
+type printJobsQuery struct {
+	Status   string `form:"status" binding:"omitempty,oneof=queued printing printed failed"`
+	PageSize int32  `form:"page_size,default=50" binding:"min=1,max=200"`
+}
+
+func (server *Server) getPrintJobs(ctx *gin.Context) {
+	var query printJobsQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	jobs, err := server.store.ListPrintJobs(ctx, db.ListPrintJobsParams{
+		ShopName: shop.Name,
+		Status:   query.Status,
+		PageSize: query.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]printJobResponse, len(jobs))
+	for i, job := range jobs {
+		res[i] = newPrintJobResponse(job)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type printJobUri struct {
+	JobID string `uri:"job_id" binding:"required,uuid"`
+}
+
+func (server *Server) getPrintJob(ctx *gin.Context) {
+	var uri printJobUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	job, err := server.store.GetPrintJob(ctx, db.GetPrintJobParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.JobID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPrintJobResponse(job))
+}
+
+// print the same payload again as a new job, on another printer if given.
+// the payload keeps the paper width of the printer it was made for
+type reprintRequest struct {
+	PrinterID uuid.UUID `json:"printer_id"`
+}
+
+func (server *Server) reprintPrintJob(ctx *gin.Context) {
+	var uri printJobUri
+	var req reprintRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	// the body is optional
+	if err := ctx.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	job, err := server.store.GetPrintJob(ctx, db.GetPrintJobParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.JobID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	printerID := job.PrinterID
+	if req.PrinterID != uuid.Nil {
+		printer, err := server.store.GetPrinter(ctx, db.GetPrinterParams{
+			ShopName: shop.Name,
+			ID:       req.PrinterID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		printerID = printer.ID
+	}
+
+	reprint, err := server.store.CreatePrintJob(ctx, db.CreatePrintJobParams{
+		ID:          uuid.New(),
+		ShopName:    shop.Name,
+		PrinterID:   printerID,
+		Kind:        job.Kind,
+		ReferenceID: job.ReferenceID,
+		Payload:     job.Payload,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPrintJobResponse(reprint))
+}
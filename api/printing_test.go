@@ -0,0 +1,535 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func randomPrinter(shop db.Shop, printsReceipts bool) db.Printer {
+	return db.Printer{
+		ID:             uuid.New(),
+		ShopName:       shop.Name,
+		Name:           utils.RandString(6),
+		Address:        "192.168.1.50:9100",
+		PaperWidth:     80,
+		PrintsReceipts: printsReceipts,
+		CreatedAt:      time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func randomPrintJob(printer db.Printer, status string) db.PrintJob {
+	return db.PrintJob{
+		ID:            uuid.New(),
+		ShopName:      printer.ShopName,
+		PrinterID:     printer.ID,
+		Kind:          utils.PrintKindReceipt,
+		ReferenceID:   uuid.New(),
+		Payload:       []byte("\x1b@" + utils.RandString(12)),
+		Status:        status,
+		NextAttemptAt: time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+		CreatedAt:     time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCreatePrinter(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	station := randomStation(shop, false)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": "bar", "address": "192.168.1.50", "station_id": station.ID},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetStation(gomock.Any(), gomock.Eq(db.GetStationParams{ShopName: shop.Name, ID: station.ID})).
+					Times(1).
+					Return(station, nil)
+				store.EXPECT().
+					CreatePrinter(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreatePrinterParams) (db.Printer, error) {
+						require.Equal(t, shop.Name, arg.ShopName)
+						require.Equal(t, "192.168.1.50:9100", arg.Address)
+						require.Equal(t, int32(80), arg.PaperWidth)
+						require.Equal(t, uuid.NullUUID{UUID: station.ID, Valid: true}, arg.StationID)
+						return db.Printer{ID: arg.ID, ShopName: arg.ShopName, Name: arg.Name, Address: arg.Address}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ReceiptPrinter",
+			body: gin.H{"name": "front", "address": "[fd00::1]:9101", "paper_width": 58, "prints_receipts": true},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetStation(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreatePrinter(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreatePrinterParams) (db.Printer, error) {
+						require.Equal(t, "[fd00::1]:9101", arg.Address)
+						require.Equal(t, int32(58), arg.PaperWidth)
+						require.False(t, arg.StationID.Valid)
+						require.True(t, arg.PrintsReceipts)
+						return db.Printer{ID: arg.ID}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "StationNotFound",
+			body: gin.H{"name": "bar", "address": "192.168.1.50", "station_id": station.ID},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetStation(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Station{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreatePrinter(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			// a metadata endpoint of the server, not a printer
+			name: "LinkLocalAddress",
+			body: gin.H{"name": "bar", "address": "169.254.169.254"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePrinter(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotAPrinterPort",
+			body: gin.H{"name": "bar", "address": "192.168.1.50:6379"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePrinter(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPaperWidth",
+			body: gin.H{"name": "bar", "address": "192.168.1.50", "paper_width": 110},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePrinter(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%v/printers", shop.ID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPost, url, tc.body))
+		})
+	}
+}
+
+func TestPrintReceipt(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := hongKongShop(user)
+	product := randomProduct(shop)
+	menuItem := createMenuItem(shop, product, "drinks")
+	orderID := uuid.New()
+	orderItem := addOrderItem(menuItem, orderID)
+
+	front := randomPrinter(shop, true)
+	bar := randomPrinter(shop, false)
+	narrow := randomPrinter(shop, false)
+	narrow.PaperWidth = 58
+
+	expectOrder := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return([]db.Order{orderItem}, nil)
+		store.EXPECT().
+			ListPaymentsByOrderID(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(nil, nil)
+	}
+	expectJobs := func(store *mockdb.MockStore, printers ...db.Printer) {
+		for _, printer := range printers {
+			printer := printer
+			store.EXPECT().
+				CreatePrintJob(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ interface{}, arg db.CreatePrintJobParams) (db.PrintJob, error) {
+					require.Equal(t, printer.ID, arg.PrinterID)
+					require.Equal(t, utils.PrintKindReceipt, arg.Kind)
+					require.Equal(t, orderID, arg.ReferenceID)
+					require.Contains(t, string(arg.Payload), "Order "+strings.ToUpper(orderID.String()[:8]))
+					return db.PrintJob{ID: arg.ID, PrinterID: arg.PrinterID, Payload: arg.Payload, Status: utils.PrintJobQueued}, nil
+				})
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "ReceiptPrinters",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListPrinters(gomock.Any(), gomock.Eq(shop.Name)).
+					Times(1).
+					Return([]db.Printer{bar, front}, nil)
+				expectOrder(store)
+				expectJobs(store, front)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []printJobResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res, 1)
+				require.Equal(t, front.ID, res[0].PrinterID)
+				require.Equal(t, utils.PrintJobQueued, res[0].Status)
+				require.NotContains(t, recorder.Body.String(), "payload")
+			},
+		},
+		{
+			name: "GivenPrinter",
+			body: gin.H{"printer_id": narrow.ID},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPrinter(gomock.Any(), gomock.Eq(db.GetPrinterParams{ShopName: shop.Name, ID: narrow.ID})).
+					Times(1).
+					Return(narrow, nil)
+				expectOrder(store)
+				expectJobs(store, narrow)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NoReceiptPrinter",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListPrinters(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Printer{bar}, nil)
+				store.EXPECT().
+					CreatePrintJob(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "PrinterNotFound",
+			body: gin.H{"printer_id": uuid.New()},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPrinter(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Printer{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreatePrintJob(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%v/orders/%v/print", shop.ID, orderID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPost, url, tc.body))
+		})
+	}
+}
+
+func TestReprintPrintJob(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	printer := randomPrinter(shop, true)
+	other := randomPrinter(shop, true)
+	job := randomPrintJob(printer, utils.PrintJobFailed)
+	job.Attempts = 8
+	job.LastError = "connection refused"
+
+	expectJob := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetPrintJob(gomock.Any(), gomock.Eq(db.GetPrintJobParams{ShopName: shop.Name, ID: job.ID})).
+			Times(1).
+			Return(job, nil)
+	}
+	expectReprint := func(store *mockdb.MockStore, printerID uuid.UUID) {
+		store.EXPECT().
+			CreatePrintJob(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ interface{}, arg db.CreatePrintJobParams) (db.PrintJob, error) {
+				require.NotEqual(t, job.ID, arg.ID)
+				require.Equal(t, printerID, arg.PrinterID)
+				require.Equal(t, job.Kind, arg.Kind)
+				require.Equal(t, job.ReferenceID, arg.ReferenceID)
+				require.Equal(t, job.Payload, arg.Payload)
+				return db.PrintJob{ID: arg.ID, PrinterID: arg.PrinterID, Status: utils.PrintJobQueued}, nil
+			})
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "SamePrinter",
+			buildStub: func(store *mockdb.MockStore) {
+				expectJob(store)
+				expectReprint(store, printer.ID)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res printJobResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, utils.PrintJobQueued, res.Status)
+				require.Zero(t, res.Attempts)
+			},
+		},
+		{
+			name: "OtherPrinter",
+			body: gin.H{"printer_id": other.ID},
+			buildStub: func(store *mockdb.MockStore) {
+				expectJob(store)
+				store.EXPECT().
+					GetPrinter(gomock.Any(), gomock.Eq(db.GetPrinterParams{ShopName: shop.Name, ID: other.ID})).
+					Times(1).
+					Return(other, nil)
+				expectReprint(store, other.ID)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPrintJob(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PrintJob{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreatePrintJob(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%v/print-jobs/%v/reprint", shop.ID, job.ID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPost, url, tc.body))
+		})
+	}
+}
+
+func TestGetPrintJobs(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	printer := randomPrinter(shop, true)
+	job := randomPrintJob(printer, utils.PrintJobFailed)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		ListPrintJobs(gomock.Any(), gomock.Eq(db.ListPrintJobsParams{ShopName: shop.Name, Status: utils.PrintJobFailed, PageSize: 50})).
+		Times(1).
+		Return([]db.PrintJob{job}, nil)
+
+	url := fmt.Sprintf("/shops/%v/print-jobs?status=failed", shop.ID)
+	recorder := serveKitchen(t, store, user, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res []printJobResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	require.Equal(t, []printJobResponse{newPrintJobResponse(job)}, res)
+
+	url = fmt.Sprintf("/shops/%v/print-jobs?status=lost", shop.ID)
+	recorder = serveKitchen(t, store, user, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestPrintKitchenTickets(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := hongKongShop(user)
+	bar := randomStation(shop, false)
+	grill := randomStation(shop, true)
+	barPrinter := randomPrinter(shop, false)
+	barPrinter.StationID = uuid.NullUUID{UUID: bar.ID, Valid: true}
+	frontPrinter := randomPrinter(shop, true)
+
+	orderID := uuid.New()
+	tickets := []db.StationTicket{
+		{
+			KitchenTicket: randomKitchenTicket(bar, orderID, utils.TicketOpen),
+			Items:         []db.Order{{ProductName: "Flat white", Amount: 2}},
+		},
+		{
+			// no printer at the grill, the ticket only shows on its screen
+			KitchenTicket: randomKitchenTicket(grill, orderID, utils.TicketOpen),
+			Items:         []db.Order{{ProductName: "Toast", Amount: 1}},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListPrinters(gomock.Any(), gomock.Eq(shop.Name)).
+		Times(1).
+		Return([]db.Printer{barPrinter, frontPrinter}, nil)
+	store.EXPECT().
+		ListStations(gomock.Any(), gomock.Eq(shop.Name)).
+		Times(1).
+		Return([]db.Station{bar, grill}, nil)
+	store.EXPECT().
+		CreatePrintJob(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ interface{}, arg db.CreatePrintJobParams) (db.PrintJob, error) {
+			require.Equal(t, barPrinter.ID, arg.PrinterID)
+			require.Equal(t, utils.PrintKindKitchenTicket, arg.Kind)
+			require.Equal(t, tickets[0].ID, arg.ReferenceID)
+			require.Contains(t, string(arg.Payload), bar.Name)
+			require.Contains(t, string(arg.Payload), "2 x Flat white")
+			require.NotContains(t, string(arg.Payload), "Toast")
+			return db.PrintJob{ID: arg.ID}, nil
+		})
+
+	server := newTestServer(t, store)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	server.printKitchenTickets(ctx, shop, tickets)
+
+	// shops without kitchen printers queue nothing
+	store.EXPECT().
+		ListPrinters(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.Printer{frontPrinter}, nil)
+	server.printKitchenTickets(ctx, shop, tickets)
+}
+
+func TestPrintHeldKitchenTickets(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := hongKongShop(user)
+	bar := randomStation(shop, true)
+	barPrinter := randomPrinter(shop, false)
+	barPrinter.StationID = uuid.NullUUID{UUID: bar.ID, Valid: true}
+
+	// the ticket of a scheduled order is held until the lead time before its slot
+	ticket := randomKitchenTicket(bar, uuid.New(), utils.TicketOpen)
+	ticket.ReleaseAt = time.Now().Add(time.Hour).UTC()
+	ticket.Released = false
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListPrinters(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.Printer{barPrinter}, nil)
+	store.EXPECT().
+		ListStations(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.Station{bar}, nil)
+	store.EXPECT().
+		CreatePrintJob(gomock.Any(), gomock.Any()).
+		Times(0)
+	store.EXPECT().
+		CreateDelayedPrintJob(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ interface{}, arg db.CreateDelayedPrintJobParams) (db.PrintJob, error) {
+			require.Equal(t, ticket.ID, arg.ReferenceID)
+			require.Equal(t, ticket.ReleaseAt, arg.NextAttemptAt)
+			return db.PrintJob{ID: arg.ID}, nil
+		})
+
+	server := newTestServer(t, store)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	server.printKitchenTickets(ctx, shop, []db.StationTicket{
+		{KitchenTicket: ticket, Items: []db.Order{{ProductName: "Flat white", Amount: 1}}},
+	})
+}
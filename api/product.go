@@ -1,26 +1,23 @@
 package api
 
 import (
-	"errors"
+	"database/sql"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	db "github.com/toml5566/go_pos_backend/internal/database"
-	"github.com/toml5566/go_pos_backend/token"
 	"github.com/toml5566/go_pos_backend/utils"
 )
 
-type usersProductsUri struct {
-	Username string `uri:"username" binding:"required,alphanum,min=1"`
-}
-
+// products make up the catalogue of the organisation, every shop puts them
+// on its menu at its own price. items of the product are taxed at tax_rate,
+// a percentage charged on top of the price.
 type createProductRequest struct {
-	UserID      uuid.UUID `json:"user_id" binding:"required"`
-	Username    string    `json:"username" binding:"required"`
-	Name        string    `json:"name" binding:"required"`
-	Price       float64   `json:"price" binding:"required,min=0"`
-	Description string    `json:"description" binding:"required"`
+	Name        string  `json:"name" binding:"required"`
+	Price       float64 `json:"price" binding:"required,min=0"`
+	Description string  `json:"description" binding:"required"`
+	TaxRate     float64 `json:"tax_rate" binding:"min=0,max=100"`
 }
 
 func (server *Server) createProduct(ctx *gin.Context) {
@@ -30,19 +27,13 @@ func (server *Server) createProduct(ctx *gin.Context) {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload) // type assertion to convert to token.Payload type
-	if req.Username != authPayload.Username {
-		err := errors.New("unauthorized user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	arg := db.CreateProductParams{
-		ID:          uuid.New(),
-		UserID:      req.UserID,
-		Name:        req.Name,
-		Price:       utils.FormottedDecimalToString(req.Price),
-		Description: req.Description,
+		ID:             uuid.New(),
+		OrganisationID: currentMember(ctx).OrganisationID,
+		Name:           req.Name,
+		Price:          utils.FormottedDecimalToString(req.Price),
+		Description:    req.Description,
+		TaxRate:        utils.FormottedDecimalToString(req.TaxRate),
 	}
 
 	product, err := server.store.CreateProduct(ctx, arg)
@@ -54,32 +45,8 @@ func (server *Server) createProduct(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, product)
 }
 
-type getAllProductsRequest struct {
-	UserID uuid.UUID `json:"user_id" binding:"required"`
-}
-
 func (server *Server) getAllProducts(ctx *gin.Context) {
-	var uri usersProductsUri
-	var req getAllProductsRequest
-
-	if err := ctx.ShouldBindUri(&uri); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-
-	// only allow logined users to check their own products
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload) // type assertion to convert to token.Payload type
-	if uri.Username != authPayload.Username {
-		err := errors.New("unauthorized user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
-	products, err := server.store.GetAllProducts(ctx, req.UserID)
+	products, err := server.store.GetAllProducts(ctx, currentMember(ctx).OrganisationID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -88,17 +55,20 @@ func (server *Server) getAllProducts(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, products)
 }
 
+type productUri struct {
+	ProductID string `uri:"productid" binding:"required,uuid"`
+}
+
 type updateProductRequest struct {
-	UserID      uuid.UUID `json:"user_id" binding:"required"`
-	ID          uuid.UUID `json:"id" binding:"required"`
-	Name        string    `json:"name" binding:"required"`
-	Price       float64   `json:"price" binding:"required"`
-	Description string    `json:"description"`
+	Name        string  `json:"name" binding:"required"`
+	Price       float64 `json:"price" binding:"required"`
+	Description string  `json:"description"`
+	TaxRate     float64 `json:"tax_rate" binding:"min=0,max=100"`
 }
 
 func (server *Server) updateProduct(ctx *gin.Context) {
+	var uri productUri
 	var req updateProductRequest
-	var uri usersProductsUri
 
 	if err := ctx.ShouldBindUri(&uri); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
@@ -109,23 +79,21 @@ func (server *Server) updateProduct(ctx *gin.Context) {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if uri.Username != authPayload.Username {
-		err := errors.New("unauthorized user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	arg := db.UpdateProductParams{
-		UserID:      req.UserID,
-		ID:          req.ID,
-		Name:        req.Name,
-		Price:       utils.FormottedDecimalToString(req.Price),
-		Description: req.Description,
+		OrganisationID: currentMember(ctx).OrganisationID,
+		ID:             uuid.MustParse(uri.ProductID),
+		Name:           req.Name,
+		Price:          utils.FormottedDecimalToString(req.Price),
+		Description:    req.Description,
+		TaxRate:        utils.FormottedDecimalToString(req.TaxRate),
 	}
 
 	updatedProduct, err := server.store.UpdateProduct(ctx, arg)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -133,30 +101,17 @@ func (server *Server) updateProduct(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, updatedProduct)
 }
 
-type deleteProductRequest struct {
-	UserID    uuid.UUID `json:"user_id" binding:"required"`
-	Username  string    `json:"username" binding:"required,alphanum,min=1"`
-	ProductID uuid.UUID `json:"product_id" binding:"required"`
-}
-
 func (server *Server) deleteProduct(ctx *gin.Context) {
-	var req deleteProductRequest
+	var uri productUri
 
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+	if err := ctx.ShouldBindUri(&uri); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if req.Username != authPayload.Username {
-		err := errors.New("unauthorized user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	arg := db.DeleteProductParams{
-		UserID: req.UserID,
-		ID:     req.ProductID,
+		OrganisationID: currentMember(ctx).OrganisationID,
+		ID:             uuid.MustParse(uri.ProductID),
 	}
 
 	err := server.store.DeleteProduct(ctx, arg)
@@ -167,3 +122,38 @@ func (server *Server) deleteProduct(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, "delete successfully")
 }
+
+// time a station needs to prepare one unit, used for the order ETA
+type setProductPrepTimeRequest struct {
+	PrepSeconds int32 `json:"prep_seconds" binding:"min=0,max=86400"`
+}
+
+func (server *Server) setProductPrepTime(ctx *gin.Context) {
+	var uri productUri
+	var req setProductPrepTimeRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	product, err := server.store.UpdateProductPrepTime(ctx, db.UpdateProductPrepTimeParams{
+		OrganisationID: currentMember(ctx).OrganisationID,
+		ID:             uuid.MustParse(uri.ProductID),
+		PrepSeconds:    req.PrepSeconds,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, product)
+}
@@ -23,14 +23,15 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
-func randomProduct(user db.User) db.Product {
+func randomProduct(shop db.Shop) db.Product {
 	return db.Product{
-		ID:          uuid.New(),
-		UserID:      user.ID,
-		Name:        utils.RandString(6),
-		Price:       fmt.Sprintf("%.2f", utils.RandomFloat(1, 100)),
-		Description: utils.RandString(10),
-		CreatedAt:   time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+		ID:             uuid.New(),
+		OrganisationID: shop.OrganisationID,
+		Name:           utils.RandString(6),
+		Price:          fmt.Sprintf("%.2f", utils.RandomFloat(1, 100)),
+		Description:    utils.RandString(10),
+		CreatedAt:      time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+		TaxRate:        "5.00",
 	}
 }
 
@@ -65,21 +66,22 @@ func requireBodyMatchProduct(t *testing.T, body *bytes.Buffer, product db.Produc
 	err = json.Unmarshal(data, &resProduct)
 	require.NoError(t, err)
 	require.Equal(t, resProduct.ID, product.ID)
-	require.Equal(t, resProduct.UserID, product.UserID)
+	require.Equal(t, resProduct.OrganisationID, product.OrganisationID)
 	require.Equal(t, resProduct.Name, product.Name)
 	require.Equal(t, resProduct.Price, product.Price)
 	require.Equal(t, resProduct.Description, product.Description)
+	require.Equal(t, resProduct.TaxRate, product.TaxRate)
 }
 
 func TestCreateProduct(t *testing.T) {
 	user, _ := randomUser(t)
-	product := randomProduct(user)
+	shop := randomShop(user)
+	product := randomProduct(shop)
 	floatPrice, err := strconv.ParseFloat(product.Price, 64)
 	require.NoError(t, err)
 
 	testCases := []struct {
 		name          string
-		user          db.User
 		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		buildStub     func(store *mockdb.MockStore)
@@ -87,24 +89,23 @@ func TestCreateProduct(t *testing.T) {
 	}{
 		{
 			name: "OK",
-			user: user,
 			body: gin.H{
-				"user_id":     product.UserID,
-				"username":    user.Username,
 				"name":        product.Name,
 				"price":       floatPrice,
 				"description": product.Description,
+				"tax_rate":    5,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				arg := db.CreateProductParams{
-					ID:          product.ID,
-					UserID:      product.UserID,
-					Name:        product.Name,
-					Price:       product.Price,
-					Description: product.Description,
+					ID:             product.ID,
+					OrganisationID: product.OrganisationID,
+					Name:           product.Name,
+					Price:          product.Price,
+					Description:    product.Description,
+					TaxRate:        product.TaxRate,
 				}
 				store.EXPECT().
 					CreateProduct(gomock.Any(), eqCreateProductParams(arg)).
@@ -118,10 +119,7 @@ func TestCreateProduct(t *testing.T) {
 		},
 		{
 			name: "InternalError",
-			user: user,
 			body: gin.H{
-				"user_id":     product.UserID,
-				"username":    user.Username,
 				"name":        product.Name,
 				"price":       floatPrice,
 				"description": product.Description,
@@ -141,11 +139,8 @@ func TestCreateProduct(t *testing.T) {
 		},
 		{
 			name: "MissingJSONField",
-			user: user,
 			body: gin.H{
-				"user_id":  product.UserID,
-				"username": user.Username,
-				"name":     product.Name,
+				"name": product.Name,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
@@ -161,10 +156,7 @@ func TestCreateProduct(t *testing.T) {
 		},
 		{
 			name: "UnauthorizatedUser",
-			user: user,
 			body: gin.H{
-				"user_id":     product.UserID,
-				"username":    user.Username,
 				"name":        product.Name,
 				"price":       floatPrice,
 				"description": product.Description,
@@ -191,6 +183,7 @@ func TestCreateProduct(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			expectOrganisationMember(store, randomMember(user, shop.OrganisationID, utils.RoleOwner), user)
 			tc.buildStub(store)
 
 			server := newTestServer(t, store)
@@ -200,7 +193,7 @@ func TestCreateProduct(t *testing.T) {
 			require.NoError(t, err)
 			jsonReader := bytes.NewReader(jsonData)
 
-			url := fmt.Sprintf("/users/%v/products", tc.user.Username)
+			url := fmt.Sprintf("/organisations/%v/products", shop.OrganisationID)
 			req, err := http.NewRequest(http.MethodPost, url, jsonReader)
 			require.NoError(t, err)
 
@@ -214,28 +207,23 @@ func TestCreateProduct(t *testing.T) {
 
 func TestGetAllProducts(t *testing.T) {
 	user, _ := randomUser(t)
-	product := randomProduct(user)
+	shop := randomShop(user)
+	product := randomProduct(shop)
 
 	testCases := []struct {
 		name          string
-		user          db.User
-		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		buildStub     func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
 			name: "OK",
-			user: user,
-			body: gin.H{
-				"user_id": user.ID,
-			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetAllProducts(gomock.Any(), gomock.Eq(user.ID)).
+					GetAllProducts(gomock.Any(), gomock.Eq(shop.OrganisationID)).
 					Times(1).
 					Return([]db.Product{product}, nil)
 			},
@@ -245,10 +233,6 @@ func TestGetAllProducts(t *testing.T) {
 		},
 		{
 			name: "InternalError",
-			user: user,
-			body: gin.H{
-				"user_id": user.ID,
-			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
@@ -262,28 +246,8 @@ func TestGetAllProducts(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
-		{
-			name: "MissingJSONField",
-			user: user,
-			body: gin.H{},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStub: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetAllProducts(gomock.Any(), gomock.Any()).
-					Times(0)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
 		{
 			name: "UnauthorizatedUser",
-			user: user,
-			body: gin.H{
-				"user_id": user.ID,
-			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "UnauthorizatedUser", time.Minute)
 			},
@@ -306,17 +270,14 @@ func TestGetAllProducts(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			expectOrganisationMember(store, randomMember(user, shop.OrganisationID, utils.RoleOwner), user)
 			tc.buildStub(store)
 
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			jsonData, err := json.Marshal(tc.body)
-			require.NoError(t, err)
-			jsonReader := bytes.NewReader(jsonData)
-
-			url := fmt.Sprintf("/users/%v/products", tc.user.Username)
-			req, err := http.NewRequest(http.MethodGet, url, jsonReader)
+			url := fmt.Sprintf("/organisations/%v/products", shop.OrganisationID)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
 			tc.setupAuth(t, req, server.tokenMaker)
@@ -328,20 +289,21 @@ func TestGetAllProducts(t *testing.T) {
 
 func TestUpdateProduct(t *testing.T) {
 	user, _ := randomUser(t)
-	product := randomProduct(user)
+	shop := randomShop(user)
+	product := randomProduct(shop)
 	updatedPrice := 10000.00
 	updatedProduct := db.Product{
-		ID:          product.ID,
-		UserID:      user.ID,
-		Name:        "updated",
-		Price:       utils.FormottedDecimalToString(updatedPrice),
-		Description: "updated",
-		CreatedAt:   product.CreatedAt,
+		ID:             product.ID,
+		OrganisationID: shop.OrganisationID,
+		Name:           "updated",
+		Price:          utils.FormottedDecimalToString(updatedPrice),
+		Description:    "updated",
+		CreatedAt:      product.CreatedAt,
+		TaxRate:        "12.50",
 	}
 
 	testCases := []struct {
 		name          string
-		user          db.User
 		product       db.Product
 		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
@@ -350,25 +312,24 @@ func TestUpdateProduct(t *testing.T) {
 	}{
 		{
 			name:    "OK",
-			user:    user,
 			product: product,
 			body: gin.H{
-				"user_id":     user.ID,
-				"id":          product.ID,
 				"name":        updatedProduct.Name,
 				"price":       updatedPrice,
 				"description": updatedProduct.Description,
+				"tax_rate":    12.5,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				arg := db.UpdateProductParams{
-					UserID:      user.ID,
-					ID:          product.ID,
-					Name:        updatedProduct.Name,
-					Price:       updatedProduct.Price,
-					Description: updatedProduct.Description,
+					OrganisationID: shop.OrganisationID,
+					ID:             product.ID,
+					Name:           updatedProduct.Name,
+					Price:          updatedProduct.Price,
+					Description:    updatedProduct.Description,
+					TaxRate:        updatedProduct.TaxRate,
 				}
 
 				store.EXPECT().
@@ -383,11 +344,8 @@ func TestUpdateProduct(t *testing.T) {
 		},
 		{
 			name:    "InternalError",
-			user:    user,
 			product: product,
 			body: gin.H{
-				"user_id":     user.ID,
-				"id":          product.ID,
 				"name":        updatedProduct.Name,
 				"price":       updatedPrice,
 				"description": updatedProduct.Description,
@@ -407,7 +365,6 @@ func TestUpdateProduct(t *testing.T) {
 		},
 		{
 			name:    "MissingJSONField",
-			user:    user,
 			product: product,
 			body:    gin.H{},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -424,11 +381,8 @@ func TestUpdateProduct(t *testing.T) {
 		},
 		{
 			name:    "UnauthorizatedUser",
-			user:    user,
 			product: product,
 			body: gin.H{
-				"user_id":     user.ID,
-				"id":          product.ID,
 				"name":        updatedProduct.Name,
 				"price":       updatedPrice,
 				"description": updatedProduct.Description,
@@ -456,6 +410,7 @@ func TestUpdateProduct(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			expectOrganisationMember(store, randomMember(user, shop.OrganisationID, utils.RoleOwner), user)
 			tc.buildStub(store)
 
 			server := newTestServer(t, store)
@@ -465,7 +420,7 @@ func TestUpdateProduct(t *testing.T) {
 			require.NoError(t, err)
 			jsonReader := bytes.NewReader(jsonData)
 
-			url := fmt.Sprintf("/users/%v/products/%v", tc.user.Username, tc.product.ID)
+			url := fmt.Sprintf("/organisations/%v/products/%v", shop.OrganisationID, tc.product.ID)
 			req, err := http.NewRequest(http.MethodPatch, url, jsonReader)
 			require.NoError(t, err)
 
@@ -478,33 +433,26 @@ func TestUpdateProduct(t *testing.T) {
 
 func TestDeleteProduct(t *testing.T) {
 	user, _ := randomUser(t)
-	product := randomProduct(user)
+	shop := randomShop(user)
+	product := randomProduct(shop)
 
 	testCases := []struct {
 		name          string
-		user          db.User
 		product       db.Product
-		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		buildStub     func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
 			name:    "OK",
-			user:    user,
 			product: product,
-			body: gin.H{
-				"user_id":    user.ID,
-				"product_id": product.ID,
-				"username":   user.Username,
-			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				arg := db.DeleteProductParams{
-					UserID: user.ID,
-					ID:     product.ID,
+					OrganisationID: shop.OrganisationID,
+					ID:             product.ID,
 				}
 				store.EXPECT().
 					DeleteProduct(gomock.Any(), gomock.Eq(arg)).
@@ -517,13 +465,7 @@ func TestDeleteProduct(t *testing.T) {
 		},
 		{
 			name:    "InternalError",
-			user:    user,
 			product: product,
-			body: gin.H{
-				"user_id":    user.ID,
-				"product_id": product.ID,
-				"username":   user.Username,
-			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
@@ -537,32 +479,9 @@ func TestDeleteProduct(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
-		{
-			name:    "MissingJSONField",
-			user:    user,
-			product: product,
-			body:    gin.H{},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStub: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					DeleteProduct(gomock.Any(), gomock.Any()).
-					Times(0)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
 		{
 			name:    "UnauthorizatedUser",
-			user:    user,
 			product: product,
-			body: gin.H{
-				"user_id":    user.ID,
-				"product_id": product.ID,
-				"username":   user.Username,
-			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "UnauthorizatedUser", time.Minute)
 			},
@@ -585,17 +504,14 @@ func TestDeleteProduct(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			expectOrganisationMember(store, randomMember(user, shop.OrganisationID, utils.RoleOwner), user)
 			tc.buildStub(store)
 
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			jsonData, err := json.Marshal(tc.body)
-			require.NoError(t, err)
-			jsonReader := bytes.NewReader(jsonData)
-
-			url := fmt.Sprintf("/users/%v/products/%v", tc.user.Username, tc.product.ID)
-			req, err := http.NewRequest(http.MethodDelete, url, jsonReader)
+			url := fmt.Sprintf("/organisations/%v/products/%v", shop.OrganisationID, tc.product.ID)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
 			require.NoError(t, err)
 
 			tc.setupAuth(t, req, server.tokenMaker)
@@ -604,3 +520,77 @@ func TestDeleteProduct(t *testing.T) {
 		})
 	}
 }
+
+func TestSetProductPrepTime(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	product := randomProduct(shop)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"prep_seconds": 240},
+			buildStub: func(store *mockdb.MockStore) {
+				updated := product
+				updated.PrepSeconds = 240
+				store.EXPECT().
+					UpdateProductPrepTime(gomock.Any(), gomock.Eq(db.UpdateProductPrepTimeParams{OrganisationID: shop.OrganisationID, ID: product.ID, PrepSeconds: 240})).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.Product
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, int32(240), res.PrepSeconds)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"prep_seconds": 240},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateProductPrepTime(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Product{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Negative",
+			body: gin.H{"prep_seconds": -1},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateProductPrepTime(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectOrganisationMember(store, randomMember(user, shop.OrganisationID, utils.RoleOwner), user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/organisations/%v/products/%v/prep-time", shop.OrganisationID, product.ID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPut, url, tc.body))
+		})
+	}
+}
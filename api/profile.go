@@ -0,0 +1,146 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// the whole profile is replaced, fields left out are cleared. currency is an
+// ISO 4217 code and timezone an IANA name
+type updateShopProfileRequest struct {
+	DisplayName   string `json:"display_name" binding:"max=100"`
+	Address       string `json:"address" binding:"max=500"`
+	Phone         string `json:"phone" binding:"max=50"`
+	Currency      string `json:"currency" binding:"required"`
+	Timezone      string `json:"timezone" binding:"required"`
+	LogoURL       string `json:"logo_url" binding:"omitempty,url,max=500"`
+	ReceiptHeader string `json:"receipt_header" binding:"max=500"`
+	ReceiptFooter string `json:"receipt_footer" binding:"max=500"`
+}
+
+func (server *Server) updateShopProfile(ctx *gin.Context) {
+	var req updateShopProfileRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !utils.IsValidCurrency(req.Currency) {
+		err := fmt.Errorf("unsupported currency %q", req.Currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !checkTimezone(ctx, req.Timezone) {
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	shop, err := server.store.UpdateShopProfile(ctx, db.UpdateShopProfileParams{
+		ID:            shop.ID,
+		DisplayName:   req.DisplayName,
+		Address:       req.Address,
+		Phone:         req.Phone,
+		Currency:      req.Currency,
+		Timezone:      req.Timezone,
+		LogoURL:       req.LogoURL,
+		ReceiptHeader: req.ReceiptHeader,
+		ReceiptFooter: req.ReceiptFooter,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newShopResponse(shop))
+}
+
+type shopProfileUri struct {
+	ShopName string `uri:"shop_name" binding:"required,alphanum,min=1"`
+}
+
+// what customers see of a shop. exceptions are those of the coming weeks
+type shopProfileResponse struct {
+	Name         string                     `json:"name"`
+	DisplayName  string                     `json:"display_name"`
+	Address      string                     `json:"address"`
+	Phone        string                     `json:"phone"`
+	Currency     string                     `json:"currency"`
+	Timezone     string                     `json:"timezone"`
+	LogoURL      string                     `json:"logo_url"`
+	OpeningHours []openingHoursResponse     `json:"opening_hours"`
+	Exceptions   []openingExceptionResponse `json:"exceptions"`
+	OpenNow      bool                       `json:"open_now"`
+}
+
+// days of upcoming exceptions listed on the public profile
+const profileExceptionDays = 30
+
+func (server *Server) getShopProfile(ctx *gin.Context) {
+	var uri shopProfileUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop, err := server.store.GetShopByName(ctx, uri.ShopName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	clock, err := newShopClock(shop)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	now := time.Now()
+	weekly, err := server.store.ListOpeningHours(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	exceptions, err := server.store.ListOpeningExceptions(ctx, db.ListOpeningExceptionsParams{
+		ShopName: shop.Name,
+		FromDay:  now.In(clock.loc).Format("2006-01-02"),
+		ToDay:    now.In(clock.loc).AddDate(0, 0, profileExceptionDays).Format("2006-01-02"),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	displayName := shop.DisplayName
+	if displayName == "" {
+		displayName = shop.Name
+	}
+
+	ctx.JSON(http.StatusOK, shopProfileResponse{
+		Name:         shop.Name,
+		DisplayName:  displayName,
+		Address:      shop.Address,
+		Phone:        shop.Phone,
+		Currency:     shop.Currency,
+		Timezone:     shop.Timezone,
+		LogoURL:      shop.LogoURL,
+		OpeningHours: newOpeningHoursResponse(weekly),
+		Exceptions:   newOpeningExceptionResponses(exceptions),
+		OpenNow:      newOpeningHours(weekly, exceptions).IsOpen(now, clock.loc),
+	})
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateShopProfile(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"display_name":   "Harbour Café",
+				"address":        "1 Harbour Road",
+				"phone":          "+85212345678",
+				"currency":       "HKD",
+				"timezone":       "Asia/Hong_Kong",
+				"logo_url":       "https://example.com/logo.png",
+				"receipt_footer": "Thank you",
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.UpdateShopProfileParams{
+					ID:            shop.ID,
+					DisplayName:   "Harbour Café",
+					Address:       "1 Harbour Road",
+					Phone:         "+85212345678",
+					Currency:      "HKD",
+					Timezone:      "Asia/Hong_Kong",
+					LogoURL:       "https://example.com/logo.png",
+					ReceiptFooter: "Thank you",
+				}
+				updated := shop
+				updated.DisplayName = arg.DisplayName
+				updated.Currency = arg.Currency
+				updated.Timezone = arg.Timezone
+				store.EXPECT().
+					UpdateShopProfile(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res shopResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, "Harbour Café", res.DisplayName)
+				require.Equal(t, "HKD", res.Currency)
+			},
+		},
+		{
+			name: "UnknownCurrency",
+			body: gin.H{"currency": "XYZ", "timezone": "UTC"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateShopProfile(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownTimezone",
+			body: gin.H{"currency": "USD", "timezone": "Mars/Olympus"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateShopProfile(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidLogoURL",
+			body: gin.H{"currency": "USD", "timezone": "UTC", "logo_url": "logo.png"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateShopProfile(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/profile", shop.ID)
+			recorder := serveKitchen(t, store, user, http.MethodPut, url, tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetShopProfile(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	shop.Currency = "EUR"
+	today := time.Now().UTC().Format("2006-01-02")
+
+	testCases := []struct {
+		name       string
+		exceptions []db.OpeningException
+		openNow    bool
+	}{
+		{"Open", nil, true},
+		{"Holiday", []db.OpeningException{{Day: today, Closed: true, Note: "Public holiday"}}, false},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectGetShop(store, shop)
+			expectOpeningHours(store, shop, nil, tc.exceptions)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// the profile is public
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/profile", shop.Name), nil)
+			require.NoError(t, err)
+			server.router.ServeHTTP(recorder, req)
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			var res shopProfileResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			require.Equal(t, shop.Name, res.DisplayName)
+			require.Equal(t, "EUR", res.Currency)
+			require.Equal(t, tc.openNow, res.OpenNow)
+			require.Len(t, res.Exceptions, len(tc.exceptions))
+			require.NotContains(t, recorder.Body.String(), "hashed_password")
+		})
+	}
+}
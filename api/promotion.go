@@ -0,0 +1,214 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// amount is a price for fixed promotions and a percentage otherwise,
+// buy_x_get_y takes it off the get_quantity cheapest units so 100 gives them
+// away. the validity window is open on a missing side, stackable defaults to
+// true. a promotion that requires a code only applies with one of its coupons
+type createPromotionRequest struct {
+	Name         string     `json:"name" binding:"required"`
+	Target       string     `json:"target" binding:"required,oneof=order product catalog"`
+	Kind         string     `json:"kind" binding:"required,oneof=percent fixed buy_x_get_y"`
+	Amount       float64    `json:"amount" binding:"required,gt=0"`
+	ProductID    uuid.UUID  `json:"product_id"`
+	Catalog      string     `json:"catalog"`
+	BuyQuantity  int32      `json:"buy_quantity" binding:"min=0"`
+	GetQuantity  int32      `json:"get_quantity" binding:"min=0"`
+	MinSpend     float64    `json:"min_spend" binding:"min=0"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+	Priority     int32      `json:"priority"`
+	Stackable    *bool      `json:"stackable"`
+	RequiresCode bool       `json:"requires_code"`
+}
+
+var (
+	errPromotionPercent = errors.New("a percentage must not be over 100")
+	errPromotionProduct = errors.New("a product promotion needs a product_id")
+	errPromotionCatalog = errors.New("a catalog promotion needs a valid catalog")
+	errPromotionBuyXGet = errors.New("buy_x_get_y discounts items and needs buy_quantity and get_quantity")
+	errPromotionWindow  = errors.New("ends_at must be after starts_at")
+	errPromotionUsed    = errors.New("the promotion discounted orders, deactivate it instead")
+)
+
+// check the request fits together, writes the error response and returns
+// false when it does not
+func checkPromotionRequest(ctx *gin.Context, req createPromotionRequest) bool {
+	var err error
+	switch {
+	case req.Kind != utils.PromotionFixed && req.Amount > 100:
+		err = errPromotionPercent
+	case req.Target == utils.PromotionProduct && req.ProductID == uuid.Nil:
+		err = errPromotionProduct
+	case req.Target == utils.PromotionCatalog && !utils.IsValidCatalog(req.Catalog):
+		err = errPromotionCatalog
+	case req.Kind == utils.PromotionBuyXGetY &&
+		(req.Target == utils.PromotionOrder || req.BuyQuantity == 0 || req.GetQuantity == 0):
+		err = errPromotionBuyXGet
+	case req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt):
+		err = errPromotionWindow
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return false
+	}
+	return true
+}
+
+func (server *Server) createPromotion(ctx *gin.Context) {
+	var req createPromotionRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !checkPromotionRequest(ctx, req) {
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.CreatePromotionParams{
+		ID:           uuid.New(),
+		ShopName:     shop.Name,
+		Name:         req.Name,
+		Target:       req.Target,
+		Kind:         req.Kind,
+		Amount:       utils.FormottedDecimalToString(req.Amount),
+		MinSpend:     utils.FormottedDecimalToString(req.MinSpend),
+		Priority:     req.Priority,
+		Stackable:    req.Stackable == nil || *req.Stackable,
+		Active:       true,
+		RequiresCode: req.RequiresCode,
+	}
+	if req.Kind == utils.PromotionBuyXGetY {
+		arg.BuyQuantity = req.BuyQuantity
+		arg.GetQuantity = req.GetQuantity
+	}
+	if req.StartsAt != nil {
+		arg.StartsAt = sql.NullTime{Time: req.StartsAt.UTC(), Valid: true}
+	}
+	if req.EndsAt != nil {
+		arg.EndsAt = sql.NullTime{Time: req.EndsAt.UTC(), Valid: true}
+	}
+
+	switch req.Target {
+	case utils.PromotionProduct:
+		// only products of the shop's organisation can be discounted
+		_, err := server.store.GetProduct(ctx, db.GetProductParams{OrganisationID: shop.OrganisationID, ID: req.ProductID})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		arg.ProductID = uuid.NullUUID{UUID: req.ProductID, Valid: true}
+	case utils.PromotionCatalog:
+		arg.Catalog = req.Catalog
+	}
+
+	promotion, err := server.store.CreatePromotion(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, promotion)
+}
+
+func (server *Server) getPromotions(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	promotions, err := server.store.ListPromotions(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, promotions)
+}
+
+type promotionUri struct {
+	PromotionID string `uri:"promotion_id" binding:"required,uuid"`
+}
+
+type setPromotionActiveRequest struct {
+	Active *bool `json:"active" binding:"required"`
+}
+
+// pause or resume a promotion, orders it already discounted keep their lines
+func (server *Server) setPromotionActive(ctx *gin.Context) {
+	var uri promotionUri
+	var req setPromotionActiveRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	promotion, err := server.store.SetPromotionActive(ctx, db.SetPromotionActiveParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.PromotionID),
+		Active:   *req.Active,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, promotion)
+}
+
+func (server *Server) deletePromotion(ctx *gin.Context) {
+	var uri promotionUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	err := server.store.DeletePromotion(ctx, db.DeletePromotionParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.PromotionID),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(errPromotionUsed))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, textResponse("delete successfully"))
+}
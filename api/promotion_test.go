@@ -0,0 +1,321 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreatePromotion(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	productID := uuid.New()
+	startsAt := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OrderPercent",
+			body: gin.H{
+				"name":      "Spring sale",
+				"target":    utils.PromotionOrder,
+				"kind":      utils.PromotionPercent,
+				"amount":    10,
+				"min_spend": 50,
+				"starts_at": startsAt,
+				"ends_at":   startsAt.AddDate(0, 1, 0),
+				"priority":  5,
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreatePromotionParams) (db.Promotion, error) {
+						require.Equal(t, shop.Name, arg.ShopName)
+						require.Equal(t, "10.00", arg.Amount)
+						require.Equal(t, "50.00", arg.MinSpend)
+						require.True(t, arg.StartsAt.Time.Equal(startsAt))
+						require.True(t, arg.EndsAt.Valid)
+						require.Equal(t, int32(5), arg.Priority)
+						// stackable unless said otherwise
+						require.True(t, arg.Stackable)
+						require.True(t, arg.Active)
+						require.False(t, arg.ProductID.Valid)
+						require.False(t, arg.RequiresCode)
+						return db.Promotion{ID: arg.ID, ShopName: arg.ShopName, Name: arg.Name}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "BuyTwoGetOneFree",
+			body: gin.H{
+				"name":         "Coffee 2+1",
+				"target":       utils.PromotionProduct,
+				"kind":         utils.PromotionBuyXGetY,
+				"amount":       100,
+				"product_id":   productID,
+				"buy_quantity": 2,
+				"get_quantity": 1,
+				"stackable":    false,
+				// only with a coupon code
+				"requires_code": true,
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(db.GetProductParams{OrganisationID: shop.OrganisationID, ID: productID})).
+					Times(1).
+					Return(db.Product{ID: productID}, nil)
+				store.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreatePromotionParams) (db.Promotion, error) {
+						require.Equal(t, uuid.NullUUID{UUID: productID, Valid: true}, arg.ProductID)
+						require.Equal(t, int32(2), arg.BuyQuantity)
+						require.Equal(t, int32(1), arg.GetQuantity)
+						require.False(t, arg.Stackable)
+						require.True(t, arg.RequiresCode)
+						return db.Promotion{ID: arg.ID}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ProductNotFound",
+			body: gin.H{
+				"name":       "Cake off",
+				"target":     utils.PromotionProduct,
+				"kind":       utils.PromotionFixed,
+				"amount":     1,
+				"product_id": productID,
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Product{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "PercentOverHundred",
+			body: gin.H{"name": "Free", "target": utils.PromotionOrder, "kind": utils.PromotionPercent, "amount": 150},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidCatalog",
+			body: gin.H{"name": "Brunch", "target": utils.PromotionCatalog, "kind": utils.PromotionPercent, "amount": 10, "catalog": "brunch"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BuyXGetYOnOrder",
+			body: gin.H{"name": "2+1", "target": utils.PromotionOrder, "kind": utils.PromotionBuyXGetY, "amount": 100, "buy_quantity": 2, "get_quantity": 1},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "WindowEndsBeforeStart",
+			body: gin.H{"name": "Backwards", "target": utils.PromotionOrder, "kind": utils.PromotionFixed, "amount": 5, "starts_at": startsAt, "ends_at": startsAt},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NameTaken",
+			body: gin.H{"name": "Spring sale", "target": utils.PromotionOrder, "kind": utils.PromotionFixed, "amount": 5},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Promotion{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/promotions", shop.ID)
+			recorder := serveKitchen(t, store, user, http.MethodPost, url, tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestSetPromotionActive(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	promotionID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Pause",
+			body: gin.H{"active": false},
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.SetPromotionActiveParams{ShopName: shop.Name, ID: promotionID, Active: false}
+				store.EXPECT().
+					SetPromotionActive(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Promotion{ID: promotionID}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "MissingActive",
+			body: gin.H{},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetPromotionActive(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"active": true},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetPromotionActive(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Promotion{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/promotions/%s/active", shop.ID, promotionID)
+			recorder := serveKitchen(t, store, user, http.MethodPatch, url, tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeletePromotion(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	promotionID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		err           error
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			// discount lines still point at it
+			name: "DiscountedOrders",
+			err:  &pq.Error{Code: "23503"},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			store.EXPECT().
+				DeletePromotion(gomock.Any(), gomock.Eq(db.DeletePromotionParams{ShopName: shop.Name, ID: promotionID})).
+				Times(1).
+				Return(tc.err)
+
+			url := fmt.Sprintf("/shops/%s/promotions/%s", shop.ID, promotionID)
+			recorder := serveKitchen(t, store, user, http.MethodDelete, url, nil)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type purchaseOrderUri struct {
+	PurchaseOrderID string `uri:"purchase_order_id" binding:"required,uuid"`
+}
+
+type createSupplierRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" binding:"omitempty,email"`
+	Phone string `json:"phone"`
+}
+
+func (server *Server) createSupplier(ctx *gin.Context) {
+	var req createSupplierRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.CreateSupplierParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		Name:     req.Name,
+		Email:    req.Email,
+		Phone:    req.Phone,
+	}
+
+	supplier, err := server.store.CreateSupplier(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, supplier)
+}
+
+func (server *Server) getSuppliers(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	suppliers, err := server.store.ListSuppliers(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, suppliers)
+}
+
+type purchaseOrderLineRequest struct {
+	ProductID uuid.UUID `json:"product_id" binding:"required"`
+	Quantity  int32     `json:"quantity" binding:"required,min=1"`
+	UnitCost  float64   `json:"unit_cost" binding:"min=0"`
+}
+
+type createPurchaseOrderRequest struct {
+	SupplierID   uuid.UUID                  `json:"supplier_id" binding:"required"`
+	ExpectedDate string                     `json:"expected_date" binding:"omitempty,datetime=2006-01-02"`
+	Note         string                     `json:"note"`
+	Lines        []purchaseOrderLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+func (server *Server) createPurchaseOrder(ctx *gin.Context) {
+	var req createPurchaseOrderRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.CreatePurchaseOrderTxParams{
+		ShopName:     shop.Name,
+		SupplierID:   req.SupplierID,
+		ExpectedDate: req.ExpectedDate,
+		Note:         req.Note,
+	}
+	for _, line := range req.Lines {
+		arg.Lines = append(arg.Lines, db.PurchaseOrderLineParams(line))
+	}
+
+	result, err := server.store.CreatePurchaseOrderTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (server *Server) getPurchaseOrders(ctx *gin.Context) {
+	var query pageQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.ListPurchaseOrdersParams{
+		ShopName: shop.Name,
+		Limit:    query.PageSize,
+		Offset:   (query.PageID - 1) * query.PageSize,
+	}
+
+	purchaseOrders, err := server.store.ListPurchaseOrders(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, purchaseOrders)
+}
+
+func (server *Server) getPurchaseOrder(ctx *gin.Context) {
+	var uri purchaseOrderUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	purchaseOrder, err := server.store.GetPurchaseOrder(ctx, db.GetPurchaseOrderParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.PurchaseOrderID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	lines, err := server.store.ListPurchaseOrderLines(ctx, purchaseOrder.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, db.PurchaseOrderTxResult{PurchaseOrder: purchaseOrder, Lines: lines})
+}
+
+// only draft orders can be sent to the supplier
+func (server *Server) sendPurchaseOrder(ctx *gin.Context) {
+	var uri purchaseOrderUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	purchaseOrderID := uuid.MustParse(uri.PurchaseOrderID)
+	purchaseOrder, err := server.store.GetPurchaseOrder(ctx, db.GetPurchaseOrderParams{
+		ShopName: shop.Name,
+		ID:       purchaseOrderID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if purchaseOrder.Status != utils.PurchaseOrderDraft {
+		err := errors.New("only draft purchase orders can be sent")
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+		return
+	}
+
+	purchaseOrder, err = server.store.UpdatePurchaseOrderStatus(ctx, db.UpdatePurchaseOrderStatusParams{
+		ShopName: shop.Name,
+		ID:       purchaseOrderID,
+		Status:   utils.PurchaseOrderSent,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, purchaseOrder)
+}
+
+type receiveLineRequest struct {
+	LineID   uuid.UUID `json:"line_id" binding:"required"`
+	Quantity int32     `json:"quantity" binding:"required,min=1"`
+	UnitCost float64   `json:"unit_cost" binding:"min=0"`
+}
+
+type receivePurchaseOrderRequest struct {
+	Lines []receiveLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+func (server *Server) receivePurchaseOrder(ctx *gin.Context) {
+	var uri purchaseOrderUri
+	var req receivePurchaseOrderRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.ReceivePurchaseOrderTxParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.PurchaseOrderID),
+	}
+	for _, line := range req.Lines {
+		arg.Lines = append(arg.Lines, db.ReceiveLineParams(line))
+	}
+
+	result, err := server.store.ReceivePurchaseOrderTx(ctx, arg)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case db.ErrNotReceivable:
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case db.ErrUnknownOrderLine, db.ErrOverReceived:
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type productCostsUri struct {
+	ProductID string `uri:"productid" binding:"required,uuid"`
+}
+
+func (server *Server) getProductCosts(ctx *gin.Context) {
+	var uri productCostsUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	costs, err := server.store.ListProductCosts(ctx, db.ListProductCostsParams{
+		ShopName:  shop.Name,
+		ProductID: uuid.MustParse(uri.ProductID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, costs)
+}
+
+// cost of goods sold uses the average unit cost of deliveries received
+// before to_date, margins are reported next to the current product price
+func (server *Server) getMarginReport(ctx *gin.Context) {
+	var query dateRangeQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if query.ToDate.Before(query.FromDate) {
+		err := errors.New("to_date must not be before from_date")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.GetProductMarginReportParams{
+		ShopName: shop.Name,
+		ToTime:   query.ToDate.AddDate(0, 0, 1),
+		FromTime: query.FromDate,
+	}
+
+	report, err := server.store.GetProductMarginReport(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,447 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func randomPurchaseOrder(shop db.Shop, status string) db.PurchaseOrder {
+	return db.PurchaseOrder{
+		ID:           uuid.New(),
+		ShopName:     shop.Name,
+		SupplierID:   uuid.New(),
+		Status:       status,
+		ExpectedDate: "2024-01-02",
+		CreatedAt:    time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:    time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCreatePurchaseOrder(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	purchaseOrder := randomPurchaseOrder(shop, utils.PurchaseOrderDraft)
+	line := db.PurchaseOrderLine{
+		ID:              uuid.New(),
+		PurchaseOrderID: purchaseOrder.ID,
+		ProductID:       product.ID,
+		QuantityOrdered: 10,
+		UnitCost:        "2.50",
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"supplier_id":   purchaseOrder.SupplierID,
+				"expected_date": purchaseOrder.ExpectedDate,
+				"lines":         []gin.H{{"product_id": product.ID, "quantity": 10, "unit_cost": 2.5}},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.CreatePurchaseOrderTxParams{
+					ShopName:     shop.Name,
+					SupplierID:   purchaseOrder.SupplierID,
+					ExpectedDate: purchaseOrder.ExpectedDate,
+					Lines:        []db.PurchaseOrderLineParams{{ProductID: product.ID, Quantity: 10, UnitCost: 2.5}},
+				}
+				store.EXPECT().
+					CreatePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.PurchaseOrderTxResult{PurchaseOrder: purchaseOrder, Lines: []db.PurchaseOrderLine{line}}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.PurchaseOrderTxResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, purchaseOrder, res.PurchaseOrder)
+				require.Equal(t, []db.PurchaseOrderLine{line}, res.Lines)
+			},
+		},
+		{
+			name: "NoLines",
+			body: gin.H{
+				"supplier_id": purchaseOrder.SupplierID,
+				"lines":       []gin.H{},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidExpectedDate",
+			body: gin.H{
+				"supplier_id":   purchaseOrder.SupplierID,
+				"expected_date": "tomorrow",
+				"lines":         []gin.H{{"product_id": product.ID, "quantity": 10, "unit_cost": 2.5}},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "SupplierNotFound",
+			body: gin.H{
+				"supplier_id": purchaseOrder.SupplierID,
+				"lines":       []gin.H{{"product_id": product.ID, "quantity": 10, "unit_cost": 2.5}},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PurchaseOrderTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/purchase-orders", shop.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(jsonData))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestSendPurchaseOrder(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	draft := randomPurchaseOrder(shop, utils.PurchaseOrderDraft)
+	sent := draft
+	sent.Status = utils.PurchaseOrderSent
+
+	testCases := []struct {
+		name          string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPurchaseOrder(gomock.Any(), gomock.Eq(db.GetPurchaseOrderParams{ShopName: shop.Name, ID: draft.ID})).
+					Times(1).
+					Return(draft, nil)
+				arg := db.UpdatePurchaseOrderStatusParams{
+					ShopName: shop.Name,
+					ID:       draft.ID,
+					Status:   utils.PurchaseOrderSent,
+				}
+				store.EXPECT().
+					UpdatePurchaseOrderStatus(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(sent, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadySent",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPurchaseOrder(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(sent, nil)
+				store.EXPECT().
+					UpdatePurchaseOrderStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPurchaseOrder(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PurchaseOrder{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shops/%v/purchase-orders/%v/send", shop.ID, draft.ID)
+			req, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestReceivePurchaseOrder(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	purchaseOrder := randomPurchaseOrder(shop, utils.PurchaseOrderPartiallyReceived)
+	lineID := uuid.New()
+	body := gin.H{"lines": []gin.H{{"line_id": lineID, "quantity": 4, "unit_cost": 2.75}}}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.ReceivePurchaseOrderTxParams{
+					ShopName: shop.Name,
+					ID:       purchaseOrder.ID,
+					Lines:    []db.ReceiveLineParams{{LineID: lineID, Quantity: 4, UnitCost: 2.75}},
+				}
+				store.EXPECT().
+					ReceivePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ReceivePurchaseOrderTxResult{PurchaseOrder: purchaseOrder}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ZeroQuantity",
+			body: gin.H{"lines": []gin.H{{"line_id": lineID, "quantity": 0, "unit_cost": 2.75}}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReceivePurchaseOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotReceivable",
+			body: body,
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReceivePurchaseOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReceivePurchaseOrderTxResult{}, db.ErrNotReceivable)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "OverReceived",
+			body: body,
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReceivePurchaseOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReceivePurchaseOrderTxResult{}, db.ErrOverReceived)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: body,
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReceivePurchaseOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReceivePurchaseOrderTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/purchase-orders/%v/receive", shop.ID, purchaseOrder.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(jsonData))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetMarginReport(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	product := randomProduct(shop)
+
+	report := []db.GetProductMarginReportRow{
+		{
+			ProductID:       product.ID,
+			Name:            product.Name,
+			Price:           "10.00",
+			AverageCost:     "4.00",
+			UnitMargin:      "6.00",
+			Sold:            3,
+			Revenue:         "30.00",
+			CostOfGoodsSold: "12.00",
+			Margin:          "18.00",
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "from_date=2024-01-01&to_date=2024-01-31",
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.GetProductMarginReportParams{
+					ShopName: shop.Name,
+					ToTime:   time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+					FromTime: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+				}
+				store.EXPECT().
+					GetProductMarginReport(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(report, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []db.GetProductMarginReportRow
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, report, res)
+			},
+		},
+		{
+			name:  "InvalidRange",
+			query: "from_date=2024-02-01&to_date=2024-01-31",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProductMarginReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shops/%v/reports/margin?%v", shop.ID, tc.query)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// token bucket per key: burst requests at once, then one every refill
+type rateLimiter struct {
+	mu      sync.Mutex
+	burst   float64
+	refill  time.Duration
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// forget buckets once this many keys are tracked, full buckets carry no state
+const maxRateLimitKeys = 10000
+
+func newRateLimiter(burst int, refill time.Duration) *rateLimiter {
+	return &rateLimiter{
+		burst:   float64(burst),
+		refill:  refill,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// take a token for the key, false when the key ran out
+func (limiter *rateLimiter) allow(key string) bool {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := limiter.now()
+	if len(limiter.buckets) >= maxRateLimitKeys {
+		limiter.prune(now)
+	}
+
+	b, ok := limiter.buckets[key]
+	if !ok {
+		b = &bucket{tokens: limiter.burst, last: now}
+		limiter.buckets[key] = b
+	}
+
+	b.tokens += float64(now.Sub(b.last)) / float64(limiter.refill)
+	if b.tokens > limiter.burst {
+		b.tokens = limiter.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (limiter *rateLimiter) prune(now time.Time) {
+	full := time.Duration(limiter.burst * float64(limiter.refill))
+	for key, b := range limiter.buckets {
+		if now.Sub(b.last) >= full {
+			delete(limiter.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2024, time.March, 2, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(2, 10*time.Second)
+	limiter.now = func() time.Time { return now }
+
+	require.True(t, limiter.allow("a"))
+	require.True(t, limiter.allow("a"))
+	require.False(t, limiter.allow("a"))
+	// keys do not share their buckets
+	require.True(t, limiter.allow("b"))
+
+	now = now.Add(5 * time.Second)
+	require.False(t, limiter.allow("a"))
+
+	now = now.Add(5 * time.Second)
+	require.True(t, limiter.allow("a"))
+	require.False(t, limiter.allow("a"))
+
+	// an idle key refills up to its burst only
+	now = now.Add(time.Hour)
+	require.True(t, limiter.allow("a"))
+	require.True(t, limiter.allow("a"))
+	require.False(t, limiter.allow("a"))
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/receipt"
+)
+
+type receiptUri struct {
+	OrderID string `uri:"order_id" binding:"required,uuid"`
+}
+
+type receiptQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=text html escpos"`
+	Width  int    `form:"width,default=80" binding:"oneof=58 80"` // paper roll in millimeters
+}
+
+func (server *Server) getReceipt(ctx *gin.Context) {
+	var uri receiptUri
+	var query receiptQuery
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	r, ok := server.loadReceipt(ctx, shop, uuid.MustParse(uri.OrderID))
+	if !ok {
+		return
+	}
+
+	var err error
+	var buf bytes.Buffer
+	var contentType string
+	width := receipt.Width(query.Width)
+	switch query.Format {
+	case "html":
+		contentType = "text/html; charset=utf-8"
+		err = receipt.WriteHTML(&buf, r, width)
+	case "escpos":
+		contentType = "application/octet-stream"
+		err = receipt.WriteESCPOS(&buf, r, width)
+	default:
+		contentType = "text/plain; charset=utf-8"
+		err = receipt.WriteText(&buf, r, width)
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// build the receipt of an order from the shop settings, its items and payments,
+// writes the error response and returns false when it cannot be built
+func (server *Server) loadReceipt(ctx *gin.Context, shop db.Shop, orderID uuid.UUID) (receipt.Receipt, bool) {
+	receiptShop, err := receipt.NewShop(shop)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return receipt.Receipt{}, false
+	}
+
+	items, err := server.store.GetOrdersByOrderID(ctx, db.GetOrdersByOrderIDParams{
+		ShopName: shop.Name,
+		OrderID:  orderID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return receipt.Receipt{}, false
+	}
+	if len(items) == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return receipt.Receipt{}, false
+	}
+
+	payments, err := server.store.ListPaymentsByOrderID(ctx, db.ListPaymentsByOrderIDParams{
+		ShopName: shop.Name,
+		OrderID:  orderID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return receipt.Receipt{}, false
+	}
+
+	r, err := receipt.Build(receiptShop, items, payments)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return receipt.Receipt{}, false
+	}
+
+	return r, true
+}
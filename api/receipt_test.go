@@ -0,0 +1,197 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetReceipt(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := hongKongShop(user)
+	shop.ReceiptFooter = "Thank you!"
+
+	product := randomProduct(shop)
+	menuItem := createMenuItem(shop, product, "drinks")
+	orderID := uuid.New()
+	orderItem := addOrderItem(menuItem, orderID)
+	orderItem.ProductPrice = "20.00"
+
+	items := []db.Order{orderItem}
+	payments := []db.Payment{
+		{ID: uuid.New(), ShopName: shop.Name, OrderID: orderID, Method: utils.PaymentCash, Amount: "21.00", Tendered: "50.00"},
+	}
+
+	expectOrder := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetOrdersByOrderID(gomock.Any(), gomock.Eq(db.GetOrdersByOrderIDParams{ShopName: shop.Name, OrderID: orderID})).
+			Times(1).
+			Return(items, nil)
+		store.EXPECT().
+			ListPaymentsByOrderID(gomock.Any(), gomock.Eq(db.ListPaymentsByOrderIDParams{ShopName: shop.Name, OrderID: orderID})).
+			Times(1).
+			Return(payments, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		orderID       string
+		query         string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "Text",
+			username:  user.Username,
+			orderID:   orderID.String(),
+			query:     "width=58",
+			buildStub: expectOrder,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/plain; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+				body := recorder.Body.String()
+				// order placed at 12:00 UTC, printed in Hong Kong time
+				require.Contains(t, body, "01/01/2022 20:00:00\n")
+				require.Contains(t, body, "TOTAL                      21.00\n")
+				require.Contains(t, body, "Change                     29.00\n")
+				require.Contains(t, body, "Thank you!")
+			},
+		},
+		{
+			name:      "HTML",
+			username:  user.Username,
+			orderID:   orderID.String(),
+			query:     "format=html",
+			buildStub: expectOrder,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Body.String(), "@page { size: 80mm auto;")
+			},
+		},
+		{
+			name:      "ESCPOS",
+			username:  user.Username,
+			orderID:   orderID.String(),
+			query:     "format=escpos",
+			buildStub: expectOrder,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/octet-stream", recorder.Header().Get("Content-Type"))
+
+				body := recorder.Body.Bytes()
+				require.Equal(t, []byte{0x1b, 0x40}, body[:2])
+				require.Equal(t, []byte{0x1d, 0x56, 0x01}, body[len(body)-3:])
+			},
+		},
+		{
+			name:     "OrderNotFound",
+			username: user.Username,
+			orderID:  orderID.String(),
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Order{}, nil)
+				store.EXPECT().
+					ListPaymentsByOrderID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "InternalError",
+			username: user.Username,
+			orderID:  orderID.String(),
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidWidth",
+			username: user.Username,
+			orderID:  orderID.String(),
+			query:    "width=76",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidFormat",
+			username: user.Username,
+			orderID:  orderID.String(),
+			query:    "format=pdf",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			username: "unauthorized",
+			orderID:  orderID.String(),
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shops/%v/orders/%v/receipt?%v", shop.ID, tc.orderID, tc.query)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+)
+
+type recipeUri struct {
+	ProductID string `uri:"productid" binding:"required,uuid"`
+}
+
+func (server *Server) getRecipe(ctx *gin.Context) {
+	var uri recipeUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	recipe, err := server.store.ListRecipeItems(ctx, db.ListRecipeItemsParams{
+		ProductID: uuid.MustParse(uri.ProductID),
+		ShopName:  shop.Name,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipe)
+}
+
+type recipeItemRequest struct {
+	IngredientID uuid.UUID `json:"ingredient_id" binding:"required"`
+	Quantity     float64   `json:"quantity" binding:"required,gt=0"`
+	Unit         string    `json:"unit" binding:"required"`
+}
+
+type setRecipeRequest struct {
+	Items []recipeItemRequest `json:"items" binding:"required,dive"`
+}
+
+func (server *Server) setRecipe(ctx *gin.Context) {
+	var uri recipeUri
+	var req setRecipeRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	// recipes can only be set on products of the shop's organisation
+	productID := uuid.MustParse(uri.ProductID)
+	_, err := server.store.GetProduct(ctx, db.GetProductParams{OrganisationID: shop.OrganisationID, ID: productID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.SetRecipeTxParams{
+		ShopName:  shop.Name,
+		ProductID: productID,
+	}
+	for _, item := range req.Items {
+		arg.Items = append(arg.Items, db.RecipeItemParams(item))
+	}
+
+	recipe, err := server.store.SetRecipeTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if err == db.ErrIncompatibleUnit {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipe)
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetRecipe(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	milk := randomIngredient(shop, utils.UnitLiter)
+
+	recipe := []db.RecipeItem{
+		{
+			ID:           uuid.New(),
+			ProductID:    product.ID,
+			IngredientID: milk.ID,
+			Quantity:     "200.000",
+			Unit:         utils.UnitMilliliter,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"items": []gin.H{
+					{"ingredient_id": milk.ID, "quantity": 200, "unit": utils.UnitMilliliter},
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(db.GetProductParams{OrganisationID: shop.OrganisationID, ID: product.ID})).
+					Times(1).
+					Return(product, nil)
+
+				arg := db.SetRecipeTxParams{
+					ShopName:  shop.Name,
+					ProductID: product.ID,
+					Items: []db.RecipeItemParams{
+						{IngredientID: milk.ID, Quantity: 200, Unit: utils.UnitMilliliter},
+					},
+				}
+				store.EXPECT().
+					SetRecipeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []db.RecipeItem
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, recipe, res)
+			},
+		},
+		{
+			name: "IncompatibleUnit",
+			body: gin.H{
+				"items": []gin.H{
+					{"ingredient_id": milk.ID, "quantity": 200, "unit": utils.UnitGram},
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(product, nil)
+				store.EXPECT().
+					SetRecipeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, db.ErrIncompatibleUnit)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NonPositiveQuantity",
+			body: gin.H{
+				"items": []gin.H{
+					{"ingredient_id": milk.ID, "quantity": 0, "unit": utils.UnitMilliliter},
+				},
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/products/%v/recipe", shop.ID, product.ID)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(jsonData))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type businessDayQuery struct {
+	BusinessDay string `form:"business_day" json:"business_day" binding:"required,datetime=2006-01-02"`
+}
+
+var errDayNotOver = errors.New("the business day has not ended yet")
+
+func (server *Server) getDailySalesReport(ctx *gin.Context) {
+	var query businessDayQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	summary, err := server.store.DailySalesSummary(ctx, db.DailySalesSummaryParams{
+		ShopName:    shop.Name,
+		BusinessDay: query.BusinessDay,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+func (server *Server) closeDay(ctx *gin.Context) {
+	var req businessDayQuery
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	// orders still land in the current business day, a day is closed once
+	// it has ended on the shop clock
+	clock, err := newShopClock(shop)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if req.BusinessDay >= clock.businessDay(time.Now()) {
+		ctx.JSON(http.StatusConflict, errorResponse(errDayNotOver))
+		return
+	}
+
+	report, err := server.store.CloseDayTx(ctx, db.DailySalesSummaryParams{
+		ShopName:    shop.Name,
+		BusinessDay: req.BusinessDay,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if err == db.ErrDayClosed {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func (server *Server) getZReports(ctx *gin.Context) {
+	var query pageQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	reports, err := server.store.ListZReports(ctx, db.ListZReportsParams{
+		ShopName: shop.Name,
+		Limit:    query.PageSize,
+		Offset:   (query.PageID - 1) * query.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reports)
+}
+
+type zReportUri struct {
+	Number int32 `uri:"number" binding:"required,min=1"`
+}
+
+func (server *Server) getZReport(ctx *gin.Context) {
+	var uri zReportUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	report, err := server.store.GetZReport(ctx, db.GetZReportParams{
+		ShopName: shop.Name,
+		Number:   uri.Number,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+type createPaymentRequest struct {
+	OrderID  uuid.UUID `json:"order_id" binding:"required"`
+	Method   string    `json:"method" binding:"required,oneof=cash card other"`
+	Amount   float64   `json:"amount" binding:"required,gt=0"`
+	Tendered float64   `json:"tendered" binding:"omitempty,gtefield=Amount"` // cash handed over, defaults to the amount
+}
+
+func (server *Server) createPayment(ctx *gin.Context) {
+	var req createPaymentRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	tendered := req.Tendered
+	if tendered == 0 {
+		tendered = req.Amount
+	}
+
+	payment, err := server.store.CreatePaymentTx(ctx, db.CreatePaymentParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		OrderID:  req.OrderID,
+		Method:   req.Method,
+		Amount:   utils.FormottedDecimalToString(req.Amount),
+		Tendered: utils.FormottedDecimalToString(tendered),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrDayClosed) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, payment)
+}
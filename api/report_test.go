@@ -0,0 +1,372 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetDailySalesReport(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	summary := db.DailySalesSummary{
+		ShopName:      shop.Name,
+		BusinessDay:   "2024-01-02",
+		GrossSales:    "120.00",
+		Discounts:     "0.00",
+		Refunds:       "20.00",
+		NetSales:      "100.00",
+		Tax:           "5.00",
+		Tenders:       []db.GetDailyTendersRow{{Method: "cash", Amount: "105.00", Payments: 4}},
+		OrderCount:    4,
+		AverageTicket: "25.00",
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "business_day=2024-01-02",
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.DailySalesSummaryParams{
+					ShopName:    shop.Name,
+					BusinessDay: "2024-01-02",
+				}
+				store.EXPECT().
+					DailySalesSummary(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(summary, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.DailySalesSummary
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, summary, res)
+			},
+		},
+		{
+			name:  "InvalidDay",
+			query: "business_day=yesterday",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DailySalesSummary(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: "business_day=2024-01-02",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DailySalesSummary(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.DailySalesSummary{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shops/%v/reports/daily-sales?%v", shop.ID, tc.query)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCloseDay(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	report := db.ZReport{
+		ID:            uuid.New(),
+		ShopName:      shop.Name,
+		Number:        3,
+		BusinessDay:   "2024-01-02",
+		GrossSales:    "100.00",
+		Discounts:     "0.00",
+		Refunds:       "0.00",
+		NetSales:      "100.00",
+		Tax:           "0.00",
+		OrderCount:    2,
+		AverageTicket: "50.00",
+		Tenders:       json.RawMessage(`[]`),
+		ClosedAt:      time.Date(2024, time.January, 2, 23, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"business_day": "2024-01-02"},
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.DailySalesSummaryParams{
+					ShopName:    shop.Name,
+					BusinessDay: "2024-01-02",
+				}
+				store.EXPECT().
+					CloseDayTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(report, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.ZReport
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, report, res)
+			},
+		},
+		{
+			name: "AlreadyClosed",
+			body: gin.H{"business_day": "2024-01-02"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CloseDayTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ZReport{}, db.ErrDayClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			// orders still come in today
+			name: "DayNotOver",
+			body: gin.H{"business_day": time.Now().UTC().Format("2006-01-02")},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CloseDayTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "MissingDay",
+			body: gin.H{},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CloseDayTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/z-reports", shop.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(jsonData))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreatePayment(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	menuItem := createMenuItem(shop, product, "breakfast")
+	orderItem := addOrderItem(menuItem, uuid.New())
+
+	payment := db.Payment{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		OrderID:  orderItem.OrderID,
+		Method:   "cash",
+		Amount:   "10.00",
+		Tendered: "20.00",
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"order_id": orderItem.OrderID, "method": "cash", "amount": 10, "tendered": 20},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePaymentTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx interface{}, arg db.CreatePaymentParams) (db.Payment, error) {
+						require.Equal(t, shop.Name, arg.ShopName)
+						require.Equal(t, orderItem.OrderID, arg.OrderID)
+						require.Equal(t, "10.00", arg.Amount)
+						require.Equal(t, "20.00", arg.Tendered)
+						return payment, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "TenderedDefaultsToAmount",
+			body: gin.H{"order_id": orderItem.OrderID, "method": "card", "amount": 10},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePaymentTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx interface{}, arg db.CreatePaymentParams) (db.Payment, error) {
+						require.Equal(t, "10.00", arg.Tendered)
+						return payment, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "TenderedBelowAmount",
+			body: gin.H{"order_id": orderItem.OrderID, "method": "cash", "amount": 10, "tendered": 5},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePaymentTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnsupportedMethod",
+			body: gin.H{"order_id": orderItem.OrderID, "method": "cheque", "amount": 10},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePaymentTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "OrderNotFound",
+			body: gin.H{"order_id": orderItem.OrderID, "method": "cash", "amount": 10},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePaymentTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Payment{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "DayClosed",
+			body: gin.H{"order_id": orderItem.OrderID, "method": "cash", "amount": 10},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePaymentTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Payment{}, db.ErrDayClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/payments", shop.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(jsonData))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
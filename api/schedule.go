@@ -0,0 +1,496 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// a range the shop takes orders in, times are HH:MM on the shop's clock and
+// 24:00 closes at midnight. weekday is ISO 8601, 1 is Monday
+type openingHoursRequest struct {
+	Weekday  int32  `json:"weekday" binding:"required,min=1,max=7"`
+	OpensAt  string `json:"opens_at" binding:"required"`
+	ClosesAt string `json:"closes_at" binding:"required"`
+}
+
+type setOpeningHoursRequest struct {
+	Hours []openingHoursRequest `json:"hours" binding:"dive"`
+}
+
+type openingHoursResponse struct {
+	Weekday  int32  `json:"weekday"`
+	OpensAt  string `json:"opens_at"`
+	ClosesAt string `json:"closes_at"`
+}
+
+func newOpeningHoursResponse(hours []db.OpeningHour) []openingHoursResponse {
+	res := make([]openingHoursResponse, 0, len(hours))
+	for _, h := range hours {
+		res = append(res, openingHoursResponse{
+			Weekday:  h.Weekday,
+			OpensAt:  utils.FormatTimeOfDay(h.OpensAt),
+			ClosesAt: utils.FormatTimeOfDay(h.ClosesAt),
+		})
+	}
+	return res
+}
+
+// replace the weekly opening hours, an empty list keeps the shop open around
+// the clock
+func (server *Server) setOpeningHours(ctx *gin.Context) {
+	var req setOpeningHoursRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.SetOpeningHoursTxParams{ShopName: shop.Name}
+	for _, h := range req.Hours {
+		opensAt, err := utils.ParseTimeOfDay(h.OpensAt)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		closesAt, err := utils.ParseTimeOfDay(h.ClosesAt)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if closesAt <= opensAt {
+			err := fmt.Errorf("closes_at %s must be after opens_at %s", h.ClosesAt, h.OpensAt)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.Hours = append(arg.Hours, db.OpeningHoursParams{
+			Weekday:  h.Weekday,
+			OpensAt:  opensAt,
+			ClosesAt: closesAt,
+		})
+	}
+
+	hours, err := server.store.SetOpeningHoursTx(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newOpeningHoursResponse(hours))
+}
+
+func (server *Server) getOpeningHours(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	hours, err := server.store.ListOpeningHours(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newOpeningHoursResponse(hours))
+}
+
+// a holiday sends closed, special hours of a day send a range per row.
+// day is a calendar day on the shop's clock, written like order_day
+type createOpeningExceptionRequest struct {
+	Day      string `json:"day" binding:"required"`
+	Closed   bool   `json:"closed"`
+	OpensAt  string `json:"opens_at" binding:"required_without=Closed"`
+	ClosesAt string `json:"closes_at" binding:"required_without=Closed"`
+	Note     string `json:"note" binding:"max=200"`
+}
+
+// opens_at and closes_at are empty on a closed day
+type openingExceptionResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Day      string    `json:"day"`
+	Closed   bool      `json:"closed"`
+	OpensAt  string    `json:"opens_at"`
+	ClosesAt string    `json:"closes_at"`
+	Note     string    `json:"note"`
+}
+
+func newOpeningExceptionResponses(exceptions []db.OpeningException) []openingExceptionResponse {
+	res := make([]openingExceptionResponse, 0, len(exceptions))
+	for _, e := range exceptions {
+		exception := openingExceptionResponse{ID: e.ID, Day: e.Day, Closed: e.Closed, Note: e.Note}
+		if !e.Closed {
+			exception.OpensAt = utils.FormatTimeOfDay(e.OpensAt)
+			exception.ClosesAt = utils.FormatTimeOfDay(e.ClosesAt)
+		}
+		res = append(res, exception)
+	}
+	return res
+}
+
+func (server *Server) createOpeningException(ctx *gin.Context) {
+	var req createOpeningExceptionRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if _, err := time.Parse("2006-01-02", req.Day); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.CreateOpeningExceptionParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		Day:      req.Day,
+		Closed:   req.Closed,
+		Note:     req.Note,
+	}
+	if !req.Closed {
+		var err error
+		arg.OpensAt, err = utils.ParseTimeOfDay(req.OpensAt)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.ClosesAt, err = utils.ParseTimeOfDay(req.ClosesAt)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if arg.ClosesAt <= arg.OpensAt {
+			err := fmt.Errorf("closes_at %s must be after opens_at %s", req.ClosesAt, req.OpensAt)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	exception, err := server.store.CreateOpeningException(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newOpeningExceptionResponses([]db.OpeningException{exception})[0])
+}
+
+// both days are inclusive, every exception is listed without them
+type listOpeningExceptionsQuery struct {
+	FromDate string `form:"from_date" binding:"omitempty,datetime=2006-01-02"`
+	ToDate   string `form:"to_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+func (server *Server) getOpeningExceptions(ctx *gin.Context) {
+	var query listOpeningExceptionsQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.ListOpeningExceptionsParams{
+		ShopName: shop.Name,
+		FromDay:  query.FromDate,
+		ToDay:    query.ToDate,
+	}
+	if arg.ToDay == "" {
+		arg.ToDay = "9999-12-31"
+	}
+
+	exceptions, err := server.store.ListOpeningExceptions(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newOpeningExceptionResponses(exceptions))
+}
+
+type openingExceptionUri struct {
+	ExceptionID string `uri:"exception_id" binding:"required,uuid"`
+}
+
+func (server *Server) deleteOpeningException(ctx *gin.Context) {
+	var uri openingExceptionUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	rows, err := server.store.DeleteOpeningException(ctx, db.DeleteOpeningExceptionParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.ExceptionID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, textResponse("delete successfully"))
+}
+
+// limits of a slot are 0 for unlimited, lead_minutes is how long before its
+// slot a scheduled order reaches the kitchen
+type updateOrderScheduleRequest struct {
+	MaxOrdersPerSlot int32 `json:"max_orders_per_slot" binding:"min=0"`
+	MaxItemsPerSlot  int32 `json:"max_items_per_slot" binding:"min=0"`
+	LeadMinutes      int32 `json:"lead_minutes" binding:"min=0,max=1440"`
+	MaxDaysAhead     int32 `json:"max_days_ahead" binding:"required,min=1,max=90"`
+}
+
+func (server *Server) updateOrderSchedule(ctx *gin.Context) {
+	var req updateOrderScheduleRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	schedule, err := server.store.UpsertOrderSchedule(ctx, db.UpsertOrderScheduleParams{
+		ShopName:         shop.Name,
+		MaxOrdersPerSlot: req.MaxOrdersPerSlot,
+		MaxItemsPerSlot:  req.MaxItemsPerSlot,
+		LeadMinutes:      req.LeadMinutes,
+		MaxDaysAhead:     req.MaxDaysAhead,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+func (server *Server) getOrderSchedule(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	schedule, err := server.orderSchedule(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+// settings of the shop's order schedule, the defaults until it saves its own
+func (server *Server) orderSchedule(ctx *gin.Context, shopName string) (db.OrderSchedule, error) {
+	schedule, err := server.store.GetOrderSchedule(ctx, shopName)
+	if err == sql.ErrNoRows {
+		return db.DefaultOrderSchedule(shopName), nil
+	}
+	return schedule, err
+}
+
+// weekly hours of the shop with its exceptions on the calendar days from to
+// to on the shop's clock
+func (server *Server) openingHours(ctx *gin.Context, shopName string, clock shopClock, from, to time.Time) (utils.OpeningHours, error) {
+	weekly, err := server.store.ListOpeningHours(ctx, shopName)
+	if err != nil {
+		return utils.OpeningHours{}, err
+	}
+	exceptions, err := server.store.ListOpeningExceptions(ctx, db.ListOpeningExceptionsParams{
+		ShopName: shopName,
+		FromDay:  from.In(clock.loc).Format("2006-01-02"),
+		ToDay:    to.In(clock.loc).Format("2006-01-02"),
+	})
+	if err != nil {
+		return utils.OpeningHours{}, err
+	}
+
+	return newOpeningHours(weekly, exceptions), nil
+}
+
+func newOpeningHours(weekly []db.OpeningHour, exceptions []db.OpeningException) utils.OpeningHours {
+	var hours utils.OpeningHours
+	for _, h := range weekly {
+		hours.Weekly = append(hours.Weekly, utils.OpeningRange{Weekday: h.Weekday, OpensAt: h.OpensAt, ClosesAt: h.ClosesAt})
+	}
+	for _, e := range exceptions {
+		hours.Exceptions = append(hours.Exceptions, utils.OpeningException{Day: e.Day, Closed: e.Closed, OpensAt: e.OpensAt, ClosesAt: e.ClosesAt})
+	}
+	return hours
+}
+
+var (
+	errSlotNotAligned = errors.New("scheduled_for must start a 15 minute slot")
+	errSlotInPast     = errors.New("scheduled_for is in the past")
+	errSlotTooFar     = errors.New("scheduled_for is too far ahead")
+	errSlotClosed     = errors.New("the shop is closed at scheduled_for")
+)
+
+// refuse a slot that does not start on the quarter hour, has already started,
+// lies beyond the days the shop takes orders for or outside its opening hours
+func (server *Server) checkScheduledSlot(ctx *gin.Context, shopName string, clock shopClock, start time.Time) bool {
+	now := time.Now()
+
+	if !start.Equal(utils.SlotStart(start)) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errSlotNotAligned))
+		return false
+	}
+	if !start.After(now) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errSlotInPast))
+		return false
+	}
+
+	schedule, err := server.orderSchedule(ctx, shopName)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	if start.After(now.AddDate(0, 0, int(schedule.MaxDaysAhead))) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errSlotTooFar))
+		return false
+	}
+
+	hours, err := server.openingHours(ctx, shopName, clock, start, start)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	if !hours.IsSlotOpen(start, clock.loc) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errSlotClosed))
+		return false
+	}
+
+	return true
+}
+
+type orderSlotsUri struct {
+	ShopName string `uri:"shop_name" binding:"required,alphanum,min=1"`
+}
+
+// date is a calendar day on the shop's clock, written like order_day
+type orderSlotsQuery struct {
+	Date string `form:"date" binding:"required"`
+}
+
+// remaining is null for a slot without limits
+type orderSlotResponse struct {
+	SlotStart       time.Time `json:"slot_start"`
+	RemainingOrders *int32    `json:"remaining_orders"`
+	RemainingItems  *int32    `json:"remaining_items"`
+	Available       bool      `json:"available"`
+}
+
+// the slots of a day customers can still schedule an order for
+func (server *Server) getOrderSlots(ctx *gin.Context) {
+	var uri orderSlotsUri
+	var query orderSlotsQuery
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop, err := server.store.GetShopByName(ctx, uri.ShopName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	clock, err := newShopClock(shop)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", query.Date, clock.loc)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	from, to := date, date.AddDate(0, 0, 1)
+
+	schedule, err := server.orderSchedule(ctx, uri.ShopName)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	hours, err := server.openingHours(ctx, uri.ShopName, clock, from, from)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	booked, err := server.store.ListOrderSlots(ctx, db.ListOrderSlotsParams{
+		ShopName: uri.ShopName,
+		FromTime: from.UTC(),
+		ToTime:   to.UTC(),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	bookedAt := make(map[time.Time]db.OrderSlot, len(booked))
+	for _, slot := range booked {
+		bookedAt[slot.SlotStart.UTC()] = slot
+	}
+
+	now := time.Now()
+	last := now.AddDate(0, 0, int(schedule.MaxDaysAhead))
+	res := []orderSlotResponse{}
+	for start := from.UTC(); start.Before(to); start = start.Add(utils.SlotLength) {
+		if !start.After(now) || start.After(last) || !hours.IsSlotOpen(start, clock.loc) {
+			continue
+		}
+
+		slot := orderSlotResponse{SlotStart: start, Available: true}
+		if schedule.MaxOrdersPerSlot > 0 {
+			remaining := max32(schedule.MaxOrdersPerSlot-bookedAt[start].Orders, 0)
+			slot.RemainingOrders = &remaining
+			slot.Available = remaining > 0
+		}
+		if schedule.MaxItemsPerSlot > 0 {
+			remaining := max32(schedule.MaxItemsPerSlot-bookedAt[start].Items, 0)
+			slot.RemainingItems = &remaining
+			slot.Available = slot.Available && remaining > 0
+		}
+		res = append(res, slot)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func max32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
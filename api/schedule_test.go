@@ -0,0 +1,363 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+// nil hours leave the shop open around the clock
+func expectOpeningHours(store *mockdb.MockStore, shop db.Shop, weekly []db.OpeningHour, exceptions []db.OpeningException) {
+	store.EXPECT().
+		ListOpeningHours(gomock.Any(), gomock.Eq(shop.Name)).
+		Times(1).
+		Return(weekly, nil)
+	store.EXPECT().
+		ListOpeningExceptions(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(exceptions, nil)
+}
+
+func TestSetOpeningHours(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"hours": []gin.H{
+				{"weekday": 1, "opens_at": "11:30", "closes_at": "14:00"},
+				{"weekday": 5, "opens_at": "18:00", "closes_at": "24:00"},
+			}},
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.SetOpeningHoursTxParams{
+					ShopName: shop.Name,
+					Hours: []db.OpeningHoursParams{
+						{Weekday: 1, OpensAt: 690, ClosesAt: 840},
+						{Weekday: 5, OpensAt: 1080, ClosesAt: 1440},
+					},
+				}
+				store.EXPECT().
+					SetOpeningHoursTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.OpeningHour{
+						{ID: uuid.New(), ShopName: shop.Name, Weekday: 1, OpensAt: 690, ClosesAt: 840},
+						{ID: uuid.New(), ShopName: shop.Name, Weekday: 5, OpensAt: 1080, ClosesAt: 1440},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []openingHoursResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, []openingHoursResponse{
+					{Weekday: 1, OpensAt: "11:30", ClosesAt: "14:00"},
+					{Weekday: 5, OpensAt: "18:00", ClosesAt: "24:00"},
+				}, res)
+			},
+		},
+		{
+			name: "ClosesBeforeOpens",
+			body: gin.H{"hours": []gin.H{{"weekday": 1, "opens_at": "14:00", "closes_at": "11:30"}}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetOpeningHoursTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidWeekday",
+			body: gin.H{"hours": []gin.H{{"weekday": 8, "opens_at": "11:30", "closes_at": "14:00"}}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetOpeningHoursTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidTime",
+			body: gin.H{"hours": []gin.H{{"weekday": 1, "opens_at": "half past eleven", "closes_at": "14:00"}}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetOpeningHoursTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/opening-hours", shop.ID)
+			recorder := serveKitchen(t, store, user, http.MethodPut, url, tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetOrderSchedule(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// a shop that never saved its schedule gets the defaults
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		GetOrderSchedule(gomock.Any(), gomock.Eq(shop.Name)).
+		Times(1).
+		Return(db.OrderSchedule{}, sql.ErrNoRows)
+
+	url := fmt.Sprintf("/shops/%s/order-schedule", shop.ID)
+	recorder := serveKitchen(t, store, user, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res db.OrderSchedule
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	require.Equal(t, db.DefaultOrderSchedule(shop.Name), res)
+}
+
+func TestUpdateOrderSchedule(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	arg := db.UpsertOrderScheduleParams{
+		ShopName:         shop.Name,
+		MaxOrdersPerSlot: 4,
+		MaxItemsPerSlot:  20,
+		LeadMinutes:      30,
+		MaxDaysAhead:     3,
+	}
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		UpsertOrderSchedule(gomock.Any(), gomock.Eq(arg)).
+		Times(1).
+		Return(db.OrderSchedule{
+			ShopName:         arg.ShopName,
+			MaxOrdersPerSlot: arg.MaxOrdersPerSlot,
+			MaxItemsPerSlot:  arg.MaxItemsPerSlot,
+			LeadMinutes:      arg.LeadMinutes,
+			MaxDaysAhead:     arg.MaxDaysAhead,
+		}, nil)
+
+	url := fmt.Sprintf("/shops/%s/order-schedule", shop.ID)
+	recorder := serveKitchen(t, store, user, http.MethodPut, url, gin.H{
+		"max_orders_per_slot": 4,
+		"max_items_per_slot":  20,
+		"lead_minutes":        30,
+		"max_days_ahead":      3,
+	})
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestGetOrderSlots(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	day := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 0, 0, 0, 0, time.UTC)
+	noon := day.Add(12 * time.Hour)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectGetShop(store, shop)
+	store.EXPECT().
+		GetOrderSchedule(gomock.Any(), gomock.Eq(shop.Name)).
+		Times(1).
+		Return(db.OrderSchedule{ShopName: shop.Name, MaxOrdersPerSlot: 2, MaxDaysAhead: 7}, nil)
+	// open from noon to 13:00 that day
+	expectOpeningHours(store, shop, []db.OpeningHour{{Weekday: utils.ISOWeekday(day), OpensAt: 720, ClosesAt: 780}}, nil)
+	store.EXPECT().
+		ListOrderSlots(gomock.Any(), gomock.Eq(db.ListOrderSlotsParams{
+			ShopName: shop.Name,
+			FromTime: day,
+			ToTime:   day.AddDate(0, 0, 1),
+		})).
+		Times(1).
+		Return([]db.OrderSlot{
+			{ShopName: shop.Name, SlotStart: noon, Orders: 2, Items: 6},
+			{ShopName: shop.Name, SlotStart: noon.Add(30 * time.Minute), Orders: 1, Items: 1},
+		}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/%s/order-slots?date=%s", shop.Name, day.Format("2006-01-02"))
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res []orderSlotResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	require.Len(t, res, 4)
+	require.True(t, res[0].SlotStart.Equal(noon))
+	require.False(t, res[0].Available)
+	require.Equal(t, int32(0), *res[0].RemainingOrders)
+	require.Nil(t, res[0].RemainingItems)
+	require.True(t, res[1].Available)
+	require.Equal(t, int32(2), *res[1].RemainingOrders)
+	require.Equal(t, int32(1), *res[2].RemainingOrders)
+	require.True(t, res[3].SlotStart.Equal(noon.Add(45*time.Minute)))
+}
+
+func TestCreateOpeningException(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Holiday",
+			body: gin.H{"day": "2024-12-25", "closed": true, "note": "Christmas"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOpeningException(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateOpeningExceptionParams) (db.OpeningException, error) {
+						require.Equal(t, shop.Name, arg.ShopName)
+						require.True(t, arg.Closed)
+						require.Zero(t, arg.ClosesAt)
+						return db.OpeningException{ID: arg.ID, ShopName: arg.ShopName, Day: arg.Day, Closed: true, Note: arg.Note}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res openingExceptionResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.True(t, res.Closed)
+				require.Empty(t, res.OpensAt)
+			},
+		},
+		{
+			name: "SpecialHours",
+			body: gin.H{"day": "2024-12-24", "opens_at": "10:00", "closes_at": "15:00"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOpeningException(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateOpeningExceptionParams) (db.OpeningException, error) {
+						require.Equal(t, int32(600), arg.OpensAt)
+						require.Equal(t, int32(900), arg.ClosesAt)
+						return db.OpeningException{ID: arg.ID, Day: arg.Day, OpensAt: arg.OpensAt, ClosesAt: arg.ClosesAt}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res openingExceptionResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, "10:00", res.OpensAt)
+				require.Equal(t, "15:00", res.ClosesAt)
+			},
+		},
+		{
+			name: "MissingHours",
+			body: gin.H{"day": "2024-12-24"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOpeningException(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidDay",
+			body: gin.H{"day": "24/12/2024", "closed": true},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateOpeningException(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/opening-exceptions", shop.ID)
+			recorder := serveKitchen(t, store, user, http.MethodPost, url, tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteOpeningException(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	exceptionID := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		DeleteOpeningException(gomock.Any(), gomock.Eq(db.DeleteOpeningExceptionParams{ShopName: shop.Name, ID: exceptionID})).
+		Times(1).
+		Return(int64(0), nil)
+
+	url := fmt.Sprintf("/shops/%s/opening-exceptions/%s", shop.ID, exceptionID)
+	recorder := serveKitchen(t, store, user, http.MethodDelete, url, nil)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
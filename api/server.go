@@ -2,31 +2,51 @@ package api
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/event"
 	"github.com/toml5566/go_pos_backend/token"
 	"github.com/toml5566/go_pos_backend/utils"
 )
 
 type Server struct {
-	config     utils.Config
-	store      db.Store
-	tokenMaker token.Maker
-	router     *gin.Engine
+	config            utils.Config
+	store             db.Store
+	tokenMaker        token.Maker
+	tableSigner       *token.TableSigner
+	tableOrderLimiter *rateLimiter
+	hub               *event.Hub
+	router            *gin.Engine
 }
 
+// orders placed through one table link, a few at once and then one every
+// half minute
+const (
+	tableOrderBurst  = 5
+	tableOrderRefill = 30 * time.Second
+)
+
 // create a new HTTP server and setup routing
-func NewServer(config utils.Config, store db.Store) (*Server, error) {
+func NewServer(config utils.Config, store db.Store, hub *event.Hub) (*Server, error) {
 	tokenMaker, err := token.NewJWTMaker(config.TokenSecretKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	tableSigner, err := token.NewTableSigner(config.TokenSecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create table signer: %w", err)
+	}
+
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:            config,
+		store:             store,
+		tokenMaker:        tokenMaker,
+		tableSigner:       tableSigner,
+		tableOrderLimiter: newRateLimiter(tableOrderBurst, tableOrderRefill),
+		hub:               hub,
 	}
 
 	server.setupRouter()
@@ -47,23 +67,164 @@ func (server *Server) setupRouter() {
 
 	router.POST("/:shop_name/order", server.createOrders)
 	router.GET("/:shop_name/order/:order_id", server.getOrdersByOrderID)
+	router.GET("/:shop_name/order/:order_id/tracking", server.getOrderTracking)
+	router.GET("/:shop_name/order-slots", server.getOrderSlots)
+	router.GET("/:shop_name/profile", server.getShopProfile)
 
 	// protected routes
 	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
 	authRoutes.GET("/users/:username", server.getUser)
-
-	authRoutes.GET("/users/:username/products", server.getAllProducts)
-	authRoutes.POST("/users/:username/products", server.createProduct)
-	authRoutes.PATCH("/users/:username/products/:productid", server.updateProduct)
-	authRoutes.DELETE("/users/:username/products/:productid", server.deleteProduct)
-
-	authRoutes.POST("/users/:username/menus", server.addMenuItem)
-	authRoutes.PATCH("/users/:username/menus/:menu_item_id", server.updateMenuItem)
-	authRoutes.DELETE("/users/:username/menus/:menu_item_id", server.deleteMenuItem)
-
-	authRoutes.PATCH("/users/:username/orders/:order_id", server.updateOrderItem)
-	authRoutes.GET("/users/:username/orders", server.getOrdersByDay)
-	authRoutes.DELETE("/users/:username/orders/:order_id", server.deleteOrderItem)
+	authRoutes.GET("/users/:username/shops", server.getUserShops)
+	authRoutes.POST("/organisations", server.createOrganisation)
+
+	// organisation wide routes, for owners and managers
+	organisationRoutes := router.Group("/organisations/:organisation_id").Use(authMiddleware(server.tokenMaker), organisationMiddleware(server.store))
+	organisationRoutes.GET("", server.getOrganisation)
+	organisationRoutes.POST("/shops", server.createShop)
+	organisationRoutes.GET("/members", server.getOrganisationMembers)
+	organisationRoutes.POST("/members", server.addOrganisationMember)
+	organisationRoutes.DELETE("/members/:user_id", server.deleteOrganisationMember)
+
+	organisationRoutes.GET("/products", server.getAllProducts)
+	organisationRoutes.POST("/products", server.createProduct)
+	organisationRoutes.PATCH("/products/:productid", server.updateProduct)
+	organisationRoutes.DELETE("/products/:productid", server.deleteProduct)
+	organisationRoutes.PUT("/products/:productid/prep-time", server.setProductPrepTime)
+
+	// routes of a single shop, for every member with access to it. its
+	// settings, prices and money are for owners and managers only
+	shopRoutes := router.Group("/shops/:shop_id", authMiddleware(server.tokenMaker), shopMiddleware(server.store))
+	managerRoutes := shopRoutes.Group("", managerMiddleware(server.store))
+	shopRoutes.GET("", server.getShop)
+	managerRoutes.PUT("/timezone", server.updateShopTimezone)
+	managerRoutes.PUT("/locale", server.updateShopLocale)
+	managerRoutes.PUT("/business-day", server.updateShopBusinessDay)
+	managerRoutes.PUT("/receipt", server.updateShopReceipt)
+	managerRoutes.PUT("/profile", server.updateShopProfile)
+
+	shopRoutes.GET("/products/:productid/recipe", server.getRecipe)
+	managerRoutes.PUT("/products/:productid/recipe", server.setRecipe)
+	managerRoutes.GET("/products/:productid/costs", server.getProductCosts)
+
+	managerRoutes.POST("/menus", server.addMenuItem)
+	managerRoutes.PATCH("/menus/:menu_item_id", server.updateMenuItem)
+	managerRoutes.DELETE("/menus/:menu_item_id", server.deleteMenuItem)
+	shopRoutes.PATCH("/menus/:menu_item_id/availability", server.setMenuItemAvailability)
+
+	shopRoutes.POST("/orders", server.createShopOrder)
+	shopRoutes.PATCH("/orders/:order_id", server.updateOrderItem)
+	shopRoutes.GET("/orders", server.getOrdersByDay)
+	shopRoutes.GET("/orders/history", server.getOrderHistory)
+	shopRoutes.DELETE("/orders/:order_id", server.deleteOrderItem)
+	shopRoutes.POST("/orders/:order_id/refund", server.refundOrderItem)
+	shopRoutes.GET("/orders/:order_id/receipt", server.getReceipt)
+	shopRoutes.POST("/orders/:order_id/print", server.printReceipt)
+	shopRoutes.POST("/payments", server.createPayment)
+	shopRoutes.PUT("/orders/:order_id/split", server.splitOrder)
+	shopRoutes.GET("/orders/:order_id/checks", server.getSplit)
+	shopRoutes.POST("/orders/:order_id/checks/:check_id/payments", server.payCheck)
+	shopRoutes.GET("/orders/:order_id/fulfilment", server.getOrderFulfilment)
+	shopRoutes.PUT("/orders/:order_id/fulfilment/status", server.setFulfilmentStatus)
+	shopRoutes.GET("/fulfilments", server.getActiveFulfilments)
+	managerRoutes.POST("/order-type-fees", server.createOrderTypeFee)
+	shopRoutes.GET("/order-type-fees", server.getOrderTypeFees)
+	managerRoutes.DELETE("/order-type-fees/:fee_id", server.deleteOrderTypeFee)
+	managerRoutes.POST("/price-lists", server.createPriceList)
+	shopRoutes.GET("/price-lists", server.getPriceLists)
+	managerRoutes.PUT("/price-lists/:price_list_id", server.updatePriceList)
+	managerRoutes.DELETE("/price-lists/:price_list_id", server.deletePriceList)
+	shopRoutes.GET("/price-lists/:price_list_id/prices", server.getPriceListPrices)
+	managerRoutes.PUT("/price-lists/:price_list_id/prices", server.setPriceListPrices)
+	managerRoutes.POST("/promotions", server.createPromotion)
+	shopRoutes.GET("/promotions", server.getPromotions)
+	managerRoutes.PATCH("/promotions/:promotion_id/active", server.setPromotionActive)
+	managerRoutes.DELETE("/promotions/:promotion_id", server.deletePromotion)
+	managerRoutes.POST("/promotions/:promotion_id/coupons", server.createCoupon)
+	managerRoutes.POST("/promotions/:promotion_id/coupons/batch", server.createCouponBatch)
+	managerRoutes.GET("/promotions/:promotion_id/coupons", server.getCoupons)
+	managerRoutes.GET("/promotions/:promotion_id/coupons/stats", server.getCouponStats)
+	managerRoutes.PATCH("/coupons/:coupon_id/active", server.setCouponActive)
+	managerRoutes.PATCH("/coupon-batches/:batch_id/active", server.setCouponBatchActive)
+	managerRoutes.PUT("/opening-hours", server.setOpeningHours)
+	shopRoutes.GET("/opening-hours", server.getOpeningHours)
+	managerRoutes.POST("/opening-exceptions", server.createOpeningException)
+	shopRoutes.GET("/opening-exceptions", server.getOpeningExceptions)
+	managerRoutes.DELETE("/opening-exceptions/:exception_id", server.deleteOpeningException)
+	managerRoutes.PUT("/order-schedule", server.updateOrderSchedule)
+	shopRoutes.GET("/order-schedule", server.getOrderSchedule)
+
+	managerRoutes.POST("/floor-areas", server.createFloorArea)
+	managerRoutes.DELETE("/floor-areas/:area_id", server.deleteFloorArea)
+	managerRoutes.POST("/tables", server.createDiningTable)
+	managerRoutes.DELETE("/tables/:table_id", server.deleteDiningTable)
+	shopRoutes.PUT("/tables/:table_id/status", server.setDiningTableStatus)
+	shopRoutes.GET("/tables/:table_id/link", server.getTableLink)
+	shopRoutes.POST("/tables/:table_id/link/rotate", server.rotateTableLink)
+	shopRoutes.GET("/floor-plan", server.getFloorPlan)
+	shopRoutes.POST("/tabs", server.openTab)
+	shopRoutes.GET("/tabs", server.getOpenTabs)
+	shopRoutes.GET("/tabs/:tab_id", server.getTab)
+	shopRoutes.POST("/tabs/:tab_id/move", server.moveTab)
+	shopRoutes.POST("/tabs/:tab_id/merge", server.mergeTab)
+	shopRoutes.POST("/tabs/:tab_id/close", server.closeTab)
+
+	shopRoutes.GET("/stock", server.getStockLevels)
+	shopRoutes.GET("/stock/:product_id", server.getStockLevel)
+	shopRoutes.POST("/stock/:product_id/movements", server.createStockMovement)
+	shopRoutes.GET("/stock/:product_id/movements", server.getStockMovements)
+	managerRoutes.PUT("/stock/:product_id/reorder", server.setStockReorderLevels)
+	shopRoutes.GET("/alerts", server.getStockAlerts)
+	shopRoutes.GET("/events", server.streamEvents)
+
+	managerRoutes.POST("/stations", server.createStation)
+	shopRoutes.GET("/stations", server.getStations)
+	managerRoutes.DELETE("/stations/:station_id", server.deleteStation)
+	managerRoutes.PUT("/station-routes", server.setStationRoute)
+	shopRoutes.GET("/station-routes", server.getStationRoutes)
+	managerRoutes.DELETE("/station-routes/:route_id", server.deleteStationRoute)
+	shopRoutes.GET("/kitchen/tickets", server.getKitchenTickets)
+	shopRoutes.POST("/kitchen/tickets/:ticket_id/bump", server.bumpKitchenTicket)
+	shopRoutes.POST("/kitchen/tickets/:ticket_id/recall", server.recallKitchenTicket)
+	shopRoutes.GET("/kitchen/orders/:order_id", server.getKitchenOrder)
+
+	managerRoutes.POST("/printers", server.createPrinter)
+	shopRoutes.GET("/printers", server.getPrinters)
+	managerRoutes.DELETE("/printers/:printer_id", server.deletePrinter)
+	shopRoutes.GET("/print-jobs", server.getPrintJobs)
+	shopRoutes.GET("/print-jobs/:job_id", server.getPrintJob)
+	shopRoutes.POST("/print-jobs/:job_id/reprint", server.reprintPrintJob)
+
+	shopRoutes.POST("/ingredients", server.createIngredient)
+	shopRoutes.GET("/ingredients", server.getIngredients)
+	shopRoutes.POST("/ingredients/:ingredient_id/movements", server.createIngredientMovement)
+	shopRoutes.GET("/ingredients/:ingredient_id/movements", server.getIngredientMovements)
+
+	managerRoutes.POST("/suppliers", server.createSupplier)
+	shopRoutes.GET("/suppliers", server.getSuppliers)
+	managerRoutes.POST("/purchase-orders", server.createPurchaseOrder)
+	shopRoutes.GET("/purchase-orders", server.getPurchaseOrders)
+	shopRoutes.GET("/purchase-orders/:purchase_order_id", server.getPurchaseOrder)
+	managerRoutes.POST("/purchase-orders/:purchase_order_id/send", server.sendPurchaseOrder)
+	shopRoutes.POST("/purchase-orders/:purchase_order_id/receive", server.receivePurchaseOrder)
+
+	managerRoutes.GET("/reports/ingredient-usage", server.getIngredientUsageReport)
+	managerRoutes.GET("/reports/purchase-suggestions", server.getPurchaseSuggestions)
+	managerRoutes.GET("/reports/margin", server.getMarginReport)
+	managerRoutes.GET("/reports/daily-sales", server.getDailySalesReport)
+	managerRoutes.GET("/reports/product-mix", server.getProductMix)
+	managerRoutes.GET("/reports/heatmap", server.getSalesHeatmap)
+	managerRoutes.GET("/reports/comparison", server.getSalesComparison)
+
+	managerRoutes.GET("/exports/orders", server.exportOrders)
+	managerRoutes.GET("/exports/daily-sales", server.exportDailySales)
+	managerRoutes.GET("/exports/product-mix", server.exportProductMix)
+	managerRoutes.GET("/exports/journal", server.exportJournal)
+	managerRoutes.GET("/accounting/accounts", server.getAccountMapping)
+	managerRoutes.PUT("/accounting/accounts", server.setAccountMapping)
+
+	managerRoutes.POST("/z-reports", server.closeDay)
+	managerRoutes.GET("/z-reports", server.getZReports)
+	managerRoutes.GET("/z-reports/:number", server.getZReport)
 
 	server.router = router
 }
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type shopResponse struct {
+	ID                uuid.UUID `json:"id"`
+	OrganisationID    uuid.UUID `json:"organisation_id"`
+	Name              string    `json:"name"`
+	DisplayName       string    `json:"display_name"`
+	Address           string    `json:"address"`
+	Phone             string    `json:"phone"`
+	Currency          string    `json:"currency"`
+	Timezone          string    `json:"timezone"`
+	Locale            string    `json:"locale"`
+	BusinessDayCutoff string    `json:"business_day_cutoff"` // wall clock time the business day rolls over at, e.g. 04:00
+	ReceiptHeader     string    `json:"receipt_header"`
+	ReceiptFooter     string    `json:"receipt_footer"`
+	LogoURL           string    `json:"logo_url"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func newShopResponse(shop db.Shop) shopResponse {
+	return shopResponse{
+		ID:                shop.ID,
+		OrganisationID:    shop.OrganisationID,
+		Name:              shop.Name,
+		DisplayName:       shop.DisplayName,
+		Address:           shop.Address,
+		Phone:             shop.Phone,
+		Currency:          shop.Currency,
+		Timezone:          shop.Timezone,
+		Locale:            shop.Locale,
+		BusinessDayCutoff: formatCutoff(shop.BusinessDayCutoff),
+		ReceiptHeader:     shop.ReceiptHeader,
+		ReceiptFooter:     shop.ReceiptFooter,
+		LogoURL:           shop.LogoURL,
+		CreatedAt:         shop.CreatedAt,
+	}
+}
+
+func newShopResponses(shops []db.Shop) []shopResponse {
+	res := make([]shopResponse, len(shops))
+	for i, shop := range shops {
+		res[i] = newShopResponse(shop)
+	}
+	return res
+}
+
+// "Local" would resolve to the server's zone, which is what the shop timezone replaces
+func checkTimezone(ctx *gin.Context, timezone string) bool {
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+		err := fmt.Errorf("unknown timezone %q", timezone)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return false
+	}
+	return true
+}
+
+func (server *Server) getShop(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, newShopResponse(currentShop(ctx)))
+}
+
+type updateShopTimezoneRequest struct {
+	Timezone string `json:"timezone" binding:"required"` // IANA name, e.g. Asia/Hong_Kong
+}
+
+func (server *Server) updateShopTimezone(ctx *gin.Context) {
+	var req updateShopTimezoneRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !checkTimezone(ctx, req.Timezone) {
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.UpdateShopTimezoneParams{
+		ID:       shop.ID,
+		Timezone: req.Timezone,
+	}
+
+	shop, err := server.store.UpdateShopTimezone(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newShopResponse(shop))
+}
+
+type updateShopLocaleRequest struct {
+	Locale string `json:"locale" binding:"required"` // e.g. en-US, de-DE
+}
+
+func (server *Server) updateShopLocale(ctx *gin.Context) {
+	var req updateShopLocaleRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !utils.IsValidLocale(req.Locale) {
+		err := fmt.Errorf("unsupported locale %q", req.Locale)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.UpdateShopLocaleParams{
+		ID:     shop.ID,
+		Locale: req.Locale,
+	}
+
+	shop, err := server.store.UpdateShopLocale(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newShopResponse(shop))
+}
+
+type updateShopBusinessDayRequest struct {
+	Cutoff string `json:"cutoff" binding:"required"` // HH:MM, e.g. 04:00 for late-night bars
+}
+
+func (server *Server) updateShopBusinessDay(ctx *gin.Context) {
+	var req updateShopBusinessDayRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cutoff, err := utils.ParseCutoff(req.Cutoff)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.UpdateShopBusinessDayCutoffParams{
+		ID:                shop.ID,
+		BusinessDayCutoff: int32(cutoff / time.Minute),
+	}
+
+	shop, err = server.store.UpdateShopBusinessDayCutoff(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newShopResponse(shop))
+}
+
+// lines printed above and below every receipt, e.g. the address and a thank you
+type updateShopReceiptRequest struct {
+	Header string `json:"header" binding:"max=500"`
+	Footer string `json:"footer" binding:"max=500"`
+}
+
+func (server *Server) updateShopReceipt(ctx *gin.Context) {
+	var req updateShopReceiptRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.UpdateShopReceiptParams{
+		ID:            shop.ID,
+		ReceiptHeader: req.Header,
+		ReceiptFooter: req.Footer,
+	}
+
+	shop, err := server.store.UpdateShopReceipt(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newShopResponse(shop))
+}
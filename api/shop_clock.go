@@ -0,0 +1,56 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// timezone and business day cutoff of a shop, order_day and every report
+// bucket by the business days it defines
+type shopClock struct {
+	loc    *time.Location
+	cutoff time.Duration
+}
+
+func newShopClock(shop db.Shop) (shopClock, error) {
+	loc, err := time.LoadLocation(shop.Timezone)
+	if err != nil {
+		return shopClock{}, err
+	}
+
+	return shopClock{
+		loc:    loc,
+		cutoff: time.Duration(shop.BusinessDayCutoff) * time.Minute,
+	}, nil
+}
+
+func (clock shopClock) businessDay(t time.Time) string {
+	return utils.BusinessDay(t, clock.loc, clock.cutoff)
+}
+
+// UTC instants bounding the business days from_date to to_date (inclusive),
+// for records that carry no order_day
+func (clock shopClock) dayRange(fromDate, toDate string) (from, to time.Time, err error) {
+	from, err = utils.BusinessDayStart(fromDate, clock.loc, clock.cutoff)
+	if err != nil {
+		return
+	}
+	to, err = utils.BusinessDayStart(toDate, clock.loc, clock.cutoff)
+	if err != nil {
+		return
+	}
+	if to.Before(from) {
+		err = errors.New("to_date must not be before from_date")
+		return
+	}
+
+	to, err = utils.BusinessDayStart(to.AddDate(0, 0, 1).Format("2006-01-02"), clock.loc, clock.cutoff)
+	return from.UTC(), to.UTC(), err
+}
+
+func formatCutoff(minutes int32) string {
+	return time.Date(0, time.January, 1, 0, int(minutes), 0, 0, time.UTC).Format("15:04")
+}
@@ -0,0 +1,511 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+// the shop a newly registered user gets, named after them
+func randomShop(user db.User) db.Shop {
+	return db.Shop{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Name:           user.Username,
+		Currency:       "USD",
+		Timezone:       "UTC",
+		Locale:         "en-US",
+		CreatedAt:      user.CreatedAt,
+	}
+}
+
+// let the user through shopMiddleware for the shop as its owner, every other
+// user or shop looks missing
+func expectShopMember(store *mockdb.MockStore, shop db.Shop, user db.User) {
+	expectShopRole(store, shop, user, utils.RoleOwner)
+}
+
+func expectShopRole(store *mockdb.MockStore, shop db.Shop, user db.User, role string) {
+	expectOrganisationMember(store, randomMember(user, shop.OrganisationID, role), user)
+	store.EXPECT().
+		GetMemberShop(gomock.Any(), gomock.Eq(db.GetMemberShopParams{ShopID: shop.ID, Username: user.Username})).
+		AnyTimes().
+		Return(shop, nil)
+	store.EXPECT().
+		GetMemberShop(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(db.Shop{}, sql.ErrNoRows)
+}
+
+// the public routes look the shop up by name
+func expectGetShop(store *mockdb.MockStore, shop db.Shop) {
+	store.EXPECT().
+		GetShopByName(gomock.Any(), gomock.Eq(shop.Name)).
+		Times(1).
+		Return(shop, nil)
+}
+
+func TestGetShop(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	other, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		username      string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res shopResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, shop.ID, res.ID)
+				require.Equal(t, shop.OrganisationID, res.OrganisationID)
+				require.Equal(t, shop.Name, res.Name)
+			},
+		},
+		{
+			name:     "NotMember",
+			username: other.Username,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			expectShopMember(store, shop, user)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shops/%v", shop.ID)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestShopRoles(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	// staff run the shop but keep out of its settings and money
+	testCases := []struct {
+		name   string
+		role   string
+		method string
+		path   string
+		code   int
+	}{
+		{name: "StaffShop", role: utils.RoleStaff, method: http.MethodGet, path: "", code: http.StatusOK},
+		{name: "StaffTimezone", role: utils.RoleStaff, method: http.MethodPut, path: "/timezone", code: http.StatusUnauthorized},
+		{name: "StaffPriceList", role: utils.RoleStaff, method: http.MethodPost, path: "/price-lists", code: http.StatusUnauthorized},
+		{name: "StaffPromotion", role: utils.RoleStaff, method: http.MethodPost, path: "/promotions", code: http.StatusUnauthorized},
+		{name: "StaffCouponStats", role: utils.RoleStaff, method: http.MethodGet, path: fmt.Sprintf("/promotions/%s/coupons/stats", uuid.New()), code: http.StatusUnauthorized},
+		{name: "StaffAccounts", role: utils.RoleStaff, method: http.MethodPut, path: "/accounting/accounts", code: http.StatusUnauthorized},
+		{name: "StaffDeleteMenuItem", role: utils.RoleStaff, method: http.MethodDelete, path: fmt.Sprintf("/menus/%s", uuid.New()), code: http.StatusUnauthorized},
+		{name: "StaffCloseDay", role: utils.RoleStaff, method: http.MethodPost, path: "/z-reports", code: http.StatusUnauthorized},
+		// the body is checked once the manager is through
+		{name: "ManagerTimezone", role: utils.RoleManager, method: http.MethodPut, path: "/timezone", code: http.StatusBadRequest},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopRole(store, shop, user, tc.role)
+
+			url := fmt.Sprintf("/shops/%s%s", shop.ID, tc.path)
+			recorder := serveKitchen(t, store, user, tc.method, url, gin.H{})
+			require.Equal(t, tc.code, recorder.Code)
+		})
+	}
+}
+
+func TestUpdateShopTimezone(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	testCases := []struct {
+		name          string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			body:     gin.H{"timezone": "Asia/Hong_Kong"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateShopTimezoneParams{
+					ID:       shop.ID,
+					Timezone: "Asia/Hong_Kong",
+				}
+				updated := shop
+				updated.Timezone = arg.Timezone
+				store.EXPECT().
+					UpdateShopTimezone(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res shopResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, "Asia/Hong_Kong", res.Timezone)
+			},
+		},
+		{
+			name:     "UnknownTimezone",
+			username: user.Username,
+			body:     gin.H{"timezone": "Mars/Olympus_Mons"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateShopTimezone(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "ServerLocal",
+			username: user.Username,
+			body:     gin.H{"timezone": "Local"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateShopTimezone(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			username: "unauthorized",
+			body:     gin.H{"timezone": "UTC"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateShopTimezone(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			expectShopMember(store, shop, user)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/timezone", shop.ID)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateShopLocale(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"locale": "de-DE"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateShopLocaleParams{
+					ID:     shop.ID,
+					Locale: "de-DE",
+				}
+				updated := shop
+				updated.Locale = arg.Locale
+				store.EXPECT().
+					UpdateShopLocale(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res shopResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, "de-DE", res.Locale)
+			},
+		},
+		{
+			name: "UnsupportedLocale",
+			body: gin.H{"locale": "xx-XX"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateShopLocale(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			expectShopMember(store, shop, user)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/locale", shop.ID)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateShopBusinessDay(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"cutoff": "04:30"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateShopBusinessDayCutoffParams{
+					ID:                shop.ID,
+					BusinessDayCutoff: 270,
+				}
+				updated := shop
+				updated.BusinessDayCutoff = arg.BusinessDayCutoff
+				store.EXPECT().
+					UpdateShopBusinessDayCutoff(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res shopResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, "04:30", res.BusinessDayCutoff)
+			},
+		},
+		{
+			name: "InvalidCutoff",
+			body: gin.H{"cutoff": "24:00"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateShopBusinessDayCutoff(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"cutoff": "00:00"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateShopBusinessDayCutoff(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Shop{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			expectShopMember(store, shop, user)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/business-day", shop.ID)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateShopReceipt(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"header": "12 Harbour Road", "footer": "Thank you!"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateShopReceiptParams{
+					ID:            shop.ID,
+					ReceiptHeader: "12 Harbour Road",
+					ReceiptFooter: "Thank you!",
+				}
+				updated := shop
+				updated.ReceiptHeader = arg.ReceiptHeader
+				updated.ReceiptFooter = arg.ReceiptFooter
+				store.EXPECT().
+					UpdateShopReceipt(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res shopResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, "12 Harbour Road", res.ReceiptHeader)
+				require.Equal(t, "Thank you!", res.ReceiptFooter)
+			},
+		},
+		{
+			name: "FooterTooLong",
+			body: gin.H{"footer": utils.RandString(501)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateShopReceipt(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			expectShopMember(store, shop, user)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/receipt", shop.ID)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,462 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/receipt"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+var (
+	errSplitChecks    = errors.New("an even split needs checks")
+	errNoSeats        = errors.New("no order item is assigned to a seat")
+	errUnknownItem    = errors.New("line is not an item of the order")
+	errDuplicateLine  = errors.New("item is on the same check twice")
+	errEmptyCheck     = errors.New("checks must be numbered from 1 without gaps")
+	errUnassignedItem = errors.New("every item of the order must be on a check")
+)
+
+type splitUri struct {
+	OrderID string `uri:"order_id" binding:"required,uuid"`
+}
+
+type splitLineRequest struct {
+	OrderItemID uuid.UUID `json:"order_item_id" binding:"required"`
+	Check       int32     `json:"check" binding:"required,min=1"` // number of the check, from 1
+	Parts       int32     `json:"parts" binding:"omitempty,min=1"`
+}
+
+// items assigns lines, or parts of lines, to numbered checks. seats gives
+// every seat a check and shares the items of seat 0 between them. even
+// shares every item between the given number of checks.
+type splitOrderRequest struct {
+	Mode   string             `json:"mode" binding:"required,oneof=items seats even"`
+	Checks int32              `json:"checks" binding:"omitempty,min=2,max=50"`
+	Lines  []splitLineRequest `json:"lines" binding:"dive"`
+}
+
+// turn a split request into the checks of the order, items that are
+// refunded are left out
+func buildSplit(req splitOrderRequest, items []db.Order) ([]db.SplitCheckParams, error) {
+	var live []db.Order
+	for _, item := range items {
+		if item.Status != utils.StatusRefunded {
+			live = append(live, item)
+		}
+	}
+
+	everyItem := func() []db.AddCheckLineParams {
+		lines := make([]db.AddCheckLineParams, 0, len(live))
+		for _, item := range live {
+			lines = append(lines, db.AddCheckLineParams{OrderItemID: item.ID, Parts: 1})
+		}
+		return lines
+	}
+
+	switch req.Mode {
+	case "even":
+		if req.Checks == 0 {
+			return nil, errSplitChecks
+		}
+		checks := make([]db.SplitCheckParams, req.Checks)
+		for i := range checks {
+			checks[i].Lines = everyItem()
+		}
+		return checks, nil
+
+	case "seats":
+		var seats []int32
+		bySeat := make(map[int32][]db.AddCheckLineParams)
+		var shared []db.AddCheckLineParams
+		for _, item := range live {
+			line := db.AddCheckLineParams{OrderItemID: item.ID, Parts: 1}
+			if item.Seat == 0 {
+				shared = append(shared, line)
+				continue
+			}
+			if _, ok := bySeat[item.Seat]; !ok {
+				seats = append(seats, item.Seat)
+			}
+			bySeat[item.Seat] = append(bySeat[item.Seat], line)
+		}
+		if len(seats) == 0 {
+			return nil, errNoSeats
+		}
+		sort.Slice(seats, func(i, j int) bool { return seats[i] < seats[j] })
+
+		checks := make([]db.SplitCheckParams, 0, len(seats))
+		for _, seat := range seats {
+			lines := append(bySeat[seat], shared...)
+			checks = append(checks, db.SplitCheckParams{
+				Label: fmt.Sprintf("Seat %d", seat),
+				Lines: lines,
+			})
+		}
+		return checks, nil
+	}
+
+	isLive := make(map[uuid.UUID]bool, len(live))
+	for _, item := range live {
+		isLive[item.ID] = true
+	}
+
+	var n int32
+	for _, line := range req.Lines {
+		if line.Check > n {
+			n = line.Check
+		}
+	}
+
+	checks := make([]db.SplitCheckParams, n)
+	assigned := make(map[uuid.UUID]bool, len(live))
+	type key struct {
+		item  uuid.UUID
+		check int32
+	}
+	seen := make(map[key]bool, len(req.Lines))
+	for _, line := range req.Lines {
+		if !isLive[line.OrderItemID] {
+			return nil, errUnknownItem
+		}
+		if seen[key{line.OrderItemID, line.Check}] {
+			return nil, errDuplicateLine
+		}
+		seen[key{line.OrderItemID, line.Check}] = true
+		assigned[line.OrderItemID] = true
+
+		parts := line.Parts
+		if parts == 0 {
+			parts = 1
+		}
+		checks[line.Check-1].Lines = append(checks[line.Check-1].Lines, db.AddCheckLineParams{
+			OrderItemID: line.OrderItemID,
+			Parts:       parts,
+		})
+	}
+
+	for _, check := range checks {
+		if len(check.Lines) == 0 {
+			return nil, errEmptyCheck
+		}
+	}
+	if len(assigned) != len(live) {
+		return nil, errUnassignedItem
+	}
+	return checks, nil
+}
+
+func (server *Server) splitOrder(ctx *gin.Context) {
+	var uri splitUri
+	var req splitOrderRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	orderID := uuid.MustParse(uri.OrderID)
+	items, err := server.store.GetOrdersByOrderID(ctx, db.GetOrdersByOrderIDParams{
+		ShopName: shop.Name,
+		OrderID:  orderID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if len(items) == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	checks, err := buildSplit(req, items)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err = server.store.SplitOrderTx(ctx, db.SplitOrderTxParams{
+		ShopName: shop.Name,
+		OrderID:  orderID,
+		Checks:   checks,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrCheckPaid) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	split, ok := server.loadSplit(ctx, shop.Name, orderID)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, split)
+}
+
+type checkLineResponse struct {
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	ProductName string    `json:"product_name"`
+	Amount      int32     `json:"amount"`
+	Parts       int32     `json:"parts"`
+	TotalParts  int32     `json:"total_parts"` // parts of the item over all checks
+}
+
+type checkResponse struct {
+	db.Check
+	Lines    []checkLineResponse `json:"lines"`
+	Subtotal string              `json:"subtotal"`
+	Tax      string              `json:"tax"`
+	Total    string              `json:"total"`
+	Paid     string              `json:"paid"`
+	Due      string              `json:"due"`
+}
+
+// unassigned lists the items ordered after the split, they are on no check
+type splitResponse struct {
+	OrderID    uuid.UUID       `json:"order_id"`
+	Checks     []checkResponse `json:"checks"`
+	Unassigned []uuid.UUID     `json:"unassigned"`
+}
+
+// load the checks of an order with what is paid and due on each. writes the
+// error response when it fails.
+func (server *Server) loadSplit(ctx *gin.Context, shopName string, orderID uuid.UUID) (splitResponse, bool) {
+	items, err := server.store.GetOrdersByOrderID(ctx, db.GetOrdersByOrderIDParams{
+		ShopName: shopName,
+		OrderID:  orderID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return splitResponse{}, false
+	}
+	if len(items) == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return splitResponse{}, false
+	}
+
+	checks, err := server.store.ListChecks(ctx, db.ListChecksParams{
+		ShopName: shopName,
+		OrderID:  orderID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return splitResponse{}, false
+	}
+	lines, err := server.store.ListCheckLines(ctx, db.ListCheckLinesParams{
+		ShopName: shopName,
+		OrderID:  orderID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return splitResponse{}, false
+	}
+	payments, err := server.store.ListPaymentsByOrderID(ctx, db.ListPaymentsByOrderIDParams{
+		ShopName: shopName,
+		OrderID:  orderID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return splitResponse{}, false
+	}
+
+	index := make(map[uuid.UUID]int, len(checks))
+	for i, check := range checks {
+		index[check.ID] = i
+	}
+	byID := make(map[uuid.UUID]db.Order, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	totalParts := make(map[uuid.UUID]int32)
+	for _, line := range lines {
+		totalParts[line.OrderItemID] += line.Parts
+	}
+
+	totals, paid, err := checkTotals(items, checks, lines, payments)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return splitResponse{}, false
+	}
+
+	res := splitResponse{
+		OrderID:    orderID,
+		Checks:     make([]checkResponse, len(checks)),
+		Unassigned: []uuid.UUID{},
+	}
+	for i, check := range checks {
+		due := totals[i].Total - paid[i]
+		if due < 0 {
+			due = 0
+		}
+		res.Checks[i] = checkResponse{
+			Check:    check,
+			Lines:    []checkLineResponse{},
+			Subtotal: utils.FormatCents(totals[i].Subtotal),
+			Tax:      utils.FormatCents(totals[i].Tax),
+			Total:    utils.FormatCents(totals[i].Total),
+			Paid:     utils.FormatCents(paid[i]),
+			Due:      utils.FormatCents(due),
+		}
+	}
+	for _, line := range lines {
+		item := byID[line.OrderItemID]
+		c := &res.Checks[index[line.CheckID]]
+		c.Lines = append(c.Lines, checkLineResponse{
+			OrderItemID: item.ID,
+			ProductName: item.ProductName,
+			Amount:      item.Amount,
+			Parts:       line.Parts,
+			TotalParts:  totalParts[item.ID],
+		})
+	}
+	if len(checks) > 0 {
+		for _, item := range items {
+			if _, ok := totalParts[item.ID]; !ok && item.Status != utils.StatusRefunded {
+				res.Unassigned = append(res.Unassigned, item.ID)
+			}
+		}
+	}
+
+	return res, true
+}
+
+// the totals of every check of a split and what is paid on each, in cents
+func checkTotals(items []db.Order, checks []db.Check, lines []db.CheckLine, payments []db.Payment) ([]receipt.CheckTotal, []int64, error) {
+	index := make(map[uuid.UUID]int, len(checks))
+	for i, check := range checks {
+		index[check.ID] = i
+	}
+
+	shares := make([]receipt.Share, 0, len(lines))
+	for _, line := range lines {
+		shares = append(shares, receipt.Share{
+			OrderItemID: line.OrderItemID,
+			Check:       index[line.CheckID],
+			Parts:       int64(line.Parts),
+		})
+	}
+
+	totals, err := receipt.Split(items, len(checks), shares)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paid := make([]int64, len(checks))
+	for _, payment := range payments {
+		i, ok := index[payment.CheckID.UUID]
+		if !payment.CheckID.Valid || !ok {
+			continue
+		}
+		amount, err := utils.ParseCents(payment.Amount)
+		if err != nil {
+			return nil, nil, err
+		}
+		paid[i] += amount
+	}
+
+	return totals, paid, nil
+}
+
+// what is due on every check of a split, the store works it out once the
+// checks are locked
+func checksDue(items []db.Order, checks []db.Check, lines []db.CheckLine, payments []db.Payment) ([]int64, error) {
+	totals, paid, err := checkTotals(items, checks, lines, payments)
+	if err != nil {
+		return nil, err
+	}
+
+	due := make([]int64, len(checks))
+	for i := range checks {
+		due[i] = totals[i].Total - paid[i]
+	}
+	return due, nil
+}
+
+func (server *Server) getSplit(ctx *gin.Context) {
+	var uri splitUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	split, ok := server.loadSplit(ctx, shop.Name, uuid.MustParse(uri.OrderID))
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, split)
+}
+
+type checkUri struct {
+	OrderID string `uri:"order_id" binding:"required,uuid"`
+	CheckID string `uri:"check_id" binding:"required,uuid"`
+}
+
+// pay one check of a split, at most what is still due on it
+func (server *Server) payCheck(ctx *gin.Context) {
+	var uri checkUri
+	var req tenderRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	tendered := req.Tendered
+	if tendered == 0 {
+		tendered = req.Amount
+	}
+
+	payment, err := server.store.PayCheckTx(ctx, db.PayCheckTxParams{
+		Payment: db.CreatePaymentParams{
+			ID:       uuid.New(),
+			ShopName: shop.Name,
+			OrderID:  uuid.MustParse(uri.OrderID),
+			Method:   req.Method,
+			Amount:   utils.FormottedDecimalToString(req.Amount),
+			Tendered: utils.FormottedDecimalToString(tendered),
+			CheckID:  uuid.NullUUID{UUID: uuid.MustParse(uri.CheckID), Valid: true},
+		},
+		Due: checksDue,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrCheckNotInSplit) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrCheckOverpaid) || errors.Is(err, db.ErrDayClosed) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, payment)
+}
@@ -0,0 +1,385 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+// two seated guests sharing a bottle of water, 5% tax on the food
+func splitOrderItems(shop db.Shop) []db.Order {
+	orderID := uuid.New()
+	product := randomProduct(shop)
+	menuItem := createMenuItem(shop, product, "breakfast")
+
+	items := make([]db.Order, 3)
+	for i := range items {
+		items[i] = addOrderItem(menuItem, orderID)
+	}
+	items[0].ProductPrice, items[0].Seat = "12.00", 1
+	items[1].ProductPrice, items[1].Seat = "9.00", 2
+	items[2].ProductPrice, items[2].TaxRate = "3.00", "0.00"
+	return items
+}
+
+func expectSplit(store *mockdb.MockStore, items []db.Order, checks []db.Check, lines []db.CheckLine, payments []db.Payment) {
+	store.EXPECT().
+		GetOrdersByOrderID(gomock.Any(), gomock.Eq(db.GetOrdersByOrderIDParams{ShopName: items[0].ShopName, OrderID: items[0].OrderID})).
+		Times(1).
+		Return(items, nil)
+	store.EXPECT().
+		ListChecks(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(checks, nil)
+	store.EXPECT().
+		ListCheckLines(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(lines, nil)
+	store.EXPECT().
+		ListPaymentsByOrderID(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(payments, nil)
+}
+
+// store the split as the transaction would and hand it back to the view
+func splitChecks(items []db.Order, arg db.SplitOrderTxParams) ([]db.Check, []db.CheckLine) {
+	var checks []db.Check
+	var lines []db.CheckLine
+	for i, c := range arg.Checks {
+		check := db.Check{ID: uuid.New(), ShopName: arg.ShopName, OrderID: arg.OrderID, Number: int32(i + 1), Label: c.Label}
+		checks = append(checks, check)
+		for _, line := range c.Lines {
+			lines = append(lines, db.CheckLine{CheckID: check.ID, OrderItemID: line.OrderItemID, Parts: line.Parts})
+		}
+	}
+	return checks, lines
+}
+
+func TestSplitOrder(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	items := splitOrderItems(shop)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Seats",
+			body: gin.H{"mode": "seats"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(items, nil)
+				var checks []db.Check
+				var lines []db.CheckLine
+				store.EXPECT().
+					SplitOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.SplitOrderTxParams) (db.SplitOrderTxResult, error) {
+						require.Len(t, arg.Checks, 2)
+						require.Equal(t, "Seat 1", arg.Checks[0].Label)
+						// the shared water is on both seats
+						require.Equal(t, []db.AddCheckLineParams{
+							{OrderItemID: items[0].ID, Parts: 1},
+							{OrderItemID: items[2].ID, Parts: 1},
+						}, arg.Checks[0].Lines)
+						checks, lines = splitChecks(items, arg)
+						return db.SplitOrderTxResult{Checks: checks, Lines: lines}, nil
+					})
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(items, nil)
+				store.EXPECT().
+					ListChecks(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_, _ interface{}) ([]db.Check, error) { return checks, nil })
+				store.EXPECT().
+					ListCheckLines(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_, _ interface{}) ([]db.CheckLine, error) { return lines, nil })
+				store.EXPECT().
+					ListPaymentsByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Payment{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res splitResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.Checks, 2)
+				// 12.00 and 9.00 with 5% tax, plus half the water each
+				require.Equal(t, "14.10", res.Checks[0].Total)
+				require.Equal(t, "10.95", res.Checks[1].Total)
+				require.Equal(t, "14.10", res.Checks[0].Due)
+				require.Equal(t, int32(2), res.Checks[0].Lines[1].TotalParts)
+				require.Empty(t, res.Unassigned)
+			},
+		},
+		{
+			name: "Even",
+			body: gin.H{"mode": "even", "checks": 3},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(items, nil)
+				store.EXPECT().
+					SplitOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.SplitOrderTxParams) (db.SplitOrderTxResult, error) {
+						require.Len(t, arg.Checks, 3)
+						for _, check := range arg.Checks {
+							require.Len(t, check.Lines, len(items))
+						}
+						return db.SplitOrderTxResult{}, nil
+					})
+				expectSplit(store, items, nil, nil, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Items",
+			body: gin.H{"mode": "items", "lines": []gin.H{
+				{"order_item_id": items[0].ID, "check": 1},
+				{"order_item_id": items[1].ID, "check": 2},
+				{"order_item_id": items[2].ID, "check": 1, "parts": 2},
+				{"order_item_id": items[2].ID, "check": 2},
+			}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(items, nil)
+				store.EXPECT().
+					SplitOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.SplitOrderTxParams) (db.SplitOrderTxResult, error) {
+						require.Len(t, arg.Checks, 2)
+						require.Equal(t, int32(2), arg.Checks[0].Lines[1].Parts)
+						return db.SplitOrderTxResult{}, nil
+					})
+				expectSplit(store, items, nil, nil, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnassignedItem",
+			body: gin.H{"mode": "items", "lines": []gin.H{
+				{"order_item_id": items[0].ID, "check": 1},
+				{"order_item_id": items[1].ID, "check": 2},
+			}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(items, nil)
+				store.EXPECT().
+					SplitOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "CheckGap",
+			body: gin.H{"mode": "items", "lines": []gin.H{
+				{"order_item_id": items[0].ID, "check": 1},
+				{"order_item_id": items[1].ID, "check": 3},
+				{"order_item_id": items[2].ID, "check": 3},
+			}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(items, nil)
+				store.EXPECT().
+					SplitOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyPaid",
+			body: gin.H{"mode": "even", "checks": 2},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(items, nil)
+				store.EXPECT().
+					SplitOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SplitOrderTxResult{}, db.ErrCheckPaid)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "EvenWithoutChecks",
+			body: gin.H{"mode": "even"},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrdersByOrderID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(items, nil)
+				store.EXPECT().
+					SplitOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/orders/%s/split", shop.ID, items[0].OrderID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPut, url, tc.body))
+		})
+	}
+}
+
+func TestPayCheck(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	items := splitOrderItems(shop)
+	checks, lines := splitChecks(items, db.SplitOrderTxParams{
+		ShopName: shop.Name,
+		OrderID:  items[0].OrderID,
+		Checks: []db.SplitCheckParams{
+			{Lines: []db.AddCheckLineParams{{OrderItemID: items[0].ID, Parts: 1}, {OrderItemID: items[2].ID, Parts: 1}}},
+			{Lines: []db.AddCheckLineParams{{OrderItemID: items[1].ID, Parts: 1}, {OrderItemID: items[2].ID, Parts: 1}}},
+		},
+	})
+	// the second guest already paid part of their check
+	paid := []db.Payment{
+		{OrderID: items[0].OrderID, Method: utils.PaymentCard, Amount: "5.00", Tendered: "5.00", CheckID: uuid.NullUUID{UUID: checks[1].ID, Valid: true}},
+	}
+
+	testCases := []struct {
+		name          string
+		checkID       uuid.UUID
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			checkID: checks[1].ID,
+			body:    gin.H{"method": "cash", "amount": 5.95, "tendered": 10},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					PayCheckTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.PayCheckTxParams) (db.Payment, error) {
+						require.Equal(t, shop.Name, arg.Payment.ShopName)
+						require.Equal(t, items[0].OrderID, arg.Payment.OrderID)
+						require.Equal(t, uuid.NullUUID{UUID: checks[1].ID, Valid: true}, arg.Payment.CheckID)
+						require.Equal(t, "5.95", arg.Payment.Amount)
+						require.Equal(t, "10.00", arg.Payment.Tendered)
+
+						// the store works out what is due once the checks are locked
+						due, err := arg.Due(items, checks, lines, paid)
+						require.NoError(t, err)
+						require.Equal(t, []int64{1410, 595}, due)
+						return db.Payment{ID: arg.Payment.ID, CheckID: arg.Payment.CheckID}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:    "Overpaid",
+			checkID: checks[1].ID,
+			body:    gin.H{"method": "cash", "amount": 6},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					PayCheckTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Payment{}, db.ErrCheckOverpaid)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:    "DayClosed",
+			checkID: checks[0].ID,
+			body:    gin.H{"method": "card", "amount": 14.10},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					PayCheckTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Payment{}, db.ErrDayClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:    "CheckNotFound",
+			checkID: uuid.New(),
+			body:    gin.H{"method": "card", "amount": 1},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					PayCheckTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Payment{}, db.ErrCheckNotInSplit)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/orders/%s/checks/%s/payments", shop.ID, items[0].OrderID, tc.checkID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPost, url, tc.body))
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type productStockUri struct {
+	ProductID string `uri:"product_id" binding:"required,uuid"`
+}
+
+func (server *Server) getStockLevels(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	stockLevels, err := server.store.ListStockLevels(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stockLevels)
+}
+
+func (server *Server) getStockLevel(ctx *gin.Context) {
+	var uri productStockUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.GetStockLevelParams{
+		ShopName:  shop.Name,
+		ProductID: uuid.MustParse(uri.ProductID),
+	}
+
+	stockLevel, err := server.store.GetStockLevel(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stockLevel)
+}
+
+type createStockMovementRequest struct {
+	MovementType string `json:"movement_type" binding:"required"`
+	Quantity     int32  `json:"quantity"`
+	Note         string `json:"note"`
+}
+
+func (server *Server) createStockMovement(ctx *gin.Context) {
+	var uri productStockUri
+	var req createStockMovementRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := validateStockMovement(req.MovementType, float64(req.Quantity)); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	// stock can only be kept for products of the shop's organisation
+	productID := uuid.MustParse(uri.ProductID)
+	_, err := server.store.GetProduct(ctx, db.GetProductParams{OrganisationID: shop.OrganisationID, ID: productID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.StockMovementTxParams{
+		ShopName:     shop.Name,
+		ProductID:    productID,
+		MovementType: req.MovementType,
+		Quantity:     req.Quantity,
+		Note:         req.Note,
+	}
+
+	result, err := server.store.StockMovementTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// shared by product stock and ingredient movements
+func validateStockMovement(movementType string, quantity float64) error {
+	if !utils.IsValidMovementType(movementType) {
+		return fmt.Errorf("unsupported movement type: %s", movementType)
+	}
+
+	switch movementType {
+	case utils.MovementReceive, utils.MovementWaste:
+		if quantity <= 0 {
+			return fmt.Errorf("%s quantity must be positive", movementType)
+		}
+	case utils.MovementCount:
+		if quantity < 0 {
+			return errors.New("count quantity must not be negative")
+		}
+	case utils.MovementAdjustment:
+		if quantity == 0 {
+			return errors.New("adjustment quantity must not be zero")
+		}
+	}
+
+	return nil
+}
+
+type pageQuery struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+func (server *Server) getStockMovements(ctx *gin.Context) {
+	var uri productStockUri
+	var query pageQuery
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.ListStockMovementsParams{
+		ShopName:  shop.Name,
+		ProductID: uuid.MustParse(uri.ProductID),
+		Limit:     query.PageSize,
+		Offset:    (query.PageID - 1) * query.PageSize,
+	}
+
+	movements, err := server.store.ListStockMovements(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, movements)
+}
+
+type setStockReorderLevelsRequest struct {
+	ReorderPoint int32 `json:"reorder_point" binding:"min=0"`
+	ParLevel     int32 `json:"par_level" binding:"min=0,gtefield=ReorderPoint"`
+}
+
+func (server *Server) setStockReorderLevels(ctx *gin.Context) {
+	var uri productStockUri
+	var req setStockReorderLevelsRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	productID := uuid.MustParse(uri.ProductID)
+	_, err := server.store.GetProduct(ctx, db.GetProductParams{OrganisationID: shop.OrganisationID, ID: productID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.SetStockReorderLevelsParams{
+		ShopName:     shop.Name,
+		ProductID:    productID,
+		ReorderPoint: req.ReorderPoint,
+		ParLevel:     req.ParLevel,
+	}
+
+	stockLevel, err := server.store.SetStockReorderLevels(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stockLevel)
+}
+
+func (server *Server) getStockAlerts(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	alerts, err := server.store.ListOpenStockAlerts(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alerts)
+}
+
+type purchaseSuggestionsQuery struct {
+	Days     int32 `form:"days" binding:"required,min=1,max=90"`
+	LeadDays int32 `form:"lead_days" binding:"min=0,max=30"`
+}
+
+type purchaseSuggestion struct {
+	ProductID         uuid.UUID `json:"product_id"`
+	ProductName       string    `json:"product_name"`
+	OnHand            int32     `json:"on_hand"`
+	ReorderPoint      int32     `json:"reorder_point"`
+	ParLevel          int32     `json:"par_level"`
+	AverageDailyUsage float64   `json:"average_daily_usage"`
+	SuggestedQuantity int32     `json:"suggested_quantity"`
+}
+
+// suggest purchases that bring each product back to its par level
+// plus the usage expected while waiting lead_days for the delivery,
+// usage is averaged over the orders of the last `days` days
+func (server *Server) getPurchaseSuggestions(ctx *gin.Context) {
+	var query purchaseSuggestionsQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.GetPurchaseSuggestionsParams{
+		Since:    time.Now().AddDate(0, 0, -int(query.Days)),
+		ShopName: shop.Name,
+	}
+
+	rows, err := server.store.GetPurchaseSuggestions(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	suggestions := []purchaseSuggestion{}
+	for _, row := range rows {
+		usage := float64(row.Sold) / float64(query.Days)
+		target := int32(math.Ceil(float64(row.ParLevel) + usage*float64(query.LeadDays)))
+		if row.OnHand >= target {
+			continue
+		}
+
+		suggestions = append(suggestions, purchaseSuggestion{
+			ProductID:         row.ProductID,
+			ProductName:       row.ProductName,
+			OnHand:            row.OnHand,
+			ReorderPoint:      row.ReorderPoint,
+			ParLevel:          row.ParLevel,
+			AverageDailyUsage: math.Round(usage*100) / 100,
+			SuggestedQuantity: target - row.OnHand,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, suggestions)
+}
@@ -0,0 +1,595 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/token"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func randomStockLevel(shop db.Shop, product db.Product) db.StockLevel {
+	return db.StockLevel{
+		ShopName:  shop.Name,
+		ProductID: product.ID,
+		OnHand:    utils.RandomInt32(1, 100),
+		UpdatedAt: time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestGetStockLevel(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	stockLevel := randomStockLevel(shop, product)
+
+	testCases := []struct {
+		name          string
+		productID     string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			productID: product.ID.String(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.GetStockLevelParams{
+					ShopName:  shop.Name,
+					ProductID: product.ID,
+				}
+				store.EXPECT().
+					GetStockLevel(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(stockLevel, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.StockLevel
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, stockLevel, res)
+			},
+		},
+		{
+			name:      "NotTracked",
+			productID: product.ID.String(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetStockLevel(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StockLevel{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidProductID",
+			productID: "invalid",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetStockLevel(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "UnauthorizatedUser",
+			productID: product.ID.String(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorizatedUser", time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetStockLevel(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shops/%v/stock/%v", shop.ID, tc.productID)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreateStockMovement(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	stockLevel := randomStockLevel(shop, product)
+
+	result := db.StockMovementTxResult{
+		StockLevel: stockLevel,
+		StockMovement: db.StockMovement{
+			ID:           uuid.New(),
+			ShopName:     shop.Name,
+			ProductID:    product.ID,
+			MovementType: utils.MovementReceive,
+			Quantity:     10,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"movement_type": utils.MovementReceive,
+				"quantity":      10,
+				"note":          "weekly delivery",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(db.GetProductParams{OrganisationID: shop.OrganisationID, ID: product.ID})).
+					Times(1).
+					Return(product, nil)
+
+				arg := db.StockMovementTxParams{
+					ShopName:     shop.Name,
+					ProductID:    product.ID,
+					MovementType: utils.MovementReceive,
+					Quantity:     10,
+					Note:         "weekly delivery",
+				}
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.StockMovementTxResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, result.StockLevel, res.StockLevel)
+				require.Equal(t, result.StockMovement.ID, res.StockMovement.ID)
+			},
+		},
+		{
+			name: "ProductNotFound",
+			body: gin.H{
+				"movement_type": utils.MovementCount,
+				"quantity":      0,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Product{}, sql.ErrNoRows)
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "SaleNotAllowed",
+			body: gin.H{
+				"movement_type": utils.MovementSale,
+				"quantity":      1,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeWaste",
+			body: gin.H{
+				"movement_type": utils.MovementWaste,
+				"quantity":      -3,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizatedUser",
+			body: gin.H{
+				"movement_type": utils.MovementReceive,
+				"quantity":      10,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorizatedUser", time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			jsonReader := bytes.NewReader(jsonData)
+
+			url := fmt.Sprintf("/shops/%v/stock/%v/movements", shop.ID, product.ID)
+			req, err := http.NewRequest(http.MethodPost, url, jsonReader)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetStockMovements(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	product := randomProduct(shop)
+
+	movements := []db.StockMovement{
+		{
+			ID:           uuid.New(),
+			ShopName:     shop.Name,
+			ProductID:    product.ID,
+			MovementType: utils.MovementSale,
+			Quantity:     -2,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "page_id=2&page_size=5",
+			buildStub: func(store *mockdb.MockStore) {
+				arg := db.ListStockMovementsParams{
+					ShopName:  shop.Name,
+					ProductID: product.ID,
+					Limit:     5,
+					Offset:    5,
+				}
+				store.EXPECT().
+					ListStockMovements(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(movements, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []db.StockMovement
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, movements, res)
+			},
+		},
+		{
+			name:  "InvalidPageSize",
+			query: "page_id=1&page_size=1000",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListStockMovements(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shops/%v/stock/%v/movements?%v", shop.ID, product.ID, tc.query)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestSetStockReorderLevels(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	product := randomProduct(shop)
+	stockLevel := randomStockLevel(shop, product)
+	stockLevel.ReorderPoint = 5
+	stockLevel.ParLevel = 20
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"reorder_point": 5, "par_level": 20},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(db.GetProductParams{OrganisationID: shop.OrganisationID, ID: product.ID})).
+					Times(1).
+					Return(product, nil)
+				arg := db.SetStockReorderLevelsParams{
+					ShopName:     shop.Name,
+					ProductID:    product.ID,
+					ReorderPoint: 5,
+					ParLevel:     20,
+				}
+				store.EXPECT().
+					SetStockReorderLevels(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(stockLevel, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.StockLevel
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, stockLevel, res)
+			},
+		},
+		{
+			name: "ParBelowReorderPoint",
+			body: gin.H{"reorder_point": 10, "par_level": 5},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetStockReorderLevels(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ProductNotFound",
+			body: gin.H{"reorder_point": 5, "par_level": 20},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Product{}, sql.ErrNoRows)
+				store.EXPECT().
+					SetStockReorderLevels(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/shops/%v/stock/%v/reorder", shop.ID, product.ID)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(jsonData))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetPurchaseSuggestions(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	low := randomProduct(shop)
+	stocked := randomProduct(shop)
+
+	rows := []db.GetPurchaseSuggestionsRow{
+		{ProductID: low.ID, ProductName: low.Name, OnHand: 3, ReorderPoint: 5, ParLevel: 20, Sold: 28},
+		{ProductID: stocked.ID, ProductName: stocked.Name, OnHand: 50, ReorderPoint: 5, ParLevel: 20, Sold: 7},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "days=14&lead_days=2",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPurchaseSuggestions(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx interface{}, arg db.GetPurchaseSuggestionsParams) ([]db.GetPurchaseSuggestionsRow, error) {
+						require.Equal(t, shop.Name, arg.ShopName)
+						require.WithinDuration(t, time.Now().AddDate(0, 0, -14), arg.Since, time.Minute)
+						return rows, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []purchaseSuggestion
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+
+				// 28 sold over 14 days is 2 a day, par 20 plus 2 lead days less 3 on hand
+				require.Len(t, res, 1)
+				require.Equal(t, low.ID, res[0].ProductID)
+				require.Equal(t, 2.0, res[0].AverageDailyUsage)
+				require.Equal(t, int32(21), res[0].SuggestedQuantity)
+			},
+		},
+		{
+			name:  "MissingDays",
+			query: "lead_days=2",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPurchaseSuggestions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: "days=7",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPurchaseSuggestions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shops/%v/reports/purchase-suggestions?%v", shop.ID, tc.query)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,552 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/receipt"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type createFloorAreaRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func (server *Server) createFloorArea(ctx *gin.Context) {
+	var req createFloorAreaRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	area, err := server.store.CreateFloorArea(ctx, db.CreateFloorAreaParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		Name:     req.Name,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, area)
+}
+
+type floorAreaUri struct {
+	AreaID string `uri:"area_id" binding:"required,uuid"`
+}
+
+// deleting an area drops its tables, tabs on them stay open without a table
+func (server *Server) deleteFloorArea(ctx *gin.Context) {
+	var uri floorAreaUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	rows, err := server.store.DeleteFloorArea(ctx, db.DeleteFloorAreaParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.AreaID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+type createDiningTableRequest struct {
+	AreaID   uuid.UUID `json:"area_id" binding:"required"`
+	Name     string    `json:"name" binding:"required"`
+	Capacity int32     `json:"capacity" binding:"required,gt=0"`
+	PosX     int32     `json:"pos_x" binding:"min=0"` // grid position on the floor plan of the area
+	PosY     int32     `json:"pos_y" binding:"min=0"`
+}
+
+func (server *Server) createDiningTable(ctx *gin.Context) {
+	var req createDiningTableRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	_, err := server.store.GetFloorArea(ctx, db.GetFloorAreaParams{
+		ShopName: shop.Name,
+		ID:       req.AreaID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	table, err := server.store.CreateDiningTable(ctx, db.CreateDiningTableParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		AreaID:   req.AreaID,
+		Name:     req.Name,
+		Capacity: req.Capacity,
+		PosX:     req.PosX,
+		PosY:     req.PosY,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, table)
+}
+
+type diningTableUri struct {
+	TableID string `uri:"table_id" binding:"required,uuid"`
+}
+
+func (server *Server) deleteDiningTable(ctx *gin.Context) {
+	var uri diningTableUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	rows, err := server.store.DeleteDiningTable(ctx, db.DeleteDiningTableParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.TableID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+// a table is occupied by opening a tab on it, staff only mark reservations
+// and clean tables
+type setDiningTableStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=free reserved dirty"`
+}
+
+func (server *Server) setDiningTableStatus(ctx *gin.Context) {
+	var uri diningTableUri
+	var req setDiningTableStatusRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	tableID := uuid.MustParse(uri.TableID)
+	_, err := server.store.GetOpenTabByTable(ctx, db.GetOpenTabByTableParams{
+		ShopName: shop.Name,
+		TableID:  uuid.NullUUID{UUID: tableID, Valid: true},
+	})
+	if err == nil {
+		ctx.JSON(http.StatusConflict, errorResponse(db.ErrTableOccupied))
+		return
+	}
+	if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	table, err := server.store.SetDiningTableStatus(ctx, db.SetDiningTableStatusParams{
+		Status:   req.Status,
+		ShopName: shop.Name,
+		ID:       tableID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, table)
+}
+
+type floorPlanTable struct {
+	db.DiningTable
+	Tab *db.Tab `json:"tab"` // open tab on the table, if any
+}
+
+type floorPlanArea struct {
+	db.FloorArea
+	Tables []floorPlanTable `json:"tables"`
+}
+
+// every area with its tables and the tabs open on them
+func (server *Server) getFloorPlan(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	areas, err := server.store.ListFloorAreas(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	tables, err := server.store.ListDiningTables(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	tabs, err := server.store.ListOpenTabs(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	openTabs := make(map[uuid.UUID]db.Tab, len(tabs))
+	for _, tab := range tabs {
+		if tab.TableID.Valid {
+			openTabs[tab.TableID.UUID] = tab
+		}
+	}
+
+	byArea := make(map[uuid.UUID][]floorPlanTable)
+	for _, table := range tables {
+		t := floorPlanTable{DiningTable: table}
+		if tab, ok := openTabs[table.ID]; ok {
+			t.Tab = &tab
+		}
+		byArea[table.AreaID] = append(byArea[table.AreaID], t)
+	}
+
+	plan := make([]floorPlanArea, 0, len(areas))
+	for _, area := range areas {
+		a := floorPlanArea{FloorArea: area, Tables: byArea[area.ID]}
+		if a.Tables == nil {
+			a.Tables = []floorPlanTable{}
+		}
+		plan = append(plan, a)
+	}
+
+	ctx.JSON(http.StatusOK, plan)
+}
+
+type openTabRequest struct {
+	TableID uuid.UUID `json:"table_id" binding:"required"`
+	Guests  int32     `json:"guests" binding:"omitempty,gt=0"` // defaults to 1
+}
+
+func (server *Server) openTab(ctx *gin.Context) {
+	var req openTabRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Guests == 0 {
+		req.Guests = 1
+	}
+
+	shop := currentShop(ctx)
+
+	tab, err := server.store.OpenTabTx(ctx, db.OpenTabTxParams{
+		ShopName: shop.Name,
+		TableID:  req.TableID,
+		OrderID:  uuid.New(),
+		Guests:   req.Guests,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrTableOccupied) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tab)
+}
+
+func (server *Server) getOpenTabs(ctx *gin.Context) {
+	shop := currentShop(ctx)
+
+	tabs, err := server.store.ListOpenTabs(ctx, shop.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tabs)
+}
+
+type tabUri struct {
+	TabID string `uri:"tab_id" binding:"required,uuid"`
+}
+
+// amounts are formatted like order prices, due is what is left to pay
+type tabResponse struct {
+	Tab      db.Tab       `json:"tab"`
+	Orders   []db.Order   `json:"orders"`
+	Payments []db.Payment `json:"payments"`
+	Total    string       `json:"total"`
+	Due      string       `json:"due"`
+}
+
+// load a tab with its rounds and what is left to pay on it, writing the error
+// response when it fails
+func (server *Server) loadTab(ctx *gin.Context, shop db.Shop, tabID uuid.UUID) (tabResponse, bool) {
+	tab, err := server.store.GetTab(ctx, db.GetTabParams{
+		ShopName: shop.Name,
+		ID:       tabID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return tabResponse{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return tabResponse{}, false
+	}
+
+	res := tabResponse{Tab: tab, Total: utils.FormatCents(0), Due: utils.FormatCents(0)}
+
+	res.Orders, err = server.store.GetOrdersByOrderID(ctx, db.GetOrdersByOrderIDParams{
+		ShopName: shop.Name,
+		OrderID:  tab.OrderID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return tabResponse{}, false
+	}
+	res.Payments, err = server.store.ListPaymentsByOrderID(ctx, db.ListPaymentsByOrderIDParams{
+		ShopName: shop.Name,
+		OrderID:  tab.OrderID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return tabResponse{}, false
+	}
+
+	// nothing ordered yet, nothing to pay
+	if len(res.Orders) == 0 {
+		return res, true
+	}
+
+	receiptShop, err := receipt.NewShop(shop)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return tabResponse{}, false
+	}
+	r, err := receipt.Build(receiptShop, res.Orders, res.Payments)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return tabResponse{}, false
+	}
+	res.Total = utils.FormatCents(r.Total)
+	res.Due = utils.FormatCents(r.Due)
+
+	return res, true
+}
+
+func (server *Server) getTab(ctx *gin.Context) {
+	var uri tabUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	res, ok := server.loadTab(ctx, shop, uuid.MustParse(uri.TabID))
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type moveTabRequest struct {
+	TableID uuid.UUID `json:"table_id" binding:"required"`
+}
+
+func (server *Server) moveTab(ctx *gin.Context) {
+	var uri tabUri
+	var req moveTabRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	tab, err := server.store.MoveTabTx(ctx, db.MoveTabTxParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.TabID),
+		TableID:  req.TableID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrTableOccupied) || errors.Is(err, db.ErrTabNotOpen) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tab)
+}
+
+type mergeTabRequest struct {
+	TabID uuid.UUID `json:"tab_id" binding:"required"` // tab merged into the tab of the uri
+}
+
+func (server *Server) mergeTab(ctx *gin.Context) {
+	var uri tabUri
+	var req mergeTabRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	tab, err := server.store.MergeTabsTx(ctx, db.MergeTabsTxParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.TabID),
+		FromID:   req.TabID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrSameTab) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrTabNotOpen) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tab)
+}
+
+type tenderRequest struct {
+	Method   string  `json:"method" binding:"required,oneof=cash card other"`
+	Amount   float64 `json:"amount" binding:"required,gt=0"`
+	Tendered float64 `json:"tendered" binding:"omitempty,gtefield=Amount"` // cash handed over, defaults to the amount
+}
+
+// the payments must settle what is still due on the tab
+type closeTabRequest struct {
+	Payments []tenderRequest `json:"payments" binding:"dive"`
+}
+
+func (server *Server) closeTab(ctx *gin.Context) {
+	var uri tabUri
+	var req closeTabRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	arg := db.CloseTabTxParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.TabID),
+	}
+	for _, p := range req.Payments {
+		tendered := p.Tendered
+		if tendered == 0 {
+			tendered = p.Amount
+		}
+
+		arg.Payments = append(arg.Payments, db.CreatePaymentParams{
+			ID:       uuid.New(),
+			Method:   p.Method,
+			Amount:   utils.FormottedDecimalToString(p.Amount),
+			Tendered: utils.FormottedDecimalToString(tendered),
+		})
+	}
+
+	result, err := server.store.CloseTabTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrTabNotOpen) || errors.Is(err, db.ErrTabNotPaid) || errors.Is(err, db.ErrDayClosed) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
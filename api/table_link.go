@@ -0,0 +1,170 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/tableqr"
+	"github.com/toml5566/go_pos_backend/token"
+)
+
+var (
+	errTableLinkRotated = errors.New("table link was rotated, scan the code on the table again")
+	errNoPublicOrderURL = errors.New("PUBLIC_ORDER_URL is not configured, table codes need the page customers order on")
+)
+
+type tableLinkResponse struct {
+	TableID uuid.UUID `json:"table_id"`
+	Token   string    `json:"token"`
+	URL     string    `json:"url,omitempty"`
+}
+
+// the page customers land on when they scan the code of a table, the link
+// carries the signed token the order is placed with. the API only takes
+// orders, so there is no link until PUBLIC_ORDER_URL points at the order page.
+func (server *Server) tableLink(table db.DiningTable) tableLinkResponse {
+	tableToken := server.tableSigner.Sign(token.TableClaims{
+		ShopName: table.ShopName,
+		TableID:  table.ID,
+		Version:  table.LinkVersion,
+	})
+
+	link := tableLinkResponse{
+		TableID: table.ID,
+		Token:   tableToken,
+	}
+	if base := server.config.PublicOrderURL; base != "" {
+		link.URL = fmt.Sprintf("%s/%s/order?table_token=%s", strings.TrimRight(base, "/"), table.ShopName, url.QueryEscape(tableToken))
+	}
+	return link
+}
+
+type tableLinkQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=json png svg"`
+	Size   int    `form:"size,default=256" binding:"min=64,max=1024"` // pixels, png only
+}
+
+func (server *Server) getTableLink(ctx *gin.Context) {
+	var uri diningTableUri
+	var query tableLinkQuery
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if server.config.PublicOrderURL == "" {
+		ctx.JSON(http.StatusConflict, errorResponse(errNoPublicOrderURL))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	table, err := server.store.GetDiningTable(ctx, db.GetDiningTableParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.TableID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	link := server.tableLink(table)
+
+	var data []byte
+	var contentType string
+	switch query.Format {
+	case "png":
+		contentType = "image/png"
+		data, err = tableqr.PNG(link.URL, query.Size)
+	case "svg":
+		contentType = "image/svg+xml"
+		data, err = tableqr.SVG(link.URL)
+	default:
+		ctx.JSON(http.StatusOK, link)
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+// revoke the printed code of a table, orders through the old link are refused
+func (server *Server) rotateTableLink(ctx *gin.Context) {
+	var uri diningTableUri
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shop := currentShop(ctx)
+
+	table, err := server.store.RotateDiningTableLink(ctx, db.RotateDiningTableLinkParams{
+		ShopName: shop.Name,
+		ID:       uuid.MustParse(uri.TableID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.tableLink(table))
+}
+
+// resolve the table of a signed table token, writing the error response when
+// the token is invalid, rotated or used too often
+func (server *Server) tokenTable(ctx *gin.Context, shopName string, tableToken string) (db.DiningTable, bool) {
+	claims, err := server.tableSigner.Verify(shopName, tableToken)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return db.DiningTable{}, false
+	}
+
+	if !server.tableOrderLimiter.allow(tableToken) {
+		err := errors.New("too many orders from this table, please wait a moment")
+		ctx.JSON(http.StatusTooManyRequests, errorResponse(err))
+		return db.DiningTable{}, false
+	}
+
+	table, err := server.store.GetDiningTable(ctx, db.GetDiningTableParams{
+		ShopName: shopName,
+		ID:       claims.TableID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return db.DiningTable{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.DiningTable{}, false
+	}
+	if table.LinkVersion != claims.Version {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errTableLinkRotated))
+		return db.DiningTable{}, false
+	}
+
+	return table, true
+}
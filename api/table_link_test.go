@@ -0,0 +1,362 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/token"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetTableLink(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	table := randomDiningTable(shop, uuid.New(), utils.TableFree)
+	table.LinkVersion = 2
+
+	testCases := []struct {
+		name          string
+		query         string
+		checkResponse func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "JSON",
+			query: "",
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res tableLinkResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.True(t, strings.HasPrefix(res.URL, "https://order.example.com/"+shop.Name+"/order?table_token="))
+
+				claims, err := server.tableSigner.Verify(shop.Name, res.Token)
+				require.NoError(t, err)
+				require.Equal(t, table.ID, claims.TableID)
+				require.Equal(t, int32(2), claims.Version)
+			},
+		},
+		{
+			name:  "PNG",
+			query: "?format=png&size=128",
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+				require.True(t, bytes.HasPrefix(recorder.Body.Bytes(), []byte("\x89PNG")))
+			},
+		},
+		{
+			name:  "SVG",
+			query: "?format=svg",
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "image/svg+xml", recorder.Header().Get("Content-Type"))
+				require.True(t, strings.HasPrefix(recorder.Body.String(), "<svg "))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			store.EXPECT().
+				GetDiningTable(gomock.Any(), gomock.Eq(db.GetDiningTableParams{ShopName: shop.Name, ID: table.ID})).
+				Times(1).
+				Return(table, nil)
+
+			server := newTestServer(t, store)
+			server.config.PublicOrderURL = "https://order.example.com/"
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shops/%s/tables/%s/link%s", shop.ID, table.ID, tc.query)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, server, recorder)
+		})
+	}
+}
+
+func TestRotateTableLink(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	table := randomDiningTable(shop, uuid.New(), utils.TableFree)
+	table.LinkVersion = 1
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	rotated := table
+	rotated.LinkVersion = 2
+	store.EXPECT().
+		RotateDiningTableLink(gomock.Any(), gomock.Eq(db.RotateDiningTableLinkParams{ShopName: shop.Name, ID: table.ID})).
+		Times(1).
+		Return(rotated, nil)
+
+	url := fmt.Sprintf("/shops/%s/tables/%s/link/rotate", shop.ID, table.ID)
+	recorder := serveKitchen(t, store, user, http.MethodPost, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res tableLinkResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	require.True(t, strings.HasSuffix(strings.Split(res.Token, ".")[1], strconv.Itoa(2)))
+	// the old code is revoked even before the order page is configured
+	require.Empty(t, res.URL)
+}
+
+func TestGetTableLinkWithoutPublicOrderURL(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	table := randomDiningTable(shop, uuid.New(), utils.TableFree)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		GetDiningTable(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	// a code pointing at the API would open a page that does not exist
+	url := fmt.Sprintf("/shops/%s/tables/%s/link?format=png", shop.ID, table.ID)
+	recorder := serveKitchen(t, store, user, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusConflict, recorder.Code)
+}
+
+func TestCreateOrderTableToken(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	table := randomDiningTable(shop, uuid.New(), utils.TableFree)
+	table.LinkVersion = 3
+	tab := randomTab(shop, utils.TabOpen)
+	tab.TableID = uuid.NullUUID{UUID: table.ID, Valid: true}
+
+	item := createOrderItemRequest{
+		ShopName:     shop.Name,
+		ProductName:  "Flat white",
+		ProductPrice: 4.5,
+		Amount:       1,
+		Status:       "pending",
+	}
+
+	sign := func(server *Server, version int32) string {
+		return server.tableSigner.Sign(token.TableClaims{ShopName: shop.Name, TableID: table.ID, Version: version})
+	}
+
+	testCases := []struct {
+		name          string
+		body          func(server *Server) gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: func(server *Server) gin.H {
+				return gin.H{"table_token": sign(server, 3), "orders": []createOrderItemRequest{item}}
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetDiningTable(gomock.Any(), gomock.Eq(db.GetDiningTableParams{ShopName: shop.Name, ID: table.ID})).
+					Times(1).
+					Return(table, nil)
+				expectGetShop(store, shop)
+				expectOpeningHours(store, shop, nil, nil)
+				// orders from the table's QR code are priced for the qr channel
+				counter := randomPriceList(shop, 2)
+				qr := randomPriceList(shop, 1)
+				expectPriceLists(store, shop, []db.PriceListRule{
+					randomPriceListRule(counter, utils.ChannelPOS, ""),
+					randomPriceListRule(qr, utils.ChannelQR, ""),
+				})
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateOrderTxParams) (db.CreateOrderTxResult, error) {
+						require.Equal(t, table.ID, arg.TableID)
+						require.Equal(t, uuid.Nil, arg.TabID)
+						require.Equal(t, []uuid.UUID{qr.ID}, arg.PriceListIDs)
+						return db.CreateOrderTxResult{Orders: []db.Order{{OrderID: tab.OrderID}}, Tab: tab}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res createOrderResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, tab.ID, res.Tab.ID)
+			},
+		},
+		{
+			// a tab id sent along cannot move the order off the table
+			name: "TabID",
+			body: func(server *Server) gin.H {
+				return gin.H{"table_token": sign(server, 3), "tab_id": uuid.New(), "orders": []createOrderItemRequest{item}}
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetDiningTable(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Rotated",
+			body: func(server *Server) gin.H {
+				return gin.H{"table_token": sign(server, 2), "orders": []createOrderItemRequest{item}}
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetDiningTable(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(table, nil)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Forged",
+			body: func(server *Server) gin.H {
+				return gin.H{"table_token": table.ID.String() + ".3.forged", "orders": []createOrderItemRequest{item}}
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetDiningTable(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "TableNotFound",
+			body: func(server *Server) gin.H {
+				return gin.H{"table_token": sign(server, 3), "orders": []createOrderItemRequest{item}}
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetDiningTable(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.DiningTable{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			tc.checkResponse(t, postOrder(t, server, shop.Name, tc.body(server)))
+		})
+	}
+}
+
+func TestCreateOrderTableTokenRateLimit(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	table := randomDiningTable(shop, uuid.New(), utils.TableFree)
+	table.LinkVersion = 1
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetDiningTable(gomock.Any(), gomock.Any()).
+		Times(tableOrderBurst).
+		Return(table, nil)
+	store.EXPECT().
+		GetShopByName(gomock.Any(), gomock.Any()).
+		Times(tableOrderBurst).
+		Return(shop, nil)
+	store.EXPECT().
+		ListOpeningHours(gomock.Any(), gomock.Any()).
+		Times(tableOrderBurst).
+		Return(nil, nil)
+	store.EXPECT().
+		ListOpeningExceptions(gomock.Any(), gomock.Any()).
+		Times(tableOrderBurst).
+		Return(nil, nil)
+	store.EXPECT().
+		ListActivePriceListRules(gomock.Any(), gomock.Any()).
+		Times(tableOrderBurst).
+		Return(nil, nil)
+	store.EXPECT().
+		CreateOrderTx(gomock.Any(), gomock.Any()).
+		Times(tableOrderBurst).
+		Return(db.CreateOrderTxResult{}, nil)
+
+	server := newTestServer(t, store)
+	body := gin.H{
+		"table_token": server.tableSigner.Sign(token.TableClaims{ShopName: shop.Name, TableID: table.ID, Version: 1}),
+		"orders": []createOrderItemRequest{{
+			ShopName:     shop.Name,
+			ProductName:  "Flat white",
+			ProductPrice: 4.5,
+			Amount:       1,
+			Status:       "pending",
+		}},
+	}
+
+	for i := 0; i < tableOrderBurst; i++ {
+		require.Equal(t, http.StatusOK, postOrder(t, server, shop.Name, body).Code)
+	}
+	require.Equal(t, http.StatusTooManyRequests, postOrder(t, server, shop.Name, body).Code)
+}
+
+func postOrder(t *testing.T, server *Server, shopName string, body gin.H) *httptest.ResponseRecorder {
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/order", shopName), bytes.NewReader(data))
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, req)
+	return recorder
+}
@@ -0,0 +1,576 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func randomDiningTable(shop db.Shop, areaID uuid.UUID, status string) db.DiningTable {
+	return db.DiningTable{
+		ID:        uuid.New(),
+		ShopName:  shop.Name,
+		AreaID:    areaID,
+		Name:      utils.RandString(3),
+		Capacity:  4,
+		Status:    status,
+		CreatedAt: time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func randomTab(shop db.Shop, status string) db.Tab {
+	return db.Tab{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		TableID:  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		OrderID:  uuid.New(),
+		Guests:   2,
+		Status:   status,
+		OpenedAt: time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCreateDiningTable(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	area := db.FloorArea{ID: uuid.New(), ShopName: shop.Name, Name: "terrace"}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"area_id": area.ID, "name": "T1", "capacity": 4, "pos_x": 2, "pos_y": 3},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetFloorArea(gomock.Any(), gomock.Eq(db.GetFloorAreaParams{ShopName: shop.Name, ID: area.ID})).
+					Times(1).
+					Return(area, nil)
+				store.EXPECT().
+					CreateDiningTable(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateDiningTableParams) (db.DiningTable, error) {
+						require.Equal(t, shop.Name, arg.ShopName)
+						require.Equal(t, area.ID, arg.AreaID)
+						require.Equal(t, int32(4), arg.Capacity)
+						require.Equal(t, int32(2), arg.PosX)
+						require.Equal(t, int32(3), arg.PosY)
+						return db.DiningTable{ID: arg.ID, Name: arg.Name, Status: utils.TableFree}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AreaNotFound",
+			body: gin.H{"area_id": area.ID, "name": "T1", "capacity": 4},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetFloorArea(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.FloorArea{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreateDiningTable(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidCapacity",
+			body: gin.H{"area_id": area.ID, "name": "T1", "capacity": 0},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateDiningTable(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/tables", shop.ID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPost, url, tc.body))
+		})
+	}
+}
+
+func TestSetDiningTableStatus(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	table := randomDiningTable(shop, uuid.New(), utils.TableDirty)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"status": utils.TableFree},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOpenTabByTable(gomock.Any(), gomock.Eq(db.GetOpenTabByTableParams{
+						ShopName: shop.Name,
+						TableID:  uuid.NullUUID{UUID: table.ID, Valid: true},
+					})).
+					Times(1).
+					Return(db.Tab{}, sql.ErrNoRows)
+				freed := table
+				freed.Status = utils.TableFree
+				store.EXPECT().
+					SetDiningTableStatus(gomock.Any(), gomock.Eq(db.SetDiningTableStatusParams{
+						Status:   utils.TableFree,
+						ShopName: shop.Name,
+						ID:       table.ID,
+					})).
+					Times(1).
+					Return(freed, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.DiningTable
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, utils.TableFree, res.Status)
+			},
+		},
+		{
+			name: "OpenTab",
+			body: gin.H{"status": utils.TableFree},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOpenTabByTable(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomTab(shop, utils.TabOpen), nil)
+				store.EXPECT().
+					SetDiningTableStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "Occupied",
+			body: gin.H{"status": utils.TableOccupied},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetDiningTableStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/tables/%s/status", shop.ID, table.ID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPut, url, tc.body))
+		})
+	}
+}
+
+func TestGetFloorPlan(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	area := db.FloorArea{ID: uuid.New(), ShopName: shop.Name, Name: "terrace"}
+	empty := db.FloorArea{ID: uuid.New(), ShopName: shop.Name, Name: "upstairs"}
+	free := randomDiningTable(shop, area.ID, utils.TableFree)
+	busy := randomDiningTable(shop, area.ID, utils.TableOccupied)
+	tab := randomTab(shop, utils.TabOpen)
+	tab.TableID = uuid.NullUUID{UUID: busy.ID, Valid: true}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectShopMember(store, shop, user)
+	store.EXPECT().
+		ListFloorAreas(gomock.Any(), gomock.Eq(shop.Name)).
+		Times(1).
+		Return([]db.FloorArea{area, empty}, nil)
+	store.EXPECT().
+		ListDiningTables(gomock.Any(), gomock.Eq(shop.Name)).
+		Times(1).
+		Return([]db.DiningTable{free, busy}, nil)
+	store.EXPECT().
+		ListOpenTabs(gomock.Any(), gomock.Eq(shop.Name)).
+		Times(1).
+		Return([]db.Tab{tab}, nil)
+
+	url := fmt.Sprintf("/shops/%s/floor-plan", shop.ID)
+	recorder := serveKitchen(t, store, user, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var plan []floorPlanArea
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &plan))
+	require.Len(t, plan, 2)
+	require.Equal(t, area.ID, plan[0].ID)
+	require.Len(t, plan[0].Tables, 2)
+	require.Nil(t, plan[0].Tables[0].Tab)
+	require.NotNil(t, plan[0].Tables[1].Tab)
+	require.Equal(t, tab.ID, plan[0].Tables[1].Tab.ID)
+	require.Empty(t, plan[1].Tables)
+}
+
+func TestOpenTab(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	table := randomDiningTable(shop, uuid.New(), utils.TableFree)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"table_id": table.ID},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					OpenTabTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.OpenTabTxParams) (db.Tab, error) {
+						require.Equal(t, shop.Name, arg.ShopName)
+						require.Equal(t, table.ID, arg.TableID)
+						require.NotEqual(t, uuid.Nil, arg.OrderID)
+						require.Equal(t, int32(1), arg.Guests)
+						return db.Tab{ID: uuid.New(), OrderID: arg.OrderID, Status: utils.TabOpen}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Occupied",
+			body: gin.H{"table_id": table.ID, "guests": 3},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					OpenTabTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Tab{}, db.ErrTableOccupied)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "TableNotFound",
+			body: gin.H{"table_id": table.ID},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					OpenTabTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Tab{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/tabs", shop.ID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPost, url, tc.body))
+		})
+	}
+}
+
+func TestMoveTab(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	tab := randomTab(shop, utils.TabOpen)
+	target := randomDiningTable(shop, uuid.New(), utils.TableFree)
+
+	testCases := []struct {
+		name          string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStub: func(store *mockdb.MockStore) {
+				moved := tab
+				moved.TableID = uuid.NullUUID{UUID: target.ID, Valid: true}
+				store.EXPECT().
+					MoveTabTx(gomock.Any(), gomock.Eq(db.MoveTabTxParams{ShopName: shop.Name, ID: tab.ID, TableID: target.ID})).
+					Times(1).
+					Return(moved, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.Tab
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, target.ID, res.TableID.UUID)
+			},
+		},
+		{
+			name: "TargetOccupied",
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					MoveTabTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Tab{}, db.ErrTableOccupied)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/tabs/%s/move", shop.ID, tab.ID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPost, url, gin.H{"table_id": target.ID}))
+		})
+	}
+}
+
+func TestMergeTab(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	tab := randomTab(shop, utils.TabOpen)
+	other := randomTab(shop, utils.TabOpen)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"tab_id": other.ID},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					MergeTabsTx(gomock.Any(), gomock.Eq(db.MergeTabsTxParams{ShopName: shop.Name, ID: tab.ID, FromID: other.ID})).
+					Times(1).
+					Return(tab, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "SameTab",
+			body: gin.H{"tab_id": tab.ID},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					MergeTabsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Tab{}, db.ErrSameTab)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "TabNotOpen",
+			body: gin.H{"tab_id": other.ID},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					MergeTabsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Tab{}, db.ErrTabNotOpen)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/tabs/%s/merge", shop.ID, tab.ID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPost, url, tc.body))
+		})
+	}
+}
+
+func TestCloseTab(t *testing.T) {
+	user, _ := randomUser(t)
+	shop := randomShop(user)
+	tab := randomTab(shop, utils.TabOpen)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"payments": []gin.H{{"method": "cash", "amount": 42, "tendered": 50}}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CloseTabTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CloseTabTxParams) (db.CloseTabTxResult, error) {
+						require.Equal(t, tab.ID, arg.ID)
+						require.Len(t, arg.Payments, 1)
+						require.Equal(t, "42.00", arg.Payments[0].Amount)
+						require.Equal(t, "50.00", arg.Payments[0].Tendered)
+						closed := tab
+						closed.Status = utils.TabClosed
+						return db.CloseTabTxResult{Tab: closed}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyPaid",
+			body: gin.H{},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CloseTabTx(gomock.Any(), gomock.Eq(db.CloseTabTxParams{ShopName: shop.Name, ID: tab.ID})).
+					Times(1).
+					Return(db.CloseTabTxResult{Tab: tab}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotPaid",
+			body: gin.H{"payments": []gin.H{{"method": "card", "amount": 40}}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CloseTabTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CloseTabTxResult{}, db.ErrTabNotPaid)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "TabClosed",
+			body: gin.H{},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CloseTabTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CloseTabTxResult{}, db.ErrTabNotOpen)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CloseTabTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CloseTabTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidMethod",
+			body: gin.H{"payments": []gin.H{{"method": "voucher", "amount": 42}}},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CloseTabTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectShopMember(store, shop, user)
+			tc.buildStub(store)
+
+			url := fmt.Sprintf("/shops/%s/tabs/%s/close", shop.ID, tab.ID)
+			tc.checkResponse(t, serveKitchen(t, store, user, http.MethodPost, url, tc.body))
+		})
+	}
+}
@@ -25,6 +25,14 @@ type userResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+func newUserResponse(user db.User) userResponse {
+	return userResponse{
+		ID:        user.ID,
+		Username:  user.Username,
+		CreatedAt: user.CreatedAt,
+	}
+}
+
 func (server *Server) createUser(ctx *gin.Context) {
 	var req createUserRequest
 
@@ -58,11 +66,7 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
-	res := userResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		CreatedAt: user.CreatedAt,
-	}
+	res := newUserResponse(user)
 
 	ctx.JSON(http.StatusOK, res)
 }
@@ -97,11 +101,7 @@ func (server *Server) getUser(ctx *gin.Context) {
 		return
 	}
 
-	res := userResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		CreatedAt: user.CreatedAt,
-	}
+	res := newUserResponse(user)
 
 	ctx.JSON(http.StatusOK, res)
 }
@@ -145,11 +145,7 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
-	userRes := userResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		CreatedAt: user.CreatedAt,
-	}
+	userRes := newUserResponse(user)
 
 	loginRes := loginUserRespone{
 		AccessToken: token,
@@ -39,12 +39,12 @@ func requireBodyMatchUser(t *testing.T, body *bytes.Buffer, user db.User) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotUser db.User
+	var gotUser userResponse
 	err = json.Unmarshal(data, &gotUser) // map JSON to struct
 	require.NoError(t, err)
 	require.Equal(t, user.Username, gotUser.Username)
 	require.Equal(t, user.ID, gotUser.ID)
-	require.Empty(t, gotUser.HashedPassword)
+	require.NotContains(t, string(data), "hashed_password")
 
 }
 
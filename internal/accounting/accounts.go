@@ -0,0 +1,57 @@
+package accounting
+
+import (
+	"encoding/json"
+	"fmt"
+
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// accounts used by shops that have not configured their own mapping
+func DefaultMapping(shopName string) db.AccountMapping {
+	return db.AccountMapping{
+		ShopName:       shopName,
+		CashClearing:   "Cash Clearing",
+		CardClearing:   "Card Clearing",
+		OtherClearing:  "Other Tender Clearing",
+		Sales:          "Sales",
+		SalesByTaxRate: json.RawMessage(`{}`),
+		TaxPayable:     "Sales Tax Payable",
+		RefundClearing: "Cash Clearing",
+		Receivable:     "Accounts Receivable",
+	}
+}
+
+type accounts struct {
+	db.AccountMapping
+	salesByTaxRate map[string]string
+}
+
+func newAccounts(mapping db.AccountMapping) (accounts, error) {
+	a := accounts{AccountMapping: mapping, salesByTaxRate: map[string]string{}}
+	if len(mapping.SalesByTaxRate) > 0 {
+		if err := json.Unmarshal(mapping.SalesByTaxRate, &a.salesByTaxRate); err != nil {
+			return a, fmt.Errorf("invalid sales_by_tax_rate: %w", err)
+		}
+	}
+	return a, nil
+}
+
+func (a accounts) clearing(method string) string {
+	switch method {
+	case utils.PaymentCash:
+		return a.CashClearing
+	case utils.PaymentCard:
+		return a.CardClearing
+	default:
+		return a.OtherClearing
+	}
+}
+
+func (a accounts) sales(taxRate string) string {
+	if account, ok := a.salesByTaxRate[taxRate]; ok && account != "" {
+		return account
+	}
+	return a.Sales
+}
@@ -0,0 +1,143 @@
+package accounting
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+var ErrUnbalanced = errors.New("journal entry is not balanced")
+
+// amounts are in cents so entries balance exactly
+type Line struct {
+	Account string
+	Debit   int64
+	Credit  int64
+	Memo    string
+}
+
+type Entry struct {
+	Date      time.Time
+	Reference string
+	Memo      string
+	Lines     []Line
+}
+
+func (entry Entry) balanced() bool {
+	var debit, credit int64
+	for _, line := range entry.Lines {
+		debit += line.Debit
+		credit += line.Credit
+	}
+	return debit == credit
+}
+
+// everything recorded for one closed business day
+type Day struct {
+	Report   db.ZReport
+	TaxRates []db.GetDailySalesByTaxRateRow
+	Tenders  []db.GetDailyTendersRow
+}
+
+// post a closed day as a sales entry and, when items were refunded, a
+// refund entry reversing the sales and tax of those items.
+//
+// the sales entry debits the clearing account of every tender and credits
+// sales per tax rate and tax payable, an unpaid balance is debited to the
+// receivable account and an overpayment credited to it
+func BuildEntries(day Day, mapping db.AccountMapping) ([]Entry, error) {
+	a, err := newAccounts(mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", day.Report.BusinessDay)
+	if err != nil {
+		return nil, err
+	}
+	reference := fmt.Sprintf("Z%d", day.Report.Number)
+
+	sales := Entry{Date: date, Reference: reference, Memo: "Sales " + day.Report.BusinessDay}
+	refunds := Entry{Date: date, Reference: reference, Memo: "Refunds " + day.Report.BusinessDay}
+
+	var paid, charged, refunded int64
+	for _, tender := range day.Tenders {
+		amount, err := utils.ParseCents(tender.Amount)
+		if err != nil {
+			return nil, err
+		}
+		paid += amount
+		sales.Lines = append(sales.Lines, Line{
+			Account: a.clearing(tender.Method),
+			Debit:   amount,
+			Memo:    tender.Method + " tenders",
+		})
+	}
+
+	var tax, refundedTax int64
+	for _, rate := range day.TaxRates {
+		amount, err := utils.ParseCents(rate.Sales)
+		if err != nil {
+			return nil, err
+		}
+		refundAmount, err := utils.ParseCents(rate.Refunds)
+		if err != nil {
+			return nil, err
+		}
+		rateTax, err := utils.ParseCents(rate.Tax)
+		if err != nil {
+			return nil, err
+		}
+		rateRefundedTax, err := utils.ParseCents(rate.RefundedTax)
+		if err != nil {
+			return nil, err
+		}
+
+		memo := "sales at " + rate.TaxRate + "% tax"
+		if amount != 0 {
+			sales.Lines = append(sales.Lines, Line{Account: a.sales(rate.TaxRate), Credit: amount, Memo: memo})
+		}
+		if refundAmount != 0 {
+			refunds.Lines = append(refunds.Lines, Line{Account: a.sales(rate.TaxRate), Debit: refundAmount, Memo: "refunded " + memo})
+		}
+
+		charged += amount + rateTax
+		refunded += refundAmount + rateRefundedTax
+		tax += rateTax
+		refundedTax += rateRefundedTax
+	}
+
+	if tax != 0 {
+		sales.Lines = append(sales.Lines, Line{Account: a.TaxPayable, Credit: tax, Memo: "tax collected"})
+	}
+	if refundedTax != 0 {
+		refunds.Lines = append(refunds.Lines, Line{Account: a.TaxPayable, Debit: refundedTax, Memo: "tax refunded"})
+	}
+
+	switch {
+	case paid < charged:
+		sales.Lines = append(sales.Lines, Line{Account: a.Receivable, Debit: charged - paid, Memo: "unpaid balance"})
+	case paid > charged:
+		sales.Lines = append(sales.Lines, Line{Account: a.Receivable, Credit: paid - charged, Memo: "overpayment"})
+	}
+
+	entries := []Entry{}
+	if len(sales.Lines) > 0 {
+		entries = append(entries, sales)
+	}
+	if refunded != 0 {
+		refunds.Lines = append(refunds.Lines, Line{Account: a.RefundClearing, Credit: refunded, Memo: "refunds paid out"})
+		entries = append(entries, refunds)
+	}
+
+	for _, entry := range entries {
+		if !entry.balanced() {
+			return nil, fmt.Errorf("%w: %s %s", ErrUnbalanced, entry.Reference, entry.Memo)
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,105 @@
+package accounting
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+)
+
+func testDay() Day {
+	return Day{
+		Report: db.ZReport{Number: 7, BusinessDay: "2024-01-02"},
+		TaxRates: []db.GetDailySalesByTaxRateRow{
+			{TaxRate: "0.00", Sales: "20.00", Refunds: "0", Tax: "0.00", RefundedTax: "0.00"},
+			{TaxRate: "5.00", Sales: "100.00", Refunds: "10.00", Tax: "5.00", RefundedTax: "0.50"},
+		},
+		Tenders: []db.GetDailyTendersRow{
+			{Method: "card", Amount: "80.00", Payments: 2},
+			{Method: "cash", Amount: "45.00", Payments: 3},
+		},
+	}
+}
+
+func TestBuildEntries(t *testing.T) {
+	mapping := DefaultMapping("shop")
+	mapping.SalesByTaxRate = json.RawMessage(`{"5.00": "Sales - Taxable"}`)
+
+	entries, err := BuildEntries(testDay(), mapping)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	sales := entries[0]
+	require.Equal(t, "Z7", sales.Reference)
+	require.Equal(t, []Line{
+		{Account: "Card Clearing", Debit: 8000, Memo: "card tenders"},
+		{Account: "Cash Clearing", Debit: 4500, Memo: "cash tenders"},
+		{Account: "Sales", Credit: 2000, Memo: "sales at 0.00% tax"},
+		{Account: "Sales - Taxable", Credit: 10000, Memo: "sales at 5.00% tax"},
+		{Account: "Sales Tax Payable", Credit: 500, Memo: "tax collected"},
+	}, sales.Lines)
+
+	refunds := entries[1]
+	require.Equal(t, []Line{
+		{Account: "Sales - Taxable", Debit: 1000, Memo: "refunded sales at 5.00% tax"},
+		{Account: "Sales Tax Payable", Debit: 50, Memo: "tax refunded"},
+		{Account: "Cash Clearing", Credit: 1050, Memo: "refunds paid out"},
+	}, refunds.Lines)
+}
+
+func TestBuildEntriesUnpaidBalance(t *testing.T) {
+	day := testDay()
+	day.Tenders = day.Tenders[:1]
+
+	entries, err := BuildEntries(day, DefaultMapping("shop"))
+	require.NoError(t, err)
+
+	lines := entries[0].Lines
+	require.Equal(t, Line{Account: "Accounts Receivable", Debit: 4500, Memo: "unpaid balance"}, lines[len(lines)-1])
+	require.True(t, entries[0].balanced())
+}
+
+func TestBuildEntriesEmptyDay(t *testing.T) {
+	entries, err := BuildEntries(Day{Report: db.ZReport{Number: 1, BusinessDay: "2024-01-02"}}, DefaultMapping("shop"))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestWriteIIF(t *testing.T) {
+	entries, err := BuildEntries(testDay(), DefaultMapping("shop"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteIIF(&buf, entries))
+
+	want := "!TRNS\tTRNSID\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tDOCNUM\tMEMO\r\n" +
+		"!SPL\tSPLID\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tDOCNUM\tMEMO\r\n" +
+		"!ENDTRNS\r\n" +
+		"TRNS\t\tGENERAL JOURNAL\t01/02/2024\tCard Clearing\t80.00\tZ7\tcard tenders\r\n" +
+		"SPL\t\tGENERAL JOURNAL\t01/02/2024\tCash Clearing\t45.00\tZ7\tcash tenders\r\n" +
+		"SPL\t\tGENERAL JOURNAL\t01/02/2024\tSales\t-20.00\tZ7\tsales at 0.00% tax\r\n" +
+		"SPL\t\tGENERAL JOURNAL\t01/02/2024\tSales\t-100.00\tZ7\tsales at 5.00% tax\r\n" +
+		"SPL\t\tGENERAL JOURNAL\t01/02/2024\tSales Tax Payable\t-5.00\tZ7\ttax collected\r\n" +
+		"ENDTRNS\r\n" +
+		"TRNS\t\tGENERAL JOURNAL\t01/02/2024\tSales\t10.00\tZ7\trefunded sales at 5.00% tax\r\n" +
+		"SPL\t\tGENERAL JOURNAL\t01/02/2024\tSales Tax Payable\t0.50\tZ7\ttax refunded\r\n" +
+		"SPL\t\tGENERAL JOURNAL\t01/02/2024\tCash Clearing\t-10.50\tZ7\trefunds paid out\r\n" +
+		"ENDTRNS\r\n"
+	require.Equal(t, want, buf.String())
+}
+
+func TestWriteCSV(t *testing.T) {
+	entries, err := BuildEntries(testDay(), DefaultMapping("shop"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, entries))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 9)
+	require.Equal(t, "Date,Reference,Entry,Account,Debit,Credit,Memo", string(lines[0]))
+	require.Equal(t, "2024-01-02,Z7,Sales 2024-01-02,Card Clearing,80.00,,card tenders", string(lines[1]))
+	require.Equal(t, "2024-01-02,Z7,Refunds 2024-01-02,Cash Clearing,,10.50,refunds paid out", string(lines[8]))
+}
@@ -0,0 +1,99 @@
+package accounting
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// generic journal, one row per line with the debit or the credit filled in
+func WriteCSV(w io.Writer, entries []Entry) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"Date", "Reference", "Entry", "Account", "Debit", "Credit", "Memo"})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		for _, line := range entry.Lines {
+			var debit, credit string
+			if line.Debit != 0 {
+				debit = utils.FormatCents(line.Debit)
+			}
+			if line.Credit != 0 {
+				credit = utils.FormatCents(line.Credit)
+			}
+
+			err := writer.Write([]string{
+				entry.Date.Format("2006-01-02"),
+				entry.Reference,
+				entry.Memo,
+				line.Account,
+				debit,
+				credit,
+				line.Memo,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// QuickBooks import format, each entry is a general journal transaction
+// whose first line is the TRNS row and the rest SPL rows, debits are
+// positive and credits negative
+func WriteIIF(w io.Writer, entries []Entry) error {
+	writer := bufio.NewWriter(w)
+
+	header := []string{
+		"!TRNS\tTRNSID\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tDOCNUM\tMEMO",
+		"!SPL\tSPLID\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tDOCNUM\tMEMO",
+		"!ENDTRNS",
+	}
+	for _, row := range header {
+		if _, err := writer.WriteString(row + "\r\n"); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range entries {
+		for i, line := range entry.Lines {
+			kind := "SPL"
+			if i == 0 {
+				kind = "TRNS"
+			}
+
+			row := strings.Join([]string{
+				kind,
+				"",
+				"GENERAL JOURNAL",
+				entry.Date.Format("01/02/2006"),
+				iifField(line.Account),
+				utils.FormatCents(line.Debit - line.Credit),
+				iifField(entry.Reference),
+				iifField(line.Memo),
+			}, "\t")
+			if _, err := writer.WriteString(row + "\r\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := writer.WriteString("ENDTRNS\r\n"); err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+// iif has no quoting, tabs and line breaks would split the field
+func iifField(s string) string {
+	return strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(s)
+}
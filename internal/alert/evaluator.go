@@ -0,0 +1,104 @@
+package alert
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/event"
+)
+
+const DefaultInterval = time.Minute
+
+// Evaluator periodically compares stock levels with their reorder points,
+// raising an alert when on-hand falls below the reorder point
+// and resolving it once the stock has been replenished
+type Evaluator struct {
+	store    db.Store
+	hub      *event.Hub
+	notifier Notifier
+	interval time.Duration
+}
+
+func NewEvaluator(store db.Store, hub *event.Hub, notifier Notifier, interval time.Duration) *Evaluator {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	return &Evaluator{
+		store:    store,
+		hub:      hub,
+		notifier: notifier,
+		interval: interval,
+	}
+}
+
+// evaluate on every tick until the context is cancelled
+func (evaluator *Evaluator) Run(ctx context.Context) {
+	ticker := time.NewTicker(evaluator.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := evaluator.Evaluate(ctx); err != nil {
+			log.Println("cannot evaluate stock alerts:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (evaluator *Evaluator) Evaluate(ctx context.Context) error {
+	resolved, err := evaluator.store.ResolveStockAlerts(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, alert := range resolved {
+		evaluator.hub.Publish(event.Event{
+			Type:     event.TypeStockRecovered,
+			ShopName: alert.ShopName,
+			Data:     alert,
+		})
+	}
+
+	levels, err := evaluator.store.ListStockLevelsBelowReorderPoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, level := range levels {
+		alert, err := evaluator.store.CreateStockAlert(ctx, db.CreateStockAlertParams{
+			ID:           uuid.New(),
+			ShopName:     level.ShopName,
+			ProductID:    level.ProductID,
+			OnHand:       level.OnHand,
+			ReorderPoint: level.ReorderPoint,
+		})
+		if err != nil {
+			// another evaluator raised the alert first
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+				continue
+			}
+			return err
+		}
+
+		evaluator.hub.Publish(event.Event{
+			Type:     event.TypeStockLow,
+			ShopName: alert.ShopName,
+			Data:     alert,
+		})
+
+		if err := evaluator.notifier.Notify(ctx, alert); err != nil {
+			log.Println("cannot notify stock alert:", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/internal/event"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+type recordingNotifier struct {
+	alerts []db.StockAlert
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, alert db.StockAlert) error {
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+func TestEvaluate(t *testing.T) {
+	shopName := utils.RandString(6)
+	level := db.StockLevel{
+		ShopName:     shopName,
+		ProductID:    uuid.New(),
+		OnHand:       2,
+		ReorderPoint: 5,
+		ParLevel:     20,
+	}
+	recovered := db.StockAlert{
+		ID:           uuid.New(),
+		ShopName:     shopName,
+		ProductID:    uuid.New(),
+		OnHand:       1,
+		ReorderPoint: 3,
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ResolveStockAlerts(gomock.Any()).
+		Times(1).
+		Return([]db.StockAlert{recovered}, nil)
+	store.EXPECT().
+		ListStockLevelsBelowReorderPoint(gomock.Any()).
+		Times(1).
+		Return([]db.StockLevel{level}, nil)
+	store.EXPECT().
+		CreateStockAlert(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, arg db.CreateStockAlertParams) (db.StockAlert, error) {
+			require.Equal(t, level.ShopName, arg.ShopName)
+			require.Equal(t, level.ProductID, arg.ProductID)
+			require.Equal(t, level.OnHand, arg.OnHand)
+			require.Equal(t, level.ReorderPoint, arg.ReorderPoint)
+			return db.StockAlert{
+				ID:           arg.ID,
+				ShopName:     arg.ShopName,
+				ProductID:    arg.ProductID,
+				OnHand:       arg.OnHand,
+				ReorderPoint: arg.ReorderPoint,
+			}, nil
+		})
+
+	hub := event.NewHub()
+	events, unsubscribe := hub.Subscribe(shopName)
+	defer unsubscribe()
+
+	notifier := &recordingNotifier{}
+	evaluator := NewEvaluator(store, hub, notifier, 0)
+
+	err := evaluator.Evaluate(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, notifier.alerts, 1)
+	require.Equal(t, level.ProductID, notifier.alerts[0].ProductID)
+
+	require.Len(t, events, 2)
+	require.Equal(t, event.TypeStockRecovered, (<-events).Type)
+	low := <-events
+	require.Equal(t, event.TypeStockLow, low.Type)
+	require.Equal(t, notifier.alerts[0], low.Data)
+}
+
+func TestEvaluateError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ResolveStockAlerts(gomock.Any()).
+		Times(1).
+		Return([]db.StockAlert{}, nil)
+	store.EXPECT().
+		ListStockLevelsBelowReorderPoint(gomock.Any()).
+		Times(1).
+		Return(nil, sql.ErrConnDone)
+	store.EXPECT().
+		CreateStockAlert(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	notifier := &recordingNotifier{}
+	evaluator := NewEvaluator(store, event.NewHub(), notifier, 0)
+
+	err := evaluator.Evaluate(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.Empty(t, notifier.alerts)
+}
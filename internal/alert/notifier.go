@@ -0,0 +1,22 @@
+package alert
+
+import (
+	"context"
+	"log"
+
+	db "github.com/toml5566/go_pos_backend/internal/database"
+)
+
+// Notifier delivers low-stock alerts outside of the event stream, e.g. by email or chat
+type Notifier interface {
+	Notify(ctx context.Context, alert db.StockAlert) error
+}
+
+// LogNotifier writes alerts to the standard logger
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, alert db.StockAlert) error {
+	log.Printf("low stock: shop %s product %s has %d on hand (reorder point %d)",
+		alert.ShopName, alert.ProductID, alert.OnHand, alert.ReorderPoint)
+	return nil
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func TestUpsertAccountMapping(t *testing.T) {
+	shop := createRandomShop(t)
+
+	arg := UpsertAccountMappingParams{
+		ShopName:       shop.Name,
+		CashClearing:   "1010 Cash",
+		CardClearing:   "1020 Card",
+		OtherClearing:  "1030 Other",
+		Sales:          "4000 Sales",
+		SalesByTaxRate: json.RawMessage(`{}`),
+		TaxPayable:     "2200 VAT",
+		RefundClearing: "1010 Cash",
+		Receivable:     "1100 Receivable",
+	}
+
+	mapping, err := testQueries.UpsertAccountMapping(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Sales, mapping.Sales)
+
+	arg.Sales = "4100 Sales"
+	arg.SalesByTaxRate = json.RawMessage(`{"5.00": "4010 Taxable Sales"}`)
+	updated, err := testQueries.UpsertAccountMapping(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "4100 Sales", updated.Sales)
+	require.JSONEq(t, string(arg.SalesByTaxRate), string(updated.SalesByTaxRate))
+
+	got, err := testQueries.GetAccountMapping(context.Background(), shop.Name)
+	require.NoError(t, err)
+	require.Equal(t, updated.Sales, got.Sales)
+}
+
+func TestGetDailySalesByTaxRate(t *testing.T) {
+	shop := createRandomShop(t)
+	orderItem := createRandomOrderItem(t, shop, utils.RandOrderID(), "2024-01-02")
+
+	rates, err := testQueries.GetDailySalesByTaxRate(context.Background(), GetDailySalesByTaxRateParams{
+		ShopName: shop.Name,
+		OrderDay: orderItem.OrderDay,
+	})
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	require.Equal(t, "0.00", rates[0].TaxRate)
+	require.Equal(t, "0.00", rates[0].Tax)
+
+	_, err = testStore.CloseDayTx(context.Background(), DailySalesSummaryParams{ShopName: shop.Name, BusinessDay: orderItem.OrderDay})
+	require.NoError(t, err)
+
+	reports, err := testQueries.ListZReportsByBusinessDay(context.Background(), ListZReportsByBusinessDayParams{
+		ShopName: shop.Name,
+		FromDay:  "2024-01-01",
+		ToDay:    "2024-01-31",
+	})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+}
@@ -0,0 +1,206 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func TestProductMix(t *testing.T) {
+	shop := createRandomShop(t)
+	orderID := utils.RandOrderID()
+	first := createRandomOrderItem(t, shop, orderID, utils.FormattedDateNow())
+	second := createRandomOrderItem(t, shop, orderID, utils.FormattedDateNow())
+
+	arg := GetProductMixParams{
+		ShopName: shop.Name,
+		FromDay:  utils.FormattedDateNow(),
+		ToDay:    utils.FormattedDateNow(),
+	}
+
+	mix, err := testQueries.GetProductMix(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, mix, 2)
+
+	quantity := map[string]int64{}
+	for _, row := range mix {
+		quantity[row.ProductName] = row.Quantity
+	}
+	require.Equal(t, int64(first.Amount), quantity[first.ProductName])
+	require.Equal(t, int64(second.Amount), quantity[second.ProductName])
+
+	comparison, err := testQueries.GetSalesComparison(context.Background(), GetSalesComparisonParams{
+		FromDay:         arg.FromDay,
+		ShopName:        shop.Name,
+		PreviousFromDay: time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02"),
+		ToDay:           arg.ToDay,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), comparison.CurrentOrderCount)
+	require.Equal(t, int64(first.Amount+second.Amount), comparison.CurrentQuantity)
+	require.Equal(t, int64(0), comparison.PreviousOrderCount)
+
+	heatmap, err := testQueries.GetSalesHeatmap(context.Background(), GetSalesHeatmapParams{
+		Timezone: "UTC",
+		ShopName: shop.Name,
+		FromDay:  arg.FromDay,
+		ToDay:    arg.ToDay,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, heatmap)
+
+	types, err := testQueries.GetOrderTypeMix(context.Background(), GetOrderTypeMixParams(arg))
+	require.NoError(t, err)
+	require.Len(t, types, 1)
+	require.Equal(t, utils.OrderDineIn, types[0].OrderType)
+	require.Equal(t, int64(1), types[0].OrderCount)
+	require.Equal(t, "1.0000", types[0].Share)
+}
+
+// a scheduled order counts on the business day of its slot, not on the day
+// it was placed
+func TestAnalyticsScheduledOrder(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+
+	now := time.Now().UTC()
+	slot := time.Date(now.Year(), now.Month(), now.Day()+3, 12, 0, 0, 0, time.UTC)
+	slotDay := slot.Format("2006-01-02")
+
+	arg := scheduledOrder(shop, menuItem, slot)
+	arg.Items[0].OrderDay = slotDay
+	_, err := testStore.CreateOrderTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	today, err := testQueries.GetProductMix(context.Background(), GetProductMixParams{
+		ShopName: shop.Name,
+		FromDay:  utils.FormattedDateNow(),
+		ToDay:    utils.FormattedDateNow(),
+	})
+	require.NoError(t, err)
+	require.Empty(t, today)
+
+	mix, err := testQueries.GetProductMix(context.Background(), GetProductMixParams{
+		ShopName: shop.Name,
+		FromDay:  slotDay,
+		ToDay:    slotDay,
+	})
+	require.NoError(t, err)
+	require.Len(t, mix, 1)
+	require.Equal(t, menuItem.ProductName, mix[0].ProductName)
+
+	heatmap, err := testQueries.GetSalesHeatmap(context.Background(), GetSalesHeatmapParams{
+		Timezone: "UTC",
+		ShopName: shop.Name,
+		FromDay:  slotDay,
+		ToDay:    slotDay,
+	})
+	require.NoError(t, err)
+	require.Len(t, heatmap, 1)
+	require.Equal(t, utils.ISOWeekday(slot), heatmap[0].DayOfWeek)
+	require.Equal(t, int32(12), heatmap[0].Hour)
+
+	comparison, err := testQueries.GetSalesComparison(context.Background(), GetSalesComparisonParams{
+		FromDay:         slotDay,
+		ShopName:        shop.Name,
+		PreviousFromDay: utils.FormattedDateNow(),
+		ToDay:           slotDay,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), comparison.CurrentOrderCount)
+	require.Equal(t, int64(0), comparison.PreviousOrderCount)
+}
+
+// fee and discount lines are not sales of products, they stay out of every
+// analytics report
+func TestAnalyticsAdjustmentLines(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	createRandomPromotion(t, shop, CreatePromotionParams{
+		Target:    utils.PromotionOrder,
+		Kind:      utils.PromotionPercent,
+		Amount:    "10.00",
+		Stackable: true,
+	})
+	createRandomOrderTypeFee(t, shop, utils.OrderTakeaway, utils.FeeFlat, "0.50")
+
+	item := tabOrderItem(shop, menuItem)
+	item.OrderID = uuid.New()
+	item.Amount = 2
+	result, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:     []CreateOrderItemParams{item},
+		OrderType: utils.OrderTakeaway,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Discounts, 1)
+	require.Len(t, result.Fees, 1)
+
+	today := utils.FormattedDateNow()
+
+	types, err := testQueries.GetOrderTypeMix(context.Background(), GetOrderTypeMixParams{
+		ShopName: shop.Name,
+		FromDay:  today,
+		ToDay:    today,
+	})
+	require.NoError(t, err)
+	require.Len(t, types, 1)
+	require.Equal(t, int64(2), types[0].Quantity)
+	require.Equal(t, "10.00", types[0].Revenue)
+
+	heatmap, err := testQueries.GetSalesHeatmap(context.Background(), GetSalesHeatmapParams{
+		Timezone: "UTC",
+		ShopName: shop.Name,
+		FromDay:  today,
+		ToDay:    today,
+	})
+	require.NoError(t, err)
+	require.Len(t, heatmap, 1)
+	require.Equal(t, int64(2), heatmap[0].Quantity)
+	require.Equal(t, "10.00", heatmap[0].Revenue)
+
+	comparison, err := testQueries.GetSalesComparison(context.Background(), GetSalesComparisonParams{
+		FromDay:         today,
+		ShopName:        shop.Name,
+		PreviousFromDay: today,
+		ToDay:           today,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), comparison.CurrentQuantity)
+	require.Equal(t, "10.00", comparison.CurrentRevenue)
+	require.Equal(t, int64(1), comparison.CurrentOrderCount)
+}
+
+func TestUpdateShopTimezone(t *testing.T) {
+	shop := createRandomShop(t)
+	require.Equal(t, "UTC", shop.Timezone)
+
+	updated, err := testQueries.UpdateShopTimezone(context.Background(), UpdateShopTimezoneParams{
+		ID:       shop.ID,
+		Timezone: "Europe/London",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Europe/London", updated.Timezone)
+}
+
+func TestUpdateShopBusinessDayCutoff(t *testing.T) {
+	shop := createRandomShop(t)
+	require.Zero(t, shop.BusinessDayCutoff)
+
+	updated, err := testQueries.UpdateShopBusinessDayCutoff(context.Background(), UpdateShopBusinessDayCutoffParams{
+		ID:                shop.ID,
+		BusinessDayCutoff: 240,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(240), updated.BusinessDayCutoff)
+
+	// the cutoff is minutes after midnight, a full day is out of range
+	_, err = testQueries.UpdateShopBusinessDayCutoff(context.Background(), UpdateShopBusinessDayCutoffParams{
+		ID:                shop.ID,
+		BusinessDayCutoff: 1440,
+	})
+	require.Error(t, err)
+}
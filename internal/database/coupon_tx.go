@@ -0,0 +1,177 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// random characters of a generated code after its prefix
+const couponCodeLength = 8
+
+// attempts at a free code before a batch gives up, codes are random so a
+// collision with an existing one is rare
+const couponCodeAttempts = 5
+
+// codes of a batch are single use, the per customer limit counts over the
+// whole batch
+type CreateCouponBatchTxParams struct {
+	ShopName       string       `json:"shop_name"`
+	PromotionID    uuid.UUID    `json:"promotion_id"`
+	Prefix         string       `json:"prefix"`
+	Count          int          `json:"count"`
+	MaxPerCustomer int32        `json:"max_per_customer"`
+	ExpiresAt      sql.NullTime `json:"expires_at"`
+}
+
+type CouponBatchTxResult struct {
+	BatchID uuid.UUID `json:"batch_id"`
+	Coupons []Coupon  `json:"coupons"`
+}
+
+// generate a batch of single use codes for a promotion of the shop
+func (store *SQLStore) CreateCouponBatchTx(ctx context.Context, arg CreateCouponBatchTxParams) (CouponBatchTxResult, error) {
+	result := CouponBatchTxResult{
+		BatchID: uuid.New(),
+		Coupons: []Coupon{},
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetPromotion(ctx, GetPromotionParams{
+			ShopName: arg.ShopName,
+			ID:       arg.PromotionID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for len(result.Coupons) < arg.Count {
+			coupon, err := createGeneratedCoupon(ctx, q, CreateCouponParams{
+				ShopName:       arg.ShopName,
+				PromotionID:    arg.PromotionID,
+				BatchID:        uuid.NullUUID{UUID: result.BatchID, Valid: true},
+				MaxRedemptions: 1,
+				MaxPerCustomer: arg.MaxPerCustomer,
+				ExpiresAt:      arg.ExpiresAt,
+			}, arg.Prefix)
+			if err != nil {
+				return err
+			}
+			result.Coupons = append(result.Coupons, coupon)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// insert a coupon under a random code, a code that is taken already is
+// skipped by the insert and another one is drawn
+func createGeneratedCoupon(ctx context.Context, q *Queries, arg CreateCouponParams, prefix string) (Coupon, error) {
+	for attempt := 0; attempt < couponCodeAttempts; attempt++ {
+		code, err := utils.NewCouponCode(prefix, couponCodeLength)
+		if err != nil {
+			return Coupon{}, err
+		}
+
+		arg.ID = uuid.New()
+		arg.Code = code
+		coupon, err := q.CreateCoupon(ctx, arg)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		return coupon, err
+	}
+
+	return Coupon{}, ErrCouponCodeTaken
+}
+
+// redeem a coupon code of the shop for an order. the redemption count is
+// raised first, which locks the coupon so concurrent orders redeeming it
+// wait for each other and see each other's redemptions, then the per
+// customer limit is checked and the redemption recorded. coupons limited per
+// customer need to know who redeems them
+func redeemOrderCoupon(ctx context.Context, q *Queries, shopName, code, customer string, orderID uuid.UUID, at time.Time) (Coupon, error) {
+	coupon, err := q.GetCouponByCode(ctx, GetCouponByCodeParams{
+		ShopName: shopName,
+		Code:     utils.NormalizeCouponCode(code),
+	})
+	if err != nil {
+		return coupon, err
+	}
+
+	if !coupon.Active || (coupon.ExpiresAt.Valid && !coupon.ExpiresAt.Time.After(at)) {
+		return coupon, ErrCouponExpired
+	}
+	customer = utils.NormalizeCustomer(customer)
+	if coupon.MaxPerCustomer > 0 && customer == "" {
+		return coupon, ErrCouponCustomer
+	}
+
+	coupon, err = q.RedeemCoupon(ctx, RedeemCouponParams{
+		ID: coupon.ID,
+		At: at,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// used up, or deactivated while the order waited for the lock
+			return coupon, ErrCouponUsedUp
+		}
+		return coupon, err
+	}
+
+	if coupon.MaxPerCustomer > 0 {
+		// the codes of a batch are different rows, orders of one customer
+		// redeeming two of them wait for each other before counting
+		if coupon.BatchID.Valid {
+			err = q.LockCouponCustomer(ctx, LockCouponCustomerParams{
+				BatchID:  coupon.BatchID.UUID,
+				Customer: customer,
+			})
+			if err != nil {
+				return coupon, err
+			}
+		}
+
+		count, err := q.CountCustomerRedemptions(ctx, CountCustomerRedemptionsParams{
+			Customer: customer,
+			CouponID: coupon.ID,
+			BatchID:  coupon.BatchID,
+		})
+		if err != nil {
+			return coupon, err
+		}
+		if count >= int64(coupon.MaxPerCustomer) {
+			return coupon, ErrCouponCustomerLimit
+		}
+	}
+
+	_, err = q.CreateCouponRedemption(ctx, CreateCouponRedemptionParams{
+		ID:          uuid.New(),
+		CouponID:    coupon.ID,
+		PromotionID: coupon.PromotionID,
+		OrderID:     orderID,
+		Customer:    customer,
+	})
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == UniqueViolation {
+		return coupon, ErrCouponRedeemed
+	}
+
+	return coupon, err
+}
+
+// a redeemed coupon must discount the round, otherwise the order is refused
+// and the redemption rolled back
+func checkCouponApplied(coupon Coupon, discounts []Order) error {
+	for _, discount := range discounts {
+		if discount.PromotionID.Valid && discount.PromotionID.UUID == coupon.PromotionID {
+			return nil
+		}
+	}
+	return ErrCouponNotApplicable
+}
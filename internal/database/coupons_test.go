@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// a promotion that only applies with one of its codes
+func createCodePromotion(t *testing.T, shop Shop) Promotion {
+	return createRandomPromotion(t, shop, CreatePromotionParams{
+		Target:       utils.PromotionOrder,
+		Kind:         utils.PromotionFixed,
+		Amount:       "1.00",
+		Stackable:    true,
+		RequiresCode: true,
+	})
+}
+
+func createRandomCoupon(t *testing.T, shop Shop, promotion Promotion, maxRedemptions, maxPerCustomer int32) Coupon {
+	arg := CreateCouponParams{
+		ID:             uuid.New(),
+		ShopName:       shop.Name,
+		PromotionID:    promotion.ID,
+		Code:           utils.NormalizeCouponCode(utils.RandString(10)),
+		MaxRedemptions: maxRedemptions,
+		MaxPerCustomer: maxPerCustomer,
+	}
+
+	coupon, err := testQueries.CreateCoupon(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Code, coupon.Code)
+	require.True(t, coupon.Active)
+	require.Zero(t, coupon.Redemptions)
+
+	return coupon
+}
+
+func couponOrder(shop Shop, menuItem Menu, code, customer string) CreateOrderTxParams {
+	item := tabOrderItem(shop, menuItem)
+	item.OrderID = uuid.New()
+	return CreateOrderTxParams{
+		Items:      []CreateOrderItemParams{item},
+		CouponCode: code,
+		Customer:   customer,
+	}
+}
+
+func TestCreateCouponBatchTx(t *testing.T) {
+	shop := createRandomShop(t)
+	promotion := createCodePromotion(t, shop)
+
+	result, err := testStore.CreateCouponBatchTx(context.Background(), CreateCouponBatchTxParams{
+		ShopName:    shop.Name,
+		PromotionID: promotion.ID,
+		Prefix:      "flyer",
+		Count:       20,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Coupons, 20)
+
+	codes := make(map[string]bool)
+	for _, coupon := range result.Coupons {
+		require.Len(t, coupon.Code, len("FLYER")+couponCodeLength)
+		require.Equal(t, uuid.NullUUID{UUID: result.BatchID, Valid: true}, coupon.BatchID)
+		// single use
+		require.Equal(t, int32(1), coupon.MaxRedemptions)
+		codes[coupon.Code] = true
+	}
+	require.Len(t, codes, 20)
+
+	// codes can be generated for promotions of the shop only
+	other := createCodePromotion(t, createRandomShop(t))
+	_, err = testStore.CreateCouponBatchTx(context.Background(), CreateCouponBatchTxParams{
+		ShopName:    shop.Name,
+		PromotionID: other.ID,
+		Count:       1,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	n, err := testQueries.SetCouponBatchActive(context.Background(), SetCouponBatchActiveParams{
+		ShopName: shop.Name,
+		BatchID:  uuid.NullUUID{UUID: result.BatchID, Valid: true},
+		Active:   false,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(20), n)
+}
+
+func TestCreateOrderTxCoupon(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	promotion := createCodePromotion(t, shop)
+	coupon := createRandomCoupon(t, shop, promotion, 2, 1)
+
+	// the promotion does not apply without its code
+	result, err := testStore.CreateOrderTx(context.Background(), couponOrder(shop, menuItem, "", ""))
+	require.NoError(t, err)
+	require.Empty(t, result.Discounts)
+
+	// codes are matched case insensitively
+	result, err = testStore.CreateOrderTx(context.Background(), couponOrder(shop, menuItem, " "+strings.ToLower(coupon.Code)+" ", "+852 1234 5678"))
+	require.NoError(t, err)
+	require.Len(t, result.Discounts, 1)
+	require.Equal(t, "-1.00", result.Discounts[0].ProductPrice)
+	require.Equal(t, int32(1), result.Coupon.Redemptions)
+
+	// limited per customer, however the customer is written
+	_, err = testStore.CreateOrderTx(context.Background(), couponOrder(shop, menuItem, coupon.Code, "+85212345678"))
+	require.ErrorIs(t, err, ErrCouponCustomerLimit)
+	_, err = testStore.CreateOrderTx(context.Background(), couponOrder(shop, menuItem, coupon.Code, ""))
+	require.ErrorIs(t, err, ErrCouponCustomer)
+
+	_, err = testStore.CreateOrderTx(context.Background(), couponOrder(shop, menuItem, coupon.Code, "+85287654321"))
+	require.NoError(t, err)
+	_, err = testStore.CreateOrderTx(context.Background(), couponOrder(shop, menuItem, coupon.Code, "+85211112222"))
+	require.ErrorIs(t, err, ErrCouponUsedUp)
+
+	// refused orders are rolled back with their redemption
+	coupon, err = testQueries.GetCouponByCode(context.Background(), GetCouponByCodeParams{ShopName: shop.Name, Code: coupon.Code})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), coupon.Redemptions)
+
+	stats, err := testQueries.GetCouponStats(context.Background(), GetCouponStatsParams{ShopName: shop.Name, PromotionID: promotion.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stats.Codes)
+	require.Equal(t, int64(1), stats.RedeemedCodes)
+	require.Equal(t, int64(2), stats.Redemptions)
+	require.Equal(t, int64(2), stats.Customers)
+	require.Equal(t, "2.00", stats.Discounts)
+
+	_, err = testStore.CreateOrderTx(context.Background(), couponOrder(shop, menuItem, "NOSUCHCODE", ""))
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	deactivated := createRandomCoupon(t, shop, promotion, 0, 0)
+	_, err = testQueries.SetCouponActive(context.Background(), SetCouponActiveParams{ShopName: shop.Name, ID: deactivated.ID, Active: false})
+	require.NoError(t, err)
+	_, err = testStore.CreateOrderTx(context.Background(), couponOrder(shop, menuItem, deactivated.Code, ""))
+	require.ErrorIs(t, err, ErrCouponExpired)
+}
+
+func TestCreateOrderTxCouponNotApplicable(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	promotion := createRandomPromotion(t, shop, CreatePromotionParams{
+		Target:       utils.PromotionOrder,
+		Kind:         utils.PromotionPercent,
+		Amount:       "10.00",
+		MinSpend:     "50.00",
+		Stackable:    true,
+		RequiresCode: true,
+	})
+	coupon := createRandomCoupon(t, shop, promotion, 0, 0)
+
+	// the order is short of the minimum spend, the code is kept for later
+	_, err := testStore.CreateOrderTx(context.Background(), couponOrder(shop, menuItem, coupon.Code, ""))
+	require.ErrorIs(t, err, ErrCouponNotApplicable)
+
+	coupon, err = testQueries.GetCouponByCode(context.Background(), GetCouponByCodeParams{ShopName: shop.Name, Code: coupon.Code})
+	require.NoError(t, err)
+	require.Zero(t, coupon.Redemptions)
+}
+
+func TestRedeemSingleUseCouponConcurrently(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	promotion := createCodePromotion(t, shop)
+	coupon := createRandomCoupon(t, shop, promotion, 1, 0)
+
+	// concurrent orders cannot redeem a single use code twice
+	n := 5
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		arg := couponOrder(shop, menuItem, coupon.Code, "")
+		go func() {
+			_, err := testStore.CreateOrderTx(context.Background(), arg)
+			errs <- err
+		}()
+	}
+
+	var redeemed int
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			redeemed++
+			continue
+		}
+		require.ErrorIs(t, err, ErrCouponUsedUp)
+	}
+	require.Equal(t, 1, redeemed)
+
+	coupon, err := testQueries.GetCouponByCode(context.Background(), GetCouponByCodeParams{ShopName: shop.Name, Code: coupon.Code})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), coupon.Redemptions)
+}
+
+func TestRedeemBatchCouponsConcurrently(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	promotion := createCodePromotion(t, shop)
+
+	n := 5
+	batch, err := testStore.CreateCouponBatchTx(context.Background(), CreateCouponBatchTxParams{
+		ShopName:       shop.Name,
+		PromotionID:    promotion.ID,
+		Count:          n,
+		MaxPerCustomer: 1,
+	})
+	require.NoError(t, err)
+
+	// one customer redeeming different codes of the batch at once only gets
+	// one of them
+	errs := make(chan error)
+	for _, coupon := range batch.Coupons {
+		arg := couponOrder(shop, menuItem, coupon.Code, "+85212345678")
+		go func() {
+			_, err := testStore.CreateOrderTx(context.Background(), arg)
+			errs <- err
+		}()
+	}
+
+	var redeemed int
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			redeemed++
+			continue
+		}
+		require.ErrorIs(t, err, ErrCouponCustomerLimit)
+	}
+	require.Equal(t, 1, redeemed)
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 
 	"github.com/lib/pq"
 )
@@ -15,3 +16,34 @@ var ErrRecordNotFound = sql.ErrNoRows
 var ErrUniqueViolation = &pq.Error{
 	Code: UniqueViolation,
 }
+
+var (
+	ErrAlreadyRefunded     = errors.New("order item is already refunded")
+	ErrRefundStatus        = errors.New("order items are refunded with a refund, which returns their stock, not by setting the status")
+	ErrAdjustmentLine      = errors.New("fee and discount lines follow the items of their order and are not changed by hand")
+	ErrRoundDiscounted     = errors.New("the round of the item has promotion discounts, refund the item instead of changing it")
+	ErrInvalidMovementType = errors.New("invalid stock movement type")
+	ErrIncompatibleUnit    = errors.New("recipe unit is not convertible to the ingredient unit")
+	ErrItemUnavailable     = errors.New("menu item is sold out")
+	ErrNotOnMenu           = errors.New("item is not on the menu of the shop")
+	ErrNotReceivable       = errors.New("purchase order is not open for receiving")
+	ErrUnknownOrderLine    = errors.New("line does not belong to the purchase order")
+	ErrOverReceived        = errors.New("received quantity exceeds the ordered quantity")
+	ErrDayClosed           = errors.New("business day is already closed")
+	ErrTableOccupied       = errors.New("table already has an open tab")
+	ErrTabNotOpen          = errors.New("tab is not open")
+	ErrTabNotPaid          = errors.New("payments do not cover the amount due on the tab")
+	ErrSameTab             = errors.New("cannot merge a tab into itself")
+	ErrCheckPaid           = errors.New("a check of the split is already paid")
+	ErrCheckOverpaid       = errors.New("payment exceeds what is due on the check")
+	ErrCheckNotInSplit     = errors.New("check does not belong to the order")
+	ErrOrderTypeMismatch   = errors.New("order was placed with another order type")
+	ErrSlotFull            = errors.New("the time slot is fully booked")
+	ErrCouponCodeTaken     = errors.New("coupon code is already taken")
+	ErrCouponExpired       = errors.New("coupon code is expired or deactivated")
+	ErrCouponUsedUp        = errors.New("coupon code has no redemptions left")
+	ErrCouponCustomer      = errors.New("coupon code is limited per customer, the order needs a customer")
+	ErrCouponCustomerLimit = errors.New("customer has no redemptions of the coupon code left")
+	ErrCouponRedeemed      = errors.New("the promotion of the coupon code is already redeemed on the order")
+	ErrCouponNotApplicable = errors.New("the promotion of the coupon code does not apply to the order")
+)
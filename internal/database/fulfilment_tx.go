@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// type specific data of an order, only read on the first round of the order.
+// scheduled_for is the start of the slot a pre-order is booked into
+type OrderFulfilmentParams struct {
+	PickupAt        sql.NullTime `json:"pickup_at"`
+	ScheduledFor    sql.NullTime `json:"scheduled_for"`
+	DeliveryAddress string       `json:"delivery_address"`
+	ContactName     string       `json:"contact_name"`
+	ContactPhone    string       `json:"contact_phone"`
+}
+
+// resolve the fulfilment of the order the items belong to, the first round
+// of an order records its type and details. later rounds must keep the type
+// the order was placed with. first reports whether the fulfilment was created.
+func orderFulfilment(ctx context.Context, q *Queries, arg CreateOrderTxParams) (fulfilment OrderFulfilment, first bool, err error) {
+	if len(arg.Items) == 0 {
+		return OrderFulfilment{}, false, nil
+	}
+	shopName := arg.Items[0].ShopName
+	orderID := arg.Items[0].OrderID
+
+	fulfilment, err = q.GetOrderFulfilmentForUpdate(ctx, GetOrderFulfilmentForUpdateParams{
+		ShopName: shopName,
+		OrderID:  orderID,
+	})
+	if err == nil {
+		if fulfilment.OrderType != arg.OrderType {
+			return OrderFulfilment{}, false, ErrOrderTypeMismatch
+		}
+		return fulfilment, false, nil
+	}
+	if err != sql.ErrNoRows {
+		return OrderFulfilment{}, false, err
+	}
+
+	fulfilment, err = q.CreateOrderFulfilment(ctx, CreateOrderFulfilmentParams{
+		ID:              uuid.New(),
+		ShopName:        shopName,
+		OrderID:         orderID,
+		OrderType:       arg.OrderType,
+		PickupAt:        arg.Fulfilment.PickupAt,
+		DeliveryAddress: arg.Fulfilment.DeliveryAddress,
+		ContactName:     arg.Fulfilment.ContactName,
+		ContactPhone:    arg.Fulfilment.ContactPhone,
+		ScheduledFor:    arg.Fulfilment.ScheduledFor,
+	})
+	return fulfilment, true, err
+}
+
+// charge the fees of the order type as lines of the round, flat fees only
+// on the first round of the order and percent fees on the items of every
+// round. fee lines are not prepared, so they skip stock and kitchen tickets.
+func addOrderTypeFees(ctx context.Context, q *Queries, fulfilment OrderFulfilment, round []Order, first bool) ([]Order, error) {
+	if len(round) == 0 {
+		return nil, nil
+	}
+
+	fees, err := q.ListOrderTypeFeesByType(ctx, ListOrderTypeFeesByTypeParams{
+		ShopName:  fulfilment.ShopName,
+		OrderType: fulfilment.OrderType,
+	})
+	if err != nil || len(fees) == 0 {
+		return nil, err
+	}
+
+	var subtotal int64
+	for _, orderItem := range round {
+		price, err := utils.ParseCents(orderItem.ProductPrice)
+		if err != nil {
+			return nil, err
+		}
+		subtotal += price * int64(orderItem.Amount)
+	}
+
+	var lines []Order
+	for _, fee := range fees {
+		amount, err := utils.ParseCents(fee.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		var price int64
+		switch fee.Kind {
+		case utils.FeeFlat:
+			if !first {
+				continue
+			}
+			price = amount
+		case utils.FeePercent:
+			price = utils.PercentOfCents(subtotal, amount)
+		}
+		if price == 0 {
+			continue
+		}
+
+		line, err := q.CreateOrderItem(ctx, CreateOrderItemParams{
+			ID:           uuid.New(),
+			ShopName:     fulfilment.ShopName,
+			OrderID:      fulfilment.OrderID,
+			OrderDay:     round[0].OrderDay,
+			ProductName:  fee.Name,
+			ProductPrice: utils.FormatCents(price),
+			Amount:       1,
+			Status:       utils.StatusPending,
+			TaxRate:      fee.TaxRate,
+			OrderType:    fulfilment.OrderType,
+			FeeID:        uuid.NullUUID{UUID: fee.ID, Valid: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,205 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func createRandomOrderTypeFee(t *testing.T, shop Shop, orderType, kind, amount string) OrderTypeFee {
+	arg := CreateOrderTypeFeeParams{
+		ID:        uuid.New(),
+		ShopName:  shop.Name,
+		OrderType: orderType,
+		Name:      utils.RandString(8),
+		Kind:      kind,
+		Amount:    amount,
+		TaxRate:   "5.00",
+	}
+
+	fee, err := testQueries.CreateOrderTypeFee(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, fee.Name)
+	require.Equal(t, arg.Kind, fee.Kind)
+	require.Equal(t, arg.Amount, fee.Amount)
+
+	return fee
+}
+
+func TestCreateOrderTxOrderTypeFees(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	packaging := createRandomOrderTypeFee(t, shop, utils.OrderTakeaway, utils.FeeFlat, "0.50")
+	createRandomOrderTypeFee(t, shop, utils.OrderTakeaway, utils.FeePercent, "10.00")
+	// fees of other order types are not charged
+	createRandomOrderTypeFee(t, shop, utils.OrderDelivery, utils.FeeFlat, "3.00")
+
+	orderID := uuid.New()
+	item := tabOrderItem(shop, menuItem)
+	item.OrderID = orderID
+	item.Amount = 2
+
+	result, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:     []CreateOrderItemParams{item},
+		OrderType: utils.OrderTakeaway,
+	})
+	require.NoError(t, err)
+	require.Equal(t, utils.OrderTakeaway, result.Fulfilment.OrderType)
+	require.Equal(t, utils.FulfilmentReceived, result.Fulfilment.Status)
+	require.Len(t, result.Orders, 3)
+	require.Len(t, result.Fees, 2)
+	require.Equal(t, packaging.Name, result.Fees[0].ProductName)
+	require.Equal(t, "0.50", result.Fees[0].ProductPrice)
+	require.Equal(t, "5.00", result.Fees[0].TaxRate)
+	// 10% of 2 x 5.00
+	require.Equal(t, "1.00", result.Fees[1].ProductPrice)
+	for _, orderItem := range result.Orders {
+		require.Equal(t, utils.OrderTakeaway, orderItem.OrderType)
+	}
+
+	// fee lines are not refunded on their own
+	_, err = testStore.RefundOrderItemTx(context.Background(), RefundOrderItemTxParams{ShopName: shop.Name, ID: result.Fees[0].ID})
+	require.ErrorIs(t, err, ErrAdjustmentLine)
+
+	// the packaging fee is charged once per order
+	item = tabOrderItem(shop, menuItem)
+	item.OrderID = orderID
+	result, err = testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:     []CreateOrderItemParams{item},
+		OrderType: utils.OrderTakeaway,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Fees, 1)
+	require.Equal(t, "0.50", result.Fees[0].ProductPrice)
+
+	item = tabOrderItem(shop, menuItem)
+	item.OrderID = orderID
+	_, err = testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items: []CreateOrderItemParams{item},
+	})
+	require.ErrorIs(t, err, ErrOrderTypeMismatch)
+}
+
+func TestOrderTypeFeeSales(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	packaging := createRandomOrderTypeFee(t, shop, utils.OrderTakeaway, utils.FeeFlat, "0.50")
+	createRandomOrderTypeFee(t, shop, utils.OrderTakeaway, utils.FeePercent, "-10.00")
+
+	item := tabOrderItem(shop, menuItem)
+	item.OrderID = uuid.New()
+	item.Amount = 2
+
+	result, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:     []CreateOrderItemParams{item},
+		OrderType: utils.OrderTakeaway,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Fees, 2)
+	require.Equal(t, uuid.NullUUID{UUID: packaging.ID, Valid: true}, result.Fees[0].FeeID)
+	require.False(t, result.Orders[0].FeeID.Valid)
+
+	// fees are sales, negative fees are discounts
+	sales, err := testQueries.GetDailySales(context.Background(), GetDailySalesParams{ShopName: shop.Name, OrderDay: item.OrderDay})
+	require.NoError(t, err)
+	require.Equal(t, "10.50", sales.GrossSales)
+	require.Equal(t, "1.00", sales.Discounts)
+
+	// and no products
+	mix, err := testQueries.GetProductMix(context.Background(), GetProductMixParams{
+		ShopName: shop.Name,
+		FromDay:  item.OrderDay,
+		ToDay:    item.OrderDay,
+	})
+	require.NoError(t, err)
+	require.Len(t, mix, 1)
+	require.Equal(t, menuItem.ProductName, mix[0].ProductName)
+}
+
+func TestCreateOrderTxDelivery(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+
+	item := tabOrderItem(shop, menuItem)
+	item.OrderID = uuid.New()
+
+	result, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:     []CreateOrderItemParams{item},
+		OrderType: utils.OrderDelivery,
+		Fulfilment: OrderFulfilmentParams{
+			DeliveryAddress: "1 Harbour Road",
+			ContactName:     "Sam",
+			ContactPhone:    "+85212345678",
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Fees)
+	require.Equal(t, "1 Harbour Road", result.Fulfilment.DeliveryAddress)
+	require.Equal(t, "+85212345678", result.Fulfilment.ContactPhone)
+
+	// a table is only ordered from by guests dining in
+	table := createRandomDiningTable(t, shop)
+	_, err = testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:     []CreateOrderItemParams{tabOrderItem(shop, menuItem)},
+		TableID:   table.ID,
+		OrderType: utils.OrderDelivery,
+	})
+	require.ErrorIs(t, err, ErrOrderTypeMismatch)
+}
+
+func TestSetOrderFulfilmentStatus(t *testing.T) {
+	shop := createRandomShop(t)
+	pickupAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	fulfilment, err := testQueries.CreateOrderFulfilment(context.Background(), CreateOrderFulfilmentParams{
+		ID:        uuid.New(),
+		ShopName:  shop.Name,
+		OrderID:   uuid.New(),
+		OrderType: utils.OrderPickup,
+		PickupAt:  sql.NullTime{Time: pickupAt, Valid: true},
+	})
+	require.NoError(t, err)
+	require.True(t, fulfilment.PickupAt.Time.Equal(pickupAt))
+
+	active, err := testQueries.ListActiveFulfilments(context.Background(), ListActiveFulfilmentsParams{
+		ShopName:  shop.Name,
+		OrderType: utils.OrderPickup,
+	})
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+
+	moved, err := testQueries.SetOrderFulfilmentStatus(context.Background(), SetOrderFulfilmentStatusParams{
+		Status:     utils.FulfilmentReady,
+		ShopName:   shop.Name,
+		OrderID:    fulfilment.OrderID,
+		FromStatus: utils.FulfilmentReceived,
+	})
+	require.NoError(t, err)
+	require.Equal(t, utils.FulfilmentReady, moved.Status)
+
+	// moved by someone else in the meantime
+	_, err = testQueries.SetOrderFulfilmentStatus(context.Background(), SetOrderFulfilmentStatusParams{
+		Status:     utils.FulfilmentPickedUp,
+		ShopName:   shop.Name,
+		OrderID:    fulfilment.OrderID,
+		FromStatus: utils.FulfilmentReceived,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.SetOrderFulfilmentStatus(context.Background(), SetOrderFulfilmentStatusParams{
+		Status:     utils.FulfilmentPickedUp,
+		ShopName:   shop.Name,
+		OrderID:    fulfilment.OrderID,
+		FromStatus: utils.FulfilmentReady,
+	})
+	require.NoError(t, err)
+
+	active, err = testQueries.ListActiveFulfilments(context.Background(), ListActiveFulfilmentsParams{ShopName: shop.Name})
+	require.NoError(t, err)
+	require.Empty(t, active)
+}
@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func createRandomIngredient(t *testing.T, shop Shop, unit string) Ingredient {
+	arg := CreateIngredientParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		Name:     utils.RandString(6),
+		Unit:     unit,
+	}
+
+	ingredient, err := testQueries.CreateIngredient(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, ingredient)
+
+	require.Equal(t, arg.ID, ingredient.ID)
+	require.Equal(t, arg.ShopName, ingredient.ShopName)
+	require.Equal(t, arg.Name, ingredient.Name)
+	require.Equal(t, arg.Unit, ingredient.Unit)
+	require.Equal(t, "0.000", ingredient.OnHand)
+	require.NotZero(t, ingredient.CreatedAt)
+
+	return ingredient
+}
+
+func TestCreateIngredient(t *testing.T) {
+	shop := createRandomShop(t)
+	createRandomIngredient(t, shop, utils.UnitGram)
+}
+
+func TestListIngredients(t *testing.T) {
+	shop := createRandomShop(t)
+	ingredient1 := createRandomIngredient(t, shop, utils.UnitGram)
+	ingredient2 := createRandomIngredient(t, shop, utils.UnitMilliliter)
+
+	ingredients, err := testQueries.ListIngredients(context.Background(), shop.Name)
+	require.NoError(t, err)
+	require.Len(t, ingredients, 2)
+
+	var ids []uuid.UUID
+	for _, ingredient := range ingredients {
+		ids = append(ids, ingredient.ID)
+	}
+	require.Contains(t, ids, ingredient1.ID)
+	require.Contains(t, ids, ingredient2.ID)
+}
+
+func TestAddIngredientStock(t *testing.T) {
+	shop := createRandomShop(t)
+	ingredient := createRandomIngredient(t, shop, utils.UnitGram)
+
+	arg := AddIngredientStockParams{
+		ShopName: shop.Name,
+		ID:       ingredient.ID,
+		OnHand:   "1500.500",
+	}
+
+	updated, err := testQueries.AddIngredientStock(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "1500.500", updated.OnHand)
+
+	arg.OnHand = "-500.500"
+	updated, err = testQueries.AddIngredientStock(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "1000.000", updated.OnHand)
+}
+
+func TestGetIngredientUsageReport(t *testing.T) {
+	shop := createRandomShop(t)
+	ingredient := createRandomIngredient(t, shop, utils.UnitGram)
+
+	movements := []struct {
+		movementType string
+		quantity     string
+	}{
+		{utils.MovementReceive, "1000.000"},
+		{utils.MovementSale, "-300.000"},
+		{utils.MovementRefund, "50.000"},
+		{utils.MovementWaste, "-20.000"},
+		{utils.MovementCount, "-30.000"},
+	}
+
+	for _, m := range movements {
+		_, err := testQueries.CreateIngredientMovement(context.Background(), CreateIngredientMovementParams{
+			ID:           uuid.New(),
+			ShopName:     shop.Name,
+			IngredientID: ingredient.ID,
+			MovementType: m.movementType,
+			Quantity:     m.quantity,
+		})
+		require.NoError(t, err)
+	}
+
+	arg := GetIngredientUsageReportParams{
+		FromTime: time.Now().Add(-time.Hour),
+		ToTime:   time.Now().Add(time.Hour),
+		ShopName: shop.Name,
+	}
+
+	report, err := testQueries.GetIngredientUsageReport(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+
+	require.Equal(t, ingredient.ID, report[0].IngredientID)
+	require.Equal(t, "250.000", report[0].TheoreticalUsage)
+	require.Equal(t, "300.000", report[0].ActualUsage)
+	require.Equal(t, "50.000", report[0].Variance)
+}
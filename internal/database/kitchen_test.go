@@ -0,0 +1,183 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func createRandomStation(t *testing.T, shop Shop, isDefault bool) Station {
+	arg := CreateStationParams{
+		ID:        uuid.New(),
+		ShopName:  shop.Name,
+		Name:      utils.RandString(6),
+		IsDefault: isDefault,
+	}
+
+	station, err := testQueries.CreateStation(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, station.Name)
+	require.Equal(t, arg.IsDefault, station.IsDefault)
+
+	return station
+}
+
+func TestCreateStationSingleDefault(t *testing.T) {
+	shop := createRandomShop(t)
+	createRandomStation(t, shop, true)
+	createRandomStation(t, shop, false)
+
+	_, err := testQueries.CreateStation(context.Background(), CreateStationParams{
+		ID:        uuid.New(),
+		ShopName:  shop.Name,
+		Name:      utils.RandString(6),
+		IsDefault: true,
+	})
+	require.Error(t, err)
+}
+
+func TestCreateOrderTxKitchenTickets(t *testing.T) {
+	shop := createRandomShop(t)
+	coffee := addRandomMenuItem(t, shop)
+	toast := addRandomMenuItem(t, shop)
+	eggs := addRandomMenuItem(t, shop)
+
+	bar := createRandomStation(t, shop, false)
+	grill := createRandomStation(t, shop, false)
+	pass := createRandomStation(t, shop, true)
+
+	// the product route of the coffee wins over the route of its catalog
+	_, err := testQueries.UpsertCatalogRoute(context.Background(), UpsertCatalogRouteParams{
+		ID:        uuid.New(),
+		ShopName:  shop.Name,
+		StationID: grill.ID,
+		Catalog:   sql.NullString{String: coffee.Catalog, Valid: true},
+	})
+	require.NoError(t, err)
+	_, err = testQueries.UpsertProductRoute(context.Background(), UpsertProductRouteParams{
+		ID:        uuid.New(),
+		ShopName:  shop.Name,
+		StationID: bar.ID,
+		ProductID: uuid.NullUUID{UUID: coffee.ProductID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	orderID := utils.RandOrderID()
+	newItem := func(productID uuid.UUID, name string) CreateOrderItemParams {
+		return CreateOrderItemParams{
+			ID:           uuid.New(),
+			ShopName:     shop.Name,
+			OrderID:      orderID,
+			OrderDay:     utils.FormattedDateNow(),
+			ProductName:  name,
+			ProductPrice: "5.00",
+			Amount:       1,
+			Status:       "pending",
+			ProductID:    uuid.NullUUID{UUID: productID, Valid: productID != uuid.Nil},
+			TaxRate:      "0.00",
+		}
+	}
+
+	result, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items: []CreateOrderItemParams{
+			newItem(toast.ProductID, toast.ProductName),
+			newItem(coffee.ProductID, coffee.ProductName),
+			newItem(uuid.Nil, eggs.ProductName), // matched to the menu by name
+			newItem(uuid.Nil, "off menu special"),
+		},
+		OpenItems: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Tickets, 3)
+
+	stations := map[uuid.UUID][]string{}
+	for _, ticket := range result.Tickets {
+		require.Equal(t, orderID, ticket.OrderID)
+		require.Equal(t, utils.TicketOpen, ticket.Status)
+		for _, item := range ticket.Items {
+			stations[ticket.StationID] = append(stations[ticket.StationID], item.ProductName)
+		}
+	}
+	require.Equal(t, []string{toast.ProductName, eggs.ProductName}, stations[grill.ID])
+	require.Equal(t, []string{coffee.ProductName}, stations[bar.ID])
+	require.Equal(t, []string{"off menu special"}, stations[pass.ID])
+
+	items, err := testQueries.ListKitchenTicketItems(context.Background(), []uuid.UUID{result.Tickets[0].ID})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	// the eggs are matched to their product through the menu
+	for _, menuItem := range []Menu{toast, eggs} {
+		_, err = testQueries.UpdateProductPrepTime(context.Background(), UpdateProductPrepTimeParams{
+			OrganisationID: shop.OrganisationID,
+			ID:             menuItem.ProductID,
+			PrepSeconds:    90,
+		})
+		require.NoError(t, err)
+	}
+	prep, err := testQueries.ListOpenKitchenTicketPrep(context.Background(), shop.Name)
+	require.NoError(t, err)
+	require.Len(t, prep, 3)
+	for _, ticket := range prep {
+		require.Equal(t, orderID, ticket.OrderID)
+		if ticket.StationID == grill.ID {
+			require.Equal(t, int64(180), ticket.PrepSeconds)
+		} else {
+			require.Zero(t, ticket.PrepSeconds)
+		}
+	}
+
+	// the order is ready once every station bumped its ticket
+	for i, ticket := range result.Tickets {
+		bumped, err := testQueries.SetKitchenTicketStatus(context.Background(), SetKitchenTicketStatusParams{
+			Status:   utils.TicketBumped,
+			ShopName: shop.Name,
+			ID:       ticket.ID,
+		})
+		require.NoError(t, err)
+		require.True(t, bumped.BumpedAt.Valid)
+
+		open, err := testQueries.CountOpenKitchenTickets(context.Background(), CountOpenKitchenTicketsParams{
+			ShopName: shop.Name,
+			OrderID:  orderID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(len(result.Tickets)-i-1), open)
+	}
+
+	bumped, err := testQueries.ListKitchenTickets(context.Background(), ListKitchenTicketsParams{
+		ShopName:  shop.Name,
+		Status:    utils.TicketBumped,
+		StationID: bar.ID,
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, bumped, 1)
+}
+
+func TestCreateOrderTxWithoutStations(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+
+	result, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items: []CreateOrderItemParams{
+			{
+				ID:           uuid.New(),
+				ShopName:     shop.Name,
+				OrderID:      utils.RandOrderID(),
+				OrderDay:     utils.FormattedDateNow(),
+				ProductName:  menuItem.ProductName,
+				ProductPrice: menuItem.ProductPrice,
+				Amount:       1,
+				Status:       "pending",
+				TaxRate:      "0.00",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Tickets)
+}
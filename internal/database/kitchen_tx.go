@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// kitchen ticket of one station with the order items it prepares
+type StationTicket struct {
+	KitchenTicket
+	Items []Order `json:"items"`
+}
+
+// split freshly created order items into one ticket per station, shops
+// without stations get no tickets. tickets released in the future are held
+// back from the kitchen until then
+func createKitchenTickets(ctx context.Context, q *Queries, orders []Order, releaseAt time.Time) ([]StationTicket, error) {
+	var tickets []StationTicket
+	byShop := map[string][]Order{}
+	var shopNames []string
+
+	for _, orderItem := range orders {
+		if _, ok := byShop[orderItem.ShopName]; !ok {
+			shopNames = append(shopNames, orderItem.ShopName)
+		}
+		byShop[orderItem.ShopName] = append(byShop[orderItem.ShopName], orderItem)
+	}
+
+	for _, shopName := range shopNames {
+		stations, err := q.ListStations(ctx, shopName)
+		if err != nil {
+			return nil, err
+		}
+		if len(stations) == 0 {
+			continue
+		}
+
+		routes, err := q.ListStationRoutes(ctx, shopName)
+		if err != nil {
+			return nil, err
+		}
+		menu, err := q.GetAllMenuItems(ctx, shopName)
+		if err != nil {
+			return nil, err
+		}
+
+		router := newStationRouter(stations, routes, menu)
+		for _, routed := range router.route(byShop[shopName]) {
+			ticket, err := q.CreateKitchenTicket(ctx, CreateKitchenTicketParams{
+				ID:        uuid.New(),
+				ShopName:  shopName,
+				OrderID:   routed.Items[0].OrderID,
+				StationID: routed.StationID,
+				ReleaseAt: releaseAt,
+				Released:  !releaseAt.After(time.Now()),
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, orderItem := range routed.Items {
+				err := q.AddKitchenTicketItem(ctx, AddKitchenTicketItemParams{
+					TicketID:    ticket.ID,
+					OrderItemID: orderItem.ID,
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			tickets = append(tickets, StationTicket{KitchenTicket: ticket, Items: routed.Items})
+		}
+	}
+
+	return tickets, nil
+}
+
+type routedItems struct {
+	StationID uuid.UUID
+	Items     []Order
+}
+
+// resolves the station of an order item: the route of its product, then
+// the route of its menu catalog, then the default station
+type stationRouter struct {
+	byProduct      map[uuid.UUID]uuid.UUID
+	byCatalog      map[string]uuid.UUID
+	defaultStation uuid.UUID
+	// order items only record the product, the catalog comes from the menu
+	catalogByProduct map[uuid.UUID]string
+	catalogByName    map[string]string
+}
+
+func newStationRouter(stations []Station, routes []StationRoute, menu []Menu) stationRouter {
+	router := stationRouter{
+		byProduct:        map[uuid.UUID]uuid.UUID{},
+		byCatalog:        map[string]uuid.UUID{},
+		catalogByProduct: map[uuid.UUID]string{},
+		catalogByName:    map[string]string{},
+	}
+
+	for _, station := range stations {
+		if station.IsDefault {
+			router.defaultStation = station.ID
+		}
+	}
+	for _, route := range routes {
+		if route.ProductID.Valid {
+			router.byProduct[route.ProductID.UUID] = route.StationID
+		} else if route.Catalog.Valid {
+			router.byCatalog[route.Catalog.String] = route.StationID
+		}
+	}
+	for _, menuItem := range menu {
+		router.catalogByProduct[menuItem.ProductID] = menuItem.Catalog
+		router.catalogByName[menuItem.ProductName] = menuItem.Catalog
+	}
+
+	return router
+}
+
+func (router stationRouter) station(orderItem Order) uuid.UUID {
+	if orderItem.ProductID.Valid {
+		if station, ok := router.byProduct[orderItem.ProductID.UUID]; ok {
+			return station
+		}
+	}
+
+	catalog, ok := router.catalogByProduct[orderItem.ProductID.UUID]
+	if !orderItem.ProductID.Valid || !ok {
+		catalog, ok = router.catalogByName[orderItem.ProductName]
+	}
+	if ok {
+		if station, ok := router.byCatalog[catalog]; ok {
+			return station
+		}
+	}
+
+	return router.defaultStation
+}
+
+// group items by station in the order stations first appear, items without
+// a station are left out
+func (router stationRouter) route(orders []Order) []routedItems {
+	var routed []routedItems
+	index := map[uuid.UUID]int{}
+
+	for _, orderItem := range orders {
+		station := router.station(orderItem)
+		if station == uuid.Nil {
+			continue
+		}
+
+		i, ok := index[station]
+		if !ok {
+			i = len(routed)
+			index[station] = i
+			routed = append(routed, routedItems{StationID: station})
+		}
+		routed[i].Items = append(routed[i].Items, orderItem)
+	}
+
+	return routed
+}
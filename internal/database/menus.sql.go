@@ -9,17 +9,17 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const addMenuItem = `-- name: AddMenuItem :one
-INSERT INTO menus (id, user_id, shop_name, product_id, product_name, product_price, catalog, description)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, user_id, shop_name, product_id, product_name, product_price, catalog, description, created_at
+INSERT INTO menus (id, shop_name, product_id, product_name, product_price, catalog, description)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, shop_name, product_id, product_name, product_price, catalog, description, created_at, available
 `
 
 type AddMenuItemParams struct {
 	ID           uuid.UUID `json:"id"`
-	UserID       uuid.UUID `json:"user_id"`
 	ShopName     string    `json:"shop_name"`
 	ProductID    uuid.UUID `json:"product_id"`
 	ProductName  string    `json:"product_name"`
@@ -31,7 +31,6 @@ type AddMenuItemParams struct {
 func (q *Queries) AddMenuItem(ctx context.Context, arg AddMenuItemParams) (Menu, error) {
 	row := q.db.QueryRowContext(ctx, addMenuItem,
 		arg.ID,
-		arg.UserID,
 		arg.ShopName,
 		arg.ProductID,
 		arg.ProductName,
@@ -42,7 +41,6 @@ func (q *Queries) AddMenuItem(ctx context.Context, arg AddMenuItemParams) (Menu,
 	var i Menu
 	err := row.Scan(
 		&i.ID,
-		&i.UserID,
 		&i.ShopName,
 		&i.ProductID,
 		&i.ProductName,
@@ -50,27 +48,28 @@ func (q *Queries) AddMenuItem(ctx context.Context, arg AddMenuItemParams) (Menu,
 		&i.Catalog,
 		&i.Description,
 		&i.CreatedAt,
+		&i.Available,
 	)
 	return i, err
 }
 
 const deleteMenuItem = `-- name: DeleteMenuItem :exec
 DELETE FROM menus
-WHERE user_id = $1 AND id = $2
+WHERE shop_name = $1 AND id = $2
 `
 
 type DeleteMenuItemParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	ID     uuid.UUID `json:"id"`
+	ShopName string    `json:"shop_name"`
+	ID       uuid.UUID `json:"id"`
 }
 
 func (q *Queries) DeleteMenuItem(ctx context.Context, arg DeleteMenuItemParams) error {
-	_, err := q.db.ExecContext(ctx, deleteMenuItem, arg.UserID, arg.ID)
+	_, err := q.db.ExecContext(ctx, deleteMenuItem, arg.ShopName, arg.ID)
 	return err
 }
 
 const getAllMenuItems = `-- name: GetAllMenuItems :many
-SELECT id, user_id, shop_name, product_id, product_name, product_price, catalog, description, created_at FROM menus 
+SELECT id, shop_name, product_id, product_name, product_price, catalog, description, created_at, available FROM menus 
 WHERE shop_name = $1
 `
 
@@ -85,7 +84,6 @@ func (q *Queries) GetAllMenuItems(ctx context.Context, shopName string) ([]Menu,
 		var i Menu
 		if err := rows.Scan(
 			&i.ID,
-			&i.UserID,
 			&i.ShopName,
 			&i.ProductID,
 			&i.ProductName,
@@ -93,6 +91,7 @@ func (q *Queries) GetAllMenuItems(ctx context.Context, shopName string) ([]Menu,
 			&i.Catalog,
 			&i.Description,
 			&i.CreatedAt,
+			&i.Available,
 		); err != nil {
 			return nil, err
 		}
@@ -107,15 +106,176 @@ func (q *Queries) GetAllMenuItems(ctx context.Context, shopName string) ([]Menu,
 	return items, nil
 }
 
+const getMenuItem = `-- name: GetMenuItem :one
+SELECT id, shop_name, product_id, product_name, product_price, catalog, description, created_at, available FROM menus
+WHERE shop_name = $1 AND id = $2 LIMIT 1
+`
+
+type GetMenuItemParams struct {
+	ShopName string    `json:"shop_name"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (q *Queries) GetMenuItem(ctx context.Context, arg GetMenuItemParams) (Menu, error) {
+	row := q.db.QueryRowContext(ctx, getMenuItem, arg.ShopName, arg.ID)
+	var i Menu
+	err := row.Scan(
+		&i.ID,
+		&i.ShopName,
+		&i.ProductID,
+		&i.ProductName,
+		&i.ProductPrice,
+		&i.Catalog,
+		&i.Description,
+		&i.CreatedAt,
+		&i.Available,
+	)
+	return i, err
+}
+
+const listMenuPrices = `-- name: ListMenuPrices :many
+SELECT m.product_id, m.product_name, m.product_price, COALESCE(p.tax_rate, 0.00)::numeric AS tax_rate
+FROM menus m
+LEFT JOIN products p ON p.id = m.product_id
+WHERE m.shop_name = $1
+ORDER BY m.created_at, m.id
+`
+
+type ListMenuPricesRow struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	ProductName  string    `json:"product_name"`
+	ProductPrice string    `json:"product_price"`
+	TaxRate      string    `json:"tax_rate"`
+}
+
+func (q *Queries) ListMenuPrices(ctx context.Context, shopName string) ([]ListMenuPricesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMenuPrices, shopName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListMenuPricesRow{}
+	for rows.Next() {
+		var i ListMenuPricesRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.ProductPrice,
+			&i.TaxRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnavailableMenuItems = `-- name: ListUnavailableMenuItems :many
+SELECT id, shop_name, product_id, product_name, product_price, catalog, description, created_at, available FROM menus
+WHERE shop_name = $1 AND available = false
+AND (product_id = ANY($2::uuid[]) OR product_name = ANY($3::varchar[]))
+`
+
+type ListUnavailableMenuItemsParams struct {
+	ShopName     string      `json:"shop_name"`
+	ProductIds   []uuid.UUID `json:"product_ids"`
+	ProductNames []string    `json:"product_names"`
+}
+
+func (q *Queries) ListUnavailableMenuItems(ctx context.Context, arg ListUnavailableMenuItemsParams) ([]Menu, error) {
+	rows, err := q.db.QueryContext(ctx, listUnavailableMenuItems, arg.ShopName, pq.Array(arg.ProductIds), pq.Array(arg.ProductNames))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Menu{}
+	for rows.Next() {
+		var i Menu
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopName,
+			&i.ProductID,
+			&i.ProductName,
+			&i.ProductPrice,
+			&i.Catalog,
+			&i.Description,
+			&i.CreatedAt,
+			&i.Available,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markProductSoldOut = `-- name: MarkProductSoldOut :exec
+UPDATE menus
+SET available = false
+WHERE shop_name = $1 AND product_id = $2
+`
+
+type MarkProductSoldOutParams struct {
+	ShopName  string    `json:"shop_name"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+func (q *Queries) MarkProductSoldOut(ctx context.Context, arg MarkProductSoldOutParams) error {
+	_, err := q.db.ExecContext(ctx, markProductSoldOut, arg.ShopName, arg.ProductID)
+	return err
+}
+
+const setMenuItemAvailability = `-- name: SetMenuItemAvailability :one
+UPDATE menus
+SET available = $3
+WHERE shop_name = $1 AND id = $2
+RETURNING id, shop_name, product_id, product_name, product_price, catalog, description, created_at, available
+`
+
+type SetMenuItemAvailabilityParams struct {
+	ShopName  string    `json:"shop_name"`
+	ID        uuid.UUID `json:"id"`
+	Available bool      `json:"available"`
+}
+
+func (q *Queries) SetMenuItemAvailability(ctx context.Context, arg SetMenuItemAvailabilityParams) (Menu, error) {
+	row := q.db.QueryRowContext(ctx, setMenuItemAvailability, arg.ShopName, arg.ID, arg.Available)
+	var i Menu
+	err := row.Scan(
+		&i.ID,
+		&i.ShopName,
+		&i.ProductID,
+		&i.ProductName,
+		&i.ProductPrice,
+		&i.Catalog,
+		&i.Description,
+		&i.CreatedAt,
+		&i.Available,
+	)
+	return i, err
+}
+
 const updateMenuItem = `-- name: UpdateMenuItem :one
 UPDATE menus
 SET product_name = $3, product_price = $4, catalog = $5, description = $6
-WHERE user_id = $1 AND id = $2
-RETURNING id, user_id, shop_name, product_id, product_name, product_price, catalog, description, created_at
+WHERE shop_name = $1 AND id = $2
+RETURNING id, shop_name, product_id, product_name, product_price, catalog, description, created_at, available
 `
 
 type UpdateMenuItemParams struct {
-	UserID       uuid.UUID `json:"user_id"`
+	ShopName     string    `json:"shop_name"`
 	ID           uuid.UUID `json:"id"`
 	ProductName  string    `json:"product_name"`
 	ProductPrice string    `json:"product_price"`
@@ -125,7 +285,7 @@ type UpdateMenuItemParams struct {
 
 func (q *Queries) UpdateMenuItem(ctx context.Context, arg UpdateMenuItemParams) (Menu, error) {
 	row := q.db.QueryRowContext(ctx, updateMenuItem,
-		arg.UserID,
+		arg.ShopName,
 		arg.ID,
 		arg.ProductName,
 		arg.ProductPrice,
@@ -135,7 +295,6 @@ func (q *Queries) UpdateMenuItem(ctx context.Context, arg UpdateMenuItemParams)
 	var i Menu
 	err := row.Scan(
 		&i.ID,
-		&i.UserID,
 		&i.ShopName,
 		&i.ProductID,
 		&i.ProductName,
@@ -143,6 +302,7 @@ func (q *Queries) UpdateMenuItem(ctx context.Context, arg UpdateMenuItemParams)
 		&i.Catalog,
 		&i.Description,
 		&i.CreatedAt,
+		&i.Available,
 	)
 	return i, err
 }
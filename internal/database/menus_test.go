@@ -9,16 +9,15 @@ import (
 	"github.com/toml5566/go_pos_backend/utils"
 )
 
-func addRandomMenuItem(t *testing.T, user User) Menu {
-	product1 := createRandomProduct(t, user)
+func addRandomMenuItem(t *testing.T, shop Shop) Menu {
+	product1 := createRandomProduct(t, shop)
 
 	arg := AddMenuItemParams{
 		ID:           uuid.New(),
-		UserID:       user.ID,
-		ShopName:     user.Username,
+		ShopName:     shop.Name,
 		ProductID:    product1.ID,
 		ProductName:  product1.Name,
-		ProductPrice: product1.Price,
+		ProductPrice: "5.00", // what tabOrderItem orders it at
 		Catalog:      "breakfast",
 		Description:  utils.RandString(10),
 	}
@@ -28,7 +27,6 @@ func addRandomMenuItem(t *testing.T, user User) Menu {
 	require.NotEmpty(t, menuItem)
 
 	require.Equal(t, menuItem.ID, arg.ID)
-	require.Equal(t, menuItem.UserID, arg.UserID)
 	require.Equal(t, menuItem.ShopName, arg.ShopName)
 	require.Equal(t, menuItem.ProductID, arg.ProductID)
 	require.Equal(t, menuItem.ProductName, arg.ProductName)
@@ -37,22 +35,22 @@ func addRandomMenuItem(t *testing.T, user User) Menu {
 	require.Equal(t, menuItem.Description, arg.Description)
 
 	require.NotZero(t, menuItem.CreatedAt)
+	require.True(t, menuItem.Available)
 
 	return menuItem
 }
 
 func TestAddMenuItem(t *testing.T) {
-	user := createRandomUser(t)
+	shop := createRandomShop(t)
 
-	addRandomMenuItem(t, user)
+	addRandomMenuItem(t, shop)
 }
 
 func TestUpdateMenuItem(t *testing.T) {
-	user := createRandomUser(t)
-	menuItem := addRandomMenuItem(t, user)
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
 
 	arg := UpdateMenuItemParams{
-		UserID:       user.ID,
 		ID:           menuItem.ID,
 		ProductName:  "updated",
 		ProductPrice: "1000.00",
@@ -65,7 +63,6 @@ func TestUpdateMenuItem(t *testing.T) {
 	require.NotEmpty(t, updatedMenuItem)
 
 	require.Equal(t, updatedMenuItem.ID, menuItem.ID)
-	require.Equal(t, updatedMenuItem.UserID, menuItem.UserID)
 	require.Equal(t, updatedMenuItem.ShopName, menuItem.ShopName)
 	require.Equal(t, updatedMenuItem.ProductID, menuItem.ProductID)
 	require.Equal(t, updatedMenuItem.ProductName, arg.ProductName)
@@ -77,12 +74,11 @@ func TestUpdateMenuItem(t *testing.T) {
 }
 
 func TestDeleteMenuItem(t *testing.T) {
-	user := createRandomUser(t)
-	menuItem := addRandomMenuItem(t, user)
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
 
 	arg := DeleteMenuItemParams{
-		UserID: user.ID,
-		ID:     menuItem.ID,
+		ID: menuItem.ID,
 	}
 
 	err := testQueries.DeleteMenuItem(context.Background(), arg)
@@ -94,9 +90,9 @@ func TestDeleteMenuItem(t *testing.T) {
 }
 
 func TestGetAllMenuItems(t *testing.T) {
-	user := createRandomUser(t)
-	menuItem1 := addRandomMenuItem(t, user)
-	menuItem2 := addRandomMenuItem(t, user)
+	shop := createRandomShop(t)
+	menuItem1 := addRandomMenuItem(t, shop)
+	menuItem2 := addRandomMenuItem(t, shop)
 
 	allMenuItems, err := testQueries.GetAllMenuItems(context.Background(), menuItem1.ShopName)
 	require.NoError(t, err)
@@ -105,7 +101,7 @@ func TestGetAllMenuItems(t *testing.T) {
 	var menuItemIDList []uuid.UUID
 	for _, i := range allMenuItems {
 		require.NotEmpty(t, i)
-		require.Equal(t, i.UserID, user.ID)
+		require.Equal(t, i.ShopName, shop.Name)
 
 		menuItemIDList = append(menuItemIDList, i.ID)
 	}
@@ -113,3 +109,25 @@ func TestGetAllMenuItems(t *testing.T) {
 	require.Contains(t, menuItemIDList, menuItem1.ID)
 	require.Contains(t, menuItemIDList, menuItem2.ID)
 }
+
+func TestSetMenuItemAvailability(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+
+	arg := SetMenuItemAvailabilityParams{
+		ShopName:  shop.Name,
+		ID:        menuItem.ID,
+		Available: false,
+	}
+
+	updatedItem, err := testQueries.SetMenuItemAvailability(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, menuItem.ID, updatedItem.ID)
+	require.False(t, updatedItem.Available)
+
+	// sold out items are still listed on the menu
+	allMenuItems, err := testQueries.GetAllMenuItems(context.Background(), shop.Name)
+	require.NoError(t, err)
+	require.Len(t, allMenuItems, 1)
+	require.False(t, allMenuItems[0].Available)
+}
@@ -12,6 +12,7 @@ package mockdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	database "github.com/toml5566/go_pos_backend/internal/database"
@@ -41,225 +42,3332 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddCheckLine mocks base method.
+func (m *MockStore) AddCheckLine(arg0 context.Context, arg1 database.AddCheckLineParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCheckLine", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCheckLine indicates an expected call of AddCheckLine.
+func (mr *MockStoreMockRecorder) AddCheckLine(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCheckLine", reflect.TypeOf((*MockStore)(nil).AddCheckLine), arg0, arg1)
+}
+
+// AddIngredientStock mocks base method.
+func (m *MockStore) AddIngredientStock(arg0 context.Context, arg1 database.AddIngredientStockParams) (database.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIngredientStock", arg0, arg1)
+	ret0, _ := ret[0].(database.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddIngredientStock indicates an expected call of AddIngredientStock.
+func (mr *MockStoreMockRecorder) AddIngredientStock(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIngredientStock", reflect.TypeOf((*MockStore)(nil).AddIngredientStock), arg0, arg1)
+}
+
+// AddKitchenTicketItem mocks base method.
+func (m *MockStore) AddKitchenTicketItem(arg0 context.Context, arg1 database.AddKitchenTicketItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddKitchenTicketItem", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddKitchenTicketItem indicates an expected call of AddKitchenTicketItem.
+func (mr *MockStoreMockRecorder) AddKitchenTicketItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddKitchenTicketItem", reflect.TypeOf((*MockStore)(nil).AddKitchenTicketItem), arg0, arg1)
+}
+
 // AddMenuItem mocks base method.
 func (m *MockStore) AddMenuItem(arg0 context.Context, arg1 database.AddMenuItemParams) (database.Menu, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddMenuItem", arg0, arg1)
-	ret0, _ := ret[0].(database.Menu)
+	ret := m.ctrl.Call(m, "AddMenuItem", arg0, arg1)
+	ret0, _ := ret[0].(database.Menu)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMenuItem indicates an expected call of AddMenuItem.
+func (mr *MockStoreMockRecorder) AddMenuItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMenuItem", reflect.TypeOf((*MockStore)(nil).AddMenuItem), arg0, arg1)
+}
+
+// AddOpeningHours mocks base method.
+func (m *MockStore) AddOpeningHours(arg0 context.Context, arg1 database.AddOpeningHoursParams) (database.OpeningHour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOpeningHours", arg0, arg1)
+	ret0, _ := ret[0].(database.OpeningHour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOpeningHours indicates an expected call of AddOpeningHours.
+func (mr *MockStoreMockRecorder) AddOpeningHours(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOpeningHours", reflect.TypeOf((*MockStore)(nil).AddOpeningHours), arg0, arg1)
+}
+
+// AddOrganisationMember mocks base method.
+func (m *MockStore) AddOrganisationMember(arg0 context.Context, arg1 database.AddOrganisationMemberParams) (database.OrganisationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrganisationMember", arg0, arg1)
+	ret0, _ := ret[0].(database.OrganisationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOrganisationMember indicates an expected call of AddOrganisationMember.
+func (mr *MockStoreMockRecorder) AddOrganisationMember(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrganisationMember", reflect.TypeOf((*MockStore)(nil).AddOrganisationMember), arg0, arg1)
+}
+
+// AddPriceListPrice mocks base method.
+func (m *MockStore) AddPriceListPrice(arg0 context.Context, arg1 database.AddPriceListPriceParams) (database.PriceListPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPriceListPrice", arg0, arg1)
+	ret0, _ := ret[0].(database.PriceListPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPriceListPrice indicates an expected call of AddPriceListPrice.
+func (mr *MockStoreMockRecorder) AddPriceListPrice(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPriceListPrice", reflect.TypeOf((*MockStore)(nil).AddPriceListPrice), arg0, arg1)
+}
+
+// AddPriceListRule mocks base method.
+func (m *MockStore) AddPriceListRule(arg0 context.Context, arg1 database.AddPriceListRuleParams) (database.PriceListRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPriceListRule", arg0, arg1)
+	ret0, _ := ret[0].(database.PriceListRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPriceListRule indicates an expected call of AddPriceListRule.
+func (mr *MockStoreMockRecorder) AddPriceListRule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPriceListRule", reflect.TypeOf((*MockStore)(nil).AddPriceListRule), arg0, arg1)
+}
+
+// AddStockLevel mocks base method.
+func (m *MockStore) AddStockLevel(arg0 context.Context, arg1 database.AddStockLevelParams) (database.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStockLevel", arg0, arg1)
+	ret0, _ := ret[0].(database.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddStockLevel indicates an expected call of AddStockLevel.
+func (mr *MockStoreMockRecorder) AddStockLevel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStockLevel", reflect.TypeOf((*MockStore)(nil).AddStockLevel), arg0, arg1)
+}
+
+// BookOrderSlot mocks base method.
+func (m *MockStore) BookOrderSlot(arg0 context.Context, arg1 database.BookOrderSlotParams) (database.OrderSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookOrderSlot", arg0, arg1)
+	ret0, _ := ret[0].(database.OrderSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BookOrderSlot indicates an expected call of BookOrderSlot.
+func (mr *MockStoreMockRecorder) BookOrderSlot(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookOrderSlot", reflect.TypeOf((*MockStore)(nil).BookOrderSlot), arg0, arg1)
+}
+
+// ClaimPrintJobs mocks base method.
+func (m *MockStore) ClaimPrintJobs(arg0 context.Context, arg1 int32) ([]database.PrintJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPrintJobs", arg0, arg1)
+	ret0, _ := ret[0].([]database.PrintJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPrintJobs indicates an expected call of ClaimPrintJobs.
+func (mr *MockStoreMockRecorder) ClaimPrintJobs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPrintJobs", reflect.TypeOf((*MockStore)(nil).ClaimPrintJobs), arg0, arg1)
+}
+
+// CloseDayTx mocks base method.
+func (m *MockStore) CloseDayTx(arg0 context.Context, arg1 database.DailySalesSummaryParams) (database.ZReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseDayTx", arg0, arg1)
+	ret0, _ := ret[0].(database.ZReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseDayTx indicates an expected call of CloseDayTx.
+func (mr *MockStoreMockRecorder) CloseDayTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseDayTx", reflect.TypeOf((*MockStore)(nil).CloseDayTx), arg0, arg1)
+}
+
+// CloseTab mocks base method.
+func (m *MockStore) CloseTab(arg0 context.Context, arg1 database.CloseTabParams) (database.Tab, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseTab", arg0, arg1)
+	ret0, _ := ret[0].(database.Tab)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseTab indicates an expected call of CloseTab.
+func (mr *MockStoreMockRecorder) CloseTab(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseTab", reflect.TypeOf((*MockStore)(nil).CloseTab), arg0, arg1)
+}
+
+// CloseTabTx mocks base method.
+func (m *MockStore) CloseTabTx(arg0 context.Context, arg1 database.CloseTabTxParams) (database.CloseTabTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseTabTx", arg0, arg1)
+	ret0, _ := ret[0].(database.CloseTabTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseTabTx indicates an expected call of CloseTabTx.
+func (mr *MockStoreMockRecorder) CloseTabTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseTabTx", reflect.TypeOf((*MockStore)(nil).CloseTabTx), arg0, arg1)
+}
+
+// CountCheckPayments mocks base method.
+func (m *MockStore) CountCheckPayments(arg0 context.Context, arg1 database.CountCheckPaymentsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCheckPayments", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCheckPayments indicates an expected call of CountCheckPayments.
+func (mr *MockStoreMockRecorder) CountCheckPayments(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCheckPayments", reflect.TypeOf((*MockStore)(nil).CountCheckPayments), arg0, arg1)
+}
+
+// CountCustomerRedemptions mocks base method.
+func (m *MockStore) CountCustomerRedemptions(arg0 context.Context, arg1 database.CountCustomerRedemptionsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCustomerRedemptions", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCustomerRedemptions indicates an expected call of CountCustomerRedemptions.
+func (mr *MockStoreMockRecorder) CountCustomerRedemptions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCustomerRedemptions", reflect.TypeOf((*MockStore)(nil).CountCustomerRedemptions), arg0, arg1)
+}
+
+// CountOpenKitchenTickets mocks base method.
+func (m *MockStore) CountOpenKitchenTickets(arg0 context.Context, arg1 database.CountOpenKitchenTicketsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenKitchenTickets", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenKitchenTickets indicates an expected call of CountOpenKitchenTickets.
+func (mr *MockStoreMockRecorder) CountOpenKitchenTickets(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenKitchenTickets", reflect.TypeOf((*MockStore)(nil).CountOpenKitchenTickets), arg0, arg1)
+}
+
+// CreateCheck mocks base method.
+func (m *MockStore) CreateCheck(arg0 context.Context, arg1 database.CreateCheckParams) (database.Check, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCheck", arg0, arg1)
+	ret0, _ := ret[0].(database.Check)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCheck indicates an expected call of CreateCheck.
+func (mr *MockStoreMockRecorder) CreateCheck(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheck", reflect.TypeOf((*MockStore)(nil).CreateCheck), arg0, arg1)
+}
+
+// CreateCoupon mocks base method.
+func (m *MockStore) CreateCoupon(arg0 context.Context, arg1 database.CreateCouponParams) (database.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCoupon", arg0, arg1)
+	ret0, _ := ret[0].(database.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCoupon indicates an expected call of CreateCoupon.
+func (mr *MockStoreMockRecorder) CreateCoupon(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCoupon", reflect.TypeOf((*MockStore)(nil).CreateCoupon), arg0, arg1)
+}
+
+// CreateCouponBatchTx mocks base method.
+func (m *MockStore) CreateCouponBatchTx(arg0 context.Context, arg1 database.CreateCouponBatchTxParams) (database.CouponBatchTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCouponBatchTx", arg0, arg1)
+	ret0, _ := ret[0].(database.CouponBatchTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCouponBatchTx indicates an expected call of CreateCouponBatchTx.
+func (mr *MockStoreMockRecorder) CreateCouponBatchTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCouponBatchTx", reflect.TypeOf((*MockStore)(nil).CreateCouponBatchTx), arg0, arg1)
+}
+
+// CreateCouponRedemption mocks base method.
+func (m *MockStore) CreateCouponRedemption(arg0 context.Context, arg1 database.CreateCouponRedemptionParams) (database.CouponRedemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCouponRedemption", arg0, arg1)
+	ret0, _ := ret[0].(database.CouponRedemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCouponRedemption indicates an expected call of CreateCouponRedemption.
+func (mr *MockStoreMockRecorder) CreateCouponRedemption(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCouponRedemption", reflect.TypeOf((*MockStore)(nil).CreateCouponRedemption), arg0, arg1)
+}
+
+// CreateDelayedPrintJob mocks base method.
+func (m *MockStore) CreateDelayedPrintJob(arg0 context.Context, arg1 database.CreateDelayedPrintJobParams) (database.PrintJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelayedPrintJob", arg0, arg1)
+	ret0, _ := ret[0].(database.PrintJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDelayedPrintJob indicates an expected call of CreateDelayedPrintJob.
+func (mr *MockStoreMockRecorder) CreateDelayedPrintJob(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelayedPrintJob", reflect.TypeOf((*MockStore)(nil).CreateDelayedPrintJob), arg0, arg1)
+}
+
+// CreateDiningTable mocks base method.
+func (m *MockStore) CreateDiningTable(arg0 context.Context, arg1 database.CreateDiningTableParams) (database.DiningTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDiningTable", arg0, arg1)
+	ret0, _ := ret[0].(database.DiningTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDiningTable indicates an expected call of CreateDiningTable.
+func (mr *MockStoreMockRecorder) CreateDiningTable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDiningTable", reflect.TypeOf((*MockStore)(nil).CreateDiningTable), arg0, arg1)
+}
+
+// CreateFloorArea mocks base method.
+func (m *MockStore) CreateFloorArea(arg0 context.Context, arg1 database.CreateFloorAreaParams) (database.FloorArea, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFloorArea", arg0, arg1)
+	ret0, _ := ret[0].(database.FloorArea)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFloorArea indicates an expected call of CreateFloorArea.
+func (mr *MockStoreMockRecorder) CreateFloorArea(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFloorArea", reflect.TypeOf((*MockStore)(nil).CreateFloorArea), arg0, arg1)
+}
+
+// CreateIngredient mocks base method.
+func (m *MockStore) CreateIngredient(arg0 context.Context, arg1 database.CreateIngredientParams) (database.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngredient", arg0, arg1)
+	ret0, _ := ret[0].(database.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngredient indicates an expected call of CreateIngredient.
+func (mr *MockStoreMockRecorder) CreateIngredient(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredient", reflect.TypeOf((*MockStore)(nil).CreateIngredient), arg0, arg1)
+}
+
+// CreateIngredientMovement mocks base method.
+func (m *MockStore) CreateIngredientMovement(arg0 context.Context, arg1 database.CreateIngredientMovementParams) (database.IngredientMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngredientMovement", arg0, arg1)
+	ret0, _ := ret[0].(database.IngredientMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngredientMovement indicates an expected call of CreateIngredientMovement.
+func (mr *MockStoreMockRecorder) CreateIngredientMovement(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredientMovement", reflect.TypeOf((*MockStore)(nil).CreateIngredientMovement), arg0, arg1)
+}
+
+// CreateKitchenTicket mocks base method.
+func (m *MockStore) CreateKitchenTicket(arg0 context.Context, arg1 database.CreateKitchenTicketParams) (database.KitchenTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKitchenTicket", arg0, arg1)
+	ret0, _ := ret[0].(database.KitchenTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKitchenTicket indicates an expected call of CreateKitchenTicket.
+func (mr *MockStoreMockRecorder) CreateKitchenTicket(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKitchenTicket", reflect.TypeOf((*MockStore)(nil).CreateKitchenTicket), arg0, arg1)
+}
+
+// CreateOpeningException mocks base method.
+func (m *MockStore) CreateOpeningException(arg0 context.Context, arg1 database.CreateOpeningExceptionParams) (database.OpeningException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOpeningException", arg0, arg1)
+	ret0, _ := ret[0].(database.OpeningException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOpeningException indicates an expected call of CreateOpeningException.
+func (mr *MockStoreMockRecorder) CreateOpeningException(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOpeningException", reflect.TypeOf((*MockStore)(nil).CreateOpeningException), arg0, arg1)
+}
+
+// CreateOrderFulfilment mocks base method.
+func (m *MockStore) CreateOrderFulfilment(arg0 context.Context, arg1 database.CreateOrderFulfilmentParams) (database.OrderFulfilment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderFulfilment", arg0, arg1)
+	ret0, _ := ret[0].(database.OrderFulfilment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderFulfilment indicates an expected call of CreateOrderFulfilment.
+func (mr *MockStoreMockRecorder) CreateOrderFulfilment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderFulfilment", reflect.TypeOf((*MockStore)(nil).CreateOrderFulfilment), arg0, arg1)
+}
+
+// CreateOrderItem mocks base method.
+func (m *MockStore) CreateOrderItem(arg0 context.Context, arg1 database.CreateOrderItemParams) (database.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderItem", arg0, arg1)
+	ret0, _ := ret[0].(database.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderItem indicates an expected call of CreateOrderItem.
+func (mr *MockStoreMockRecorder) CreateOrderItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItem", reflect.TypeOf((*MockStore)(nil).CreateOrderItem), arg0, arg1)
+}
+
+// CreateOrderTx mocks base method.
+func (m *MockStore) CreateOrderTx(arg0 context.Context, arg1 database.CreateOrderTxParams) (database.CreateOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(database.CreateOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderTx indicates an expected call of CreateOrderTx.
+func (mr *MockStoreMockRecorder) CreateOrderTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderTx", reflect.TypeOf((*MockStore)(nil).CreateOrderTx), arg0, arg1)
+}
+
+// CreateOrderTypeFee mocks base method.
+func (m *MockStore) CreateOrderTypeFee(arg0 context.Context, arg1 database.CreateOrderTypeFeeParams) (database.OrderTypeFee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderTypeFee", arg0, arg1)
+	ret0, _ := ret[0].(database.OrderTypeFee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderTypeFee indicates an expected call of CreateOrderTypeFee.
+func (mr *MockStoreMockRecorder) CreateOrderTypeFee(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderTypeFee", reflect.TypeOf((*MockStore)(nil).CreateOrderTypeFee), arg0, arg1)
+}
+
+// CreateOrganisation mocks base method.
+func (m *MockStore) CreateOrganisation(arg0 context.Context, arg1 database.CreateOrganisationParams) (database.Organisation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganisation", arg0, arg1)
+	ret0, _ := ret[0].(database.Organisation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganisation indicates an expected call of CreateOrganisation.
+func (mr *MockStoreMockRecorder) CreateOrganisation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganisation", reflect.TypeOf((*MockStore)(nil).CreateOrganisation), arg0, arg1)
+}
+
+// CreateOrganisationTx mocks base method.
+func (m *MockStore) CreateOrganisationTx(arg0 context.Context, arg1 database.CreateOrganisationTxParams) (database.CreateOrganisationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganisationTx", arg0, arg1)
+	ret0, _ := ret[0].(database.CreateOrganisationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganisationTx indicates an expected call of CreateOrganisationTx.
+func (mr *MockStoreMockRecorder) CreateOrganisationTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganisationTx", reflect.TypeOf((*MockStore)(nil).CreateOrganisationTx), arg0, arg1)
+}
+
+// CreatePayment mocks base method.
+func (m *MockStore) CreatePayment(arg0 context.Context, arg1 database.CreatePaymentParams) (database.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", arg0, arg1)
+	ret0, _ := ret[0].(database.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockStoreMockRecorder) CreatePayment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockStore)(nil).CreatePayment), arg0, arg1)
+}
+
+// CreatePaymentTx mocks base method.
+func (m *MockStore) CreatePaymentTx(arg0 context.Context, arg1 database.CreatePaymentParams) (database.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentTx", arg0, arg1)
+	ret0, _ := ret[0].(database.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentTx indicates an expected call of CreatePaymentTx.
+func (mr *MockStoreMockRecorder) CreatePaymentTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentTx", reflect.TypeOf((*MockStore)(nil).CreatePaymentTx), arg0, arg1)
+}
+
+// CreatePriceList mocks base method.
+func (m *MockStore) CreatePriceList(arg0 context.Context, arg1 database.CreatePriceListParams) (database.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceList", arg0, arg1)
+	ret0, _ := ret[0].(database.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePriceList indicates an expected call of CreatePriceList.
+func (mr *MockStoreMockRecorder) CreatePriceList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceList", reflect.TypeOf((*MockStore)(nil).CreatePriceList), arg0, arg1)
+}
+
+// CreatePriceListTx mocks base method.
+func (m *MockStore) CreatePriceListTx(arg0 context.Context, arg1 database.SetPriceListTxParams) (database.PriceListTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceListTx", arg0, arg1)
+	ret0, _ := ret[0].(database.PriceListTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePriceListTx indicates an expected call of CreatePriceListTx.
+func (mr *MockStoreMockRecorder) CreatePriceListTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceListTx", reflect.TypeOf((*MockStore)(nil).CreatePriceListTx), arg0, arg1)
+}
+
+// CreatePrintJob mocks base method.
+func (m *MockStore) CreatePrintJob(arg0 context.Context, arg1 database.CreatePrintJobParams) (database.PrintJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePrintJob", arg0, arg1)
+	ret0, _ := ret[0].(database.PrintJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePrintJob indicates an expected call of CreatePrintJob.
+func (mr *MockStoreMockRecorder) CreatePrintJob(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePrintJob", reflect.TypeOf((*MockStore)(nil).CreatePrintJob), arg0, arg1)
+}
+
+// CreatePrinter mocks base method.
+func (m *MockStore) CreatePrinter(arg0 context.Context, arg1 database.CreatePrinterParams) (database.Printer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePrinter", arg0, arg1)
+	ret0, _ := ret[0].(database.Printer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePrinter indicates an expected call of CreatePrinter.
+func (mr *MockStoreMockRecorder) CreatePrinter(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePrinter", reflect.TypeOf((*MockStore)(nil).CreatePrinter), arg0, arg1)
+}
+
+// CreateProduct mocks base method.
+func (m *MockStore) CreateProduct(arg0 context.Context, arg1 database.CreateProductParams) (database.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProduct", arg0, arg1)
+	ret0, _ := ret[0].(database.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *MockStoreMockRecorder) CreateProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockStore)(nil).CreateProduct), arg0, arg1)
+}
+
+// CreateProductCost mocks base method.
+func (m *MockStore) CreateProductCost(arg0 context.Context, arg1 database.CreateProductCostParams) (database.ProductCost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductCost", arg0, arg1)
+	ret0, _ := ret[0].(database.ProductCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductCost indicates an expected call of CreateProductCost.
+func (mr *MockStoreMockRecorder) CreateProductCost(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductCost", reflect.TypeOf((*MockStore)(nil).CreateProductCost), arg0, arg1)
+}
+
+// CreatePromotion mocks base method.
+func (m *MockStore) CreatePromotion(arg0 context.Context, arg1 database.CreatePromotionParams) (database.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", arg0, arg1)
+	ret0, _ := ret[0].(database.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockStoreMockRecorder) CreatePromotion(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockStore)(nil).CreatePromotion), arg0, arg1)
+}
+
+// CreatePurchaseOrder mocks base method.
+func (m *MockStore) CreatePurchaseOrder(arg0 context.Context, arg1 database.CreatePurchaseOrderParams) (database.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrder", arg0, arg1)
+	ret0, _ := ret[0].(database.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrder indicates an expected call of CreatePurchaseOrder.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrder", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrder), arg0, arg1)
+}
+
+// CreatePurchaseOrderLine mocks base method.
+func (m *MockStore) CreatePurchaseOrderLine(arg0 context.Context, arg1 database.CreatePurchaseOrderLineParams) (database.PurchaseOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrderLine", arg0, arg1)
+	ret0, _ := ret[0].(database.PurchaseOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrderLine indicates an expected call of CreatePurchaseOrderLine.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrderLine(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrderLine", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrderLine), arg0, arg1)
+}
+
+// CreatePurchaseOrderTx mocks base method.
+func (m *MockStore) CreatePurchaseOrderTx(arg0 context.Context, arg1 database.CreatePurchaseOrderTxParams) (database.PurchaseOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(database.PurchaseOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrderTx indicates an expected call of CreatePurchaseOrderTx.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrderTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrderTx", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrderTx), arg0, arg1)
+}
+
+// CreateRecipeItem mocks base method.
+func (m *MockStore) CreateRecipeItem(arg0 context.Context, arg1 database.CreateRecipeItemParams) (database.RecipeItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecipeItem", arg0, arg1)
+	ret0, _ := ret[0].(database.RecipeItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecipeItem indicates an expected call of CreateRecipeItem.
+func (mr *MockStoreMockRecorder) CreateRecipeItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipeItem", reflect.TypeOf((*MockStore)(nil).CreateRecipeItem), arg0, arg1)
+}
+
+// CreateShop mocks base method.
+func (m *MockStore) CreateShop(arg0 context.Context, arg1 database.CreateShopParams) (database.Shop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShop", arg0, arg1)
+	ret0, _ := ret[0].(database.Shop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShop indicates an expected call of CreateShop.
+func (mr *MockStoreMockRecorder) CreateShop(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShop", reflect.TypeOf((*MockStore)(nil).CreateShop), arg0, arg1)
+}
+
+// CreateStation mocks base method.
+func (m *MockStore) CreateStation(arg0 context.Context, arg1 database.CreateStationParams) (database.Station, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStation", arg0, arg1)
+	ret0, _ := ret[0].(database.Station)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStation indicates an expected call of CreateStation.
+func (mr *MockStoreMockRecorder) CreateStation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStation", reflect.TypeOf((*MockStore)(nil).CreateStation), arg0, arg1)
+}
+
+// CreateStockAlert mocks base method.
+func (m *MockStore) CreateStockAlert(arg0 context.Context, arg1 database.CreateStockAlertParams) (database.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockAlert", arg0, arg1)
+	ret0, _ := ret[0].(database.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockAlert indicates an expected call of CreateStockAlert.
+func (mr *MockStoreMockRecorder) CreateStockAlert(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockAlert", reflect.TypeOf((*MockStore)(nil).CreateStockAlert), arg0, arg1)
+}
+
+// CreateStockMovement mocks base method.
+func (m *MockStore) CreateStockMovement(arg0 context.Context, arg1 database.CreateStockMovementParams) (database.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockMovement", arg0, arg1)
+	ret0, _ := ret[0].(database.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockMovement indicates an expected call of CreateStockMovement.
+func (mr *MockStoreMockRecorder) CreateStockMovement(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockMovement", reflect.TypeOf((*MockStore)(nil).CreateStockMovement), arg0, arg1)
+}
+
+// CreateSupplier mocks base method.
+func (m *MockStore) CreateSupplier(arg0 context.Context, arg1 database.CreateSupplierParams) (database.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSupplier", arg0, arg1)
+	ret0, _ := ret[0].(database.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSupplier indicates an expected call of CreateSupplier.
+func (mr *MockStoreMockRecorder) CreateSupplier(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSupplier", reflect.TypeOf((*MockStore)(nil).CreateSupplier), arg0, arg1)
+}
+
+// CreateTab mocks base method.
+func (m *MockStore) CreateTab(arg0 context.Context, arg1 database.CreateTabParams) (database.Tab, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTab", arg0, arg1)
+	ret0, _ := ret[0].(database.Tab)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTab indicates an expected call of CreateTab.
+func (mr *MockStoreMockRecorder) CreateTab(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTab", reflect.TypeOf((*MockStore)(nil).CreateTab), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 database.CreateUserParams) (database.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(database.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// CreateZReport mocks base method.
+func (m *MockStore) CreateZReport(arg0 context.Context, arg1 database.CreateZReportParams) (database.ZReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateZReport", arg0, arg1)
+	ret0, _ := ret[0].(database.ZReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateZReport indicates an expected call of CreateZReport.
+func (mr *MockStoreMockRecorder) CreateZReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateZReport", reflect.TypeOf((*MockStore)(nil).CreateZReport), arg0, arg1)
+}
+
+// DailySalesSummary mocks base method.
+func (m *MockStore) DailySalesSummary(arg0 context.Context, arg1 database.DailySalesSummaryParams) (database.DailySalesSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DailySalesSummary", arg0, arg1)
+	ret0, _ := ret[0].(database.DailySalesSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DailySalesSummary indicates an expected call of DailySalesSummary.
+func (mr *MockStoreMockRecorder) DailySalesSummary(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DailySalesSummary", reflect.TypeOf((*MockStore)(nil).DailySalesSummary), arg0, arg1)
+}
+
+// DeleteChecks mocks base method.
+func (m *MockStore) DeleteChecks(arg0 context.Context, arg1 database.DeleteChecksParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecks", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChecks indicates an expected call of DeleteChecks.
+func (mr *MockStoreMockRecorder) DeleteChecks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecks", reflect.TypeOf((*MockStore)(nil).DeleteChecks), arg0, arg1)
+}
+
+// DeleteDiningTable mocks base method.
+func (m *MockStore) DeleteDiningTable(arg0 context.Context, arg1 database.DeleteDiningTableParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDiningTable", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDiningTable indicates an expected call of DeleteDiningTable.
+func (mr *MockStoreMockRecorder) DeleteDiningTable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDiningTable", reflect.TypeOf((*MockStore)(nil).DeleteDiningTable), arg0, arg1)
+}
+
+// DeleteFloorArea mocks base method.
+func (m *MockStore) DeleteFloorArea(arg0 context.Context, arg1 database.DeleteFloorAreaParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFloorArea", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFloorArea indicates an expected call of DeleteFloorArea.
+func (mr *MockStoreMockRecorder) DeleteFloorArea(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFloorArea", reflect.TypeOf((*MockStore)(nil).DeleteFloorArea), arg0, arg1)
+}
+
+// DeleteMenuItem mocks base method.
+func (m *MockStore) DeleteMenuItem(arg0 context.Context, arg1 database.DeleteMenuItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMenuItem", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMenuItem indicates an expected call of DeleteMenuItem.
+func (mr *MockStoreMockRecorder) DeleteMenuItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMenuItem", reflect.TypeOf((*MockStore)(nil).DeleteMenuItem), arg0, arg1)
+}
+
+// DeleteOpeningException mocks base method.
+func (m *MockStore) DeleteOpeningException(arg0 context.Context, arg1 database.DeleteOpeningExceptionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOpeningException", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOpeningException indicates an expected call of DeleteOpeningException.
+func (mr *MockStoreMockRecorder) DeleteOpeningException(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOpeningException", reflect.TypeOf((*MockStore)(nil).DeleteOpeningException), arg0, arg1)
+}
+
+// DeleteOpeningHours mocks base method.
+func (m *MockStore) DeleteOpeningHours(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOpeningHours", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOpeningHours indicates an expected call of DeleteOpeningHours.
+func (mr *MockStoreMockRecorder) DeleteOpeningHours(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOpeningHours", reflect.TypeOf((*MockStore)(nil).DeleteOpeningHours), arg0, arg1)
+}
+
+// DeleteOrderItem mocks base method.
+func (m *MockStore) DeleteOrderItem(arg0 context.Context, arg1 database.DeleteOrderItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrderItem", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrderItem indicates an expected call of DeleteOrderItem.
+func (mr *MockStoreMockRecorder) DeleteOrderItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrderItem", reflect.TypeOf((*MockStore)(nil).DeleteOrderItem), arg0, arg1)
+}
+
+// DeleteOrderItemTx mocks base method.
+func (m *MockStore) DeleteOrderItemTx(arg0 context.Context, arg1 database.DeleteOrderItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrderItemTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrderItemTx indicates an expected call of DeleteOrderItemTx.
+func (mr *MockStoreMockRecorder) DeleteOrderItemTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrderItemTx", reflect.TypeOf((*MockStore)(nil).DeleteOrderItemTx), arg0, arg1)
+}
+
+// DeleteOrderTypeFee mocks base method.
+func (m *MockStore) DeleteOrderTypeFee(arg0 context.Context, arg1 database.DeleteOrderTypeFeeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrderTypeFee", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrderTypeFee indicates an expected call of DeleteOrderTypeFee.
+func (mr *MockStoreMockRecorder) DeleteOrderTypeFee(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrderTypeFee", reflect.TypeOf((*MockStore)(nil).DeleteOrderTypeFee), arg0, arg1)
+}
+
+// DeleteOrganisationMember mocks base method.
+func (m *MockStore) DeleteOrganisationMember(arg0 context.Context, arg1 database.DeleteOrganisationMemberParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganisationMember", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOrganisationMember indicates an expected call of DeleteOrganisationMember.
+func (mr *MockStoreMockRecorder) DeleteOrganisationMember(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganisationMember", reflect.TypeOf((*MockStore)(nil).DeleteOrganisationMember), arg0, arg1)
+}
+
+// DeletePriceList mocks base method.
+func (m *MockStore) DeletePriceList(arg0 context.Context, arg1 database.DeletePriceListParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePriceList", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePriceList indicates an expected call of DeletePriceList.
+func (mr *MockStoreMockRecorder) DeletePriceList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePriceList", reflect.TypeOf((*MockStore)(nil).DeletePriceList), arg0, arg1)
+}
+
+// DeletePriceListPrices mocks base method.
+func (m *MockStore) DeletePriceListPrices(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePriceListPrices", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePriceListPrices indicates an expected call of DeletePriceListPrices.
+func (mr *MockStoreMockRecorder) DeletePriceListPrices(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePriceListPrices", reflect.TypeOf((*MockStore)(nil).DeletePriceListPrices), arg0, arg1)
+}
+
+// DeletePriceListRules mocks base method.
+func (m *MockStore) DeletePriceListRules(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePriceListRules", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePriceListRules indicates an expected call of DeletePriceListRules.
+func (mr *MockStoreMockRecorder) DeletePriceListRules(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePriceListRules", reflect.TypeOf((*MockStore)(nil).DeletePriceListRules), arg0, arg1)
+}
+
+// DeletePrinter mocks base method.
+func (m *MockStore) DeletePrinter(arg0 context.Context, arg1 database.DeletePrinterParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrinter", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePrinter indicates an expected call of DeletePrinter.
+func (mr *MockStoreMockRecorder) DeletePrinter(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrinter", reflect.TypeOf((*MockStore)(nil).DeletePrinter), arg0, arg1)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockStore) DeleteProduct(arg0 context.Context, arg1 database.DeleteProductParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockStoreMockRecorder) DeleteProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockStore)(nil).DeleteProduct), arg0, arg1)
+}
+
+// DeletePromotion mocks base method.
+func (m *MockStore) DeletePromotion(arg0 context.Context, arg1 database.DeletePromotionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePromotion", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePromotion indicates an expected call of DeletePromotion.
+func (mr *MockStoreMockRecorder) DeletePromotion(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePromotion", reflect.TypeOf((*MockStore)(nil).DeletePromotion), arg0, arg1)
+}
+
+// DeleteRecipeItems mocks base method.
+func (m *MockStore) DeleteRecipeItems(arg0 context.Context, arg1 database.DeleteRecipeItemsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeItems", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeItems indicates an expected call of DeleteRecipeItems.
+func (mr *MockStoreMockRecorder) DeleteRecipeItems(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeItems", reflect.TypeOf((*MockStore)(nil).DeleteRecipeItems), arg0, arg1)
+}
+
+// DeleteStation mocks base method.
+func (m *MockStore) DeleteStation(arg0 context.Context, arg1 database.DeleteStationParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStation", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStation indicates an expected call of DeleteStation.
+func (mr *MockStoreMockRecorder) DeleteStation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStation", reflect.TypeOf((*MockStore)(nil).DeleteStation), arg0, arg1)
+}
+
+// DeleteStationRoute mocks base method.
+func (m *MockStore) DeleteStationRoute(arg0 context.Context, arg1 database.DeleteStationRouteParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStationRoute", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStationRoute indicates an expected call of DeleteStationRoute.
+func (mr *MockStoreMockRecorder) DeleteStationRoute(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStationRoute", reflect.TypeOf((*MockStore)(nil).DeleteStationRoute), arg0, arg1)
+}
+
+// DeleteUser mocks base method.
+func (m *MockStore) DeleteUser(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockStoreMockRecorder) DeleteUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), arg0, arg1)
+}
+
+// GetAccountMapping mocks base method.
+func (m *MockStore) GetAccountMapping(arg0 context.Context, arg1 string) (database.AccountMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountMapping", arg0, arg1)
+	ret0, _ := ret[0].(database.AccountMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountMapping indicates an expected call of GetAccountMapping.
+func (mr *MockStoreMockRecorder) GetAccountMapping(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountMapping", reflect.TypeOf((*MockStore)(nil).GetAccountMapping), arg0, arg1)
+}
+
+// GetAllMenuItems mocks base method.
+func (m *MockStore) GetAllMenuItems(arg0 context.Context, arg1 string) ([]database.Menu, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllMenuItems", arg0, arg1)
+	ret0, _ := ret[0].([]database.Menu)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllMenuItems indicates an expected call of GetAllMenuItems.
+func (mr *MockStoreMockRecorder) GetAllMenuItems(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllMenuItems", reflect.TypeOf((*MockStore)(nil).GetAllMenuItems), arg0, arg1)
+}
+
+// GetAllProducts mocks base method.
+func (m *MockStore) GetAllProducts(arg0 context.Context, arg1 uuid.UUID) ([]database.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllProducts", arg0, arg1)
+	ret0, _ := ret[0].([]database.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllProducts indicates an expected call of GetAllProducts.
+func (mr *MockStoreMockRecorder) GetAllProducts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockStore)(nil).GetAllProducts), arg0, arg1)
+}
+
+// GetCategoryMix mocks base method.
+func (m *MockStore) GetCategoryMix(arg0 context.Context, arg1 database.GetCategoryMixParams) ([]database.GetCategoryMixRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryMix", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetCategoryMixRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryMix indicates an expected call of GetCategoryMix.
+func (mr *MockStoreMockRecorder) GetCategoryMix(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryMix", reflect.TypeOf((*MockStore)(nil).GetCategoryMix), arg0, arg1)
+}
+
+// GetCouponByCode mocks base method.
+func (m *MockStore) GetCouponByCode(arg0 context.Context, arg1 database.GetCouponByCodeParams) (database.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCouponByCode", arg0, arg1)
+	ret0, _ := ret[0].(database.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCouponByCode indicates an expected call of GetCouponByCode.
+func (mr *MockStoreMockRecorder) GetCouponByCode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCouponByCode", reflect.TypeOf((*MockStore)(nil).GetCouponByCode), arg0, arg1)
+}
+
+// GetCouponStats mocks base method.
+func (m *MockStore) GetCouponStats(arg0 context.Context, arg1 database.GetCouponStatsParams) (database.GetCouponStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCouponStats", arg0, arg1)
+	ret0, _ := ret[0].(database.GetCouponStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCouponStats indicates an expected call of GetCouponStats.
+func (mr *MockStoreMockRecorder) GetCouponStats(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCouponStats", reflect.TypeOf((*MockStore)(nil).GetCouponStats), arg0, arg1)
+}
+
+// GetDailySales mocks base method.
+func (m *MockStore) GetDailySales(arg0 context.Context, arg1 database.GetDailySalesParams) (database.GetDailySalesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailySales", arg0, arg1)
+	ret0, _ := ret[0].(database.GetDailySalesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailySales indicates an expected call of GetDailySales.
+func (mr *MockStoreMockRecorder) GetDailySales(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailySales", reflect.TypeOf((*MockStore)(nil).GetDailySales), arg0, arg1)
+}
+
+// GetDailySalesByTaxRate mocks base method.
+func (m *MockStore) GetDailySalesByTaxRate(arg0 context.Context, arg1 database.GetDailySalesByTaxRateParams) ([]database.GetDailySalesByTaxRateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailySalesByTaxRate", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetDailySalesByTaxRateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailySalesByTaxRate indicates an expected call of GetDailySalesByTaxRate.
+func (mr *MockStoreMockRecorder) GetDailySalesByTaxRate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailySalesByTaxRate", reflect.TypeOf((*MockStore)(nil).GetDailySalesByTaxRate), arg0, arg1)
+}
+
+// GetDailyTenders mocks base method.
+func (m *MockStore) GetDailyTenders(arg0 context.Context, arg1 database.GetDailyTendersParams) ([]database.GetDailyTendersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyTenders", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetDailyTendersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyTenders indicates an expected call of GetDailyTenders.
+func (mr *MockStoreMockRecorder) GetDailyTenders(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyTenders", reflect.TypeOf((*MockStore)(nil).GetDailyTenders), arg0, arg1)
+}
+
+// GetDiningTable mocks base method.
+func (m *MockStore) GetDiningTable(arg0 context.Context, arg1 database.GetDiningTableParams) (database.DiningTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiningTable", arg0, arg1)
+	ret0, _ := ret[0].(database.DiningTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiningTable indicates an expected call of GetDiningTable.
+func (mr *MockStoreMockRecorder) GetDiningTable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiningTable", reflect.TypeOf((*MockStore)(nil).GetDiningTable), arg0, arg1)
+}
+
+// GetDiningTableForUpdate mocks base method.
+func (m *MockStore) GetDiningTableForUpdate(arg0 context.Context, arg1 database.GetDiningTableForUpdateParams) (database.DiningTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiningTableForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(database.DiningTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiningTableForUpdate indicates an expected call of GetDiningTableForUpdate.
+func (mr *MockStoreMockRecorder) GetDiningTableForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiningTableForUpdate", reflect.TypeOf((*MockStore)(nil).GetDiningTableForUpdate), arg0, arg1)
+}
+
+// GetFloorArea mocks base method.
+func (m *MockStore) GetFloorArea(arg0 context.Context, arg1 database.GetFloorAreaParams) (database.FloorArea, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFloorArea", arg0, arg1)
+	ret0, _ := ret[0].(database.FloorArea)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFloorArea indicates an expected call of GetFloorArea.
+func (mr *MockStoreMockRecorder) GetFloorArea(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFloorArea", reflect.TypeOf((*MockStore)(nil).GetFloorArea), arg0, arg1)
+}
+
+// GetIngredient mocks base method.
+func (m *MockStore) GetIngredient(arg0 context.Context, arg1 database.GetIngredientParams) (database.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredient", arg0, arg1)
+	ret0, _ := ret[0].(database.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredient indicates an expected call of GetIngredient.
+func (mr *MockStoreMockRecorder) GetIngredient(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredient", reflect.TypeOf((*MockStore)(nil).GetIngredient), arg0, arg1)
+}
+
+// GetIngredientForUpdate mocks base method.
+func (m *MockStore) GetIngredientForUpdate(arg0 context.Context, arg1 database.GetIngredientForUpdateParams) (database.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredientForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(database.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredientForUpdate indicates an expected call of GetIngredientForUpdate.
+func (mr *MockStoreMockRecorder) GetIngredientForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredientForUpdate", reflect.TypeOf((*MockStore)(nil).GetIngredientForUpdate), arg0, arg1)
+}
+
+// GetIngredientUsageReport mocks base method.
+func (m *MockStore) GetIngredientUsageReport(arg0 context.Context, arg1 database.GetIngredientUsageReportParams) ([]database.GetIngredientUsageReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredientUsageReport", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetIngredientUsageReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredientUsageReport indicates an expected call of GetIngredientUsageReport.
+func (mr *MockStoreMockRecorder) GetIngredientUsageReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredientUsageReport", reflect.TypeOf((*MockStore)(nil).GetIngredientUsageReport), arg0, arg1)
+}
+
+// GetMemberShop mocks base method.
+func (m *MockStore) GetMemberShop(arg0 context.Context, arg1 database.GetMemberShopParams) (database.Shop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberShop", arg0, arg1)
+	ret0, _ := ret[0].(database.Shop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberShop indicates an expected call of GetMemberShop.
+func (mr *MockStoreMockRecorder) GetMemberShop(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberShop", reflect.TypeOf((*MockStore)(nil).GetMemberShop), arg0, arg1)
+}
+
+// GetMenuItem mocks base method.
+func (m *MockStore) GetMenuItem(arg0 context.Context, arg1 database.GetMenuItemParams) (database.Menu, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMenuItem", arg0, arg1)
+	ret0, _ := ret[0].(database.Menu)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMenuItem indicates an expected call of GetMenuItem.
+func (mr *MockStoreMockRecorder) GetMenuItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMenuItem", reflect.TypeOf((*MockStore)(nil).GetMenuItem), arg0, arg1)
+}
+
+// GetNextZReportNumber mocks base method.
+func (m *MockStore) GetNextZReportNumber(arg0 context.Context, arg1 string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextZReportNumber", arg0, arg1)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextZReportNumber indicates an expected call of GetNextZReportNumber.
+func (mr *MockStoreMockRecorder) GetNextZReportNumber(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextZReportNumber", reflect.TypeOf((*MockStore)(nil).GetNextZReportNumber), arg0, arg1)
+}
+
+// GetOpenTabByTable mocks base method.
+func (m *MockStore) GetOpenTabByTable(arg0 context.Context, arg1 database.GetOpenTabByTableParams) (database.Tab, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenTabByTable", arg0, arg1)
+	ret0, _ := ret[0].(database.Tab)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenTabByTable indicates an expected call of GetOpenTabByTable.
+func (mr *MockStoreMockRecorder) GetOpenTabByTable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenTabByTable", reflect.TypeOf((*MockStore)(nil).GetOpenTabByTable), arg0, arg1)
+}
+
+// GetOrderFulfilment mocks base method.
+func (m *MockStore) GetOrderFulfilment(arg0 context.Context, arg1 database.GetOrderFulfilmentParams) (database.OrderFulfilment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderFulfilment", arg0, arg1)
+	ret0, _ := ret[0].(database.OrderFulfilment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderFulfilment indicates an expected call of GetOrderFulfilment.
+func (mr *MockStoreMockRecorder) GetOrderFulfilment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderFulfilment", reflect.TypeOf((*MockStore)(nil).GetOrderFulfilment), arg0, arg1)
+}
+
+// GetOrderFulfilmentForUpdate mocks base method.
+func (m *MockStore) GetOrderFulfilmentForUpdate(arg0 context.Context, arg1 database.GetOrderFulfilmentForUpdateParams) (database.OrderFulfilment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderFulfilmentForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(database.OrderFulfilment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderFulfilmentForUpdate indicates an expected call of GetOrderFulfilmentForUpdate.
+func (mr *MockStoreMockRecorder) GetOrderFulfilmentForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderFulfilmentForUpdate", reflect.TypeOf((*MockStore)(nil).GetOrderFulfilmentForUpdate), arg0, arg1)
+}
+
+// GetOrderItem mocks base method.
+func (m *MockStore) GetOrderItem(arg0 context.Context, arg1 database.GetOrderItemParams) (database.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderItem", arg0, arg1)
+	ret0, _ := ret[0].(database.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderItem indicates an expected call of GetOrderItem.
+func (mr *MockStoreMockRecorder) GetOrderItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItem", reflect.TypeOf((*MockStore)(nil).GetOrderItem), arg0, arg1)
+}
+
+// GetOrderItemForUpdate mocks base method.
+func (m *MockStore) GetOrderItemForUpdate(arg0 context.Context, arg1 database.GetOrderItemForUpdateParams) (database.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderItemForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(database.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderItemForUpdate indicates an expected call of GetOrderItemForUpdate.
+func (mr *MockStoreMockRecorder) GetOrderItemForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemForUpdate", reflect.TypeOf((*MockStore)(nil).GetOrderItemForUpdate), arg0, arg1)
+}
+
+// GetOrderSchedule mocks base method.
+func (m *MockStore) GetOrderSchedule(arg0 context.Context, arg1 string) (database.OrderSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderSchedule", arg0, arg1)
+	ret0, _ := ret[0].(database.OrderSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderSchedule indicates an expected call of GetOrderSchedule.
+func (mr *MockStoreMockRecorder) GetOrderSchedule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderSchedule", reflect.TypeOf((*MockStore)(nil).GetOrderSchedule), arg0, arg1)
+}
+
+// GetOrderTypeMix mocks base method.
+func (m *MockStore) GetOrderTypeMix(arg0 context.Context, arg1 database.GetOrderTypeMixParams) ([]database.GetOrderTypeMixRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderTypeMix", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetOrderTypeMixRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderTypeMix indicates an expected call of GetOrderTypeMix.
+func (mr *MockStoreMockRecorder) GetOrderTypeMix(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderTypeMix", reflect.TypeOf((*MockStore)(nil).GetOrderTypeMix), arg0, arg1)
+}
+
+// GetOrdersByDay mocks base method.
+func (m *MockStore) GetOrdersByDay(arg0 context.Context, arg1 database.GetOrdersByDayParams) ([]database.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByDay", arg0, arg1)
+	ret0, _ := ret[0].([]database.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersByDay indicates an expected call of GetOrdersByDay.
+func (mr *MockStoreMockRecorder) GetOrdersByDay(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByDay", reflect.TypeOf((*MockStore)(nil).GetOrdersByDay), arg0, arg1)
+}
+
+// GetOrdersByOrderID mocks base method.
+func (m *MockStore) GetOrdersByOrderID(arg0 context.Context, arg1 database.GetOrdersByOrderIDParams) ([]database.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByOrderID", arg0, arg1)
+	ret0, _ := ret[0].([]database.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersByOrderID indicates an expected call of GetOrdersByOrderID.
+func (mr *MockStoreMockRecorder) GetOrdersByOrderID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByOrderID", reflect.TypeOf((*MockStore)(nil).GetOrdersByOrderID), arg0, arg1)
+}
+
+// GetOrganisation mocks base method.
+func (m *MockStore) GetOrganisation(arg0 context.Context, arg1 uuid.UUID) (database.Organisation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganisation", arg0, arg1)
+	ret0, _ := ret[0].(database.Organisation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganisation indicates an expected call of GetOrganisation.
+func (mr *MockStoreMockRecorder) GetOrganisation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganisation", reflect.TypeOf((*MockStore)(nil).GetOrganisation), arg0, arg1)
+}
+
+// GetOrganisationMember mocks base method.
+func (m *MockStore) GetOrganisationMember(arg0 context.Context, arg1 database.GetOrganisationMemberParams) (database.OrganisationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganisationMember", arg0, arg1)
+	ret0, _ := ret[0].(database.OrganisationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganisationMember indicates an expected call of GetOrganisationMember.
+func (mr *MockStoreMockRecorder) GetOrganisationMember(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganisationMember", reflect.TypeOf((*MockStore)(nil).GetOrganisationMember), arg0, arg1)
+}
+
+// GetPriceList mocks base method.
+func (m *MockStore) GetPriceList(arg0 context.Context, arg1 database.GetPriceListParams) (database.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceList", arg0, arg1)
+	ret0, _ := ret[0].(database.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceList indicates an expected call of GetPriceList.
+func (mr *MockStoreMockRecorder) GetPriceList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceList", reflect.TypeOf((*MockStore)(nil).GetPriceList), arg0, arg1)
+}
+
+// GetPrintJob mocks base method.
+func (m *MockStore) GetPrintJob(arg0 context.Context, arg1 database.GetPrintJobParams) (database.PrintJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrintJob", arg0, arg1)
+	ret0, _ := ret[0].(database.PrintJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrintJob indicates an expected call of GetPrintJob.
+func (mr *MockStoreMockRecorder) GetPrintJob(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrintJob", reflect.TypeOf((*MockStore)(nil).GetPrintJob), arg0, arg1)
+}
+
+// GetPrinter mocks base method.
+func (m *MockStore) GetPrinter(arg0 context.Context, arg1 database.GetPrinterParams) (database.Printer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrinter", arg0, arg1)
+	ret0, _ := ret[0].(database.Printer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrinter indicates an expected call of GetPrinter.
+func (mr *MockStoreMockRecorder) GetPrinter(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrinter", reflect.TypeOf((*MockStore)(nil).GetPrinter), arg0, arg1)
+}
+
+// GetProduct mocks base method.
+func (m *MockStore) GetProduct(arg0 context.Context, arg1 database.GetProductParams) (database.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", arg0, arg1)
+	ret0, _ := ret[0].(database.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockStoreMockRecorder) GetProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockStore)(nil).GetProduct), arg0, arg1)
+}
+
+// GetProductMarginReport mocks base method.
+func (m *MockStore) GetProductMarginReport(arg0 context.Context, arg1 database.GetProductMarginReportParams) ([]database.GetProductMarginReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductMarginReport", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetProductMarginReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductMarginReport indicates an expected call of GetProductMarginReport.
+func (mr *MockStoreMockRecorder) GetProductMarginReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductMarginReport", reflect.TypeOf((*MockStore)(nil).GetProductMarginReport), arg0, arg1)
+}
+
+// GetProductMix mocks base method.
+func (m *MockStore) GetProductMix(arg0 context.Context, arg1 database.GetProductMixParams) ([]database.GetProductMixRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductMix", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetProductMixRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductMix indicates an expected call of GetProductMix.
+func (mr *MockStoreMockRecorder) GetProductMix(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductMix", reflect.TypeOf((*MockStore)(nil).GetProductMix), arg0, arg1)
+}
+
+// GetProductsByName mocks base method.
+func (m *MockStore) GetProductsByName(arg0 context.Context, arg1 database.GetProductsByNameParams) ([]database.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductsByName", arg0, arg1)
+	ret0, _ := ret[0].([]database.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductsByName indicates an expected call of GetProductsByName.
+func (mr *MockStoreMockRecorder) GetProductsByName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsByName", reflect.TypeOf((*MockStore)(nil).GetProductsByName), arg0, arg1)
+}
+
+// GetPromotion mocks base method.
+func (m *MockStore) GetPromotion(arg0 context.Context, arg1 database.GetPromotionParams) (database.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotion", arg0, arg1)
+	ret0, _ := ret[0].(database.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotion indicates an expected call of GetPromotion.
+func (mr *MockStoreMockRecorder) GetPromotion(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotion", reflect.TypeOf((*MockStore)(nil).GetPromotion), arg0, arg1)
+}
+
+// GetPurchaseOrder mocks base method.
+func (m *MockStore) GetPurchaseOrder(arg0 context.Context, arg1 database.GetPurchaseOrderParams) (database.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrder", arg0, arg1)
+	ret0, _ := ret[0].(database.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrder indicates an expected call of GetPurchaseOrder.
+func (mr *MockStoreMockRecorder) GetPurchaseOrder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrder", reflect.TypeOf((*MockStore)(nil).GetPurchaseOrder), arg0, arg1)
+}
+
+// GetPurchaseOrderForUpdate mocks base method.
+func (m *MockStore) GetPurchaseOrderForUpdate(arg0 context.Context, arg1 database.GetPurchaseOrderForUpdateParams) (database.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrderForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(database.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrderForUpdate indicates an expected call of GetPurchaseOrderForUpdate.
+func (mr *MockStoreMockRecorder) GetPurchaseOrderForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrderForUpdate", reflect.TypeOf((*MockStore)(nil).GetPurchaseOrderForUpdate), arg0, arg1)
+}
+
+// GetPurchaseSuggestions mocks base method.
+func (m *MockStore) GetPurchaseSuggestions(arg0 context.Context, arg1 database.GetPurchaseSuggestionsParams) ([]database.GetPurchaseSuggestionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseSuggestions", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetPurchaseSuggestionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseSuggestions indicates an expected call of GetPurchaseSuggestions.
+func (mr *MockStoreMockRecorder) GetPurchaseSuggestions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseSuggestions", reflect.TypeOf((*MockStore)(nil).GetPurchaseSuggestions), arg0, arg1)
+}
+
+// GetRecipeUsage mocks base method.
+func (m *MockStore) GetRecipeUsage(arg0 context.Context, arg1 database.GetRecipeUsageParams) ([]database.GetRecipeUsageRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeUsage", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetRecipeUsageRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeUsage indicates an expected call of GetRecipeUsage.
+func (mr *MockStoreMockRecorder) GetRecipeUsage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeUsage", reflect.TypeOf((*MockStore)(nil).GetRecipeUsage), arg0, arg1)
+}
+
+// GetSalesComparison mocks base method.
+func (m *MockStore) GetSalesComparison(arg0 context.Context, arg1 database.GetSalesComparisonParams) (database.GetSalesComparisonRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSalesComparison", arg0, arg1)
+	ret0, _ := ret[0].(database.GetSalesComparisonRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSalesComparison indicates an expected call of GetSalesComparison.
+func (mr *MockStoreMockRecorder) GetSalesComparison(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalesComparison", reflect.TypeOf((*MockStore)(nil).GetSalesComparison), arg0, arg1)
+}
+
+// GetSalesHeatmap mocks base method.
+func (m *MockStore) GetSalesHeatmap(arg0 context.Context, arg1 database.GetSalesHeatmapParams) ([]database.GetSalesHeatmapRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSalesHeatmap", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetSalesHeatmapRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSalesHeatmap indicates an expected call of GetSalesHeatmap.
+func (mr *MockStoreMockRecorder) GetSalesHeatmap(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalesHeatmap", reflect.TypeOf((*MockStore)(nil).GetSalesHeatmap), arg0, arg1)
+}
+
+// GetShop mocks base method.
+func (m *MockStore) GetShop(arg0 context.Context, arg1 uuid.UUID) (database.Shop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShop", arg0, arg1)
+	ret0, _ := ret[0].(database.Shop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShop indicates an expected call of GetShop.
+func (mr *MockStoreMockRecorder) GetShop(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShop", reflect.TypeOf((*MockStore)(nil).GetShop), arg0, arg1)
+}
+
+// GetShopByName mocks base method.
+func (m *MockStore) GetShopByName(arg0 context.Context, arg1 string) (database.Shop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShopByName", arg0, arg1)
+	ret0, _ := ret[0].(database.Shop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShopByName indicates an expected call of GetShopByName.
+func (mr *MockStoreMockRecorder) GetShopByName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShopByName", reflect.TypeOf((*MockStore)(nil).GetShopByName), arg0, arg1)
+}
+
+// GetShopForShare mocks base method.
+func (m *MockStore) GetShopForShare(arg0 context.Context, arg1 string) (database.Shop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShopForShare", arg0, arg1)
+	ret0, _ := ret[0].(database.Shop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShopForShare indicates an expected call of GetShopForShare.
+func (mr *MockStoreMockRecorder) GetShopForShare(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShopForShare", reflect.TypeOf((*MockStore)(nil).GetShopForShare), arg0, arg1)
+}
+
+// GetShopForUpdate mocks base method.
+func (m *MockStore) GetShopForUpdate(arg0 context.Context, arg1 string) (database.Shop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShopForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(database.Shop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShopForUpdate indicates an expected call of GetShopForUpdate.
+func (mr *MockStoreMockRecorder) GetShopForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShopForUpdate", reflect.TypeOf((*MockStore)(nil).GetShopForUpdate), arg0, arg1)
+}
+
+// GetShopProduct mocks base method.
+func (m *MockStore) GetShopProduct(arg0 context.Context, arg1 database.GetShopProductParams) (database.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShopProduct", arg0, arg1)
+	ret0, _ := ret[0].(database.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShopProduct indicates an expected call of GetShopProduct.
+func (mr *MockStoreMockRecorder) GetShopProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShopProduct", reflect.TypeOf((*MockStore)(nil).GetShopProduct), arg0, arg1)
+}
+
+// GetStation mocks base method.
+func (m *MockStore) GetStation(arg0 context.Context, arg1 database.GetStationParams) (database.Station, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStation", arg0, arg1)
+	ret0, _ := ret[0].(database.Station)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStation indicates an expected call of GetStation.
+func (mr *MockStoreMockRecorder) GetStation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStation", reflect.TypeOf((*MockStore)(nil).GetStation), arg0, arg1)
+}
+
+// GetStockLevel mocks base method.
+func (m *MockStore) GetStockLevel(arg0 context.Context, arg1 database.GetStockLevelParams) (database.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockLevel", arg0, arg1)
+	ret0, _ := ret[0].(database.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockLevel indicates an expected call of GetStockLevel.
+func (mr *MockStoreMockRecorder) GetStockLevel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockLevel", reflect.TypeOf((*MockStore)(nil).GetStockLevel), arg0, arg1)
+}
+
+// GetStockLevelForUpdate mocks base method.
+func (m *MockStore) GetStockLevelForUpdate(arg0 context.Context, arg1 database.GetStockLevelForUpdateParams) (database.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockLevelForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(database.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockLevelForUpdate indicates an expected call of GetStockLevelForUpdate.
+func (mr *MockStoreMockRecorder) GetStockLevelForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockLevelForUpdate", reflect.TypeOf((*MockStore)(nil).GetStockLevelForUpdate), arg0, arg1)
+}
+
+// GetSupplier mocks base method.
+func (m *MockStore) GetSupplier(arg0 context.Context, arg1 database.GetSupplierParams) (database.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplier", arg0, arg1)
+	ret0, _ := ret[0].(database.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplier indicates an expected call of GetSupplier.
+func (mr *MockStoreMockRecorder) GetSupplier(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplier", reflect.TypeOf((*MockStore)(nil).GetSupplier), arg0, arg1)
+}
+
+// GetTab mocks base method.
+func (m *MockStore) GetTab(arg0 context.Context, arg1 database.GetTabParams) (database.Tab, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTab", arg0, arg1)
+	ret0, _ := ret[0].(database.Tab)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTab indicates an expected call of GetTab.
+func (mr *MockStoreMockRecorder) GetTab(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTab", reflect.TypeOf((*MockStore)(nil).GetTab), arg0, arg1)
+}
+
+// GetTabForUpdate mocks base method.
+func (m *MockStore) GetTabForUpdate(arg0 context.Context, arg1 database.GetTabForUpdateParams) (database.Tab, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTabForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(database.Tab)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTabForUpdate indicates an expected call of GetTabForUpdate.
+func (mr *MockStoreMockRecorder) GetTabForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTabForUpdate", reflect.TypeOf((*MockStore)(nil).GetTabForUpdate), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (database.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(database.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetZReport mocks base method.
+func (m *MockStore) GetZReport(arg0 context.Context, arg1 database.GetZReportParams) (database.ZReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetZReport", arg0, arg1)
+	ret0, _ := ret[0].(database.ZReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetZReport indicates an expected call of GetZReport.
+func (mr *MockStoreMockRecorder) GetZReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetZReport", reflect.TypeOf((*MockStore)(nil).GetZReport), arg0, arg1)
+}
+
+// IngredientMovementTx mocks base method.
+func (m *MockStore) IngredientMovementTx(arg0 context.Context, arg1 database.IngredientMovementTxParams) (database.IngredientMovementTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IngredientMovementTx", arg0, arg1)
+	ret0, _ := ret[0].(database.IngredientMovementTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IngredientMovementTx indicates an expected call of IngredientMovementTx.
+func (mr *MockStoreMockRecorder) IngredientMovementTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngredientMovementTx", reflect.TypeOf((*MockStore)(nil).IngredientMovementTx), arg0, arg1)
+}
+
+// IsDayClosed mocks base method.
+func (m *MockStore) IsDayClosed(arg0 context.Context, arg1 database.IsDayClosedParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDayClosed", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsDayClosed indicates an expected call of IsDayClosed.
+func (mr *MockStoreMockRecorder) IsDayClosed(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDayClosed", reflect.TypeOf((*MockStore)(nil).IsDayClosed), arg0, arg1)
+}
+
+// ListActiveFulfilments mocks base method.
+func (m *MockStore) ListActiveFulfilments(arg0 context.Context, arg1 database.ListActiveFulfilmentsParams) ([]database.OrderFulfilment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveFulfilments", arg0, arg1)
+	ret0, _ := ret[0].([]database.OrderFulfilment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveFulfilments indicates an expected call of ListActiveFulfilments.
+func (mr *MockStoreMockRecorder) ListActiveFulfilments(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveFulfilments", reflect.TypeOf((*MockStore)(nil).ListActiveFulfilments), arg0, arg1)
+}
+
+// ListActivePriceListRules mocks base method.
+func (m *MockStore) ListActivePriceListRules(arg0 context.Context, arg1 string) ([]database.PriceListRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActivePriceListRules", arg0, arg1)
+	ret0, _ := ret[0].([]database.PriceListRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActivePriceListRules indicates an expected call of ListActivePriceListRules.
+func (mr *MockStoreMockRecorder) ListActivePriceListRules(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivePriceListRules", reflect.TypeOf((*MockStore)(nil).ListActivePriceListRules), arg0, arg1)
+}
+
+// ListActivePromotions mocks base method.
+func (m *MockStore) ListActivePromotions(arg0 context.Context, arg1 database.ListActivePromotionsParams) ([]database.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActivePromotions", arg0, arg1)
+	ret0, _ := ret[0].([]database.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActivePromotions indicates an expected call of ListActivePromotions.
+func (mr *MockStoreMockRecorder) ListActivePromotions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivePromotions", reflect.TypeOf((*MockStore)(nil).ListActivePromotions), arg0, arg1)
+}
+
+// ListCheckLines mocks base method.
+func (m *MockStore) ListCheckLines(arg0 context.Context, arg1 database.ListCheckLinesParams) ([]database.CheckLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCheckLines", arg0, arg1)
+	ret0, _ := ret[0].([]database.CheckLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCheckLines indicates an expected call of ListCheckLines.
+func (mr *MockStoreMockRecorder) ListCheckLines(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCheckLines", reflect.TypeOf((*MockStore)(nil).ListCheckLines), arg0, arg1)
+}
+
+// ListChecks mocks base method.
+func (m *MockStore) ListChecks(arg0 context.Context, arg1 database.ListChecksParams) ([]database.Check, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChecks", arg0, arg1)
+	ret0, _ := ret[0].([]database.Check)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChecks indicates an expected call of ListChecks.
+func (mr *MockStoreMockRecorder) ListChecks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChecks", reflect.TypeOf((*MockStore)(nil).ListChecks), arg0, arg1)
+}
+
+// ListChecksForUpdate mocks base method.
+func (m *MockStore) ListChecksForUpdate(arg0 context.Context, arg1 database.ListChecksForUpdateParams) ([]database.Check, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChecksForUpdate", arg0, arg1)
+	ret0, _ := ret[0].([]database.Check)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChecksForUpdate indicates an expected call of ListChecksForUpdate.
+func (mr *MockStoreMockRecorder) ListChecksForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChecksForUpdate", reflect.TypeOf((*MockStore)(nil).ListChecksForUpdate), arg0, arg1)
+}
+
+// ListCoupons mocks base method.
+func (m *MockStore) ListCoupons(arg0 context.Context, arg1 database.ListCouponsParams) ([]database.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCoupons", arg0, arg1)
+	ret0, _ := ret[0].([]database.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCoupons indicates an expected call of ListCoupons.
+func (mr *MockStoreMockRecorder) ListCoupons(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCoupons", reflect.TypeOf((*MockStore)(nil).ListCoupons), arg0, arg1)
+}
+
+// ListDailySales mocks base method.
+func (m *MockStore) ListDailySales(arg0 context.Context, arg1 database.ListDailySalesParams) ([]database.ListDailySalesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDailySales", arg0, arg1)
+	ret0, _ := ret[0].([]database.ListDailySalesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDailySales indicates an expected call of ListDailySales.
+func (mr *MockStoreMockRecorder) ListDailySales(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDailySales", reflect.TypeOf((*MockStore)(nil).ListDailySales), arg0, arg1)
+}
+
+// ListDiningTables mocks base method.
+func (m *MockStore) ListDiningTables(arg0 context.Context, arg1 string) ([]database.DiningTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDiningTables", arg0, arg1)
+	ret0, _ := ret[0].([]database.DiningTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDiningTables indicates an expected call of ListDiningTables.
+func (mr *MockStoreMockRecorder) ListDiningTables(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDiningTables", reflect.TypeOf((*MockStore)(nil).ListDiningTables), arg0, arg1)
+}
+
+// ListFloorAreas mocks base method.
+func (m *MockStore) ListFloorAreas(arg0 context.Context, arg1 string) ([]database.FloorArea, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFloorAreas", arg0, arg1)
+	ret0, _ := ret[0].([]database.FloorArea)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFloorAreas indicates an expected call of ListFloorAreas.
+func (mr *MockStoreMockRecorder) ListFloorAreas(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFloorAreas", reflect.TypeOf((*MockStore)(nil).ListFloorAreas), arg0, arg1)
+}
+
+// ListIngredientMovements mocks base method.
+func (m *MockStore) ListIngredientMovements(arg0 context.Context, arg1 database.ListIngredientMovementsParams) ([]database.IngredientMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredientMovements", arg0, arg1)
+	ret0, _ := ret[0].([]database.IngredientMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredientMovements indicates an expected call of ListIngredientMovements.
+func (mr *MockStoreMockRecorder) ListIngredientMovements(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredientMovements", reflect.TypeOf((*MockStore)(nil).ListIngredientMovements), arg0, arg1)
+}
+
+// ListIngredientSalesByOrderItem mocks base method.
+func (m *MockStore) ListIngredientSalesByOrderItem(arg0 context.Context, arg1 uuid.NullUUID) ([]database.IngredientMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredientSalesByOrderItem", arg0, arg1)
+	ret0, _ := ret[0].([]database.IngredientMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredientSalesByOrderItem indicates an expected call of ListIngredientSalesByOrderItem.
+func (mr *MockStoreMockRecorder) ListIngredientSalesByOrderItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredientSalesByOrderItem", reflect.TypeOf((*MockStore)(nil).ListIngredientSalesByOrderItem), arg0, arg1)
+}
+
+// ListIngredients mocks base method.
+func (m *MockStore) ListIngredients(arg0 context.Context, arg1 string) ([]database.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredients", arg0, arg1)
+	ret0, _ := ret[0].([]database.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredients indicates an expected call of ListIngredients.
+func (mr *MockStoreMockRecorder) ListIngredients(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredients", reflect.TypeOf((*MockStore)(nil).ListIngredients), arg0, arg1)
+}
+
+// ListKitchenTicketItems mocks base method.
+func (m *MockStore) ListKitchenTicketItems(arg0 context.Context, arg1 []uuid.UUID) ([]database.ListKitchenTicketItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKitchenTicketItems", arg0, arg1)
+	ret0, _ := ret[0].([]database.ListKitchenTicketItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKitchenTicketItems indicates an expected call of ListKitchenTicketItems.
+func (mr *MockStoreMockRecorder) ListKitchenTicketItems(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKitchenTicketItems", reflect.TypeOf((*MockStore)(nil).ListKitchenTicketItems), arg0, arg1)
+}
+
+// ListKitchenTickets mocks base method.
+func (m *MockStore) ListKitchenTickets(arg0 context.Context, arg1 database.ListKitchenTicketsParams) ([]database.KitchenTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKitchenTickets", arg0, arg1)
+	ret0, _ := ret[0].([]database.KitchenTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKitchenTickets indicates an expected call of ListKitchenTickets.
+func (mr *MockStoreMockRecorder) ListKitchenTickets(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKitchenTickets", reflect.TypeOf((*MockStore)(nil).ListKitchenTickets), arg0, arg1)
+}
+
+// ListKitchenTicketsByOrderID mocks base method.
+func (m *MockStore) ListKitchenTicketsByOrderID(arg0 context.Context, arg1 database.ListKitchenTicketsByOrderIDParams) ([]database.KitchenTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKitchenTicketsByOrderID", arg0, arg1)
+	ret0, _ := ret[0].([]database.KitchenTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKitchenTicketsByOrderID indicates an expected call of ListKitchenTicketsByOrderID.
+func (mr *MockStoreMockRecorder) ListKitchenTicketsByOrderID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKitchenTicketsByOrderID", reflect.TypeOf((*MockStore)(nil).ListKitchenTicketsByOrderID), arg0, arg1)
+}
+
+// ListMemberShops mocks base method.
+func (m *MockStore) ListMemberShops(arg0 context.Context, arg1 string) ([]database.Shop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMemberShops", arg0, arg1)
+	ret0, _ := ret[0].([]database.Shop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMemberShops indicates an expected call of ListMemberShops.
+func (mr *MockStoreMockRecorder) ListMemberShops(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMemberShops", reflect.TypeOf((*MockStore)(nil).ListMemberShops), arg0, arg1)
+}
+
+// ListMenuPrices mocks base method.
+func (m *MockStore) ListMenuPrices(arg0 context.Context, arg1 string) ([]database.ListMenuPricesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMenuPrices", arg0, arg1)
+	ret0, _ := ret[0].([]database.ListMenuPricesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMenuPrices indicates an expected call of ListMenuPrices.
+func (mr *MockStoreMockRecorder) ListMenuPrices(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMenuPrices", reflect.TypeOf((*MockStore)(nil).ListMenuPrices), arg0, arg1)
+}
+
+// ListOpenKitchenTicketPrep mocks base method.
+func (m *MockStore) ListOpenKitchenTicketPrep(arg0 context.Context, arg1 string) ([]database.ListOpenKitchenTicketPrepRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenKitchenTicketPrep", arg0, arg1)
+	ret0, _ := ret[0].([]database.ListOpenKitchenTicketPrepRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenKitchenTicketPrep indicates an expected call of ListOpenKitchenTicketPrep.
+func (mr *MockStoreMockRecorder) ListOpenKitchenTicketPrep(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenKitchenTicketPrep", reflect.TypeOf((*MockStore)(nil).ListOpenKitchenTicketPrep), arg0, arg1)
+}
+
+// ListOpenStockAlerts mocks base method.
+func (m *MockStore) ListOpenStockAlerts(arg0 context.Context, arg1 string) ([]database.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenStockAlerts", arg0, arg1)
+	ret0, _ := ret[0].([]database.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenStockAlerts indicates an expected call of ListOpenStockAlerts.
+func (mr *MockStoreMockRecorder) ListOpenStockAlerts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenStockAlerts", reflect.TypeOf((*MockStore)(nil).ListOpenStockAlerts), arg0, arg1)
+}
+
+// ListOpenTabs mocks base method.
+func (m *MockStore) ListOpenTabs(arg0 context.Context, arg1 string) ([]database.Tab, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenTabs", arg0, arg1)
+	ret0, _ := ret[0].([]database.Tab)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenTabs indicates an expected call of ListOpenTabs.
+func (mr *MockStoreMockRecorder) ListOpenTabs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenTabs", reflect.TypeOf((*MockStore)(nil).ListOpenTabs), arg0, arg1)
+}
+
+// ListOpeningExceptions mocks base method.
+func (m *MockStore) ListOpeningExceptions(arg0 context.Context, arg1 database.ListOpeningExceptionsParams) ([]database.OpeningException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpeningExceptions", arg0, arg1)
+	ret0, _ := ret[0].([]database.OpeningException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpeningExceptions indicates an expected call of ListOpeningExceptions.
+func (mr *MockStoreMockRecorder) ListOpeningExceptions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpeningExceptions", reflect.TypeOf((*MockStore)(nil).ListOpeningExceptions), arg0, arg1)
+}
+
+// ListOpeningHours mocks base method.
+func (m *MockStore) ListOpeningHours(arg0 context.Context, arg1 string) ([]database.OpeningHour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpeningHours", arg0, arg1)
+	ret0, _ := ret[0].([]database.OpeningHour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpeningHours indicates an expected call of ListOpeningHours.
+func (mr *MockStoreMockRecorder) ListOpeningHours(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpeningHours", reflect.TypeOf((*MockStore)(nil).ListOpeningHours), arg0, arg1)
+}
+
+// ListOrderHistory mocks base method.
+func (m *MockStore) ListOrderHistory(arg0 context.Context, arg1 database.OrderHistoryParams) ([]database.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderHistory", arg0, arg1)
+	ret0, _ := ret[0].([]database.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderHistory indicates an expected call of ListOrderHistory.
+func (mr *MockStoreMockRecorder) ListOrderHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderHistory", reflect.TypeOf((*MockStore)(nil).ListOrderHistory), arg0, arg1)
+}
+
+// ListOrderHistoryByAmountAsc mocks base method.
+func (m *MockStore) ListOrderHistoryByAmountAsc(arg0 context.Context, arg1 database.ListOrderHistoryByAmountAscParams) ([]database.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderHistoryByAmountAsc", arg0, arg1)
+	ret0, _ := ret[0].([]database.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderHistoryByAmountAsc indicates an expected call of ListOrderHistoryByAmountAsc.
+func (mr *MockStoreMockRecorder) ListOrderHistoryByAmountAsc(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderHistoryByAmountAsc", reflect.TypeOf((*MockStore)(nil).ListOrderHistoryByAmountAsc), arg0, arg1)
+}
+
+// ListOrderHistoryByAmountDesc mocks base method.
+func (m *MockStore) ListOrderHistoryByAmountDesc(arg0 context.Context, arg1 database.ListOrderHistoryByAmountDescParams) ([]database.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderHistoryByAmountDesc", arg0, arg1)
+	ret0, _ := ret[0].([]database.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderHistoryByAmountDesc indicates an expected call of ListOrderHistoryByAmountDesc.
+func (mr *MockStoreMockRecorder) ListOrderHistoryByAmountDesc(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderHistoryByAmountDesc", reflect.TypeOf((*MockStore)(nil).ListOrderHistoryByAmountDesc), arg0, arg1)
+}
+
+// ListOrderHistoryByCreatedAtAsc mocks base method.
+func (m *MockStore) ListOrderHistoryByCreatedAtAsc(arg0 context.Context, arg1 database.ListOrderHistoryByCreatedAtAscParams) ([]database.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderHistoryByCreatedAtAsc", arg0, arg1)
+	ret0, _ := ret[0].([]database.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderHistoryByCreatedAtAsc indicates an expected call of ListOrderHistoryByCreatedAtAsc.
+func (mr *MockStoreMockRecorder) ListOrderHistoryByCreatedAtAsc(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderHistoryByCreatedAtAsc", reflect.TypeOf((*MockStore)(nil).ListOrderHistoryByCreatedAtAsc), arg0, arg1)
+}
+
+// ListOrderHistoryByCreatedAtDesc mocks base method.
+func (m *MockStore) ListOrderHistoryByCreatedAtDesc(arg0 context.Context, arg1 database.ListOrderHistoryByCreatedAtDescParams) ([]database.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderHistoryByCreatedAtDesc", arg0, arg1)
+	ret0, _ := ret[0].([]database.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderHistoryByCreatedAtDesc indicates an expected call of ListOrderHistoryByCreatedAtDesc.
+func (mr *MockStoreMockRecorder) ListOrderHistoryByCreatedAtDesc(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderHistoryByCreatedAtDesc", reflect.TypeOf((*MockStore)(nil).ListOrderHistoryByCreatedAtDesc), arg0, arg1)
+}
+
+// ListOrderPrices mocks base method.
+func (m *MockStore) ListOrderPrices(arg0 context.Context, arg1 []uuid.UUID) ([]database.ListOrderPricesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderPrices", arg0, arg1)
+	ret0, _ := ret[0].([]database.ListOrderPricesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderPrices indicates an expected call of ListOrderPrices.
+func (mr *MockStoreMockRecorder) ListOrderPrices(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderPrices", reflect.TypeOf((*MockStore)(nil).ListOrderPrices), arg0, arg1)
+}
+
+// ListOrderSlots mocks base method.
+func (m *MockStore) ListOrderSlots(arg0 context.Context, arg1 database.ListOrderSlotsParams) ([]database.OrderSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderSlots", arg0, arg1)
+	ret0, _ := ret[0].([]database.OrderSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderSlots indicates an expected call of ListOrderSlots.
+func (mr *MockStoreMockRecorder) ListOrderSlots(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderSlots", reflect.TypeOf((*MockStore)(nil).ListOrderSlots), arg0, arg1)
+}
+
+// ListOrderTypeFees mocks base method.
+func (m *MockStore) ListOrderTypeFees(arg0 context.Context, arg1 string) ([]database.OrderTypeFee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderTypeFees", arg0, arg1)
+	ret0, _ := ret[0].([]database.OrderTypeFee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderTypeFees indicates an expected call of ListOrderTypeFees.
+func (mr *MockStoreMockRecorder) ListOrderTypeFees(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderTypeFees", reflect.TypeOf((*MockStore)(nil).ListOrderTypeFees), arg0, arg1)
+}
+
+// ListOrderTypeFeesByType mocks base method.
+func (m *MockStore) ListOrderTypeFeesByType(arg0 context.Context, arg1 database.ListOrderTypeFeesByTypeParams) ([]database.OrderTypeFee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderTypeFeesByType", arg0, arg1)
+	ret0, _ := ret[0].([]database.OrderTypeFee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderTypeFeesByType indicates an expected call of ListOrderTypeFeesByType.
+func (mr *MockStoreMockRecorder) ListOrderTypeFeesByType(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderTypeFeesByType", reflect.TypeOf((*MockStore)(nil).ListOrderTypeFeesByType), arg0, arg1)
+}
+
+// ListOrganisationMembers mocks base method.
+func (m *MockStore) ListOrganisationMembers(arg0 context.Context, arg1 uuid.UUID) ([]database.ListOrganisationMembersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganisationMembers", arg0, arg1)
+	ret0, _ := ret[0].([]database.ListOrganisationMembersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganisationMembers indicates an expected call of ListOrganisationMembers.
+func (mr *MockStoreMockRecorder) ListOrganisationMembers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganisationMembers", reflect.TypeOf((*MockStore)(nil).ListOrganisationMembers), arg0, arg1)
+}
+
+// ListOrganisationShops mocks base method.
+func (m *MockStore) ListOrganisationShops(arg0 context.Context, arg1 uuid.UUID) ([]database.Shop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganisationShops", arg0, arg1)
+	ret0, _ := ret[0].([]database.Shop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganisationShops indicates an expected call of ListOrganisationShops.
+func (mr *MockStoreMockRecorder) ListOrganisationShops(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganisationShops", reflect.TypeOf((*MockStore)(nil).ListOrganisationShops), arg0, arg1)
+}
+
+// ListPaymentsByOrderID mocks base method.
+func (m *MockStore) ListPaymentsByOrderID(arg0 context.Context, arg1 database.ListPaymentsByOrderIDParams) ([]database.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaymentsByOrderID", arg0, arg1)
+	ret0, _ := ret[0].([]database.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaymentsByOrderID indicates an expected call of ListPaymentsByOrderID.
+func (mr *MockStoreMockRecorder) ListPaymentsByOrderID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentsByOrderID", reflect.TypeOf((*MockStore)(nil).ListPaymentsByOrderID), arg0, arg1)
+}
+
+// ListPriceListPrices mocks base method.
+func (m *MockStore) ListPriceListPrices(arg0 context.Context, arg1 uuid.UUID) ([]database.PriceListPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPriceListPrices", arg0, arg1)
+	ret0, _ := ret[0].([]database.PriceListPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPriceListPrices indicates an expected call of ListPriceListPrices.
+func (mr *MockStoreMockRecorder) ListPriceListPrices(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPriceListPrices", reflect.TypeOf((*MockStore)(nil).ListPriceListPrices), arg0, arg1)
+}
+
+// ListPriceListRules mocks base method.
+func (m *MockStore) ListPriceListRules(arg0 context.Context, arg1 string) ([]database.PriceListRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPriceListRules", arg0, arg1)
+	ret0, _ := ret[0].([]database.PriceListRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPriceListRules indicates an expected call of ListPriceListRules.
+func (mr *MockStoreMockRecorder) ListPriceListRules(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPriceListRules", reflect.TypeOf((*MockStore)(nil).ListPriceListRules), arg0, arg1)
+}
+
+// ListPriceLists mocks base method.
+func (m *MockStore) ListPriceLists(arg0 context.Context, arg1 string) ([]database.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPriceLists", arg0, arg1)
+	ret0, _ := ret[0].([]database.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPriceLists indicates an expected call of ListPriceLists.
+func (mr *MockStoreMockRecorder) ListPriceLists(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPriceLists", reflect.TypeOf((*MockStore)(nil).ListPriceLists), arg0, arg1)
+}
+
+// ListPrintJobs mocks base method.
+func (m *MockStore) ListPrintJobs(arg0 context.Context, arg1 database.ListPrintJobsParams) ([]database.PrintJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrintJobs", arg0, arg1)
+	ret0, _ := ret[0].([]database.PrintJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrintJobs indicates an expected call of ListPrintJobs.
+func (mr *MockStoreMockRecorder) ListPrintJobs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrintJobs", reflect.TypeOf((*MockStore)(nil).ListPrintJobs), arg0, arg1)
+}
+
+// ListPrinters mocks base method.
+func (m *MockStore) ListPrinters(arg0 context.Context, arg1 string) ([]database.Printer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrinters", arg0, arg1)
+	ret0, _ := ret[0].([]database.Printer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrinters indicates an expected call of ListPrinters.
+func (mr *MockStoreMockRecorder) ListPrinters(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrinters", reflect.TypeOf((*MockStore)(nil).ListPrinters), arg0, arg1)
+}
+
+// ListProductCosts mocks base method.
+func (m *MockStore) ListProductCosts(arg0 context.Context, arg1 database.ListProductCostsParams) ([]database.ProductCost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductCosts", arg0, arg1)
+	ret0, _ := ret[0].([]database.ProductCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductCosts indicates an expected call of ListProductCosts.
+func (mr *MockStoreMockRecorder) ListProductCosts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductCosts", reflect.TypeOf((*MockStore)(nil).ListProductCosts), arg0, arg1)
+}
+
+// ListPromotions mocks base method.
+func (m *MockStore) ListPromotions(arg0 context.Context, arg1 string) ([]database.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPromotions", arg0, arg1)
+	ret0, _ := ret[0].([]database.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPromotions indicates an expected call of ListPromotions.
+func (mr *MockStoreMockRecorder) ListPromotions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPromotions", reflect.TypeOf((*MockStore)(nil).ListPromotions), arg0, arg1)
+}
+
+// ListPurchaseOrderLines mocks base method.
+func (m *MockStore) ListPurchaseOrderLines(arg0 context.Context, arg1 uuid.UUID) ([]database.PurchaseOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchaseOrderLines", arg0, arg1)
+	ret0, _ := ret[0].([]database.PurchaseOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchaseOrderLines indicates an expected call of ListPurchaseOrderLines.
+func (mr *MockStoreMockRecorder) ListPurchaseOrderLines(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseOrderLines", reflect.TypeOf((*MockStore)(nil).ListPurchaseOrderLines), arg0, arg1)
+}
+
+// ListPurchaseOrders mocks base method.
+func (m *MockStore) ListPurchaseOrders(arg0 context.Context, arg1 database.ListPurchaseOrdersParams) ([]database.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchaseOrders", arg0, arg1)
+	ret0, _ := ret[0].([]database.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchaseOrders indicates an expected call of ListPurchaseOrders.
+func (mr *MockStoreMockRecorder) ListPurchaseOrders(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseOrders", reflect.TypeOf((*MockStore)(nil).ListPurchaseOrders), arg0, arg1)
+}
+
+// ListRecipeItems mocks base method.
+func (m *MockStore) ListRecipeItems(arg0 context.Context, arg1 database.ListRecipeItemsParams) ([]database.RecipeItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeItems", arg0, arg1)
+	ret0, _ := ret[0].([]database.RecipeItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeItems indicates an expected call of ListRecipeItems.
+func (mr *MockStoreMockRecorder) ListRecipeItems(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeItems", reflect.TypeOf((*MockStore)(nil).ListRecipeItems), arg0, arg1)
+}
+
+// ListStationRoutes mocks base method.
+func (m *MockStore) ListStationRoutes(arg0 context.Context, arg1 string) ([]database.StationRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStationRoutes", arg0, arg1)
+	ret0, _ := ret[0].([]database.StationRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStationRoutes indicates an expected call of ListStationRoutes.
+func (mr *MockStoreMockRecorder) ListStationRoutes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStationRoutes", reflect.TypeOf((*MockStore)(nil).ListStationRoutes), arg0, arg1)
+}
+
+// ListStations mocks base method.
+func (m *MockStore) ListStations(arg0 context.Context, arg1 string) ([]database.Station, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStations", arg0, arg1)
+	ret0, _ := ret[0].([]database.Station)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStations indicates an expected call of ListStations.
+func (mr *MockStoreMockRecorder) ListStations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStations", reflect.TypeOf((*MockStore)(nil).ListStations), arg0, arg1)
+}
+
+// ListStockLevels mocks base method.
+func (m *MockStore) ListStockLevels(arg0 context.Context, arg1 string) ([]database.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockLevels", arg0, arg1)
+	ret0, _ := ret[0].([]database.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockLevels indicates an expected call of ListStockLevels.
+func (mr *MockStoreMockRecorder) ListStockLevels(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockLevels", reflect.TypeOf((*MockStore)(nil).ListStockLevels), arg0, arg1)
+}
+
+// ListStockLevelsBelowReorderPoint mocks base method.
+func (m *MockStore) ListStockLevelsBelowReorderPoint(arg0 context.Context) ([]database.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockLevelsBelowReorderPoint", arg0)
+	ret0, _ := ret[0].([]database.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockLevelsBelowReorderPoint indicates an expected call of ListStockLevelsBelowReorderPoint.
+func (mr *MockStoreMockRecorder) ListStockLevelsBelowReorderPoint(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockLevelsBelowReorderPoint", reflect.TypeOf((*MockStore)(nil).ListStockLevelsBelowReorderPoint), arg0)
+}
+
+// ListStockMovements mocks base method.
+func (m *MockStore) ListStockMovements(arg0 context.Context, arg1 database.ListStockMovementsParams) ([]database.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockMovements", arg0, arg1)
+	ret0, _ := ret[0].([]database.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockMovements indicates an expected call of ListStockMovements.
+func (mr *MockStoreMockRecorder) ListStockMovements(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockMovements", reflect.TypeOf((*MockStore)(nil).ListStockMovements), arg0, arg1)
+}
+
+// ListSuppliers mocks base method.
+func (m *MockStore) ListSuppliers(arg0 context.Context, arg1 string) ([]database.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSuppliers", arg0, arg1)
+	ret0, _ := ret[0].([]database.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSuppliers indicates an expected call of ListSuppliers.
+func (mr *MockStoreMockRecorder) ListSuppliers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSuppliers", reflect.TypeOf((*MockStore)(nil).ListSuppliers), arg0, arg1)
+}
+
+// ListUnavailableMenuItems mocks base method.
+func (m *MockStore) ListUnavailableMenuItems(arg0 context.Context, arg1 database.ListUnavailableMenuItemsParams) ([]database.Menu, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnavailableMenuItems", arg0, arg1)
+	ret0, _ := ret[0].([]database.Menu)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnavailableMenuItems indicates an expected call of ListUnavailableMenuItems.
+func (mr *MockStoreMockRecorder) ListUnavailableMenuItems(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnavailableMenuItems", reflect.TypeOf((*MockStore)(nil).ListUnavailableMenuItems), arg0, arg1)
+}
+
+// ListZReports mocks base method.
+func (m *MockStore) ListZReports(arg0 context.Context, arg1 database.ListZReportsParams) ([]database.ZReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListZReports", arg0, arg1)
+	ret0, _ := ret[0].([]database.ZReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListZReports indicates an expected call of ListZReports.
+func (mr *MockStoreMockRecorder) ListZReports(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListZReports", reflect.TypeOf((*MockStore)(nil).ListZReports), arg0, arg1)
+}
+
+// ListZReportsByBusinessDay mocks base method.
+func (m *MockStore) ListZReportsByBusinessDay(arg0 context.Context, arg1 database.ListZReportsByBusinessDayParams) ([]database.ZReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListZReportsByBusinessDay", arg0, arg1)
+	ret0, _ := ret[0].([]database.ZReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListZReportsByBusinessDay indicates an expected call of ListZReportsByBusinessDay.
+func (mr *MockStoreMockRecorder) ListZReportsByBusinessDay(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListZReportsByBusinessDay", reflect.TypeOf((*MockStore)(nil).ListZReportsByBusinessDay), arg0, arg1)
+}
+
+// LockCouponCustomer mocks base method.
+func (m *MockStore) LockCouponCustomer(arg0 context.Context, arg1 database.LockCouponCustomerParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCouponCustomer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockCouponCustomer indicates an expected call of LockCouponCustomer.
+func (mr *MockStoreMockRecorder) LockCouponCustomer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCouponCustomer", reflect.TypeOf((*MockStore)(nil).LockCouponCustomer), arg0, arg1)
+}
+
+// MarkProductSoldOut mocks base method.
+func (m *MockStore) MarkProductSoldOut(arg0 context.Context, arg1 database.MarkProductSoldOutParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkProductSoldOut", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkProductSoldOut indicates an expected call of MarkProductSoldOut.
+func (mr *MockStoreMockRecorder) MarkProductSoldOut(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkProductSoldOut", reflect.TypeOf((*MockStore)(nil).MarkProductSoldOut), arg0, arg1)
+}
+
+// MergeTabsTx mocks base method.
+func (m *MockStore) MergeTabsTx(arg0 context.Context, arg1 database.MergeTabsTxParams) (database.Tab, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTabsTx", arg0, arg1)
+	ret0, _ := ret[0].(database.Tab)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeTabsTx indicates an expected call of MergeTabsTx.
+func (mr *MockStoreMockRecorder) MergeTabsTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTabsTx", reflect.TypeOf((*MockStore)(nil).MergeTabsTx), arg0, arg1)
+}
+
+// MoveKitchenTickets mocks base method.
+func (m *MockStore) MoveKitchenTickets(arg0 context.Context, arg1 database.MoveKitchenTicketsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveKitchenTickets", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveKitchenTickets indicates an expected call of MoveKitchenTickets.
+func (mr *MockStoreMockRecorder) MoveKitchenTickets(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveKitchenTickets", reflect.TypeOf((*MockStore)(nil).MoveKitchenTickets), arg0, arg1)
+}
+
+// MoveOrderItems mocks base method.
+func (m *MockStore) MoveOrderItems(arg0 context.Context, arg1 database.MoveOrderItemsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveOrderItems", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveOrderItems indicates an expected call of MoveOrderItems.
+func (mr *MockStoreMockRecorder) MoveOrderItems(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveOrderItems", reflect.TypeOf((*MockStore)(nil).MoveOrderItems), arg0, arg1)
+}
+
+// MovePayments mocks base method.
+func (m *MockStore) MovePayments(arg0 context.Context, arg1 database.MovePaymentsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovePayments", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MovePayments indicates an expected call of MovePayments.
+func (mr *MockStoreMockRecorder) MovePayments(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovePayments", reflect.TypeOf((*MockStore)(nil).MovePayments), arg0, arg1)
+}
+
+// MoveTab mocks base method.
+func (m *MockStore) MoveTab(arg0 context.Context, arg1 database.MoveTabParams) (database.Tab, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTab", arg0, arg1)
+	ret0, _ := ret[0].(database.Tab)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTab indicates an expected call of MoveTab.
+func (mr *MockStoreMockRecorder) MoveTab(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTab", reflect.TypeOf((*MockStore)(nil).MoveTab), arg0, arg1)
+}
+
+// MoveTabTx mocks base method.
+func (m *MockStore) MoveTabTx(arg0 context.Context, arg1 database.MoveTabTxParams) (database.Tab, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTabTx", arg0, arg1)
+	ret0, _ := ret[0].(database.Tab)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTabTx indicates an expected call of MoveTabTx.
+func (mr *MockStoreMockRecorder) MoveTabTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTabTx", reflect.TypeOf((*MockStore)(nil).MoveTabTx), arg0, arg1)
+}
+
+// OpenTabTx mocks base method.
+func (m *MockStore) OpenTabTx(arg0 context.Context, arg1 database.OpenTabTxParams) (database.Tab, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenTabTx", arg0, arg1)
+	ret0, _ := ret[0].(database.Tab)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenTabTx indicates an expected call of OpenTabTx.
+func (mr *MockStoreMockRecorder) OpenTabTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenTabTx", reflect.TypeOf((*MockStore)(nil).OpenTabTx), arg0, arg1)
+}
+
+// PayCheckTx mocks base method.
+func (m *MockStore) PayCheckTx(arg0 context.Context, arg1 database.PayCheckTxParams) (database.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayCheckTx", arg0, arg1)
+	ret0, _ := ret[0].(database.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayCheckTx indicates an expected call of PayCheckTx.
+func (mr *MockStoreMockRecorder) PayCheckTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayCheckTx", reflect.TypeOf((*MockStore)(nil).PayCheckTx), arg0, arg1)
+}
+
+// ReceivePurchaseOrderLine mocks base method.
+func (m *MockStore) ReceivePurchaseOrderLine(arg0 context.Context, arg1 database.ReceivePurchaseOrderLineParams) (database.PurchaseOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceivePurchaseOrderLine", arg0, arg1)
+	ret0, _ := ret[0].(database.PurchaseOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceivePurchaseOrderLine indicates an expected call of ReceivePurchaseOrderLine.
+func (mr *MockStoreMockRecorder) ReceivePurchaseOrderLine(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceivePurchaseOrderLine", reflect.TypeOf((*MockStore)(nil).ReceivePurchaseOrderLine), arg0, arg1)
+}
+
+// ReceivePurchaseOrderTx mocks base method.
+func (m *MockStore) ReceivePurchaseOrderTx(arg0 context.Context, arg1 database.ReceivePurchaseOrderTxParams) (database.ReceivePurchaseOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceivePurchaseOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(database.ReceivePurchaseOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceivePurchaseOrderTx indicates an expected call of ReceivePurchaseOrderTx.
+func (mr *MockStoreMockRecorder) ReceivePurchaseOrderTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceivePurchaseOrderTx", reflect.TypeOf((*MockStore)(nil).ReceivePurchaseOrderTx), arg0, arg1)
+}
+
+// RedeemCoupon mocks base method.
+func (m *MockStore) RedeemCoupon(arg0 context.Context, arg1 database.RedeemCouponParams) (database.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemCoupon", arg0, arg1)
+	ret0, _ := ret[0].(database.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeemCoupon indicates an expected call of RedeemCoupon.
+func (mr *MockStoreMockRecorder) RedeemCoupon(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemCoupon", reflect.TypeOf((*MockStore)(nil).RedeemCoupon), arg0, arg1)
+}
+
+// RefundOrderItemTx mocks base method.
+func (m *MockStore) RefundOrderItemTx(arg0 context.Context, arg1 database.RefundOrderItemTxParams) (database.RefundOrderItemTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundOrderItemTx", arg0, arg1)
+	ret0, _ := ret[0].(database.RefundOrderItemTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundOrderItemTx indicates an expected call of RefundOrderItemTx.
+func (mr *MockStoreMockRecorder) RefundOrderItemTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundOrderItemTx", reflect.TypeOf((*MockStore)(nil).RefundOrderItemTx), arg0, arg1)
+}
+
+// ReleaseDueKitchenTickets mocks base method.
+func (m *MockStore) ReleaseDueKitchenTickets(arg0 context.Context, arg1 time.Time) ([]database.KitchenTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDueKitchenTickets", arg0, arg1)
+	ret0, _ := ret[0].([]database.KitchenTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseDueKitchenTickets indicates an expected call of ReleaseDueKitchenTickets.
+func (mr *MockStoreMockRecorder) ReleaseDueKitchenTickets(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDueKitchenTickets", reflect.TypeOf((*MockStore)(nil).ReleaseDueKitchenTickets), arg0, arg1)
+}
+
+// RequeueInterruptedPrintJobs mocks base method.
+func (m *MockStore) RequeueInterruptedPrintJobs(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueInterruptedPrintJobs", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueInterruptedPrintJobs indicates an expected call of RequeueInterruptedPrintJobs.
+func (mr *MockStoreMockRecorder) RequeueInterruptedPrintJobs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueInterruptedPrintJobs", reflect.TypeOf((*MockStore)(nil).RequeueInterruptedPrintJobs), arg0)
+}
+
+// ResolveStockAlerts mocks base method.
+func (m *MockStore) ResolveStockAlerts(arg0 context.Context) ([]database.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveStockAlerts", arg0)
+	ret0, _ := ret[0].([]database.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveStockAlerts indicates an expected call of ResolveStockAlerts.
+func (mr *MockStoreMockRecorder) ResolveStockAlerts(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveStockAlerts", reflect.TypeOf((*MockStore)(nil).ResolveStockAlerts), arg0)
+}
+
+// RotateDiningTableLink mocks base method.
+func (m *MockStore) RotateDiningTableLink(arg0 context.Context, arg1 database.RotateDiningTableLinkParams) (database.DiningTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateDiningTableLink", arg0, arg1)
+	ret0, _ := ret[0].(database.DiningTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateDiningTableLink indicates an expected call of RotateDiningTableLink.
+func (mr *MockStoreMockRecorder) RotateDiningTableLink(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateDiningTableLink", reflect.TypeOf((*MockStore)(nil).RotateDiningTableLink), arg0, arg1)
+}
+
+// SetCouponActive mocks base method.
+func (m *MockStore) SetCouponActive(arg0 context.Context, arg1 database.SetCouponActiveParams) (database.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCouponActive", arg0, arg1)
+	ret0, _ := ret[0].(database.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCouponActive indicates an expected call of SetCouponActive.
+func (mr *MockStoreMockRecorder) SetCouponActive(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCouponActive", reflect.TypeOf((*MockStore)(nil).SetCouponActive), arg0, arg1)
+}
+
+// SetCouponBatchActive mocks base method.
+func (m *MockStore) SetCouponBatchActive(arg0 context.Context, arg1 database.SetCouponBatchActiveParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCouponBatchActive", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AddMenuItem indicates an expected call of AddMenuItem.
-func (mr *MockStoreMockRecorder) AddMenuItem(arg0, arg1 any) *gomock.Call {
+// SetCouponBatchActive indicates an expected call of SetCouponBatchActive.
+func (mr *MockStoreMockRecorder) SetCouponBatchActive(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMenuItem", reflect.TypeOf((*MockStore)(nil).AddMenuItem), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCouponBatchActive", reflect.TypeOf((*MockStore)(nil).SetCouponBatchActive), arg0, arg1)
 }
 
-// CreateOrderItem mocks base method.
-func (m *MockStore) CreateOrderItem(arg0 context.Context, arg1 database.CreateOrderItemParams) (database.Order, error) {
+// SetDiningTableStatus mocks base method.
+func (m *MockStore) SetDiningTableStatus(arg0 context.Context, arg1 database.SetDiningTableStatusParams) (database.DiningTable, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrderItem", arg0, arg1)
-	ret0, _ := ret[0].(database.Order)
+	ret := m.ctrl.Call(m, "SetDiningTableStatus", arg0, arg1)
+	ret0, _ := ret[0].(database.DiningTable)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateOrderItem indicates an expected call of CreateOrderItem.
-func (mr *MockStoreMockRecorder) CreateOrderItem(arg0, arg1 any) *gomock.Call {
+// SetDiningTableStatus indicates an expected call of SetDiningTableStatus.
+func (mr *MockStoreMockRecorder) SetDiningTableStatus(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItem", reflect.TypeOf((*MockStore)(nil).CreateOrderItem), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDiningTableStatus", reflect.TypeOf((*MockStore)(nil).SetDiningTableStatus), arg0, arg1)
 }
 
-// CreateProduct mocks base method.
-func (m *MockStore) CreateProduct(arg0 context.Context, arg1 database.CreateProductParams) (database.Product, error) {
+// SetIngredientStock mocks base method.
+func (m *MockStore) SetIngredientStock(arg0 context.Context, arg1 database.SetIngredientStockParams) (database.Ingredient, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProduct", arg0, arg1)
-	ret0, _ := ret[0].(database.Product)
+	ret := m.ctrl.Call(m, "SetIngredientStock", arg0, arg1)
+	ret0, _ := ret[0].(database.Ingredient)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateProduct indicates an expected call of CreateProduct.
-func (mr *MockStoreMockRecorder) CreateProduct(arg0, arg1 any) *gomock.Call {
+// SetIngredientStock indicates an expected call of SetIngredientStock.
+func (mr *MockStoreMockRecorder) SetIngredientStock(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockStore)(nil).CreateProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIngredientStock", reflect.TypeOf((*MockStore)(nil).SetIngredientStock), arg0, arg1)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(arg0 context.Context, arg1 database.CreateUserParams) (database.User, error) {
+// SetKitchenTicketStatus mocks base method.
+func (m *MockStore) SetKitchenTicketStatus(arg0 context.Context, arg1 database.SetKitchenTicketStatusParams) (database.KitchenTicket, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
-	ret0, _ := ret[0].(database.User)
+	ret := m.ctrl.Call(m, "SetKitchenTicketStatus", arg0, arg1)
+	ret0, _ := ret[0].(database.KitchenTicket)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 any) *gomock.Call {
+// SetKitchenTicketStatus indicates an expected call of SetKitchenTicketStatus.
+func (mr *MockStoreMockRecorder) SetKitchenTicketStatus(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKitchenTicketStatus", reflect.TypeOf((*MockStore)(nil).SetKitchenTicketStatus), arg0, arg1)
 }
 
-// DeleteMenuItem mocks base method.
-func (m *MockStore) DeleteMenuItem(arg0 context.Context, arg1 database.DeleteMenuItemParams) error {
+// SetMenuItemAvailability mocks base method.
+func (m *MockStore) SetMenuItemAvailability(arg0 context.Context, arg1 database.SetMenuItemAvailabilityParams) (database.Menu, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMenuItem", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SetMenuItemAvailability", arg0, arg1)
+	ret0, _ := ret[0].(database.Menu)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteMenuItem indicates an expected call of DeleteMenuItem.
-func (mr *MockStoreMockRecorder) DeleteMenuItem(arg0, arg1 any) *gomock.Call {
+// SetMenuItemAvailability indicates an expected call of SetMenuItemAvailability.
+func (mr *MockStoreMockRecorder) SetMenuItemAvailability(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMenuItem", reflect.TypeOf((*MockStore)(nil).DeleteMenuItem), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMenuItemAvailability", reflect.TypeOf((*MockStore)(nil).SetMenuItemAvailability), arg0, arg1)
 }
 
-// DeleteOrderItem mocks base method.
-func (m *MockStore) DeleteOrderItem(arg0 context.Context, arg1 database.DeleteOrderItemParams) error {
+// SetOpeningHoursTx mocks base method.
+func (m *MockStore) SetOpeningHoursTx(arg0 context.Context, arg1 database.SetOpeningHoursTxParams) ([]database.OpeningHour, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOrderItem", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SetOpeningHoursTx", arg0, arg1)
+	ret0, _ := ret[0].([]database.OpeningHour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteOrderItem indicates an expected call of DeleteOrderItem.
-func (mr *MockStoreMockRecorder) DeleteOrderItem(arg0, arg1 any) *gomock.Call {
+// SetOpeningHoursTx indicates an expected call of SetOpeningHoursTx.
+func (mr *MockStoreMockRecorder) SetOpeningHoursTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrderItem", reflect.TypeOf((*MockStore)(nil).DeleteOrderItem), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOpeningHoursTx", reflect.TypeOf((*MockStore)(nil).SetOpeningHoursTx), arg0, arg1)
 }
 
-// DeleteProduct mocks base method.
-func (m *MockStore) DeleteProduct(arg0 context.Context, arg1 database.DeleteProductParams) error {
+// SetOrderFulfilmentStatus mocks base method.
+func (m *MockStore) SetOrderFulfilmentStatus(arg0 context.Context, arg1 database.SetOrderFulfilmentStatusParams) (database.OrderFulfilment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProduct", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SetOrderFulfilmentStatus", arg0, arg1)
+	ret0, _ := ret[0].(database.OrderFulfilment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteProduct indicates an expected call of DeleteProduct.
-func (mr *MockStoreMockRecorder) DeleteProduct(arg0, arg1 any) *gomock.Call {
+// SetOrderFulfilmentStatus indicates an expected call of SetOrderFulfilmentStatus.
+func (mr *MockStoreMockRecorder) SetOrderFulfilmentStatus(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockStore)(nil).DeleteProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrderFulfilmentStatus", reflect.TypeOf((*MockStore)(nil).SetOrderFulfilmentStatus), arg0, arg1)
 }
 
-// DeleteUser mocks base method.
-func (m *MockStore) DeleteUser(arg0 context.Context, arg1 uuid.UUID) error {
+// SetPriceListPricesTx mocks base method.
+func (m *MockStore) SetPriceListPricesTx(arg0 context.Context, arg1 database.SetPriceListPricesTxParams) ([]database.PriceListPrice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SetPriceListPricesTx", arg0, arg1)
+	ret0, _ := ret[0].([]database.PriceListPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockStoreMockRecorder) DeleteUser(arg0, arg1 any) *gomock.Call {
+// SetPriceListPricesTx indicates an expected call of SetPriceListPricesTx.
+func (mr *MockStoreMockRecorder) SetPriceListPricesTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPriceListPricesTx", reflect.TypeOf((*MockStore)(nil).SetPriceListPricesTx), arg0, arg1)
 }
 
-// GetAllMenuItems mocks base method.
-func (m *MockStore) GetAllMenuItems(arg0 context.Context, arg1 string) ([]database.Menu, error) {
+// SetPrintJobFailed mocks base method.
+func (m *MockStore) SetPrintJobFailed(arg0 context.Context, arg1 database.SetPrintJobFailedParams) (database.PrintJob, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllMenuItems", arg0, arg1)
-	ret0, _ := ret[0].([]database.Menu)
+	ret := m.ctrl.Call(m, "SetPrintJobFailed", arg0, arg1)
+	ret0, _ := ret[0].(database.PrintJob)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllMenuItems indicates an expected call of GetAllMenuItems.
-func (mr *MockStoreMockRecorder) GetAllMenuItems(arg0, arg1 any) *gomock.Call {
+// SetPrintJobFailed indicates an expected call of SetPrintJobFailed.
+func (mr *MockStoreMockRecorder) SetPrintJobFailed(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllMenuItems", reflect.TypeOf((*MockStore)(nil).GetAllMenuItems), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrintJobFailed", reflect.TypeOf((*MockStore)(nil).SetPrintJobFailed), arg0, arg1)
 }
 
-// GetAllProducts mocks base method.
-func (m *MockStore) GetAllProducts(arg0 context.Context, arg1 uuid.UUID) ([]database.Product, error) {
+// SetPrintJobPrinted mocks base method.
+func (m *MockStore) SetPrintJobPrinted(arg0 context.Context, arg1 uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllProducts", arg0, arg1)
-	ret0, _ := ret[0].([]database.Product)
+	ret := m.ctrl.Call(m, "SetPrintJobPrinted", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrintJobPrinted indicates an expected call of SetPrintJobPrinted.
+func (mr *MockStoreMockRecorder) SetPrintJobPrinted(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrintJobPrinted", reflect.TypeOf((*MockStore)(nil).SetPrintJobPrinted), arg0, arg1)
+}
+
+// SetPromotionActive mocks base method.
+func (m *MockStore) SetPromotionActive(arg0 context.Context, arg1 database.SetPromotionActiveParams) (database.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPromotionActive", arg0, arg1)
+	ret0, _ := ret[0].(database.Promotion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllProducts indicates an expected call of GetAllProducts.
-func (mr *MockStoreMockRecorder) GetAllProducts(arg0, arg1 any) *gomock.Call {
+// SetPromotionActive indicates an expected call of SetPromotionActive.
+func (mr *MockStoreMockRecorder) SetPromotionActive(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockStore)(nil).GetAllProducts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPromotionActive", reflect.TypeOf((*MockStore)(nil).SetPromotionActive), arg0, arg1)
 }
 
-// GetOrdersByDay mocks base method.
-func (m *MockStore) GetOrdersByDay(arg0 context.Context, arg1 database.GetOrdersByDayParams) ([]database.Order, error) {
+// SetRecipeTx mocks base method.
+func (m *MockStore) SetRecipeTx(arg0 context.Context, arg1 database.SetRecipeTxParams) ([]database.RecipeItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrdersByDay", arg0, arg1)
-	ret0, _ := ret[0].([]database.Order)
+	ret := m.ctrl.Call(m, "SetRecipeTx", arg0, arg1)
+	ret0, _ := ret[0].([]database.RecipeItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrdersByDay indicates an expected call of GetOrdersByDay.
-func (mr *MockStoreMockRecorder) GetOrdersByDay(arg0, arg1 any) *gomock.Call {
+// SetRecipeTx indicates an expected call of SetRecipeTx.
+func (mr *MockStoreMockRecorder) SetRecipeTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByDay", reflect.TypeOf((*MockStore)(nil).GetOrdersByDay), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecipeTx", reflect.TypeOf((*MockStore)(nil).SetRecipeTx), arg0, arg1)
 }
 
-// GetOrdersByOrderID mocks base method.
-func (m *MockStore) GetOrdersByOrderID(arg0 context.Context, arg1 database.GetOrdersByOrderIDParams) ([]database.Order, error) {
+// SetStockLevel mocks base method.
+func (m *MockStore) SetStockLevel(arg0 context.Context, arg1 database.SetStockLevelParams) (database.StockLevel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrdersByOrderID", arg0, arg1)
-	ret0, _ := ret[0].([]database.Order)
+	ret := m.ctrl.Call(m, "SetStockLevel", arg0, arg1)
+	ret0, _ := ret[0].(database.StockLevel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrdersByOrderID indicates an expected call of GetOrdersByOrderID.
-func (mr *MockStoreMockRecorder) GetOrdersByOrderID(arg0, arg1 any) *gomock.Call {
+// SetStockLevel indicates an expected call of SetStockLevel.
+func (mr *MockStoreMockRecorder) SetStockLevel(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByOrderID", reflect.TypeOf((*MockStore)(nil).GetOrdersByOrderID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStockLevel", reflect.TypeOf((*MockStore)(nil).SetStockLevel), arg0, arg1)
 }
 
-// GetProduct mocks base method.
-func (m *MockStore) GetProduct(arg0 context.Context, arg1 database.GetProductParams) (database.Product, error) {
+// SetStockReorderLevels mocks base method.
+func (m *MockStore) SetStockReorderLevels(arg0 context.Context, arg1 database.SetStockReorderLevelsParams) (database.StockLevel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProduct", arg0, arg1)
-	ret0, _ := ret[0].(database.Product)
+	ret := m.ctrl.Call(m, "SetStockReorderLevels", arg0, arg1)
+	ret0, _ := ret[0].(database.StockLevel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProduct indicates an expected call of GetProduct.
-func (mr *MockStoreMockRecorder) GetProduct(arg0, arg1 any) *gomock.Call {
+// SetStockReorderLevels indicates an expected call of SetStockReorderLevels.
+func (mr *MockStoreMockRecorder) SetStockReorderLevels(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockStore)(nil).GetProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStockReorderLevels", reflect.TypeOf((*MockStore)(nil).SetStockReorderLevels), arg0, arg1)
 }
 
-// GetProductsByName mocks base method.
-func (m *MockStore) GetProductsByName(arg0 context.Context, arg1 database.GetProductsByNameParams) ([]database.Product, error) {
+// SplitOrderTx mocks base method.
+func (m *MockStore) SplitOrderTx(arg0 context.Context, arg1 database.SplitOrderTxParams) (database.SplitOrderTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductsByName", arg0, arg1)
-	ret0, _ := ret[0].([]database.Product)
+	ret := m.ctrl.Call(m, "SplitOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(database.SplitOrderTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductsByName indicates an expected call of GetProductsByName.
-func (mr *MockStoreMockRecorder) GetProductsByName(arg0, arg1 any) *gomock.Call {
+// SplitOrderTx indicates an expected call of SplitOrderTx.
+func (mr *MockStoreMockRecorder) SplitOrderTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsByName", reflect.TypeOf((*MockStore)(nil).GetProductsByName), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SplitOrderTx", reflect.TypeOf((*MockStore)(nil).SplitOrderTx), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (database.User, error) {
+// StockMovementTx mocks base method.
+func (m *MockStore) StockMovementTx(arg0 context.Context, arg1 database.StockMovementTxParams) (database.StockMovementTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(database.User)
+	ret := m.ctrl.Call(m, "StockMovementTx", arg0, arg1)
+	ret0, _ := ret[0].(database.StockMovementTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 any) *gomock.Call {
+// StockMovementTx indicates an expected call of StockMovementTx.
+func (mr *MockStoreMockRecorder) StockMovementTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StockMovementTx", reflect.TypeOf((*MockStore)(nil).StockMovementTx), arg0, arg1)
 }
 
 // UpdateMenuItem mocks base method.
@@ -292,6 +3400,66 @@ func (mr *MockStoreMockRecorder) UpdateOrderItem(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderItem", reflect.TypeOf((*MockStore)(nil).UpdateOrderItem), arg0, arg1)
 }
 
+// UpdateOrderItemPrice mocks base method.
+func (m *MockStore) UpdateOrderItemPrice(arg0 context.Context, arg1 database.UpdateOrderItemPriceParams) (database.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderItemPrice", arg0, arg1)
+	ret0, _ := ret[0].(database.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrderItemPrice indicates an expected call of UpdateOrderItemPrice.
+func (mr *MockStoreMockRecorder) UpdateOrderItemPrice(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderItemPrice", reflect.TypeOf((*MockStore)(nil).UpdateOrderItemPrice), arg0, arg1)
+}
+
+// UpdateOrderItemTx mocks base method.
+func (m *MockStore) UpdateOrderItemTx(arg0 context.Context, arg1 database.UpdateOrderItemParams) (database.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderItemTx", arg0, arg1)
+	ret0, _ := ret[0].(database.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrderItemTx indicates an expected call of UpdateOrderItemTx.
+func (mr *MockStoreMockRecorder) UpdateOrderItemTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderItemTx", reflect.TypeOf((*MockStore)(nil).UpdateOrderItemTx), arg0, arg1)
+}
+
+// UpdatePriceList mocks base method.
+func (m *MockStore) UpdatePriceList(arg0 context.Context, arg1 database.UpdatePriceListParams) (database.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePriceList", arg0, arg1)
+	ret0, _ := ret[0].(database.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePriceList indicates an expected call of UpdatePriceList.
+func (mr *MockStoreMockRecorder) UpdatePriceList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePriceList", reflect.TypeOf((*MockStore)(nil).UpdatePriceList), arg0, arg1)
+}
+
+// UpdatePriceListTx mocks base method.
+func (m *MockStore) UpdatePriceListTx(arg0 context.Context, arg1 database.SetPriceListTxParams) (database.PriceListTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePriceListTx", arg0, arg1)
+	ret0, _ := ret[0].(database.PriceListTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePriceListTx indicates an expected call of UpdatePriceListTx.
+func (mr *MockStoreMockRecorder) UpdatePriceListTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePriceListTx", reflect.TypeOf((*MockStore)(nil).UpdatePriceListTx), arg0, arg1)
+}
+
 // UpdateProduct mocks base method.
 func (m *MockStore) UpdateProduct(arg0 context.Context, arg1 database.UpdateProductParams) (database.Product, error) {
 	m.ctrl.T.Helper()
@@ -306,3 +3474,183 @@ func (mr *MockStoreMockRecorder) UpdateProduct(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockStore)(nil).UpdateProduct), arg0, arg1)
 }
+
+// UpdateProductPrepTime mocks base method.
+func (m *MockStore) UpdateProductPrepTime(arg0 context.Context, arg1 database.UpdateProductPrepTimeParams) (database.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductPrepTime", arg0, arg1)
+	ret0, _ := ret[0].(database.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProductPrepTime indicates an expected call of UpdateProductPrepTime.
+func (mr *MockStoreMockRecorder) UpdateProductPrepTime(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductPrepTime", reflect.TypeOf((*MockStore)(nil).UpdateProductPrepTime), arg0, arg1)
+}
+
+// UpdatePurchaseOrderStatus mocks base method.
+func (m *MockStore) UpdatePurchaseOrderStatus(arg0 context.Context, arg1 database.UpdatePurchaseOrderStatusParams) (database.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePurchaseOrderStatus", arg0, arg1)
+	ret0, _ := ret[0].(database.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePurchaseOrderStatus indicates an expected call of UpdatePurchaseOrderStatus.
+func (mr *MockStoreMockRecorder) UpdatePurchaseOrderStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePurchaseOrderStatus", reflect.TypeOf((*MockStore)(nil).UpdatePurchaseOrderStatus), arg0, arg1)
+}
+
+// UpdateShopBusinessDayCutoff mocks base method.
+func (m *MockStore) UpdateShopBusinessDayCutoff(arg0 context.Context, arg1 database.UpdateShopBusinessDayCutoffParams) (database.Shop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShopBusinessDayCutoff", arg0, arg1)
+	ret0, _ := ret[0].(database.Shop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateShopBusinessDayCutoff indicates an expected call of UpdateShopBusinessDayCutoff.
+func (mr *MockStoreMockRecorder) UpdateShopBusinessDayCutoff(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShopBusinessDayCutoff", reflect.TypeOf((*MockStore)(nil).UpdateShopBusinessDayCutoff), arg0, arg1)
+}
+
+// UpdateShopLocale mocks base method.
+func (m *MockStore) UpdateShopLocale(arg0 context.Context, arg1 database.UpdateShopLocaleParams) (database.Shop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShopLocale", arg0, arg1)
+	ret0, _ := ret[0].(database.Shop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateShopLocale indicates an expected call of UpdateShopLocale.
+func (mr *MockStoreMockRecorder) UpdateShopLocale(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShopLocale", reflect.TypeOf((*MockStore)(nil).UpdateShopLocale), arg0, arg1)
+}
+
+// UpdateShopProfile mocks base method.
+func (m *MockStore) UpdateShopProfile(arg0 context.Context, arg1 database.UpdateShopProfileParams) (database.Shop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShopProfile", arg0, arg1)
+	ret0, _ := ret[0].(database.Shop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateShopProfile indicates an expected call of UpdateShopProfile.
+func (mr *MockStoreMockRecorder) UpdateShopProfile(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShopProfile", reflect.TypeOf((*MockStore)(nil).UpdateShopProfile), arg0, arg1)
+}
+
+// UpdateShopReceipt mocks base method.
+func (m *MockStore) UpdateShopReceipt(arg0 context.Context, arg1 database.UpdateShopReceiptParams) (database.Shop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShopReceipt", arg0, arg1)
+	ret0, _ := ret[0].(database.Shop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateShopReceipt indicates an expected call of UpdateShopReceipt.
+func (mr *MockStoreMockRecorder) UpdateShopReceipt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShopReceipt", reflect.TypeOf((*MockStore)(nil).UpdateShopReceipt), arg0, arg1)
+}
+
+// UpdateShopTimezone mocks base method.
+func (m *MockStore) UpdateShopTimezone(arg0 context.Context, arg1 database.UpdateShopTimezoneParams) (database.Shop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShopTimezone", arg0, arg1)
+	ret0, _ := ret[0].(database.Shop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateShopTimezone indicates an expected call of UpdateShopTimezone.
+func (mr *MockStoreMockRecorder) UpdateShopTimezone(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShopTimezone", reflect.TypeOf((*MockStore)(nil).UpdateShopTimezone), arg0, arg1)
+}
+
+// UpsertAccountMapping mocks base method.
+func (m *MockStore) UpsertAccountMapping(arg0 context.Context, arg1 database.UpsertAccountMappingParams) (database.AccountMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertAccountMapping", arg0, arg1)
+	ret0, _ := ret[0].(database.AccountMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertAccountMapping indicates an expected call of UpsertAccountMapping.
+func (mr *MockStoreMockRecorder) UpsertAccountMapping(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertAccountMapping", reflect.TypeOf((*MockStore)(nil).UpsertAccountMapping), arg0, arg1)
+}
+
+// UpsertCatalogRoute mocks base method.
+func (m *MockStore) UpsertCatalogRoute(arg0 context.Context, arg1 database.UpsertCatalogRouteParams) (database.StationRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCatalogRoute", arg0, arg1)
+	ret0, _ := ret[0].(database.StationRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCatalogRoute indicates an expected call of UpsertCatalogRoute.
+func (mr *MockStoreMockRecorder) UpsertCatalogRoute(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCatalogRoute", reflect.TypeOf((*MockStore)(nil).UpsertCatalogRoute), arg0, arg1)
+}
+
+// UpsertOrderSchedule mocks base method.
+func (m *MockStore) UpsertOrderSchedule(arg0 context.Context, arg1 database.UpsertOrderScheduleParams) (database.OrderSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertOrderSchedule", arg0, arg1)
+	ret0, _ := ret[0].(database.OrderSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertOrderSchedule indicates an expected call of UpsertOrderSchedule.
+func (mr *MockStoreMockRecorder) UpsertOrderSchedule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOrderSchedule", reflect.TypeOf((*MockStore)(nil).UpsertOrderSchedule), arg0, arg1)
+}
+
+// UpsertProductRoute mocks base method.
+func (m *MockStore) UpsertProductRoute(arg0 context.Context, arg1 database.UpsertProductRouteParams) (database.StationRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertProductRoute", arg0, arg1)
+	ret0, _ := ret[0].(database.StationRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertProductRoute indicates an expected call of UpsertProductRoute.
+func (mr *MockStoreMockRecorder) UpsertProductRoute(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProductRoute", reflect.TypeOf((*MockStore)(nil).UpsertProductRoute), arg0, arg1)
+}
+
+// UpsertStockLevel mocks base method.
+func (m *MockStore) UpsertStockLevel(arg0 context.Context, arg1 database.UpsertStockLevelParams) (database.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertStockLevel", arg0, arg1)
+	ret0, _ := ret[0].(database.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertStockLevel indicates an expected call of UpsertStockLevel.
+func (mr *MockStoreMockRecorder) UpsertStockLevel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertStockLevel", reflect.TypeOf((*MockStore)(nil).UpsertStockLevel), arg0, arg1)
+}
@@ -5,14 +5,123 @@
 package database
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type AccountMapping struct {
+	ShopName       string          `json:"shop_name"`
+	CashClearing   string          `json:"cash_clearing"`
+	CardClearing   string          `json:"card_clearing"`
+	OtherClearing  string          `json:"other_clearing"`
+	Sales          string          `json:"sales"`
+	SalesByTaxRate json.RawMessage `json:"sales_by_tax_rate"`
+	TaxPayable     string          `json:"tax_payable"`
+	RefundClearing string          `json:"refund_clearing"`
+	Receivable     string          `json:"receivable"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+type Check struct {
+	ID        uuid.UUID `json:"id"`
+	ShopName  string    `json:"shop_name"`
+	OrderID   uuid.UUID `json:"order_id"`
+	Number    int32     `json:"number"`
+	Label     string    `json:"label"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CheckLine struct {
+	CheckID     uuid.UUID `json:"check_id"`
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	Parts       int32     `json:"parts"`
+}
+
+type Coupon struct {
+	ID             uuid.UUID     `json:"id"`
+	ShopName       string        `json:"shop_name"`
+	PromotionID    uuid.UUID     `json:"promotion_id"`
+	Code           string        `json:"code"`
+	BatchID        uuid.NullUUID `json:"batch_id"`
+	MaxRedemptions int32         `json:"max_redemptions"`
+	MaxPerCustomer int32         `json:"max_per_customer"`
+	Redemptions    int32         `json:"redemptions"`
+	ExpiresAt      sql.NullTime  `json:"expires_at"`
+	Active         bool          `json:"active"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type CouponRedemption struct {
+	ID          uuid.UUID `json:"id"`
+	CouponID    uuid.UUID `json:"coupon_id"`
+	PromotionID uuid.UUID `json:"promotion_id"`
+	OrderID     uuid.UUID `json:"order_id"`
+	Customer    string    `json:"customer"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type DiningTable struct {
+	ID          uuid.UUID `json:"id"`
+	ShopName    string    `json:"shop_name"`
+	AreaID      uuid.UUID `json:"area_id"`
+	Name        string    `json:"name"`
+	Capacity    int32     `json:"capacity"`
+	PosX        int32     `json:"pos_x"`
+	PosY        int32     `json:"pos_y"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	LinkVersion int32     `json:"link_version"`
+}
+
+type FloorArea struct {
+	ID        uuid.UUID `json:"id"`
+	ShopName  string    `json:"shop_name"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Ingredient struct {
+	ID        uuid.UUID `json:"id"`
+	ShopName  string    `json:"shop_name"`
+	Name      string    `json:"name"`
+	Unit      string    `json:"unit"`
+	OnHand    string    `json:"on_hand"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type IngredientMovement struct {
+	ID           uuid.UUID     `json:"id"`
+	ShopName     string        `json:"shop_name"`
+	IngredientID uuid.UUID     `json:"ingredient_id"`
+	MovementType string        `json:"movement_type"`
+	Quantity     string        `json:"quantity"`
+	OrderItemID  uuid.NullUUID `json:"order_item_id"`
+	Note         string        `json:"note"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type KitchenTicket struct {
+	ID        uuid.UUID    `json:"id"`
+	ShopName  string       `json:"shop_name"`
+	OrderID   uuid.UUID    `json:"order_id"`
+	StationID uuid.UUID    `json:"station_id"`
+	Status    string       `json:"status"`
+	CreatedAt time.Time    `json:"created_at"`
+	BumpedAt  sql.NullTime `json:"bumped_at"`
+	ReleaseAt time.Time    `json:"release_at"`
+	Released  bool         `json:"released"`
+}
+
+type KitchenTicketItem struct {
+	TicketID    uuid.UUID `json:"ticket_id"`
+	OrderItemID uuid.UUID `json:"order_item_id"`
+}
+
 type Menu struct {
 	ID           uuid.UUID `json:"id"`
-	UserID       uuid.UUID `json:"user_id"`
 	ShopName     string    `json:"shop_name"`
 	ProductID    uuid.UUID `json:"product_id"`
 	ProductName  string    `json:"product_name"`
@@ -20,27 +129,320 @@ type Menu struct {
 	Catalog      string    `json:"catalog"`
 	Description  string    `json:"description"`
 	CreatedAt    time.Time `json:"created_at"`
+	Available    bool      `json:"available"`
+}
+
+type OpeningException struct {
+	ID        uuid.UUID `json:"id"`
+	ShopName  string    `json:"shop_name"`
+	Day       string    `json:"day"`
+	Closed    bool      `json:"closed"`
+	OpensAt   int32     `json:"opens_at"`
+	ClosesAt  int32     `json:"closes_at"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OpeningHour struct {
+	ID       uuid.UUID `json:"id"`
+	ShopName string    `json:"shop_name"`
+	Weekday  int32     `json:"weekday"`
+	OpensAt  int32     `json:"opens_at"`
+	ClosesAt int32     `json:"closes_at"`
 }
 
 type Order struct {
+	ID           uuid.UUID     `json:"id"`
+	ShopName     string        `json:"shop_name"`
+	OrderID      uuid.UUID     `json:"order_id"`
+	OrderDay     string        `json:"order_day"`
+	ProductName  string        `json:"product_name"`
+	ProductPrice string        `json:"product_price"`
+	Amount       int32         `json:"amount"`
+	Status       string        `json:"status"`
+	CreatedAt    time.Time     `json:"created_at"`
+	ProductID    uuid.NullUUID `json:"product_id"`
+	TaxRate      string        `json:"tax_rate"`
+	Seat         int32         `json:"seat"`
+	OrderType    string        `json:"order_type"`
+	PriceListID  uuid.NullUUID `json:"price_list_id"`
+	PromotionID  uuid.NullUUID `json:"promotion_id"`
+	FeeID        uuid.NullUUID `json:"fee_id"`
+}
+
+type OrderFulfilment struct {
+	ID              uuid.UUID    `json:"id"`
+	ShopName        string       `json:"shop_name"`
+	OrderID         uuid.UUID    `json:"order_id"`
+	OrderType       string       `json:"order_type"`
+	Status          string       `json:"status"`
+	PickupAt        sql.NullTime `json:"pickup_at"`
+	DeliveryAddress string       `json:"delivery_address"`
+	ContactName     string       `json:"contact_name"`
+	ContactPhone    string       `json:"contact_phone"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+	ScheduledFor    sql.NullTime `json:"scheduled_for"`
+}
+
+type OrderSchedule struct {
+	ShopName         string    `json:"shop_name"`
+	MaxOrdersPerSlot int32     `json:"max_orders_per_slot"`
+	MaxItemsPerSlot  int32     `json:"max_items_per_slot"`
+	LeadMinutes      int32     `json:"lead_minutes"`
+	MaxDaysAhead     int32     `json:"max_days_ahead"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type OrderSlot struct {
+	ShopName  string    `json:"shop_name"`
+	SlotStart time.Time `json:"slot_start"`
+	Orders    int32     `json:"orders"`
+	Items     int32     `json:"items"`
+}
+
+type OrderTypeFee struct {
+	ID        uuid.UUID `json:"id"`
+	ShopName  string    `json:"shop_name"`
+	OrderType string    `json:"order_type"`
+	Name      string    `json:"name"`
+	Kind      string    `json:"kind"`
+	Amount    string    `json:"amount"`
+	TaxRate   string    `json:"tax_rate"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Organisation struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OrganisationMember struct {
+	OrganisationID uuid.UUID     `json:"organisation_id"`
+	UserID         uuid.UUID     `json:"user_id"`
+	ShopID         uuid.NullUUID `json:"shop_id"`
+	Role           string        `json:"role"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type Payment struct {
+	ID        uuid.UUID     `json:"id"`
+	ShopName  string        `json:"shop_name"`
+	OrderID   uuid.UUID     `json:"order_id"`
+	Method    string        `json:"method"`
+	Amount    string        `json:"amount"`
+	Tendered  string        `json:"tendered"`
+	CreatedAt time.Time     `json:"created_at"`
+	CheckID   uuid.NullUUID `json:"check_id"`
+}
+
+type PriceList struct {
+	ID        uuid.UUID `json:"id"`
+	ShopName  string    `json:"shop_name"`
+	Name      string    `json:"name"`
+	Priority  int32     `json:"priority"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PriceListPrice struct {
+	ID          uuid.UUID     `json:"id"`
+	PriceListID uuid.UUID     `json:"price_list_id"`
+	ProductID   uuid.NullUUID `json:"product_id"`
+	MenuItemID  uuid.NullUUID `json:"menu_item_id"`
+	Price       string        `json:"price"`
+}
+
+type PriceListRule struct {
+	ID          uuid.UUID `json:"id"`
+	PriceListID uuid.UUID `json:"price_list_id"`
+	Channel     string    `json:"channel"`
+	OrderType   string    `json:"order_type"`
+	Weekday     int32     `json:"weekday"`
+	StartsAt    int32     `json:"starts_at"`
+	EndsAt      int32     `json:"ends_at"`
+}
+
+type PrintJob struct {
+	ID            uuid.UUID    `json:"id"`
+	ShopName      string       `json:"shop_name"`
+	PrinterID     uuid.UUID    `json:"printer_id"`
+	Kind          string       `json:"kind"`
+	ReferenceID   uuid.UUID    `json:"reference_id"`
+	Payload       []byte       `json:"payload"`
+	Status        string       `json:"status"`
+	Attempts      int32        `json:"attempts"`
+	LastError     string       `json:"last_error"`
+	NextAttemptAt time.Time    `json:"next_attempt_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+	PrintedAt     sql.NullTime `json:"printed_at"`
+}
+
+type Printer struct {
+	ID             uuid.UUID     `json:"id"`
+	ShopName       string        `json:"shop_name"`
+	Name           string        `json:"name"`
+	Address        string        `json:"address"`
+	PaperWidth     int32         `json:"paper_width"`
+	StationID      uuid.NullUUID `json:"station_id"`
+	PrintsReceipts bool          `json:"prints_receipts"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type Product struct {
+	ID             uuid.UUID `json:"id"`
+	OrganisationID uuid.UUID `json:"organisation_id"`
+	Name           string    `json:"name"`
+	Price          string    `json:"price"`
+	Description    string    `json:"description"`
+	CreatedAt      time.Time `json:"created_at"`
+	PrepSeconds    int32     `json:"prep_seconds"`
+	TaxRate        string    `json:"tax_rate"`
+}
+
+type ProductCost struct {
+	ID                  uuid.UUID     `json:"id"`
+	ShopName            string        `json:"shop_name"`
+	ProductID           uuid.UUID     `json:"product_id"`
+	PurchaseOrderLineID uuid.NullUUID `json:"purchase_order_line_id"`
+	Quantity            int32         `json:"quantity"`
+	UnitCost            string        `json:"unit_cost"`
+	CreatedAt           time.Time     `json:"created_at"`
+}
+
+type Promotion struct {
+	ID           uuid.UUID     `json:"id"`
+	ShopName     string        `json:"shop_name"`
+	Name         string        `json:"name"`
+	Target       string        `json:"target"`
+	Kind         string        `json:"kind"`
+	Amount       string        `json:"amount"`
+	ProductID    uuid.NullUUID `json:"product_id"`
+	Catalog      string        `json:"catalog"`
+	BuyQuantity  int32         `json:"buy_quantity"`
+	GetQuantity  int32         `json:"get_quantity"`
+	MinSpend     string        `json:"min_spend"`
+	StartsAt     sql.NullTime  `json:"starts_at"`
+	EndsAt       sql.NullTime  `json:"ends_at"`
+	Priority     int32         `json:"priority"`
+	Stackable    bool          `json:"stackable"`
+	Active       bool          `json:"active"`
+	CreatedAt    time.Time     `json:"created_at"`
+	RequiresCode bool          `json:"requires_code"`
+}
+
+type PurchaseOrder struct {
 	ID           uuid.UUID `json:"id"`
 	ShopName     string    `json:"shop_name"`
-	OrderID      uuid.UUID `json:"order_id"`
-	OrderDay     string    `json:"order_day"`
-	ProductName  string    `json:"product_name"`
-	ProductPrice string    `json:"product_price"`
-	Amount       int32     `json:"amount"`
+	SupplierID   uuid.UUID `json:"supplier_id"`
 	Status       string    `json:"status"`
+	ExpectedDate string    `json:"expected_date"`
+	Note         string    `json:"note"`
 	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
 
-type Product struct {
-	ID          uuid.UUID `json:"id"`
-	UserID      uuid.UUID `json:"user_id"`
-	Name        string    `json:"name"`
-	Price       string    `json:"price"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
+type PurchaseOrderLine struct {
+	ID               uuid.UUID `json:"id"`
+	PurchaseOrderID  uuid.UUID `json:"purchase_order_id"`
+	ProductID        uuid.UUID `json:"product_id"`
+	QuantityOrdered  int32     `json:"quantity_ordered"`
+	QuantityReceived int32     `json:"quantity_received"`
+	UnitCost         string    `json:"unit_cost"`
+}
+
+type RecipeItem struct {
+	ID           uuid.UUID `json:"id"`
+	ProductID    uuid.UUID `json:"product_id"`
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	Quantity     string    `json:"quantity"`
+	Unit         string    `json:"unit"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Shop struct {
+	ID                uuid.UUID `json:"id"`
+	OrganisationID    uuid.UUID `json:"organisation_id"`
+	Name              string    `json:"name"`
+	DisplayName       string    `json:"display_name"`
+	Address           string    `json:"address"`
+	Phone             string    `json:"phone"`
+	Currency          string    `json:"currency"`
+	Timezone          string    `json:"timezone"`
+	Locale            string    `json:"locale"`
+	BusinessDayCutoff int32     `json:"business_day_cutoff"`
+	ReceiptHeader     string    `json:"receipt_header"`
+	ReceiptFooter     string    `json:"receipt_footer"`
+	LogoURL           string    `json:"logo_url"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type Station struct {
+	ID        uuid.UUID `json:"id"`
+	ShopName  string    `json:"shop_name"`
+	Name      string    `json:"name"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type StationRoute struct {
+	ID        uuid.UUID      `json:"id"`
+	ShopName  string         `json:"shop_name"`
+	StationID uuid.UUID      `json:"station_id"`
+	Catalog   sql.NullString `json:"catalog"`
+	ProductID uuid.NullUUID  `json:"product_id"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type StockAlert struct {
+	ID           uuid.UUID    `json:"id"`
+	ShopName     string       `json:"shop_name"`
+	ProductID    uuid.UUID    `json:"product_id"`
+	OnHand       int32        `json:"on_hand"`
+	ReorderPoint int32        `json:"reorder_point"`
+	CreatedAt    time.Time    `json:"created_at"`
+	ResolvedAt   sql.NullTime `json:"resolved_at"`
+}
+
+type StockLevel struct {
+	ShopName     string    `json:"shop_name"`
+	ProductID    uuid.UUID `json:"product_id"`
+	OnHand       int32     `json:"on_hand"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	ReorderPoint int32     `json:"reorder_point"`
+	ParLevel     int32     `json:"par_level"`
+}
+
+type StockMovement struct {
+	ID           uuid.UUID     `json:"id"`
+	ShopName     string        `json:"shop_name"`
+	ProductID    uuid.UUID     `json:"product_id"`
+	MovementType string        `json:"movement_type"`
+	Quantity     int32         `json:"quantity"`
+	OrderItemID  uuid.NullUUID `json:"order_item_id"`
+	Note         string        `json:"note"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type Supplier struct {
+	ID        uuid.UUID `json:"id"`
+	ShopName  string    `json:"shop_name"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Tab struct {
+	ID       uuid.UUID     `json:"id"`
+	ShopName string        `json:"shop_name"`
+	TableID  uuid.NullUUID `json:"table_id"`
+	OrderID  uuid.UUID     `json:"order_id"`
+	Guests   int32         `json:"guests"`
+	Status   string        `json:"status"`
+	OpenedAt time.Time     `json:"opened_at"`
+	ClosedAt sql.NullTime  `json:"closed_at"`
 }
 
 type User struct {
@@ -49,3 +451,19 @@ type User struct {
 	HashedPassword string    `json:"hashed_password"`
 	CreatedAt      time.Time `json:"created_at"`
 }
+
+type ZReport struct {
+	ID            uuid.UUID       `json:"id"`
+	ShopName      string          `json:"shop_name"`
+	Number        int32           `json:"number"`
+	BusinessDay   string          `json:"business_day"`
+	GrossSales    string          `json:"gross_sales"`
+	Discounts     string          `json:"discounts"`
+	Refunds       string          `json:"refunds"`
+	NetSales      string          `json:"net_sales"`
+	Tax           string          `json:"tax"`
+	OrderCount    int32           `json:"order_count"`
+	AverageTicket string          `json:"average_ticket"`
+	Tenders       json.RawMessage `json:"tenders"`
+	ClosedAt      time.Time       `json:"closed_at"`
+}
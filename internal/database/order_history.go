@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OrderHistorySortCreatedAt = "created_at"
+	OrderHistorySortAmount    = "amount"
+)
+
+// position of the last row of a page, only the field of the sort column is used
+type OrderHistoryCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	Amount    int32     `json:"amount"`
+	ID        uuid.UUID `json:"id"`
+}
+
+type OrderHistoryParams struct {
+	ShopName    string
+	FromDay     string
+	ToDay       string
+	Status      string // empty matches every status
+	ProductName string // case-insensitive substring, empty matches every product
+	MinAmount   int32
+	MaxAmount   int32
+	SortBy      string
+	Descending  bool
+	Cursor      *OrderHistoryCursor // nil starts from the first page
+	PageSize    int32
+}
+
+// list a page of order items in keyset order, each sort direction has its
+// own query so postgres can walk the (shop_name, sort column, id) index
+func (store *SQLStore) ListOrderHistory(ctx context.Context, arg OrderHistoryParams) ([]Order, error) {
+	cursor := OrderHistoryCursor{
+		CreatedAt: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Amount:    math.MinInt32,
+		ID:        uuid.Nil,
+	}
+	if arg.Descending {
+		cursor = OrderHistoryCursor{
+			CreatedAt: time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC),
+			Amount:    math.MaxInt32,
+			ID:        uuid.Max,
+		}
+	}
+	if arg.Cursor != nil {
+		cursor = *arg.Cursor
+	}
+
+	if arg.SortBy == OrderHistorySortAmount {
+		params := ListOrderHistoryByAmountAscParams{
+			ShopName:     arg.ShopName,
+			FromDay:      arg.FromDay,
+			ToDay:        arg.ToDay,
+			Status:       arg.Status,
+			ProductName:  arg.ProductName,
+			MinAmount:    arg.MinAmount,
+			MaxAmount:    arg.MaxAmount,
+			CursorAmount: cursor.Amount,
+			CursorID:     cursor.ID,
+			PageSize:     arg.PageSize,
+		}
+		if arg.Descending {
+			return store.ListOrderHistoryByAmountDesc(ctx, ListOrderHistoryByAmountDescParams(params))
+		}
+		return store.ListOrderHistoryByAmountAsc(ctx, params)
+	}
+
+	params := ListOrderHistoryByCreatedAtAscParams{
+		ShopName:        arg.ShopName,
+		FromDay:         arg.FromDay,
+		ToDay:           arg.ToDay,
+		Status:          arg.Status,
+		ProductName:     arg.ProductName,
+		MinAmount:       arg.MinAmount,
+		MaxAmount:       arg.MaxAmount,
+		CursorCreatedAt: cursor.CreatedAt,
+		CursorID:        cursor.ID,
+		PageSize:        arg.PageSize,
+	}
+	if arg.Descending {
+		return store.ListOrderHistoryByCreatedAtDesc(ctx, ListOrderHistoryByCreatedAtDescParams(params))
+	}
+	return store.ListOrderHistoryByCreatedAtAsc(ctx, params)
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func TestListOrderHistory(t *testing.T) {
+	shop := createRandomShop(t)
+	for i := 0; i < 5; i++ {
+		createRandomOrderItem(t, shop, utils.RandOrderID(), "2024-02-01")
+	}
+	createRandomOrderItem(t, shop, utils.RandOrderID(), "2024-03-01")
+
+	for _, sortBy := range []string{OrderHistorySortCreatedAt, OrderHistorySortAmount} {
+		for _, descending := range []bool{false, true} {
+			arg := OrderHistoryParams{
+				ShopName:   shop.Name,
+				FromDay:    "2024-02-01",
+				ToDay:      "2024-02-29",
+				MaxAmount:  math.MaxInt32,
+				SortBy:     sortBy,
+				Descending: descending,
+				PageSize:   2,
+			}
+
+			// walk every page, each row is seen exactly once and in order
+			var seen []Order
+			for {
+				page, err := testStore.ListOrderHistory(context.Background(), arg)
+				require.NoError(t, err)
+				if len(page) == 0 {
+					break
+				}
+				seen = append(seen, page...)
+
+				last := page[len(page)-1]
+				arg.Cursor = &OrderHistoryCursor{CreatedAt: last.CreatedAt, Amount: last.Amount, ID: last.ID}
+			}
+			require.Len(t, seen, 5)
+
+			for i := 1; i < len(seen); i++ {
+				prev, cur := seen[i-1], seen[i]
+				if sortBy == OrderHistorySortAmount {
+					if descending {
+						require.GreaterOrEqual(t, prev.Amount, cur.Amount)
+					} else {
+						require.LessOrEqual(t, prev.Amount, cur.Amount)
+					}
+				} else if descending {
+					require.False(t, prev.CreatedAt.Before(cur.CreatedAt))
+				} else {
+					require.False(t, cur.CreatedAt.Before(prev.CreatedAt))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,508 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// an order goes to the counter unless it targets a table or an open tab,
+// table and tab orders take the order id of the tab and are always dine-in.
+// an empty order type is dine-in. price_list_ids are the price lists that
+// apply to the order, highest priority first. priced_at picks the promotions
+// that apply, zero is now. coupon_code redeems a coupon for the order on
+// behalf of customer.
+type CreateOrderTxParams struct {
+	Items        []CreateOrderItemParams `json:"items"`
+	TableID      uuid.UUID               `json:"table_id"`
+	TabID        uuid.UUID               `json:"tab_id"`
+	OrderType    string                  `json:"order_type"`
+	Fulfilment   OrderFulfilmentParams   `json:"fulfilment"`
+	PriceListIDs []uuid.UUID             `json:"price_list_ids"`
+	PricedAt     time.Time               `json:"priced_at"`
+	CouponCode   string                  `json:"coupon_code"`
+	Customer     string                  `json:"customer"`
+	OpenItems    bool                    `json:"open_items"` // items off the menu are taken at their product price or the price they are ordered at
+}
+
+type CreateOrderTxResult struct {
+	Orders              []Order              `json:"orders"`
+	Movements           []StockMovement      `json:"movements"`
+	IngredientMovements []IngredientMovement `json:"ingredient_movements"`
+	Tickets             []StationTicket      `json:"tickets"`
+	Tab                 Tab                  `json:"tab"` // zero for counter orders
+	Fulfilment          OrderFulfilment      `json:"fulfilment"`
+	Coupon              Coupon               `json:"coupon"`    // zero without a coupon code
+	Discounts           []Order              `json:"discounts"` // discount lines of the promotions, also in orders
+	Fees                []Order              `json:"fees"`      // fee lines of the order type, also in orders
+}
+
+// price the items of an order by its price lists, insert them and write a
+// sale movement for every item whose product has tracked stock, then deplete
+// the ingredients of their recipes and route the items to kitchen stations,
+// then apply the promotions and charge the fees of the order type, within a
+// single transaction. a scheduled order books its
+// slot and its kitchen tickets are held until the lead time before the slot.
+// the order is refused if any of its items is marked unavailable on the menu
+// or falls on a business day that is already closed, items are never created
+// refunded.
+func (store *SQLStore) CreateOrderTx(ctx context.Context, arg CreateOrderTxParams) (CreateOrderTxResult, error) {
+	var result CreateOrderTxResult
+
+	if arg.OrderType == "" {
+		arg.OrderType = utils.OrderDineIn
+	}
+	if (arg.TableID != uuid.Nil || arg.TabID != uuid.Nil) && arg.OrderType != utils.OrderDineIn {
+		return result, ErrOrderTypeMismatch
+	}
+	for _, item := range arg.Items {
+		if item.Status == utils.StatusRefunded {
+			return result, ErrRefundStatus
+		}
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := checkOrderDaysOpen(ctx, q, arg.Items); err != nil {
+			return err
+		}
+
+		var err error
+		result.Tab, err = orderTab(ctx, q, arg)
+		if err != nil {
+			return err
+		}
+
+		if err := checkOrderAvailability(ctx, q, arg.Items); err != nil {
+			return err
+		}
+
+		arg.Items, err = priceOrderItems(ctx, q, arg.PriceListIDs, arg.Items, arg.OpenItems)
+		if err != nil {
+			return err
+		}
+
+		var first bool
+		result.Fulfilment, first, err = orderFulfilment(ctx, q, arg)
+		if err != nil {
+			return err
+		}
+
+		releaseAt, err := reserveOrderSlot(ctx, q, result.Fulfilment, arg.Items, first)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range arg.Items {
+			item.OrderType = arg.OrderType
+			orderItem, err := q.CreateOrderItem(ctx, item)
+			if err != nil {
+				return err
+			}
+			result.Orders = append(result.Orders, orderItem)
+		}
+
+		// lock stock rows in product order so concurrent orders cannot deadlock
+		sold := make([]Order, len(result.Orders))
+		copy(sold, result.Orders)
+		sort.SliceStable(sold, func(i, j int) bool {
+			return bytes.Compare(sold[i].ProductID.UUID[:], sold[j].ProductID.UUID[:]) < 0
+		})
+
+		for _, orderItem := range sold {
+			movement, tracked, err := addOrderStockMovement(ctx, q, orderItem, utils.MovementSale, -orderItem.Amount)
+			if err != nil {
+				return err
+			}
+			if tracked {
+				result.Movements = append(result.Movements, movement)
+			}
+		}
+
+		usage, err := orderIngredientUsage(ctx, q, result.Orders)
+		if err != nil {
+			return err
+		}
+
+		for _, u := range usage {
+			quantity := utils.FormattedQuantityToString(-u.quantity)
+			movement, err := addIngredientOrderMovement(ctx, q, u.orderItem.ShopName, u.ingredientID, u.orderItem.ID, utils.MovementSale, quantity)
+			if err != nil {
+				return err
+			}
+			result.IngredientMovements = append(result.IngredientMovements, movement)
+		}
+
+		result.Tickets, err = createKitchenTickets(ctx, q, result.Orders, releaseAt)
+		if err != nil {
+			return err
+		}
+
+		pricedAt := arg.PricedAt
+		if pricedAt.IsZero() {
+			pricedAt = time.Now()
+		}
+		var couponPromotion uuid.NullUUID
+		if arg.CouponCode != "" {
+			if len(result.Orders) == 0 {
+				return ErrCouponNotApplicable
+			}
+			result.Coupon, err = redeemOrderCoupon(ctx, q, result.Orders[0].ShopName, arg.CouponCode, arg.Customer, result.Orders[0].OrderID, pricedAt.UTC())
+			if err != nil {
+				return err
+			}
+			couponPromotion = uuid.NullUUID{UUID: result.Coupon.PromotionID, Valid: true}
+		}
+		result.Discounts, err = addPromotionDiscounts(ctx, q, result.Orders, first, pricedAt.UTC(), couponPromotion)
+		if err != nil {
+			return err
+		}
+		if couponPromotion.Valid {
+			if err := checkCouponApplied(result.Coupon, result.Discounts); err != nil {
+				return err
+			}
+		}
+		result.Orders = append(result.Orders, result.Discounts...)
+
+		result.Fees, err = addOrderTypeFees(ctx, q, result.Fulfilment, result.Orders, first)
+		result.Orders = append(result.Orders, result.Fees...)
+		return err
+	})
+
+	return result, err
+}
+
+type RefundOrderItemTxParams struct {
+	ShopName string    `json:"shop_name"`
+	ID       uuid.UUID `json:"id"`
+}
+
+// discounts are the discount lines of the round that were lowered or
+// refunded with the item
+type RefundOrderItemTxResult struct {
+	Order               Order                `json:"order"`
+	Discounts           []Order              `json:"discounts"`
+	Movements           []StockMovement      `json:"movements"`
+	IngredientMovements []IngredientMovement `json:"ingredient_movements"`
+}
+
+// mark an order item as refunded and return its amount to stock,
+// ingredients are restored by reversing the sale movements of the item.
+// fee and discount lines are not refunded on their own, the discount lines
+// of the round are worked out again without the item.
+func (store *SQLStore) RefundOrderItemTx(ctx context.Context, arg RefundOrderItemTxParams) (RefundOrderItemTxResult, error) {
+	var result RefundOrderItemTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		orderItem, err := q.GetOrderItemForUpdate(ctx, GetOrderItemForUpdateParams(arg))
+		if err != nil {
+			return err
+		}
+
+		if orderItem.PromotionID.Valid || orderItem.FeeID.Valid {
+			return ErrAdjustmentLine
+		}
+
+		if orderItem.Status == utils.StatusRefunded {
+			return ErrAlreadyRefunded
+		}
+
+		if err := checkDayOpen(ctx, q, orderItem.ShopName, orderItem.OrderDay); err != nil {
+			return err
+		}
+
+		result.Order, err = q.UpdateOrderItem(ctx, UpdateOrderItemParams{
+			ShopName: arg.ShopName,
+			ID:       arg.ID,
+			Amount:   orderItem.Amount,
+			Status:   utils.StatusRefunded,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Discounts, err = reviseRoundDiscounts(ctx, q, result.Order)
+		if err != nil {
+			return err
+		}
+
+		result.Movements, result.IngredientMovements, err = returnOrderItemStock(ctx, q, result.Order)
+		return err
+	})
+
+	return result, err
+}
+
+// return the amount of an order item to stock and restore its ingredients by
+// reversing the sale movements of the item
+func returnOrderItemStock(ctx context.Context, q *Queries, orderItem Order) ([]StockMovement, []IngredientMovement, error) {
+	var movements []StockMovement
+	var ingredientMovements []IngredientMovement
+
+	movement, tracked, err := addOrderStockMovement(ctx, q, orderItem, utils.MovementRefund, orderItem.Amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tracked {
+		movements = append(movements, movement)
+	}
+
+	sales, err := q.ListIngredientSalesByOrderItem(ctx, uuid.NullUUID{UUID: orderItem.ID, Valid: true})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, sale := range sales {
+		quantity, err := strconv.ParseFloat(sale.Quantity, 64)
+		if err != nil {
+			return nil, nil, err
+		}
+		movement, err := addIngredientOrderMovement(ctx, q, sale.ShopName, sale.IngredientID, orderItem.ID, utils.MovementRefund, utils.FormattedQuantityToString(-quantity))
+		if err != nil {
+			return nil, nil, err
+		}
+		ingredientMovements = append(ingredientMovements, movement)
+	}
+
+	return movements, ingredientMovements, nil
+}
+
+// sell amount more units of an order item, or return them to stock when
+// negative. the movements are sales of the item, so a later refund reverses
+// them with the rest of its sale
+func addOrderItemSale(ctx context.Context, q *Queries, orderItem Order, amount int32) error {
+	if _, _, err := addOrderStockMovement(ctx, q, orderItem, utils.MovementSale, -amount); err != nil {
+		return err
+	}
+
+	sold := orderItem
+	sold.Amount = amount
+	usage, err := orderIngredientUsage(ctx, q, []Order{sold})
+	if err != nil {
+		return err
+	}
+
+	for _, u := range usage {
+		quantity := utils.FormattedQuantityToString(-u.quantity)
+		if _, err := addIngredientOrderMovement(ctx, q, orderItem.ShopName, u.ingredientID, orderItem.ID, utils.MovementSale, quantity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// change the amount and status of an order item and post the change of the
+// amount to stock and ingredients. items of a closed business day are frozen
+// in its z report, refunded items are final and an item is only refunded by
+// RefundOrderItemTx, which returns its stock. fee and discount lines are not
+// changed by hand and the amount of an item is not changed once promotions
+// discounted its round.
+func (store *SQLStore) UpdateOrderItemTx(ctx context.Context, arg UpdateOrderItemParams) (Order, error) {
+	var result Order
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		orderItem, err := q.GetOrderItemForUpdate(ctx, GetOrderItemForUpdateParams{
+			ShopName: arg.ShopName,
+			ID:       arg.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if orderItem.Status == utils.StatusRefunded {
+			return ErrAlreadyRefunded
+		}
+		if arg.Status == utils.StatusRefunded {
+			return ErrRefundStatus
+		}
+		if orderItem.PromotionID.Valid || orderItem.FeeID.Valid {
+			return ErrAdjustmentLine
+		}
+		if arg.Amount != orderItem.Amount {
+			if err := checkRoundUndiscounted(ctx, q, orderItem); err != nil {
+				return err
+			}
+		}
+
+		if err := checkDayOpen(ctx, q, orderItem.ShopName, orderItem.OrderDay); err != nil {
+			return err
+		}
+
+		result, err = q.UpdateOrderItem(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		if delta := result.Amount - orderItem.Amount; delta != 0 {
+			return addOrderItemSale(ctx, q, result, delta)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// delete an order item unless its business day is closed, the stock of an
+// item that is not refunded yet is returned as for a refund. fee and
+// discount lines are not deleted by hand and neither are the items of a
+// round promotions discounted.
+func (store *SQLStore) DeleteOrderItemTx(ctx context.Context, arg DeleteOrderItemParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		orderItem, err := q.GetOrderItemForUpdate(ctx, GetOrderItemForUpdateParams(arg))
+		if err != nil {
+			return err
+		}
+
+		if orderItem.PromotionID.Valid || orderItem.FeeID.Valid {
+			return ErrAdjustmentLine
+		}
+		if err := checkRoundUndiscounted(ctx, q, orderItem); err != nil {
+			return err
+		}
+
+		if err := checkDayOpen(ctx, q, orderItem.ShopName, orderItem.OrderDay); err != nil {
+			return err
+		}
+
+		if orderItem.Status != utils.StatusRefunded {
+			if _, _, err := returnOrderItemStock(ctx, q, orderItem); err != nil {
+				return err
+			}
+		}
+
+		return q.DeleteOrderItem(ctx, arg)
+	})
+}
+
+// refuse changes to the items of a round that promotions discounted, the
+// discount lines were worked out from the items as they were ordered. a round
+// is inserted in one transaction, so its lines share their created_at.
+func checkRoundUndiscounted(ctx context.Context, q *Queries, orderItem Order) error {
+	orders, err := q.GetOrdersByOrderID(ctx, GetOrdersByOrderIDParams{
+		ShopName: orderItem.ShopName,
+		OrderID:  orderItem.OrderID,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		if order.PromotionID.Valid && order.Status != utils.StatusRefunded && order.CreatedAt.Equal(orderItem.CreatedAt) {
+			return ErrRoundDiscounted
+		}
+	}
+
+	return nil
+}
+
+// apply quantity to the stock of the order item's product and record the movement,
+// products without a stock level are not tracked and are skipped
+func addOrderStockMovement(ctx context.Context, q *Queries, orderItem Order, movementType string, quantity int32) (StockMovement, bool, error) {
+	if !orderItem.ProductID.Valid {
+		return StockMovement{}, false, nil
+	}
+
+	level, err := q.AddStockLevel(ctx, AddStockLevelParams{
+		ShopName:  orderItem.ShopName,
+		ProductID: orderItem.ProductID.UUID,
+		OnHand:    quantity,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return StockMovement{}, false, nil
+		}
+		return StockMovement{}, false, err
+	}
+
+	if quantity < 0 {
+		if err := markSoldOut(ctx, q, level); err != nil {
+			return StockMovement{}, false, err
+		}
+	}
+
+	movement, err := q.CreateStockMovement(ctx, CreateStockMovementParams{
+		ID:           uuid.New(),
+		ShopName:     orderItem.ShopName,
+		ProductID:    orderItem.ProductID.UUID,
+		MovementType: movementType,
+		Quantity:     quantity,
+		OrderItemID:  uuid.NullUUID{UUID: orderItem.ID, Valid: true},
+	})
+	if err != nil {
+		return StockMovement{}, false, err
+	}
+
+	return movement, true, nil
+}
+
+// refuse items whose product is marked unavailable on the shop's menu,
+// items without a product id are matched by product name
+func checkOrderAvailability(ctx context.Context, q *Queries, items []CreateOrderItemParams) error {
+	shops := map[string]*ListUnavailableMenuItemsParams{}
+	var shopNames []string
+
+	for _, item := range items {
+		arg, ok := shops[item.ShopName]
+		if !ok {
+			arg = &ListUnavailableMenuItemsParams{ShopName: item.ShopName}
+			shops[item.ShopName] = arg
+			shopNames = append(shopNames, item.ShopName)
+		}
+		if item.ProductID.Valid {
+			arg.ProductIds = append(arg.ProductIds, item.ProductID.UUID)
+		} else {
+			arg.ProductNames = append(arg.ProductNames, item.ProductName)
+		}
+	}
+
+	for _, shopName := range shopNames {
+		unavailable, err := q.ListUnavailableMenuItems(ctx, *shops[shopName])
+		if err != nil {
+			return err
+		}
+		if len(unavailable) > 0 {
+			return fmt.Errorf("%w: %s", ErrItemUnavailable, unavailable[0].ProductName)
+		}
+	}
+
+	return nil
+}
+
+// refuse orders into a closed business day, rounds of a tab and the fee and
+// discount lines of the order take the business day of its items
+func checkOrderDaysOpen(ctx context.Context, q *Queries, items []CreateOrderItemParams) error {
+	checked := make(map[IsDayClosedParams]bool)
+
+	for _, item := range items {
+		day := IsDayClosedParams{ShopName: item.ShopName, BusinessDay: item.OrderDay}
+		if checked[day] {
+			continue
+		}
+		checked[day] = true
+
+		if err := checkDayOpen(ctx, q, day.ShopName, day.BusinessDay); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mark the product unavailable on every menu once its tracked stock runs out
+func markSoldOut(ctx context.Context, q *Queries, level StockLevel) error {
+	if level.OnHand > 0 {
+		return nil
+	}
+
+	return q.MarkProductSoldOut(ctx, MarkProductSoldOutParams{
+		ShopName:  level.ShopName,
+		ProductID: level.ProductID,
+	})
+}
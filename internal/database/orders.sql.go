@@ -7,25 +7,33 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 const createOrderItem = `-- name: CreateOrderItem :one
-INSERT INTO orders (id, shop_name, order_id, order_day, product_name, product_price, amount, status)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at
+INSERT INTO orders (id, shop_name, order_id, order_day, product_name, product_price, amount, status, product_id, tax_rate, seat, order_type, price_list_id, promotion_id, fee_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+RETURNING id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at, product_id, tax_rate, seat, order_type, price_list_id, promotion_id, fee_id
 `
 
 type CreateOrderItemParams struct {
-	ID           uuid.UUID `json:"id"`
-	ShopName     string    `json:"shop_name"`
-	OrderID      uuid.UUID `json:"order_id"`
-	OrderDay     string    `json:"order_day"`
-	ProductName  string    `json:"product_name"`
-	ProductPrice string    `json:"product_price"`
-	Amount       int32     `json:"amount"`
-	Status       string    `json:"status"`
+	ID           uuid.UUID     `json:"id"`
+	ShopName     string        `json:"shop_name"`
+	OrderID      uuid.UUID     `json:"order_id"`
+	OrderDay     string        `json:"order_day"`
+	ProductName  string        `json:"product_name"`
+	ProductPrice string        `json:"product_price"`
+	Amount       int32         `json:"amount"`
+	Status       string        `json:"status"`
+	ProductID    uuid.NullUUID `json:"product_id"`
+	TaxRate      string        `json:"tax_rate"`
+	Seat         int32         `json:"seat"`
+	OrderType    string        `json:"order_type"`
+	PriceListID  uuid.NullUUID `json:"price_list_id"`
+	PromotionID  uuid.NullUUID `json:"promotion_id"`
+	FeeID        uuid.NullUUID `json:"fee_id"`
 }
 
 func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (Order, error) {
@@ -38,6 +46,13 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		arg.ProductPrice,
 		arg.Amount,
 		arg.Status,
+		arg.ProductID,
+		arg.TaxRate,
+		arg.Seat,
+		arg.OrderType,
+		arg.PriceListID,
+		arg.PromotionID,
+		arg.FeeID,
 	)
 	var i Order
 	err := row.Scan(
@@ -50,6 +65,13 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		&i.Amount,
 		&i.Status,
 		&i.CreatedAt,
+		&i.ProductID,
+		&i.TaxRate,
+		&i.Seat,
+		&i.OrderType,
+		&i.PriceListID,
+		&i.PromotionID,
+		&i.FeeID,
 	)
 	return i, err
 }
@@ -69,8 +91,77 @@ func (q *Queries) DeleteOrderItem(ctx context.Context, arg DeleteOrderItemParams
 	return err
 }
 
+const getOrderItem = `-- name: GetOrderItem :one
+SELECT id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at, product_id, tax_rate, seat, order_type, price_list_id, promotion_id, fee_id FROM orders
+WHERE shop_name = $1 AND id = $2 LIMIT 1
+`
+
+type GetOrderItemParams struct {
+	ShopName string    `json:"shop_name"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (q *Queries) GetOrderItem(ctx context.Context, arg GetOrderItemParams) (Order, error) {
+	row := q.db.QueryRowContext(ctx, getOrderItem, arg.ShopName, arg.ID)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.ShopName,
+		&i.OrderID,
+		&i.OrderDay,
+		&i.ProductName,
+		&i.ProductPrice,
+		&i.Amount,
+		&i.Status,
+		&i.CreatedAt,
+		&i.ProductID,
+		&i.TaxRate,
+		&i.Seat,
+		&i.OrderType,
+		&i.PriceListID,
+		&i.PromotionID,
+		&i.FeeID,
+	)
+	return i, err
+}
+
+const getOrderItemForUpdate = `-- name: GetOrderItemForUpdate :one
+SELECT id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at, product_id, tax_rate, seat, order_type, price_list_id, promotion_id, fee_id FROM orders
+WHERE shop_name = $1 AND id = $2 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+type GetOrderItemForUpdateParams struct {
+	ShopName string    `json:"shop_name"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (q *Queries) GetOrderItemForUpdate(ctx context.Context, arg GetOrderItemForUpdateParams) (Order, error) {
+	row := q.db.QueryRowContext(ctx, getOrderItemForUpdate, arg.ShopName, arg.ID)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.ShopName,
+		&i.OrderID,
+		&i.OrderDay,
+		&i.ProductName,
+		&i.ProductPrice,
+		&i.Amount,
+		&i.Status,
+		&i.CreatedAt,
+		&i.ProductID,
+		&i.TaxRate,
+		&i.Seat,
+		&i.OrderType,
+		&i.PriceListID,
+		&i.PromotionID,
+		&i.FeeID,
+	)
+	return i, err
+}
+
 const getOrdersByDay = `-- name: GetOrdersByDay :many
-SELECT id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at FROM orders
+SELECT id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at, product_id, tax_rate, seat, order_type, price_list_id, promotion_id, fee_id FROM orders
 WHERE shop_name = $1 AND order_day = $2
 `
 
@@ -98,6 +189,13 @@ func (q *Queries) GetOrdersByDay(ctx context.Context, arg GetOrdersByDayParams)
 			&i.Amount,
 			&i.Status,
 			&i.CreatedAt,
+			&i.ProductID,
+			&i.TaxRate,
+			&i.Seat,
+			&i.OrderType,
+			&i.PriceListID,
+			&i.PromotionID,
+			&i.FeeID,
 		); err != nil {
 			return nil, err
 		}
@@ -113,7 +211,7 @@ func (q *Queries) GetOrdersByDay(ctx context.Context, arg GetOrdersByDayParams)
 }
 
 const getOrdersByOrderID = `-- name: GetOrdersByOrderID :many
-SELECT id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at FROM orders
+SELECT id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at, product_id, tax_rate, seat, order_type, price_list_id, promotion_id, fee_id FROM orders
 WHERE shop_name = $1 AND order_id = $2
 `
 
@@ -141,6 +239,317 @@ func (q *Queries) GetOrdersByOrderID(ctx context.Context, arg GetOrdersByOrderID
 			&i.Amount,
 			&i.Status,
 			&i.CreatedAt,
+			&i.ProductID,
+			&i.TaxRate,
+			&i.Seat,
+			&i.OrderType,
+			&i.PriceListID,
+			&i.PromotionID,
+			&i.FeeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderHistoryByAmountAsc = `-- name: ListOrderHistoryByAmountAsc :many
+SELECT id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at, product_id, tax_rate, seat, order_type, price_list_id, promotion_id, fee_id FROM orders
+WHERE shop_name = $1
+AND order_day >= $2 AND order_day <= $3
+AND ($4::varchar = '' OR status = $4)
+AND ($5::varchar = '' OR product_name ILIKE '%' || $5 || '%')
+AND amount >= $6 AND amount <= $7
+AND (amount, id) > ($8::integer, $9::uuid)
+ORDER BY amount ASC, id ASC
+LIMIT $10
+`
+
+type ListOrderHistoryByAmountAscParams struct {
+	ShopName     string    `json:"shop_name"`
+	FromDay      string    `json:"from_day"`
+	ToDay        string    `json:"to_day"`
+	Status       string    `json:"status"`
+	ProductName  string    `json:"product_name"`
+	MinAmount    int32     `json:"min_amount"`
+	MaxAmount    int32     `json:"max_amount"`
+	CursorAmount int32     `json:"cursor_amount"`
+	CursorID     uuid.UUID `json:"cursor_id"`
+	PageSize     int32     `json:"page_size"`
+}
+
+func (q *Queries) ListOrderHistoryByAmountAsc(ctx context.Context, arg ListOrderHistoryByAmountAscParams) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, listOrderHistoryByAmountAsc,
+		arg.ShopName,
+		arg.FromDay,
+		arg.ToDay,
+		arg.Status,
+		arg.ProductName,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CursorAmount,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Order{}
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopName,
+			&i.OrderID,
+			&i.OrderDay,
+			&i.ProductName,
+			&i.ProductPrice,
+			&i.Amount,
+			&i.Status,
+			&i.CreatedAt,
+			&i.ProductID,
+			&i.TaxRate,
+			&i.Seat,
+			&i.OrderType,
+			&i.PriceListID,
+			&i.PromotionID,
+			&i.FeeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderHistoryByAmountDesc = `-- name: ListOrderHistoryByAmountDesc :many
+SELECT id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at, product_id, tax_rate, seat, order_type, price_list_id, promotion_id, fee_id FROM orders
+WHERE shop_name = $1
+AND order_day >= $2 AND order_day <= $3
+AND ($4::varchar = '' OR status = $4)
+AND ($5::varchar = '' OR product_name ILIKE '%' || $5 || '%')
+AND amount >= $6 AND amount <= $7
+AND (amount, id) < ($8::integer, $9::uuid)
+ORDER BY amount DESC, id DESC
+LIMIT $10
+`
+
+type ListOrderHistoryByAmountDescParams struct {
+	ShopName     string    `json:"shop_name"`
+	FromDay      string    `json:"from_day"`
+	ToDay        string    `json:"to_day"`
+	Status       string    `json:"status"`
+	ProductName  string    `json:"product_name"`
+	MinAmount    int32     `json:"min_amount"`
+	MaxAmount    int32     `json:"max_amount"`
+	CursorAmount int32     `json:"cursor_amount"`
+	CursorID     uuid.UUID `json:"cursor_id"`
+	PageSize     int32     `json:"page_size"`
+}
+
+func (q *Queries) ListOrderHistoryByAmountDesc(ctx context.Context, arg ListOrderHistoryByAmountDescParams) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, listOrderHistoryByAmountDesc,
+		arg.ShopName,
+		arg.FromDay,
+		arg.ToDay,
+		arg.Status,
+		arg.ProductName,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CursorAmount,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Order{}
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopName,
+			&i.OrderID,
+			&i.OrderDay,
+			&i.ProductName,
+			&i.ProductPrice,
+			&i.Amount,
+			&i.Status,
+			&i.CreatedAt,
+			&i.ProductID,
+			&i.TaxRate,
+			&i.Seat,
+			&i.OrderType,
+			&i.PriceListID,
+			&i.PromotionID,
+			&i.FeeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderHistoryByCreatedAtAsc = `-- name: ListOrderHistoryByCreatedAtAsc :many
+SELECT id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at, product_id, tax_rate, seat, order_type, price_list_id, promotion_id, fee_id FROM orders
+WHERE shop_name = $1
+AND order_day >= $2 AND order_day <= $3
+AND ($4::varchar = '' OR status = $4)
+AND ($5::varchar = '' OR product_name ILIKE '%' || $5 || '%')
+AND amount >= $6 AND amount <= $7
+AND (created_at, id) > ($8::timestamp, $9::uuid)
+ORDER BY created_at ASC, id ASC
+LIMIT $10
+`
+
+type ListOrderHistoryByCreatedAtAscParams struct {
+	ShopName        string    `json:"shop_name"`
+	FromDay         string    `json:"from_day"`
+	ToDay           string    `json:"to_day"`
+	Status          string    `json:"status"`
+	ProductName     string    `json:"product_name"`
+	MinAmount       int32     `json:"min_amount"`
+	MaxAmount       int32     `json:"max_amount"`
+	CursorCreatedAt time.Time `json:"cursor_created_at"`
+	CursorID        uuid.UUID `json:"cursor_id"`
+	PageSize        int32     `json:"page_size"`
+}
+
+func (q *Queries) ListOrderHistoryByCreatedAtAsc(ctx context.Context, arg ListOrderHistoryByCreatedAtAscParams) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, listOrderHistoryByCreatedAtAsc,
+		arg.ShopName,
+		arg.FromDay,
+		arg.ToDay,
+		arg.Status,
+		arg.ProductName,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Order{}
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopName,
+			&i.OrderID,
+			&i.OrderDay,
+			&i.ProductName,
+			&i.ProductPrice,
+			&i.Amount,
+			&i.Status,
+			&i.CreatedAt,
+			&i.ProductID,
+			&i.TaxRate,
+			&i.Seat,
+			&i.OrderType,
+			&i.PriceListID,
+			&i.PromotionID,
+			&i.FeeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderHistoryByCreatedAtDesc = `-- name: ListOrderHistoryByCreatedAtDesc :many
+SELECT id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at, product_id, tax_rate, seat, order_type, price_list_id, promotion_id, fee_id FROM orders
+WHERE shop_name = $1
+AND order_day >= $2 AND order_day <= $3
+AND ($4::varchar = '' OR status = $4)
+AND ($5::varchar = '' OR product_name ILIKE '%' || $5 || '%')
+AND amount >= $6 AND amount <= $7
+AND (created_at, id) < ($8::timestamp, $9::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT $10
+`
+
+type ListOrderHistoryByCreatedAtDescParams struct {
+	ShopName        string    `json:"shop_name"`
+	FromDay         string    `json:"from_day"`
+	ToDay           string    `json:"to_day"`
+	Status          string    `json:"status"`
+	ProductName     string    `json:"product_name"`
+	MinAmount       int32     `json:"min_amount"`
+	MaxAmount       int32     `json:"max_amount"`
+	CursorCreatedAt time.Time `json:"cursor_created_at"`
+	CursorID        uuid.UUID `json:"cursor_id"`
+	PageSize        int32     `json:"page_size"`
+}
+
+func (q *Queries) ListOrderHistoryByCreatedAtDesc(ctx context.Context, arg ListOrderHistoryByCreatedAtDescParams) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, listOrderHistoryByCreatedAtDesc,
+		arg.ShopName,
+		arg.FromDay,
+		arg.ToDay,
+		arg.Status,
+		arg.ProductName,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Order{}
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopName,
+			&i.OrderID,
+			&i.OrderDay,
+			&i.ProductName,
+			&i.ProductPrice,
+			&i.Amount,
+			&i.Status,
+			&i.CreatedAt,
+			&i.ProductID,
+			&i.TaxRate,
+			&i.Seat,
+			&i.OrderType,
+			&i.PriceListID,
+			&i.PromotionID,
+			&i.FeeID,
 		); err != nil {
 			return nil, err
 		}
@@ -159,7 +568,7 @@ const updateOrderItem = `-- name: UpdateOrderItem :one
 UPDATE orders
 SET amount = $3, status = $4
 WHERE shop_name = $1 AND id = $2
-RETURNING id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at
+RETURNING id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at, product_id, tax_rate, seat, order_type, price_list_id, promotion_id, fee_id
 `
 
 type UpdateOrderItemParams struct {
@@ -187,6 +596,50 @@ func (q *Queries) UpdateOrderItem(ctx context.Context, arg UpdateOrderItemParams
 		&i.Amount,
 		&i.Status,
 		&i.CreatedAt,
+		&i.ProductID,
+		&i.TaxRate,
+		&i.Seat,
+		&i.OrderType,
+		&i.PriceListID,
+		&i.PromotionID,
+		&i.FeeID,
+	)
+	return i, err
+}
+
+const updateOrderItemPrice = `-- name: UpdateOrderItemPrice :one
+UPDATE orders
+SET product_price = $3
+WHERE shop_name = $1 AND id = $2
+RETURNING id, shop_name, order_id, order_day, product_name, product_price, amount, status, created_at, product_id, tax_rate, seat, order_type, price_list_id, promotion_id, fee_id
+`
+
+type UpdateOrderItemPriceParams struct {
+	ShopName     string    `json:"shop_name"`
+	ID           uuid.UUID `json:"id"`
+	ProductPrice string    `json:"product_price"`
+}
+
+func (q *Queries) UpdateOrderItemPrice(ctx context.Context, arg UpdateOrderItemPriceParams) (Order, error) {
+	row := q.db.QueryRowContext(ctx, updateOrderItemPrice, arg.ShopName, arg.ID, arg.ProductPrice)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.ShopName,
+		&i.OrderID,
+		&i.OrderDay,
+		&i.ProductName,
+		&i.ProductPrice,
+		&i.Amount,
+		&i.Status,
+		&i.CreatedAt,
+		&i.ProductID,
+		&i.TaxRate,
+		&i.Seat,
+		&i.OrderType,
+		&i.PriceListID,
+		&i.PromotionID,
+		&i.FeeID,
 	)
 	return i, err
 }
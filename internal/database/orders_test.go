@@ -9,18 +9,21 @@ import (
 	"github.com/toml5566/go_pos_backend/utils"
 )
 
-func createRandomOrderItem(t *testing.T, user User, orderID uuid.UUID, orderDay string) Order {
-	product := createRandomProduct(t, user)
+func createRandomOrderItem(t *testing.T, shop Shop, orderID uuid.UUID, orderDay string) Order {
+	product := createRandomProduct(t, shop)
 
 	arg := CreateOrderItemParams{
 		ID:           uuid.New(),
-		ShopName:     user.Username,
+		ShopName:     shop.Name,
 		OrderID:      orderID,
 		OrderDay:     orderDay,
 		ProductName:  product.Name,
 		ProductPrice: product.Price,
 		Amount:       utils.RandomInt32(1, 10),
 		Status:       "pending",
+		ProductID:    uuid.NullUUID{UUID: product.ID, Valid: true},
+		TaxRate:      "0.00",
+		OrderType:    utils.OrderDineIn,
 	}
 
 	orderItem, err := testQueries.CreateOrderItem(context.Background(), arg)
@@ -35,6 +38,8 @@ func createRandomOrderItem(t *testing.T, user User, orderID uuid.UUID, orderDay
 	require.Equal(t, orderItem.ProductPrice, arg.ProductPrice)
 	require.Equal(t, orderItem.Amount, arg.Amount)
 	require.Equal(t, orderItem.Status, arg.Status)
+	require.Equal(t, orderItem.ProductID, arg.ProductID)
+	require.Equal(t, orderItem.OrderType, arg.OrderType)
 
 	require.NotZero(t, orderItem.CreatedAt)
 
@@ -42,22 +47,22 @@ func createRandomOrderItem(t *testing.T, user User, orderID uuid.UUID, orderDay
 }
 
 func TestCreateOrderItem(t *testing.T) {
-	user := createRandomUser(t)
+	shop := createRandomShop(t)
 	orderID := utils.RandOrderID()
 	orderDay := utils.FormattedDateNow()
 
-	createRandomOrderItem(t, user, orderID, orderDay)
+	createRandomOrderItem(t, shop, orderID, orderDay)
 }
 
 func TestUpdateOrderItem(t *testing.T) {
-	user := createRandomUser(t)
+	shop := createRandomShop(t)
 	orderID := utils.RandOrderID()
 	orderDay := utils.FormattedDateNow()
 
-	orderItem := createRandomOrderItem(t, user, orderID, orderDay)
+	orderItem := createRandomOrderItem(t, shop, orderID, orderDay)
 
 	arg := UpdateOrderItemParams{
-		ShopName: user.Username,
+		ShopName: shop.Name,
 		ID:       orderItem.ID,
 		Amount:   int32(1000),
 		Status:   "accepted",
@@ -82,14 +87,14 @@ func TestUpdateOrderItem(t *testing.T) {
 }
 
 func TestDeleteOrderitem(t *testing.T) {
-	user := createRandomUser(t)
+	shop := createRandomShop(t)
 	orderID := utils.RandOrderID()
 	orderDay := utils.FormattedDateNow()
 
-	orderItem := createRandomOrderItem(t, user, orderID, orderDay)
+	orderItem := createRandomOrderItem(t, shop, orderID, orderDay)
 
 	arg := DeleteOrderItemParams{
-		ShopName: user.Username,
+		ShopName: shop.Name,
 		ID:       orderItem.ID,
 	}
 
@@ -97,7 +102,7 @@ func TestDeleteOrderitem(t *testing.T) {
 	require.NoError(t, err)
 
 	arg1 := GetOrdersByOrderIDParams{
-		ShopName: user.Username,
+		ShopName: shop.Name,
 		OrderID:  orderItem.OrderID,
 	}
 
@@ -107,16 +112,16 @@ func TestDeleteOrderitem(t *testing.T) {
 }
 
 func TestGetOrderByDay(t *testing.T) {
-	user := createRandomUser(t)
+	shop := createRandomShop(t)
 	orderID := utils.RandOrderID()
 	orderDay := utils.FormattedDateNow()
 
-	createRandomOrderItem(t, user, orderID, orderDay)
-	createRandomOrderItem(t, user, uuid.New(), orderDay)
-	createRandomOrderItem(t, user, uuid.New(), "2006-01-02")
+	createRandomOrderItem(t, shop, orderID, orderDay)
+	createRandomOrderItem(t, shop, uuid.New(), orderDay)
+	createRandomOrderItem(t, shop, uuid.New(), "2006-01-02")
 
 	arg := GetOrdersByDayParams{
-		ShopName: user.Username,
+		ShopName: shop.Name,
 		OrderDay: orderDay,
 	}
 
@@ -127,21 +132,21 @@ func TestGetOrderByDay(t *testing.T) {
 	for _, o := range orders {
 		require.NotEmpty(t, o)
 		require.Equal(t, o.OrderDay, orderDay)
-		require.Equal(t, o.ShopName, user.Username)
+		require.Equal(t, o.ShopName, shop.Name)
 	}
 }
 
 func TestGetOrdersByOrderID(t *testing.T) {
-	user := createRandomUser(t)
+	shop := createRandomShop(t)
 	orderID := utils.RandOrderID()
 	orderDay := utils.FormattedDateNow()
 
-	createRandomOrderItem(t, user, orderID, orderDay)
-	createRandomOrderItem(t, user, orderID, orderDay)
-	createRandomOrderItem(t, user, uuid.New(), "2006-01-02")
+	createRandomOrderItem(t, shop, orderID, orderDay)
+	createRandomOrderItem(t, shop, orderID, orderDay)
+	createRandomOrderItem(t, shop, uuid.New(), "2006-01-02")
 
 	arg := GetOrdersByOrderIDParams{
-		ShopName: user.Username,
+		ShopName: shop.Name,
 		OrderID:  orderID,
 	}
 
@@ -151,7 +156,7 @@ func TestGetOrdersByOrderID(t *testing.T) {
 
 	for _, o := range orders {
 		require.NotEmpty(t, o)
-		require.Equal(t, o.ShopName, user.Username)
+		require.Equal(t, o.ShopName, shop.Name)
 		require.Equal(t, o.OrderID, orderID)
 	}
 }
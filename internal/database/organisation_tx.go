@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type CreateOrganisationTxParams struct {
+	Name    string           `json:"name"`
+	OwnerID uuid.UUID        `json:"owner_id"`
+	Shop    CreateShopParams `json:"shop"` // the first shop, its ids are filled in
+}
+
+type CreateOrganisationTxResult struct {
+	Organisation Organisation       `json:"organisation"`
+	Shop         Shop               `json:"shop"`
+	Owner        OrganisationMember `json:"owner"`
+}
+
+// create an organisation with its first shop, the user creating it owns it
+func (store *SQLStore) CreateOrganisationTx(ctx context.Context, arg CreateOrganisationTxParams) (CreateOrganisationTxResult, error) {
+	var result CreateOrganisationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Organisation, err = q.CreateOrganisation(ctx, CreateOrganisationParams{
+			ID:   uuid.New(),
+			Name: arg.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		shop := arg.Shop
+		shop.ID = uuid.New()
+		shop.OrganisationID = result.Organisation.ID
+		result.Shop, err = q.CreateShop(ctx, shop)
+		if err != nil {
+			return err
+		}
+
+		result.Owner, err = q.AddOrganisationMember(ctx, AddOrganisationMemberParams{
+			OrganisationID: result.Organisation.ID,
+			UserID:         arg.OwnerID,
+			Role:           utils.RoleOwner,
+		})
+		return err
+	})
+
+	return result, err
+}
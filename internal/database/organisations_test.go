@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func createRandomOrganisation(t *testing.T) (CreateOrganisationTxResult, User) {
+	owner := createRandomUser(t)
+
+	arg := CreateOrganisationTxParams{
+		Name:    utils.RandString(8),
+		OwnerID: owner.ID,
+		Shop: CreateShopParams{
+			Name:     owner.Username,
+			Currency: utils.DefaultCurrency,
+			Timezone: "UTC",
+			Locale:   utils.DefaultLocale,
+		},
+	}
+
+	result, err := testStore.CreateOrganisationTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, result.Organisation.Name)
+	require.Equal(t, result.Organisation.ID, result.Shop.OrganisationID)
+	require.Equal(t, arg.Shop.Name, result.Shop.Name)
+	require.Equal(t, owner.ID, result.Owner.UserID)
+	require.Equal(t, utils.RoleOwner, result.Owner.Role)
+	require.False(t, result.Owner.ShopID.Valid)
+
+	return result, owner
+}
+
+// a shop of its own organisation, named after its owner
+func createRandomShop(t *testing.T) Shop {
+	result, _ := createRandomOrganisation(t)
+	return result.Shop
+}
+
+func addRandomShop(t *testing.T, organisationID uuid.UUID) Shop {
+	shop, err := testQueries.CreateShop(context.Background(), CreateShopParams{
+		ID:             uuid.New(),
+		OrganisationID: organisationID,
+		Name:           utils.RandString(8),
+		Currency:       utils.DefaultCurrency,
+		Timezone:       "UTC",
+		Locale:         utils.DefaultLocale,
+	})
+	require.NoError(t, err)
+
+	return shop
+}
+
+func TestCreateOrganisationTx(t *testing.T) {
+	result, owner := createRandomOrganisation(t)
+
+	shop, err := testQueries.GetShopByName(context.Background(), owner.Username)
+	require.NoError(t, err)
+	require.Equal(t, result.Shop.ID, shop.ID)
+
+	// the shop name is shared with the public routes, no two shops can have it
+	_, err = testQueries.CreateShop(context.Background(), CreateShopParams{
+		ID:             uuid.New(),
+		OrganisationID: result.Organisation.ID,
+		Name:           shop.Name,
+		Currency:       utils.DefaultCurrency,
+		Timezone:       "UTC",
+		Locale:         utils.DefaultLocale,
+	})
+	require.Error(t, err)
+}
+
+func TestGetMemberShop(t *testing.T) {
+	result, owner := createRandomOrganisation(t)
+	second := addRandomShop(t, result.Organisation.ID)
+
+	staff := createRandomUser(t)
+	_, err := testQueries.AddOrganisationMember(context.Background(), AddOrganisationMemberParams{
+		OrganisationID: result.Organisation.ID,
+		UserID:         staff.ID,
+		ShopID:         uuid.NullUUID{UUID: second.ID, Valid: true},
+		Role:           utils.RoleStaff,
+	})
+	require.NoError(t, err)
+
+	// the owner works at every shop
+	for _, shop := range []Shop{result.Shop, second} {
+		got, err := testQueries.GetMemberShop(context.Background(), GetMemberShopParams{ShopID: shop.ID, Username: owner.Username})
+		require.NoError(t, err)
+		require.Equal(t, shop.Name, got.Name)
+	}
+
+	// staff only at theirs
+	_, err = testQueries.GetMemberShop(context.Background(), GetMemberShopParams{ShopID: second.ID, Username: staff.Username})
+	require.NoError(t, err)
+	_, err = testQueries.GetMemberShop(context.Background(), GetMemberShopParams{ShopID: result.Shop.ID, Username: staff.Username})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	shops, err := testQueries.ListMemberShops(context.Background(), staff.Username)
+	require.NoError(t, err)
+	require.Len(t, shops, 1)
+	require.Equal(t, second.ID, shops[0].ID)
+
+	// somebody else's shop
+	outsider := createRandomShop(t)
+	_, err = testQueries.GetMemberShop(context.Background(), GetMemberShopParams{ShopID: outsider.ID, Username: owner.Username})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestDeleteOrganisationMember(t *testing.T) {
+	result, owner := createRandomOrganisation(t)
+
+	manager := createRandomUser(t)
+	_, err := testQueries.AddOrganisationMember(context.Background(), AddOrganisationMemberParams{
+		OrganisationID: result.Organisation.ID,
+		UserID:         manager.ID,
+		Role:           utils.RoleManager,
+	})
+	require.NoError(t, err)
+
+	members, err := testQueries.ListOrganisationMembers(context.Background(), result.Organisation.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+
+	// the owner stays
+	rows, err := testQueries.DeleteOrganisationMember(context.Background(), DeleteOrganisationMemberParams{
+		OrganisationID: result.Organisation.ID,
+		UserID:         owner.ID,
+	})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	rows, err = testQueries.DeleteOrganisationMember(context.Background(), DeleteOrganisationMemberParams{
+		OrganisationID: result.Organisation.ID,
+		UserID:         manager.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
+
+func TestUpdateShopReceipt(t *testing.T) {
+	shop := createRandomShop(t)
+	require.Empty(t, shop.ReceiptHeader)
+	require.Empty(t, shop.ReceiptFooter)
+
+	arg := UpdateShopReceiptParams{
+		ID:            shop.ID,
+		ReceiptHeader: "12 Harbour Road\nTel 2345 6789",
+		ReceiptFooter: "Thank you!",
+	}
+
+	updated, err := testQueries.UpdateShopReceipt(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ReceiptHeader, updated.ReceiptHeader)
+	require.Equal(t, arg.ReceiptFooter, updated.ReceiptFooter)
+}
+
+func TestUpdateShopProfile(t *testing.T) {
+	shop := createRandomShop(t)
+	require.Equal(t, "USD", shop.Currency)
+	require.Empty(t, shop.DisplayName)
+
+	arg := UpdateShopProfileParams{
+		ID:            shop.ID,
+		DisplayName:   "Harbour Café",
+		Address:       "12 Harbour Road",
+		Phone:         "+852 2345 6789",
+		Currency:      "HKD",
+		Timezone:      "Asia/Hong_Kong",
+		LogoURL:       "https://example.com/logo.png",
+		ReceiptHeader: shop.ReceiptHeader,
+		ReceiptFooter: "Thank you!",
+	}
+
+	updated, err := testQueries.UpdateShopProfile(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.DisplayName, updated.DisplayName)
+	require.Equal(t, arg.Address, updated.Address)
+	require.Equal(t, arg.Phone, updated.Phone)
+	require.Equal(t, arg.Currency, updated.Currency)
+	require.Equal(t, arg.Timezone, updated.Timezone)
+	require.Equal(t, arg.LogoURL, updated.LogoURL)
+	require.Equal(t, arg.ReceiptFooter, updated.ReceiptFooter)
+	require.Equal(t, shop.Name, updated.Name)
+}
@@ -0,0 +1,287 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type PriceListRuleParams struct {
+	Channel   string `json:"channel"`
+	OrderType string `json:"order_type"`
+	Weekday   int32  `json:"weekday"`
+	StartsAt  int32  `json:"starts_at"`
+	EndsAt    int32  `json:"ends_at"`
+}
+
+// id is ignored when the list is created
+type SetPriceListTxParams struct {
+	ShopName string                `json:"shop_name"`
+	ID       uuid.UUID             `json:"id"`
+	Name     string                `json:"name"`
+	Priority int32                 `json:"priority"`
+	Active   bool                  `json:"active"`
+	Rules    []PriceListRuleParams `json:"rules"`
+}
+
+type PriceListTxResult struct {
+	PriceList PriceList       `json:"price_list"`
+	Rules     []PriceListRule `json:"rules"`
+}
+
+// create a price list of a shop together with the rules of when it applies
+func (store *SQLStore) CreatePriceListTx(ctx context.Context, arg SetPriceListTxParams) (PriceListTxResult, error) {
+	var result PriceListTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.PriceList, err = q.CreatePriceList(ctx, CreatePriceListParams{
+			ID:       uuid.New(),
+			ShopName: arg.ShopName,
+			Name:     arg.Name,
+			Priority: arg.Priority,
+			Active:   arg.Active,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Rules, err = addPriceListRules(ctx, q, result.PriceList.ID, arg.Rules)
+		return err
+	})
+
+	return result, err
+}
+
+// update a price list of a shop and replace its rules
+func (store *SQLStore) UpdatePriceListTx(ctx context.Context, arg SetPriceListTxParams) (PriceListTxResult, error) {
+	var result PriceListTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.PriceList, err = q.UpdatePriceList(ctx, UpdatePriceListParams{
+			ShopName: arg.ShopName,
+			ID:       arg.ID,
+			Name:     arg.Name,
+			Priority: arg.Priority,
+			Active:   arg.Active,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := q.DeletePriceListRules(ctx, arg.ID); err != nil {
+			return err
+		}
+
+		result.Rules, err = addPriceListRules(ctx, q, arg.ID, arg.Rules)
+		return err
+	})
+
+	return result, err
+}
+
+func addPriceListRules(ctx context.Context, q *Queries, priceListID uuid.UUID, rules []PriceListRuleParams) ([]PriceListRule, error) {
+	added := []PriceListRule{}
+	for _, rule := range rules {
+		r, err := q.AddPriceListRule(ctx, AddPriceListRuleParams{
+			ID:          uuid.New(),
+			PriceListID: priceListID,
+			Channel:     rule.Channel,
+			OrderType:   rule.OrderType,
+			Weekday:     rule.Weekday,
+			StartsAt:    rule.StartsAt,
+			EndsAt:      rule.EndsAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		added = append(added, r)
+	}
+
+	return added, nil
+}
+
+// a price names either a product or a menu item
+type PriceListPriceParams struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	MenuItemID uuid.UUID `json:"menu_item_id"`
+	Price      string    `json:"price"`
+}
+
+type SetPriceListPricesTxParams struct {
+	ShopName       string                 `json:"shop_name"`
+	OrganisationID uuid.UUID              `json:"organisation_id"`
+	PriceListID    uuid.UUID              `json:"price_list_id"`
+	Prices         []PriceListPriceParams `json:"prices"`
+}
+
+// replace the prices of a price list, products must belong to the shop's
+// organisation and menu items to the shop
+func (store *SQLStore) SetPriceListPricesTx(ctx context.Context, arg SetPriceListPricesTxParams) ([]PriceListPrice, error) {
+	prices := []PriceListPrice{}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetPriceList(ctx, GetPriceListParams{
+			ShopName: arg.ShopName,
+			ID:       arg.PriceListID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := q.DeletePriceListPrices(ctx, arg.PriceListID); err != nil {
+			return err
+		}
+
+		for _, p := range arg.Prices {
+			if p.MenuItemID != uuid.Nil {
+				_, err = q.GetMenuItem(ctx, GetMenuItemParams{
+					ShopName: arg.ShopName,
+					ID:       p.MenuItemID,
+				})
+			} else {
+				_, err = q.GetProduct(ctx, GetProductParams{
+					OrganisationID: arg.OrganisationID,
+					ID:             p.ProductID,
+				})
+			}
+			if err != nil {
+				return err
+			}
+
+			price, err := q.AddPriceListPrice(ctx, AddPriceListPriceParams{
+				ID:          uuid.New(),
+				PriceListID: arg.PriceListID,
+				ProductID:   uuid.NullUUID{UUID: p.ProductID, Valid: p.MenuItemID == uuid.Nil},
+				MenuItemID:  uuid.NullUUID{UUID: p.MenuItemID, Valid: p.MenuItemID != uuid.Nil},
+				Price:       p.Price,
+			})
+			if err != nil {
+				return err
+			}
+			prices = append(prices, price)
+		}
+
+		return nil
+	})
+
+	return prices, err
+}
+
+// price every item with the first list, in priority order, that has a price
+// for it and record that list on the line. items no list prices cost what
+// the menu of the shop asks. an item that is not on the menu is an open
+// item, rung up by staff at the product price or at the price they give, and
+// refused unless the order takes open items. a priced item takes the name of
+// what priced it, so tickets and receipts show what was paid for, and the tax
+// rate of its product. only open items without a product keep the tax rate
+// they are ordered at.
+func priceOrderItems(ctx context.Context, q *Queries, priceListIDs []uuid.UUID, items []CreateOrderItemParams, openItems bool) ([]CreateOrderItemParams, error) {
+	// the prices of a list come with its menu item prices first
+	prices := make(map[uuid.UUID][]ListOrderPricesRow, len(priceListIDs))
+	if len(priceListIDs) > 0 {
+		rows, err := q.ListOrderPrices(ctx, priceListIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			prices[row.PriceListID] = append(prices[row.PriceListID], row)
+		}
+	}
+
+	menus := make(map[string][]ListMenuPricesRow)
+
+	priced := make([]CreateOrderItemParams, len(items))
+	copy(priced, items)
+	for i := range priced {
+		listed := false
+		for _, id := range priceListIDs {
+			price, ok := orderItemPrice(priced[i], prices[id])
+			if !ok {
+				continue
+			}
+			priced[i].ProductPrice = price.Price
+			priced[i].ProductName = price.ProductName
+			priced[i].TaxRate = price.TaxRate
+			priced[i].PriceListID = uuid.NullUUID{UUID: id, Valid: true}
+			listed = true
+			break
+		}
+		if listed {
+			continue
+		}
+
+		menu, ok := menus[priced[i].ShopName]
+		if !ok {
+			var err error
+			menu, err = q.ListMenuPrices(ctx, priced[i].ShopName)
+			if err != nil {
+				return nil, err
+			}
+			menus[priced[i].ShopName] = menu
+		}
+
+		if menuItem, ok := itemMenuPrice(priced[i], menu); ok {
+			priced[i].ProductPrice = menuItem.ProductPrice
+			priced[i].ProductName = menuItem.ProductName
+			priced[i].TaxRate = menuItem.TaxRate
+			continue
+		}
+
+		if !openItems {
+			return nil, fmt.Errorf("%w: %s", ErrNotOnMenu, priced[i].ProductName)
+		}
+		if !priced[i].ProductID.Valid {
+			continue
+		}
+		product, err := q.GetShopProduct(ctx, GetShopProductParams{
+			ShopName: priced[i].ShopName,
+			ID:       priced[i].ProductID.UUID,
+		})
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		priced[i].ProductPrice = product.Price
+		priced[i].ProductName = product.Name
+		priced[i].TaxRate = product.TaxRate
+	}
+
+	return priced, nil
+}
+
+// the menu item of the shop an item is, matched like price list prices
+func itemMenuPrice(item CreateOrderItemParams, menu []ListMenuPricesRow) (ListMenuPricesRow, bool) {
+	for _, menuItem := range menu {
+		if item.ProductID.Valid {
+			if menuItem.ProductID == item.ProductID.UUID {
+				return menuItem, true
+			}
+		} else if menuItem.ProductName == item.ProductName {
+			return menuItem, true
+		}
+	}
+
+	return ListMenuPricesRow{}, false
+}
+
+// items are matched by product id, items without one only by the name of a
+// menu item price
+func orderItemPrice(item CreateOrderItemParams, prices []ListOrderPricesRow) (ListOrderPricesRow, bool) {
+	for _, price := range prices {
+		if item.ProductID.Valid {
+			if price.ProductID == item.ProductID.UUID {
+				return price, true
+			}
+		} else if price.MenuItem && price.ProductName == item.ProductName {
+			return price, true
+		}
+	}
+
+	return ListOrderPricesRow{}, false
+}
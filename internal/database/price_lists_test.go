@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func createRandomPriceList(t *testing.T, shop Shop, priority int32, rules ...PriceListRuleParams) PriceListTxResult {
+	arg := SetPriceListTxParams{
+		ShopName: shop.Name,
+		Name:     utils.RandString(8),
+		Priority: priority,
+		Active:   true,
+		Rules:    rules,
+	}
+
+	result, err := testStore.CreatePriceListTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, result.PriceList.Name)
+	require.Equal(t, arg.Priority, result.PriceList.Priority)
+	require.True(t, result.PriceList.Active)
+	require.Len(t, result.Rules, len(rules))
+
+	return result
+}
+
+func setPriceListPrices(t *testing.T, shop Shop, priceList PriceList, prices ...PriceListPriceParams) []PriceListPrice {
+	set, err := testStore.SetPriceListPricesTx(context.Background(), SetPriceListPricesTxParams{
+		ShopName:       shop.Name,
+		OrganisationID: shop.OrganisationID,
+		PriceListID:    priceList.ID,
+		Prices:         prices,
+	})
+	require.NoError(t, err)
+	require.Len(t, set, len(prices))
+
+	return set
+}
+
+func TestUpdatePriceListTx(t *testing.T) {
+	shop := createRandomShop(t)
+	created := createRandomPriceList(t, shop, 1, PriceListRuleParams{OrderType: utils.OrderDelivery, EndsAt: 1440})
+
+	arg := SetPriceListTxParams{
+		ShopName: shop.Name,
+		ID:       created.PriceList.ID,
+		Name:     "happy hour",
+		Priority: 5,
+		Rules: []PriceListRuleParams{
+			{Weekday: 5, StartsAt: 17 * 60, EndsAt: 19 * 60},
+			{Weekday: 6, StartsAt: 17 * 60, EndsAt: 19 * 60},
+		},
+	}
+	updated, err := testStore.UpdatePriceListTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "happy hour", updated.PriceList.Name)
+	require.Equal(t, int32(5), updated.PriceList.Priority)
+	require.False(t, updated.PriceList.Active)
+	require.Len(t, updated.Rules, 2)
+
+	// the rules are replaced
+	rules, err := testQueries.ListPriceListRules(context.Background(), shop.Name)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+
+	// inactive lists never apply
+	active, err := testQueries.ListActivePriceListRules(context.Background(), shop.Name)
+	require.NoError(t, err)
+	require.Empty(t, active)
+
+	// priorities are unique within a shop
+	other := createRandomPriceList(t, shop, 2)
+	arg.ID = other.PriceList.ID
+	arg.Name = utils.RandString(8)
+	_, err = testStore.UpdatePriceListTx(context.Background(), arg)
+	require.Error(t, err)
+}
+
+func TestSetPriceListPricesTx(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	priceList := createRandomPriceList(t, shop, 1).PriceList
+
+	setPriceListPrices(t, shop, priceList,
+		PriceListPriceParams{ProductID: menuItem.ProductID, Price: "4.00"},
+		PriceListPriceParams{MenuItemID: menuItem.ID, Price: "3.50"},
+	)
+	prices := setPriceListPrices(t, shop, priceList, PriceListPriceParams{MenuItemID: menuItem.ID, Price: "3.00"})
+	require.Equal(t, "3.00", prices[0].Price)
+
+	listed, err := testQueries.ListPriceListPrices(context.Background(), priceList.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+
+	// menu items of other shops cannot be priced
+	otherItem := addRandomMenuItem(t, createRandomShop(t))
+	_, err = testStore.SetPriceListPricesTx(context.Background(), SetPriceListPricesTxParams{
+		ShopName:       shop.Name,
+		OrganisationID: shop.OrganisationID,
+		PriceListID:    priceList.ID,
+		Prices:         []PriceListPriceParams{{MenuItemID: otherItem.ID, Price: "1.00"}},
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestCreateOrderTxPriceLists(t *testing.T) {
+	shop := createRandomShop(t)
+	coffee := addRandomMenuItem(t, shop)
+	cake := addRandomMenuItem(t, shop)
+	tea := addRandomMenuItem(t, shop)
+
+	happyHour := createRandomPriceList(t, shop, 10).PriceList
+	setPriceListPrices(t, shop, happyHour, PriceListPriceParams{MenuItemID: coffee.ID, Price: "2.00"})
+	delivery := createRandomPriceList(t, shop, 1).PriceList
+	setPriceListPrices(t, shop, delivery,
+		PriceListPriceParams{ProductID: coffee.ProductID, Price: "6.00"},
+		PriceListPriceParams{ProductID: cake.ProductID, Price: "7.00"},
+		// a menu item price beats a product price of the same list
+		PriceListPriceParams{MenuItemID: cake.ID, Price: "6.50"},
+	)
+
+	items := []CreateOrderItemParams{tabOrderItem(shop, coffee), tabOrderItem(shop, cake), tabOrderItem(shop, tea)}
+	orderID := uuid.New()
+	for i := range items {
+		items[i].OrderID = orderID
+	}
+	// an item without a product id is matched by its menu name
+	items[1].ProductID = uuid.NullUUID{}
+	// the price sent with an item is never trusted
+	items[2].ProductPrice = "0.01"
+
+	result, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:        items,
+		OrderType:    utils.OrderDelivery,
+		PriceListIDs: []uuid.UUID{happyHour.ID, delivery.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Orders, 3)
+
+	require.Equal(t, "2.00", result.Orders[0].ProductPrice)
+	require.Equal(t, uuid.NullUUID{UUID: happyHour.ID, Valid: true}, result.Orders[0].PriceListID)
+	require.Equal(t, "6.50", result.Orders[1].ProductPrice)
+	require.Equal(t, uuid.NullUUID{UUID: delivery.ID, Valid: true}, result.Orders[1].PriceListID)
+	// no list prices the tea, it costs what the menu asks
+	require.Equal(t, tea.ProductPrice, result.Orders[2].ProductPrice)
+	require.False(t, result.Orders[2].PriceListID.Valid)
+
+	// items off the menu are only taken as open items
+	special := tabOrderItem(shop, tea)
+	special.ProductID = uuid.NullUUID{}
+	special.ProductName = "off menu special"
+	_, err = testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{Items: []CreateOrderItemParams{special}})
+	require.ErrorIs(t, err, ErrNotOnMenu)
+
+	// the name sent with an item is never trusted either
+	renamed := tabOrderItem(shop, tea)
+	renamed.OrderID = uuid.New()
+	renamed.ProductName = cake.ProductName
+	result, err = testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{Items: []CreateOrderItemParams{renamed}})
+	require.NoError(t, err)
+	require.Equal(t, tea.ProductName, result.Orders[0].ProductName)
+	require.Equal(t, tea.ProductPrice, result.Orders[0].ProductPrice)
+
+	// a product of the shop that is not on the menu is an open item, which
+	// costs its product price
+	product := createRandomProduct(t, shop)
+	item := tabOrderItem(shop, tea)
+	item.OrderID = uuid.New()
+	item.ProductID = uuid.NullUUID{UUID: product.ID, Valid: true}
+	item.ProductName = "house special"
+	_, err = testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{Items: []CreateOrderItemParams{item}})
+	require.ErrorIs(t, err, ErrNotOnMenu)
+
+	result, err = testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{Items: []CreateOrderItemParams{item}, OpenItems: true})
+	require.NoError(t, err)
+	require.Equal(t, product.Price, result.Orders[0].ProductPrice)
+	require.Equal(t, product.Name, result.Orders[0].ProductName)
+
+	// a list that priced orders cannot be deleted
+	err = testQueries.DeletePriceList(context.Background(), DeletePriceListParams{ShopName: shop.Name, ID: happyHour.ID})
+	require.Error(t, err)
+}
+
+func TestCreateOrderTxTaxRates(t *testing.T) {
+	shop := createRandomShop(t)
+	coffee := addRandomMenuItem(t, shop)
+	cake := addRandomMenuItem(t, shop)
+
+	for _, menuItem := range []Menu{coffee, cake} {
+		product, err := testQueries.GetProduct(context.Background(), GetProductParams{OrganisationID: shop.OrganisationID, ID: menuItem.ProductID})
+		require.NoError(t, err)
+		_, err = testQueries.UpdateProduct(context.Background(), UpdateProductParams{
+			OrganisationID: shop.OrganisationID,
+			ID:             product.ID,
+			Name:           product.Name,
+			Price:          product.Price,
+			Description:    product.Description,
+			TaxRate:        "8.00",
+		})
+		require.NoError(t, err)
+	}
+
+	priceList := createRandomPriceList(t, shop, 1).PriceList
+	setPriceListPrices(t, shop, priceList, PriceListPriceParams{MenuItemID: cake.ID, Price: "4.00"})
+
+	items := []CreateOrderItemParams{tabOrderItem(shop, coffee), tabOrderItem(shop, cake)}
+	orderID := uuid.New()
+	for i := range items {
+		items[i].OrderID = orderID
+		// the rate sent with an item is never trusted
+		items[i].TaxRate = "0.00"
+	}
+
+	result, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:        items,
+		PriceListIDs: []uuid.UUID{priceList.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Orders, 2)
+	require.Equal(t, "8.00", result.Orders[0].TaxRate)
+	require.Equal(t, "8.00", result.Orders[1].TaxRate)
+
+	// open items without a product keep the rate staff ring them up at
+	open := tabOrderItem(shop, coffee)
+	open.OrderID = uuid.New()
+	open.ProductID = uuid.NullUUID{}
+	open.ProductName = "corkage"
+	open.TaxRate = "12.50"
+	result, err = testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{Items: []CreateOrderItemParams{open}, OpenItems: true})
+	require.NoError(t, err)
+	require.Equal(t, "12.50", result.Orders[0].TaxRate)
+}
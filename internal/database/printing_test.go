@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func createRandomPrinter(t *testing.T, shop Shop) Printer {
+	arg := CreatePrinterParams{
+		ID:         uuid.New(),
+		ShopName:   shop.Name,
+		Name:       utils.RandString(6),
+		Address:    "127.0.0.1:9100",
+		PaperWidth: 80,
+	}
+
+	printer, err := testQueries.CreatePrinter(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Address, printer.Address)
+	require.False(t, printer.StationID.Valid)
+
+	return printer
+}
+
+func createRandomPrintJob(t *testing.T, printer Printer) PrintJob {
+	arg := CreatePrintJobParams{
+		ID:          uuid.New(),
+		ShopName:    printer.ShopName,
+		PrinterID:   printer.ID,
+		Kind:        utils.PrintKindReceipt,
+		ReferenceID: uuid.New(),
+		Payload:     []byte("\x1b@" + utils.RandString(12)),
+	}
+
+	job, err := testQueries.CreatePrintJob(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Payload, job.Payload)
+	require.Equal(t, utils.PrintJobQueued, job.Status)
+	require.Zero(t, job.Attempts)
+
+	return job
+}
+
+func TestPrintJobLifecycle(t *testing.T) {
+	shop := createRandomShop(t)
+	printer := createRandomPrinter(t, shop)
+	job := createRandomPrintJob(t, printer)
+
+	claimed, err := testQueries.ClaimPrintJobs(context.Background(), 1000)
+	require.NoError(t, err)
+
+	var found bool
+	for _, c := range claimed {
+		if c.ID == job.ID {
+			found = true
+			require.Equal(t, utils.PrintJobPrinting, c.Status)
+			require.Equal(t, int32(1), c.Attempts)
+		}
+	}
+	require.True(t, found)
+
+	// a retried job is not due again before its delay is up
+	retried, err := testQueries.SetPrintJobFailed(context.Background(), SetPrintJobFailedParams{
+		Status:         utils.PrintJobQueued,
+		LastError:      "connection refused",
+		RetryInSeconds: 60,
+		ID:             job.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "connection refused", retried.LastError)
+	require.True(t, retried.NextAttemptAt.After(job.NextAttemptAt))
+
+	claimed, err = testQueries.ClaimPrintJobs(context.Background(), 1000)
+	require.NoError(t, err)
+	for _, c := range claimed {
+		require.NotEqual(t, job.ID, c.ID)
+	}
+
+	err = testQueries.SetPrintJobPrinted(context.Background(), job.ID)
+	require.NoError(t, err)
+
+	printed, err := testQueries.GetPrintJob(context.Background(), GetPrintJobParams{ShopName: shop.Name, ID: job.ID})
+	require.NoError(t, err)
+	require.Equal(t, utils.PrintJobPrinted, printed.Status)
+	require.Empty(t, printed.LastError)
+	require.True(t, printed.PrintedAt.Valid)
+
+	jobs, err := testQueries.ListPrintJobs(context.Background(), ListPrintJobsParams{
+		ShopName: shop.Name,
+		Status:   utils.PrintJobPrinted,
+		PageSize: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+
+	// deleting the printer drops its jobs
+	deleted, err := testQueries.DeletePrinter(context.Background(), DeletePrinterParams{ShopName: shop.Name, ID: printer.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	jobs, err = testQueries.ListPrintJobs(context.Background(), ListPrintJobsParams{ShopName: shop.Name, PageSize: 10})
+	require.NoError(t, err)
+	require.Empty(t, jobs)
+}
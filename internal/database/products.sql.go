@@ -12,61 +12,65 @@ import (
 )
 
 const createProduct = `-- name: CreateProduct :one
-INSERT INTO products (id, user_id, name, price, description)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, user_id, name, price, description, created_at
+INSERT INTO products (id, organisation_id, name, price, description, tax_rate)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, organisation_id, name, price, description, created_at, prep_seconds, tax_rate
 `
 
 type CreateProductParams struct {
-	ID          uuid.UUID `json:"id"`
-	UserID      uuid.UUID `json:"user_id"`
-	Name        string    `json:"name"`
-	Price       string    `json:"price"`
-	Description string    `json:"description"`
+	ID             uuid.UUID `json:"id"`
+	OrganisationID uuid.UUID `json:"organisation_id"`
+	Name           string    `json:"name"`
+	Price          string    `json:"price"`
+	Description    string    `json:"description"`
+	TaxRate        string    `json:"tax_rate"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error) {
 	row := q.db.QueryRowContext(ctx, createProduct,
 		arg.ID,
-		arg.UserID,
+		arg.OrganisationID,
 		arg.Name,
 		arg.Price,
 		arg.Description,
+		arg.TaxRate,
 	)
 	var i Product
 	err := row.Scan(
 		&i.ID,
-		&i.UserID,
+		&i.OrganisationID,
 		&i.Name,
 		&i.Price,
 		&i.Description,
 		&i.CreatedAt,
+		&i.PrepSeconds,
+		&i.TaxRate,
 	)
 	return i, err
 }
 
 const deleteProduct = `-- name: DeleteProduct :exec
 DELETE FROM products
-WHERE user_id = $1 AND id = $2
+WHERE organisation_id = $1 AND id = $2
 `
 
 type DeleteProductParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	ID     uuid.UUID `json:"id"`
+	OrganisationID uuid.UUID `json:"organisation_id"`
+	ID             uuid.UUID `json:"id"`
 }
 
 func (q *Queries) DeleteProduct(ctx context.Context, arg DeleteProductParams) error {
-	_, err := q.db.ExecContext(ctx, deleteProduct, arg.UserID, arg.ID)
+	_, err := q.db.ExecContext(ctx, deleteProduct, arg.OrganisationID, arg.ID)
 	return err
 }
 
 const getAllProducts = `-- name: GetAllProducts :many
-SELECT id, user_id, name, price, description, created_at FROM products
-WHERE user_id = $1
+SELECT id, organisation_id, name, price, description, created_at, prep_seconds, tax_rate FROM products
+WHERE organisation_id = $1
 `
 
-func (q *Queries) GetAllProducts(ctx context.Context, userID uuid.UUID) ([]Product, error) {
-	rows, err := q.db.QueryContext(ctx, getAllProducts, userID)
+func (q *Queries) GetAllProducts(ctx context.Context, organisationID uuid.UUID) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, getAllProducts, organisationID)
 	if err != nil {
 		return nil, err
 	}
@@ -76,11 +80,13 @@ func (q *Queries) GetAllProducts(ctx context.Context, userID uuid.UUID) ([]Produ
 		var i Product
 		if err := rows.Scan(
 			&i.ID,
-			&i.UserID,
+			&i.OrganisationID,
 			&i.Name,
 			&i.Price,
 			&i.Description,
 			&i.CreatedAt,
+			&i.PrepSeconds,
+			&i.TaxRate,
 		); err != nil {
 			return nil, err
 		}
@@ -96,41 +102,43 @@ func (q *Queries) GetAllProducts(ctx context.Context, userID uuid.UUID) ([]Produ
 }
 
 const getProduct = `-- name: GetProduct :one
-SELECT id, user_id, name, price, description, created_at FROM products
-WHERE user_id = $1 AND id = $2 LIMIT 1
+SELECT id, organisation_id, name, price, description, created_at, prep_seconds, tax_rate FROM products
+WHERE organisation_id = $1 AND id = $2 LIMIT 1
 `
 
 type GetProductParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	ID     uuid.UUID `json:"id"`
+	OrganisationID uuid.UUID `json:"organisation_id"`
+	ID             uuid.UUID `json:"id"`
 }
 
 func (q *Queries) GetProduct(ctx context.Context, arg GetProductParams) (Product, error) {
-	row := q.db.QueryRowContext(ctx, getProduct, arg.UserID, arg.ID)
+	row := q.db.QueryRowContext(ctx, getProduct, arg.OrganisationID, arg.ID)
 	var i Product
 	err := row.Scan(
 		&i.ID,
-		&i.UserID,
+		&i.OrganisationID,
 		&i.Name,
 		&i.Price,
 		&i.Description,
 		&i.CreatedAt,
+		&i.PrepSeconds,
+		&i.TaxRate,
 	)
 	return i, err
 }
 
 const getProductsByName = `-- name: GetProductsByName :many
-SELECT id, user_id, name, price, description, created_at FROM products
-WHERE user_id = $1 AND name = $2
+SELECT id, organisation_id, name, price, description, created_at, prep_seconds, tax_rate FROM products
+WHERE organisation_id = $1 AND name = $2
 `
 
 type GetProductsByNameParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	Name   string    `json:"name"`
+	OrganisationID uuid.UUID `json:"organisation_id"`
+	Name           string    `json:"name"`
 }
 
 func (q *Queries) GetProductsByName(ctx context.Context, arg GetProductsByNameParams) ([]Product, error) {
-	rows, err := q.db.QueryContext(ctx, getProductsByName, arg.UserID, arg.Name)
+	rows, err := q.db.QueryContext(ctx, getProductsByName, arg.OrganisationID, arg.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -140,11 +148,13 @@ func (q *Queries) GetProductsByName(ctx context.Context, arg GetProductsByNamePa
 		var i Product
 		if err := rows.Scan(
 			&i.ID,
-			&i.UserID,
+			&i.OrganisationID,
 			&i.Name,
 			&i.Price,
 			&i.Description,
 			&i.CreatedAt,
+			&i.PrepSeconds,
+			&i.TaxRate,
 		); err != nil {
 			return nil, err
 		}
@@ -159,37 +169,98 @@ func (q *Queries) GetProductsByName(ctx context.Context, arg GetProductsByNamePa
 	return items, nil
 }
 
+const getShopProduct = `-- name: GetShopProduct :one
+SELECT p.id, p.organisation_id, p.name, p.price, p.description, p.created_at, p.prep_seconds, p.tax_rate FROM products p
+JOIN shops s ON s.organisation_id = p.organisation_id
+WHERE s.name = $1 AND p.id = $2
+LIMIT 1
+`
+
+type GetShopProductParams struct {
+	ShopName string    `json:"shop_name"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (q *Queries) GetShopProduct(ctx context.Context, arg GetShopProductParams) (Product, error) {
+	row := q.db.QueryRowContext(ctx, getShopProduct, arg.ShopName, arg.ID)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.OrganisationID,
+		&i.Name,
+		&i.Price,
+		&i.Description,
+		&i.CreatedAt,
+		&i.PrepSeconds,
+		&i.TaxRate,
+	)
+	return i, err
+}
+
 const updateProduct = `-- name: UpdateProduct :one
 UPDATE products
-SET name = $3, price = $4, description = $5
-WHERE user_id = $1 AND id = $2
-RETURNING id, user_id, name, price, description, created_at
+SET name = $3, price = $4, description = $5, tax_rate = $6
+WHERE organisation_id = $1 AND id = $2
+RETURNING id, organisation_id, name, price, description, created_at, prep_seconds, tax_rate
 `
 
 type UpdateProductParams struct {
-	UserID      uuid.UUID `json:"user_id"`
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Price       string    `json:"price"`
-	Description string    `json:"description"`
+	OrganisationID uuid.UUID `json:"organisation_id"`
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	Price          string    `json:"price"`
+	Description    string    `json:"description"`
+	TaxRate        string    `json:"tax_rate"`
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error) {
 	row := q.db.QueryRowContext(ctx, updateProduct,
-		arg.UserID,
+		arg.OrganisationID,
 		arg.ID,
 		arg.Name,
 		arg.Price,
 		arg.Description,
+		arg.TaxRate,
 	)
 	var i Product
 	err := row.Scan(
 		&i.ID,
-		&i.UserID,
+		&i.OrganisationID,
+		&i.Name,
+		&i.Price,
+		&i.Description,
+		&i.CreatedAt,
+		&i.PrepSeconds,
+		&i.TaxRate,
+	)
+	return i, err
+}
+
+const updateProductPrepTime = `-- name: UpdateProductPrepTime :one
+UPDATE products
+SET prep_seconds = $3
+WHERE organisation_id = $1 AND id = $2
+RETURNING id, organisation_id, name, price, description, created_at, prep_seconds, tax_rate
+`
+
+type UpdateProductPrepTimeParams struct {
+	OrganisationID uuid.UUID `json:"organisation_id"`
+	ID             uuid.UUID `json:"id"`
+	PrepSeconds    int32     `json:"prep_seconds"`
+}
+
+func (q *Queries) UpdateProductPrepTime(ctx context.Context, arg UpdateProductPrepTimeParams) (Product, error) {
+	row := q.db.QueryRowContext(ctx, updateProductPrepTime, arg.OrganisationID, arg.ID, arg.PrepSeconds)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.OrganisationID,
 		&i.Name,
 		&i.Price,
 		&i.Description,
 		&i.CreatedAt,
+		&i.PrepSeconds,
+		&i.TaxRate,
 	)
 	return i, err
 }
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"strconv"
 	"testing"
 	"time"
@@ -11,13 +12,14 @@ import (
 	"github.com/toml5566/go_pos_backend/utils"
 )
 
-func createRandomProduct(t *testing.T, user1 User) Product {
+func createRandomProduct(t *testing.T, shop1 Shop) Product {
 	arg := CreateProductParams{
-		ID:          uuid.New(),
-		UserID:      user1.ID,
-		Name:        utils.RandString(4),
-		Price:       strconv.FormatFloat(utils.RandomFloat(1, 100), 'f', 2, 64),
-		Description: utils.RandString(10),
+		ID:             uuid.New(),
+		OrganisationID: shop1.OrganisationID,
+		Name:           utils.RandString(4),
+		Price:          strconv.FormatFloat(utils.RandomFloat(1, 100), 'f', 2, 64),
+		Description:    utils.RandString(10),
+		TaxRate:        "0.00",
 	}
 
 	product, err := testQueries.CreateProduct(context.Background(), arg)
@@ -25,31 +27,34 @@ func createRandomProduct(t *testing.T, user1 User) Product {
 	require.NotEmpty(t, product)
 
 	require.Equal(t, arg.ID, product.ID)
-	require.Equal(t, arg.UserID, product.UserID)
+	require.Equal(t, arg.OrganisationID, product.OrganisationID)
 	require.Equal(t, arg.Name, product.Name)
 	require.Equal(t, arg.Price, product.Price)
 	require.Equal(t, arg.Description, product.Description)
+	require.Equal(t, arg.TaxRate, product.TaxRate)
 
 	require.NotZero(t, product.CreatedAt)
 
 	return product
 }
 
-func createProductWithSameName(t *testing.T, user User, productName string) []Product {
+func createProductWithSameName(t *testing.T, shop Shop, productName string) []Product {
 	// create 2 products with same name
 	arg1 := CreateProductParams{
-		ID:          uuid.New(),
-		UserID:      user.ID,
-		Name:        productName,
-		Price:       "12.50",
-		Description: "it is an apple",
+		ID:             uuid.New(),
+		OrganisationID: shop.OrganisationID,
+		Name:           productName,
+		Price:          "12.50",
+		Description:    "it is an apple",
+		TaxRate:        "0.00",
 	}
 	arg2 := CreateProductParams{
-		ID:          uuid.New(),
-		UserID:      user.ID,
-		Name:        productName,
-		Price:       "20.00",
-		Description: "it is an orange",
+		ID:             uuid.New(),
+		OrganisationID: shop.OrganisationID,
+		Name:           productName,
+		Price:          "20.00",
+		Description:    "it is an orange",
+		TaxRate:        "0.00",
 	}
 
 	product1, err := testQueries.CreateProduct(context.Background(), arg1)
@@ -64,18 +69,18 @@ func createProductWithSameName(t *testing.T, user User, productName string) []Pr
 }
 
 func TestCreateProduct(t *testing.T) {
-	user1 := createRandomUser(t)
-	createRandomProduct(t, user1)
+	shop1 := createRandomShop(t)
+	createRandomProduct(t, shop1)
 }
 
 func TestGetProduct(t *testing.T) {
-	user1 := createRandomUser(t)
+	shop1 := createRandomShop(t)
 
-	product1 := createRandomProduct(t, user1)
+	product1 := createRandomProduct(t, shop1)
 
 	arg := GetProductParams{
-		UserID: user1.ID,
-		ID:     product1.ID,
+		OrganisationID: shop1.OrganisationID,
+		ID:             product1.ID,
 	}
 
 	productFromDB, err := testQueries.GetProduct(context.Background(), arg)
@@ -83,7 +88,7 @@ func TestGetProduct(t *testing.T) {
 	require.NotEmpty(t, productFromDB)
 
 	require.Equal(t, productFromDB.ID, product1.ID)
-	require.Equal(t, productFromDB.UserID, product1.UserID)
+	require.Equal(t, productFromDB.OrganisationID, product1.OrganisationID)
 	require.Equal(t, productFromDB.Name, product1.Name)
 	require.Equal(t, productFromDB.Price, product1.Price)
 	require.Equal(t, productFromDB.Description, product1.Description)
@@ -93,15 +98,15 @@ func TestGetProduct(t *testing.T) {
 }
 
 func TestGetProductsByName(t *testing.T) {
-	user1 := createRandomUser(t)
+	shop1 := createRandomShop(t)
 	productName := utils.RandString(5)
 
-	createProductWithSameName(t, user1, productName)
+	createProductWithSameName(t, shop1, productName)
 
 	// get products by name
 	arg := GetProductsByNameParams{
-		UserID: user1.ID,
-		Name:   productName,
+		OrganisationID: shop1.OrganisationID,
+		Name:           productName,
 	}
 
 	products, err := testQueries.GetProductsByName(context.Background(), arg)
@@ -112,25 +117,25 @@ func TestGetProductsByName(t *testing.T) {
 		require.NotEmpty(t, product)
 
 		require.Equal(t, product.Name, productName)
-		require.Equal(t, product.UserID, user1.ID)
+		require.Equal(t, product.OrganisationID, shop1.OrganisationID)
 	}
 
 }
 
 func TestGetAllProducts(t *testing.T) {
-	user := createRandomUser(t)
+	shop := createRandomShop(t)
 
-	product1 := createRandomProduct(t, user)
-	product2 := createRandomProduct(t, user)
+	product1 := createRandomProduct(t, shop)
+	product2 := createRandomProduct(t, shop)
 
-	products, err := testQueries.GetAllProducts(context.Background(), user.ID)
+	products, err := testQueries.GetAllProducts(context.Background(), shop.OrganisationID)
 	require.NoError(t, err)
 	require.NotEmpty(t, products)
 
 	var productIDList []uuid.UUID
 	for _, p := range products {
 		require.NotEmpty(t, p)
-		require.Equal(t, p.UserID, user.ID)
+		require.Equal(t, p.OrganisationID, shop.OrganisationID)
 
 		productIDList = append(productIDList, p.ID)
 	}
@@ -139,15 +144,16 @@ func TestGetAllProducts(t *testing.T) {
 }
 
 func TestUpdateProduct(t *testing.T) {
-	user := createRandomUser(t)
-	product := createRandomProduct(t, user)
+	shop := createRandomShop(t)
+	product := createRandomProduct(t, shop)
 
 	arg := UpdateProductParams{
-		UserID:      user.ID,
-		ID:          product.ID,
-		Name:        "Updated name",
-		Price:       "1000.00",
-		Description: "Updated description",
+		OrganisationID: shop.OrganisationID,
+		ID:             product.ID,
+		Name:           "Updated name",
+		Price:          "1000.00",
+		Description:    "Updated description",
+		TaxRate:        "8.00",
 	}
 
 	updatedProduct, err := testQueries.UpdateProduct(context.Background(), arg)
@@ -155,28 +161,52 @@ func TestUpdateProduct(t *testing.T) {
 	require.NotEmpty(t, updatedProduct)
 
 	require.Equal(t, updatedProduct.ID, product.ID)
-	require.Equal(t, updatedProduct.UserID, product.UserID)
+	require.Equal(t, updatedProduct.OrganisationID, product.OrganisationID)
 	require.Equal(t, updatedProduct.Name, arg.Name)
 	require.Equal(t, updatedProduct.Price, arg.Price)
 	require.Equal(t, updatedProduct.Description, arg.Description)
+	require.Equal(t, updatedProduct.TaxRate, arg.TaxRate)
 
 }
 
+func TestUpdateProductPrepTime(t *testing.T) {
+	shop := createRandomShop(t)
+	product := createRandomProduct(t, shop)
+	require.Zero(t, product.PrepSeconds)
+
+	updatedProduct, err := testQueries.UpdateProductPrepTime(context.Background(), UpdateProductPrepTimeParams{
+		OrganisationID: shop.OrganisationID,
+		ID:             product.ID,
+		PrepSeconds:    240,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(240), updatedProduct.PrepSeconds)
+	require.Equal(t, product.Name, updatedProduct.Name)
+
+	// products of other users are left alone
+	_, err = testQueries.UpdateProductPrepTime(context.Background(), UpdateProductPrepTimeParams{
+		OrganisationID: createRandomShop(t).ID,
+		ID:             product.ID,
+		PrepSeconds:    60,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
 func TestDeleteProduct(t *testing.T) {
-	user := createRandomUser(t)
-	product := createRandomProduct(t, user)
+	shop := createRandomShop(t)
+	product := createRandomProduct(t, shop)
 
 	arg1 := DeleteProductParams{
-		UserID: user.ID,
-		ID:     product.ID,
+		OrganisationID: shop.OrganisationID,
+		ID:             product.ID,
 	}
 
 	err := testQueries.DeleteProduct(context.Background(), arg1)
 	require.NoError(t, err)
 
 	arg2 := GetProductParams{
-		UserID: user.ID,
-		ID:     product.ID,
+		OrganisationID: shop.OrganisationID,
+		ID:             product.ID,
 	}
 
 	deletedProduct, err := testQueries.GetProduct(context.Background(), arg2)
@@ -0,0 +1,441 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// an item of the round promotions can discount, in cents. left is what the
+// promotions evaluated so far left of the line
+type promotionLine struct {
+	order   Order
+	catalog string
+	price   int64
+	left    int64
+}
+
+// the cents a promotion takes off the items of a tax rate
+type promotionDiscount struct {
+	promotion Promotion
+	taxRate   string
+	amount    int64
+}
+
+// apply the promotions of the shop that are active at the instant at to the
+// items of a round, as discount lines with a negative price. a promotion
+// gets a line per tax rate of the items it discounts so tax is charged on
+// what is left. discount lines are not prepared, so they skip stock and
+// kitchen tickets. promotions that require a code only apply when it is the
+// promotion of the coupon redeemed for the round.
+func addPromotionDiscounts(ctx context.Context, q *Queries, round []Order, first bool, at time.Time, couponPromotion uuid.NullUUID) ([]Order, error) {
+	if len(round) == 0 {
+		return nil, nil
+	}
+
+	promotions, err := q.ListActivePromotions(ctx, ListActivePromotionsParams{
+		ShopName:          round[0].ShopName,
+		At:                at,
+		CouponPromotionID: couponPromotion,
+	})
+	if err != nil || len(promotions) == 0 {
+		return nil, err
+	}
+
+	lines, err := promotionLines(ctx, q, round, promotions)
+	if err != nil {
+		return nil, err
+	}
+
+	discounts, err := evaluatePromotions(promotions, lines, first)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []Order
+	for _, discount := range discounts {
+		line, err := q.CreateOrderItem(ctx, CreateOrderItemParams{
+			ID:           uuid.New(),
+			ShopName:     round[0].ShopName,
+			OrderID:      round[0].OrderID,
+			OrderDay:     round[0].OrderDay,
+			ProductName:  discount.promotion.Name,
+			ProductPrice: utils.FormatCents(-discount.amount),
+			Amount:       1,
+			Status:       utils.StatusPending,
+			TaxRate:      discount.taxRate,
+			OrderType:    round[0].OrderType,
+			PromotionID:  uuid.NullUUID{UUID: discount.promotion.ID, Valid: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, line)
+	}
+
+	return created, nil
+}
+
+// the items of a round as lines the promotions can discount
+func promotionLines(ctx context.Context, q *Queries, round []Order, promotions []Promotion) ([]*promotionLine, error) {
+	catalogs, err := roundCatalogs(ctx, q, round, promotions)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]*promotionLine, 0, len(round))
+	for i, orderItem := range round {
+		price, err := utils.ParseCents(orderItem.ProductPrice)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, &promotionLine{
+			order:   orderItem,
+			catalog: catalogs[i],
+			price:   price,
+			left:    price * int64(orderItem.Amount),
+		})
+	}
+
+	return lines, nil
+}
+
+// work the discounts of the round of a refunded item out again from the
+// items left in the round, so the refund does not keep the discount the item
+// earned. promotions cannot be changed once created, so evaluating the ones
+// that applied gives what they give the rest of the round. a discount line is
+// lowered to what is still due and refunded when nothing is, it never grows.
+func reviseRoundDiscounts(ctx context.Context, q *Queries, refunded Order) ([]Order, error) {
+	orders, err := q.GetOrdersByOrderID(ctx, GetOrdersByOrderIDParams{
+		ShopName: refunded.ShopName,
+		OrderID:  refunded.OrderID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var round, discountLines []Order
+	first := true
+	for _, order := range orders {
+		inRound := order.CreatedAt.Equal(refunded.CreatedAt)
+		if order.PromotionID.Valid || order.FeeID.Valid {
+			if order.PromotionID.Valid && inRound && order.Status != utils.StatusRefunded {
+				discountLines = append(discountLines, order)
+			}
+			continue
+		}
+		if order.CreatedAt.Before(refunded.CreatedAt) {
+			first = false
+		}
+		if inRound && order.Status != utils.StatusRefunded {
+			round = append(round, order)
+		}
+	}
+	if len(discountLines) == 0 {
+		return nil, nil
+	}
+
+	type discountKey struct {
+		promotionID uuid.UUID
+		taxRate     string
+	}
+	due := make(map[discountKey]int64)
+
+	if len(round) > 0 {
+		var promotions []Promotion
+		seen := make(map[uuid.UUID]bool)
+		for _, line := range discountLines {
+			if seen[line.PromotionID.UUID] {
+				continue
+			}
+			seen[line.PromotionID.UUID] = true
+			promotion, err := q.GetPromotion(ctx, GetPromotionParams{
+				ShopName: refunded.ShopName,
+				ID:       line.PromotionID.UUID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			promotions = append(promotions, promotion)
+		}
+		// in the order ListActivePromotions evaluated them
+		sort.SliceStable(promotions, func(i, j int) bool {
+			if promotions[i].Priority != promotions[j].Priority {
+				return promotions[i].Priority > promotions[j].Priority
+			}
+			if !promotions[i].CreatedAt.Equal(promotions[j].CreatedAt) {
+				return promotions[i].CreatedAt.Before(promotions[j].CreatedAt)
+			}
+			return bytes.Compare(promotions[i].ID[:], promotions[j].ID[:]) < 0
+		})
+
+		lines, err := promotionLines(ctx, q, round, promotions)
+		if err != nil {
+			return nil, err
+		}
+		discounts, err := evaluatePromotions(promotions, lines, first)
+		if err != nil {
+			return nil, err
+		}
+		for _, discount := range discounts {
+			due[discountKey{discount.promotion.ID, discount.taxRate}] += discount.amount
+		}
+	}
+
+	var revised []Order
+	for _, line := range discountLines {
+		price, err := utils.ParseCents(line.ProductPrice)
+		if err != nil {
+			return nil, err
+		}
+		amount := due[discountKey{line.PromotionID.UUID, line.TaxRate}]
+		if amount >= -price {
+			continue
+		}
+
+		if amount <= 0 {
+			line, err = q.UpdateOrderItem(ctx, UpdateOrderItemParams{
+				ShopName: line.ShopName,
+				ID:       line.ID,
+				Amount:   line.Amount,
+				Status:   utils.StatusRefunded,
+			})
+		} else {
+			line, err = q.UpdateOrderItemPrice(ctx, UpdateOrderItemPriceParams{
+				ShopName:     line.ShopName,
+				ID:           line.ID,
+				ProductPrice: utils.FormatCents(-amount),
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		revised = append(revised, line)
+	}
+
+	return revised, nil
+}
+
+// the menu catalog of every item of the round, only looked up when a
+// promotion targets a catalog. items are matched to the menu by product id,
+// items without one by name, the oldest menu item wins like in the product mix
+func roundCatalogs(ctx context.Context, q *Queries, round []Order, promotions []Promotion) ([]string, error) {
+	catalogs := make([]string, len(round))
+
+	lookup := false
+	for _, promotion := range promotions {
+		lookup = lookup || promotion.Target == utils.PromotionCatalog
+	}
+	if !lookup {
+		return catalogs, nil
+	}
+
+	menu, err := q.GetAllMenuItems(ctx, round[0].ShopName)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(menu, func(i, j int) bool {
+		return menu[i].CreatedAt.Before(menu[j].CreatedAt)
+	})
+
+	byProduct := make(map[uuid.UUID]string, len(menu))
+	byName := make(map[string]string, len(menu))
+	for _, menuItem := range menu {
+		if _, ok := byProduct[menuItem.ProductID]; !ok {
+			byProduct[menuItem.ProductID] = menuItem.Catalog
+		}
+		if _, ok := byName[menuItem.ProductName]; !ok {
+			byName[menuItem.ProductName] = menuItem.Catalog
+		}
+	}
+
+	for i, orderItem := range round {
+		if orderItem.ProductID.Valid {
+			catalogs[i] = byProduct[orderItem.ProductID.UUID]
+		} else {
+			catalogs[i] = byName[orderItem.ProductName]
+		}
+	}
+
+	return catalogs, nil
+}
+
+// evaluate the promotions in the order given, highest priority first. a
+// promotion applies when the round reaches its minimum spend, before any
+// discount, and takes its discount off what earlier promotions left. a
+// promotion that is not stackable is skipped once another one applied and
+// ends the evaluation when it applies itself.
+func evaluatePromotions(promotions []Promotion, lines []*promotionLine, first bool) ([]promotionDiscount, error) {
+	var subtotal int64
+	for _, line := range lines {
+		subtotal += line.left
+	}
+
+	var discounts []promotionDiscount
+	applied := false
+	for _, promotion := range promotions {
+		if applied && !promotion.Stackable {
+			continue
+		}
+
+		minSpend, err := utils.ParseCents(promotion.MinSpend)
+		if err != nil {
+			return nil, err
+		}
+		if subtotal < minSpend {
+			continue
+		}
+
+		amount, err := utils.ParseCents(promotion.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		var rates []string
+		byRate := make(map[string]int64)
+		for i, cents := range promotionTakes(promotion, amount, lines, first) {
+			if cents <= 0 {
+				continue
+			}
+			line := lines[i]
+			line.left -= cents
+			if _, ok := byRate[line.order.TaxRate]; !ok {
+				rates = append(rates, line.order.TaxRate)
+			}
+			byRate[line.order.TaxRate] += cents
+		}
+		if len(rates) == 0 {
+			continue
+		}
+
+		for _, rate := range rates {
+			discounts = append(discounts, promotionDiscount{
+				promotion: promotion,
+				taxRate:   rate,
+				amount:    byRate[rate],
+			})
+		}
+		applied = true
+		if !promotion.Stackable {
+			break
+		}
+	}
+
+	return discounts, nil
+}
+
+// the cents a promotion takes off each line, never more than is left of it.
+// amount is the promotion amount in cents, or in hundredths of a percent for
+// percentages
+func promotionTakes(promotion Promotion, amount int64, lines []*promotionLine, first bool) []int64 {
+	taken := make([]int64, len(lines))
+
+	matches := func(line *promotionLine) bool {
+		if line.left <= 0 {
+			return false
+		}
+		switch promotion.Target {
+		case utils.PromotionProduct:
+			return line.order.ProductID.Valid && line.order.ProductID.UUID == promotion.ProductID.UUID
+		case utils.PromotionCatalog:
+			return line.catalog == promotion.Catalog
+		}
+		return true
+	}
+
+	switch promotion.Kind {
+	case utils.PromotionPercent:
+		for i, line := range lines {
+			if matches(line) {
+				taken[i] = utils.PercentOfCents(line.left, amount)
+			}
+		}
+
+	case utils.PromotionFixed:
+		if promotion.Target != utils.PromotionOrder {
+			// per unit of the items
+			for i, line := range lines {
+				if matches(line) {
+					taken[i] = amount * int64(line.order.Amount)
+				}
+			}
+			break
+		}
+
+		// once per order, spread over the items by what is left of them
+		if !first {
+			break
+		}
+		var total int64
+		for _, line := range lines {
+			if matches(line) {
+				total += line.left
+			}
+		}
+		if total == 0 {
+			break
+		}
+		if amount > total {
+			amount = total
+		}
+		var spread int64
+		for i, line := range lines {
+			if matches(line) {
+				taken[i] = amount * line.left / total
+				spread += taken[i]
+			}
+		}
+		// the cents lost to rounding down go to the first items
+		for i := 0; spread < amount && i < len(lines); i++ {
+			if matches(lines[i]) && taken[i] < lines[i].left {
+				taken[i]++
+				spread++
+			}
+		}
+
+	case utils.PromotionBuyXGetY:
+		// units of the items from the most to the least expensive, the last
+		// get_quantity units of every buy_quantity + get_quantity are
+		// discounted. the units are counted a line at a time, never listed.
+		var matched []int
+		for i, line := range lines {
+			if matches(line) {
+				matched = append(matched, i)
+			}
+		}
+		sort.SliceStable(matched, func(i, j int) bool {
+			return lines[matched[i]].price > lines[matched[j]].price
+		})
+
+		buy := int64(promotion.BuyQuantity)
+		group := buy + int64(promotion.GetQuantity)
+		// the discounted units among the first n
+		discounted := func(n int64) int64 {
+			free := n / group * int64(promotion.GetQuantity)
+			if rest := n % group; rest > buy {
+				free += rest - buy
+			}
+			return free
+		}
+
+		var counted int64
+		for _, i := range matched {
+			units := int64(lines[i].order.Amount)
+			free := discounted(counted+units) - discounted(counted)
+			taken[i] += free * utils.PercentOfCents(lines[i].price, amount)
+			counted += units
+		}
+	}
+
+	for i, line := range lines {
+		if taken[i] > line.left {
+			taken[i] = line.left
+		}
+	}
+
+	return taken
+}
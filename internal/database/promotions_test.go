@@ -0,0 +1,232 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func createRandomPromotion(t *testing.T, shop Shop, arg CreatePromotionParams) Promotion {
+	arg.ID = uuid.New()
+	arg.ShopName = shop.Name
+	arg.Name = utils.RandString(8)
+	arg.Active = true
+	if arg.MinSpend == "" {
+		arg.MinSpend = "0.00"
+	}
+
+	promotion, err := testQueries.CreatePromotion(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, promotion.Name)
+	require.Equal(t, arg.Amount, promotion.Amount)
+
+	return promotion
+}
+
+func TestEvaluatePromotions(t *testing.T) {
+	coffeeID := uuid.New()
+	newLines := func() []*promotionLine {
+		return []*promotionLine{
+			{order: Order{ProductID: uuid.NullUUID{UUID: coffeeID, Valid: true}, Amount: 3, TaxRate: "5.00"}, catalog: utils.Breakfast, price: 400, left: 1200},
+			{order: Order{Amount: 1, TaxRate: "0.00"}, catalog: utils.Lunch, price: 600, left: 600},
+		}
+	}
+
+	twoPlusOne := Promotion{ID: uuid.New(), Target: utils.PromotionProduct, Kind: utils.PromotionBuyXGetY, Amount: "100.00",
+		ProductID: uuid.NullUUID{UUID: coffeeID, Valid: true}, BuyQuantity: 2, GetQuantity: 1, MinSpend: "0.00", Stackable: true}
+	tenOff := Promotion{ID: uuid.New(), Target: utils.PromotionOrder, Kind: utils.PromotionPercent, Amount: "10.00", MinSpend: "15.00", Stackable: true}
+	fiveOff := Promotion{ID: uuid.New(), Target: utils.PromotionOrder, Kind: utils.PromotionFixed, Amount: "5.00", MinSpend: "0.00"}
+	lunch := Promotion{ID: uuid.New(), Target: utils.PromotionCatalog, Kind: utils.PromotionFixed, Amount: "1.00", Catalog: utils.Lunch, MinSpend: "0.00", Stackable: true}
+
+	// stacked promotions take their discount off what the earlier ones left,
+	// a promotion that is not stackable is skipped once another applied
+	discounts, err := evaluatePromotions([]Promotion{twoPlusOne, tenOff, fiveOff}, newLines(), true)
+	require.NoError(t, err)
+	require.Equal(t, []promotionDiscount{
+		{promotion: twoPlusOne, taxRate: "5.00", amount: 400},
+		{promotion: tenOff, taxRate: "5.00", amount: 80},
+		{promotion: tenOff, taxRate: "0.00", amount: 60},
+	}, discounts)
+
+	// a promotion that is not stackable ends the evaluation when it applies,
+	// the minimum spend is checked before any discount
+	discounts, err = evaluatePromotions([]Promotion{fiveOff, tenOff}, newLines(), true)
+	require.NoError(t, err)
+	require.Equal(t, []promotionDiscount{
+		// spread over the items by what is left of them
+		{promotion: fiveOff, taxRate: "5.00", amount: 334},
+		{promotion: fiveOff, taxRate: "0.00", amount: 166},
+	}, discounts)
+
+	// a fixed amount off the order only applies to the first round, items of
+	// a catalog only when on the menu under it
+	discounts, err = evaluatePromotions([]Promotion{fiveOff, lunch}, newLines(), false)
+	require.NoError(t, err)
+	require.Equal(t, []promotionDiscount{
+		{promotion: lunch, taxRate: "0.00", amount: 100},
+	}, discounts)
+
+	// short of the minimum spend
+	lines := newLines()
+	lines[0].left, lines[0].order.Amount = 400, 1
+	discounts, err = evaluatePromotions([]Promotion{tenOff}, lines, true)
+	require.NoError(t, err)
+	require.Empty(t, discounts)
+}
+
+func TestPromotionTakesBuyXGetY(t *testing.T) {
+	coffeeID := uuid.New()
+	twoPlusOne := Promotion{Target: utils.PromotionProduct, Kind: utils.PromotionBuyXGetY,
+		ProductID: uuid.NullUUID{UUID: coffeeID, Valid: true}, BuyQuantity: 2, GetQuantity: 1}
+	coffee := func(price int64, amount int32) *promotionLine {
+		order := Order{ProductID: uuid.NullUUID{UUID: coffeeID, Valid: true}, Amount: amount}
+		return &promotionLine{order: order, price: price, left: price * int64(amount)}
+	}
+
+	// the third and sixth unit, counted from the most expensive, are free
+	taken := promotionTakes(twoPlusOne, 10000, []*promotionLine{coffee(300, 4), coffee(500, 2)}, true)
+	require.Equal(t, []int64{600, 0}, taken)
+
+	// the units of a line are counted, not listed one by one
+	taken = promotionTakes(twoPlusOne, 10000, []*promotionLine{coffee(1, 1_000_000_000)}, true)
+	require.Equal(t, []int64{333_333_333}, taken)
+}
+
+func TestCreateOrderTxPromotions(t *testing.T) {
+	shop := createRandomShop(t)
+	coffee := addRandomMenuItem(t, shop)
+	cake := addRandomMenuItem(t, shop)
+
+	now := time.Now().UTC()
+	createRandomPromotion(t, shop, CreatePromotionParams{
+		Target:    utils.PromotionProduct,
+		Kind:      utils.PromotionFixed,
+		Amount:    "1.00",
+		ProductID: uuid.NullUUID{UUID: coffee.ProductID, Valid: true},
+		Priority:  10,
+		Stackable: true,
+	})
+	tenOff := createRandomPromotion(t, shop, CreatePromotionParams{
+		Target:    utils.PromotionOrder,
+		Kind:      utils.PromotionPercent,
+		Amount:    "10.00",
+		StartsAt:  sql.NullTime{Time: now.Add(-time.Hour), Valid: true},
+		EndsAt:    sql.NullTime{Time: now.Add(time.Hour), Valid: true},
+		Stackable: true,
+	})
+	// not yet valid
+	createRandomPromotion(t, shop, CreatePromotionParams{
+		Target:    utils.PromotionOrder,
+		Kind:      utils.PromotionFixed,
+		Amount:    "3.00",
+		StartsAt:  sql.NullTime{Time: now.Add(time.Hour), Valid: true},
+		Stackable: true,
+	})
+
+	orderID := uuid.New()
+	items := []CreateOrderItemParams{tabOrderItem(shop, coffee), tabOrderItem(shop, cake)}
+	for i := range items {
+		items[i].OrderID = orderID
+	}
+
+	result, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{Items: items})
+	require.NoError(t, err)
+	require.Len(t, result.Discounts, 2)
+	require.Len(t, result.Orders, 4)
+
+	// 1.00 off the coffee, then 10% off the 4.00 and 5.00 left
+	require.Equal(t, "-1.00", result.Discounts[0].ProductPrice)
+	require.Equal(t, "-0.90", result.Discounts[1].ProductPrice)
+	require.Equal(t, uuid.NullUUID{UUID: tenOff.ID, Valid: true}, result.Discounts[1].PromotionID)
+	require.Equal(t, tenOff.Name, result.Discounts[1].ProductName)
+
+	sales, err := testQueries.GetDailySales(context.Background(), GetDailySalesParams{ShopName: shop.Name, OrderDay: items[0].OrderDay})
+	require.NoError(t, err)
+	require.Equal(t, "10.00", sales.GrossSales)
+	require.Equal(t, "1.90", sales.Discounts)
+
+	// the discount lines follow the items they were worked out from
+	discount := result.Discounts[1]
+	_, err = testStore.UpdateOrderItemTx(context.Background(), UpdateOrderItemParams{ShopName: shop.Name, ID: discount.ID, Amount: 5, Status: discount.Status})
+	require.ErrorIs(t, err, ErrAdjustmentLine)
+	err = testStore.DeleteOrderItemTx(context.Background(), DeleteOrderItemParams{ShopName: shop.Name, ID: discount.ID})
+	require.ErrorIs(t, err, ErrAdjustmentLine)
+
+	// so the items of the discounted round keep their amounts, only their status moves on
+	coffeeLine := result.Orders[0]
+	_, err = testStore.UpdateOrderItemTx(context.Background(), UpdateOrderItemParams{ShopName: shop.Name, ID: coffeeLine.ID, Amount: 3, Status: coffeeLine.Status})
+	require.ErrorIs(t, err, ErrRoundDiscounted)
+	err = testStore.DeleteOrderItemTx(context.Background(), DeleteOrderItemParams{ShopName: shop.Name, ID: coffeeLine.ID})
+	require.ErrorIs(t, err, ErrRoundDiscounted)
+	updated, err := testStore.UpdateOrderItemTx(context.Background(), UpdateOrderItemParams{ShopName: shop.Name, ID: coffeeLine.ID, Amount: coffeeLine.Amount, Status: "done"})
+	require.NoError(t, err)
+	require.Equal(t, "done", updated.Status)
+
+	// a promotion that discounted orders cannot be deleted
+	err = testQueries.DeletePromotion(context.Background(), DeletePromotionParams{ShopName: shop.Name, ID: tenOff.ID})
+	require.Error(t, err)
+}
+
+func TestRefundOrderItemTxPromotions(t *testing.T) {
+	shop := createRandomShop(t)
+	coffee := addRandomMenuItem(t, shop)
+	cake := addRandomMenuItem(t, shop)
+	createRandomPromotion(t, shop, CreatePromotionParams{
+		Target:    utils.PromotionOrder,
+		Kind:      utils.PromotionPercent,
+		Amount:    "50.00",
+		Stackable: true,
+	})
+
+	orderID := uuid.New()
+	items := []CreateOrderItemParams{tabOrderItem(shop, coffee), tabOrderItem(shop, cake)}
+	for i := range items {
+		items[i].OrderID = orderID
+	}
+
+	result, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{Items: items})
+	require.NoError(t, err)
+	require.Len(t, result.Discounts, 1)
+	require.Equal(t, "-5.00", result.Discounts[0].ProductPrice)
+
+	// discount lines are not refunded on their own
+	_, err = testStore.RefundOrderItemTx(context.Background(), RefundOrderItemTxParams{ShopName: shop.Name, ID: result.Discounts[0].ID})
+	require.ErrorIs(t, err, ErrAdjustmentLine)
+
+	salesArg := GetDailySalesParams{ShopName: shop.Name, OrderDay: items[0].OrderDay}
+
+	// the cake keeps its half of the discount
+	refund, err := testStore.RefundOrderItemTx(context.Background(), RefundOrderItemTxParams{ShopName: shop.Name, ID: result.Orders[0].ID})
+	require.NoError(t, err)
+	require.Len(t, refund.Discounts, 1)
+	require.Equal(t, "-2.50", refund.Discounts[0].ProductPrice)
+	require.Equal(t, utils.StatusPending, refund.Discounts[0].Status)
+
+	sales, err := testQueries.GetDailySales(context.Background(), salesArg)
+	require.NoError(t, err)
+	summary, err := SummarizeDailySales(shop.Name, salesArg.OrderDay, sales)
+	require.NoError(t, err)
+	require.Equal(t, "10.00", summary.GrossSales)
+	require.Equal(t, "2.50", summary.Discounts)
+	require.Equal(t, "5.00", summary.Refunds)
+	require.Equal(t, "2.50", summary.NetSales)
+
+	// nothing is left to discount
+	refund, err = testStore.RefundOrderItemTx(context.Background(), RefundOrderItemTxParams{ShopName: shop.Name, ID: result.Orders[1].ID})
+	require.NoError(t, err)
+	require.Len(t, refund.Discounts, 1)
+	require.Equal(t, utils.StatusRefunded, refund.Discounts[0].Status)
+
+	sales, err = testQueries.GetDailySales(context.Background(), salesArg)
+	require.NoError(t, err)
+	summary, err = SummarizeDailySales(shop.Name, salesArg.OrderDay, sales)
+	require.NoError(t, err)
+	require.Equal(t, "0.00", summary.Discounts)
+	require.Equal(t, "10.00", summary.Refunds)
+	require.Equal(t, "0.00", summary.NetSales)
+}
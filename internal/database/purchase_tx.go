@@ -0,0 +1,216 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type PurchaseOrderLineParams struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Quantity  int32     `json:"quantity"`
+	UnitCost  float64   `json:"unit_cost"`
+}
+
+type CreatePurchaseOrderTxParams struct {
+	ShopName     string                    `json:"shop_name"`
+	SupplierID   uuid.UUID                 `json:"supplier_id"`
+	ExpectedDate string                    `json:"expected_date"`
+	Note         string                    `json:"note"`
+	Lines        []PurchaseOrderLineParams `json:"lines"`
+}
+
+type PurchaseOrderTxResult struct {
+	PurchaseOrder PurchaseOrder       `json:"purchase_order"`
+	Lines         []PurchaseOrderLine `json:"lines"`
+}
+
+// create a draft purchase order with its lines,
+// the supplier must belong to the shop and every product to its organisation
+func (store *SQLStore) CreatePurchaseOrderTx(ctx context.Context, arg CreatePurchaseOrderTxParams) (PurchaseOrderTxResult, error) {
+	var result PurchaseOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetSupplier(ctx, GetSupplierParams{ShopName: arg.ShopName, ID: arg.SupplierID})
+		if err != nil {
+			return err
+		}
+
+		shop, err := q.GetShopByName(ctx, arg.ShopName)
+		if err != nil {
+			return err
+		}
+
+		result.PurchaseOrder, err = q.CreatePurchaseOrder(ctx, CreatePurchaseOrderParams{
+			ID:           uuid.New(),
+			ShopName:     arg.ShopName,
+			SupplierID:   arg.SupplierID,
+			ExpectedDate: arg.ExpectedDate,
+			Note:         arg.Note,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, line := range arg.Lines {
+			_, err := q.GetProduct(ctx, GetProductParams{OrganisationID: shop.OrganisationID, ID: line.ProductID})
+			if err != nil {
+				return err
+			}
+
+			orderLine, err := q.CreatePurchaseOrderLine(ctx, CreatePurchaseOrderLineParams{
+				ID:              uuid.New(),
+				PurchaseOrderID: result.PurchaseOrder.ID,
+				ProductID:       line.ProductID,
+				QuantityOrdered: line.Quantity,
+				UnitCost:        utils.FormottedDecimalToString(line.UnitCost),
+			})
+			if err != nil {
+				return err
+			}
+			result.Lines = append(result.Lines, orderLine)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+type ReceiveLineParams struct {
+	LineID   uuid.UUID `json:"line_id"`
+	Quantity int32     `json:"quantity"`
+	UnitCost float64   `json:"unit_cost"`
+}
+
+type ReceivePurchaseOrderTxParams struct {
+	ShopName string              `json:"shop_name"`
+	ID       uuid.UUID           `json:"id"`
+	Lines    []ReceiveLineParams `json:"lines"`
+}
+
+type ReceivePurchaseOrderTxResult struct {
+	PurchaseOrder PurchaseOrder       `json:"purchase_order"`
+	Lines         []PurchaseOrderLine `json:"lines"`
+	Movements     []StockMovement     `json:"movements"`
+	Costs         []ProductCost       `json:"costs"`
+}
+
+// record a delivery against a sent purchase order: the received quantities
+// are posted to stock as receive movements and their unit costs are kept
+// in the cost history, the order is received once every line is complete
+func (store *SQLStore) ReceivePurchaseOrderTx(ctx context.Context, arg ReceivePurchaseOrderTxParams) (ReceivePurchaseOrderTxResult, error) {
+	var result ReceivePurchaseOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		purchaseOrder, err := q.GetPurchaseOrderForUpdate(ctx, GetPurchaseOrderForUpdateParams{
+			ShopName: arg.ShopName,
+			ID:       arg.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if purchaseOrder.Status != utils.PurchaseOrderSent && purchaseOrder.Status != utils.PurchaseOrderPartiallyReceived {
+			return ErrNotReceivable
+		}
+
+		lines, err := q.ListPurchaseOrderLines(ctx, purchaseOrder.ID)
+		if err != nil {
+			return err
+		}
+
+		orderLines := make(map[uuid.UUID]PurchaseOrderLine, len(lines))
+		for _, line := range lines {
+			orderLines[line.ID] = line
+		}
+
+		// lock stock rows in product order so concurrent deliveries cannot deadlock
+		received := make([]ReceiveLineParams, len(arg.Lines))
+		copy(received, arg.Lines)
+		sort.SliceStable(received, func(i, j int) bool {
+			pi := orderLines[received[i].LineID].ProductID
+			pj := orderLines[received[j].LineID].ProductID
+			return bytes.Compare(pi[:], pj[:]) < 0
+		})
+
+		for _, delivery := range received {
+			line, ok := orderLines[delivery.LineID]
+			if !ok {
+				return ErrUnknownOrderLine
+			}
+			if line.QuantityReceived+delivery.Quantity > line.QuantityOrdered {
+				return ErrOverReceived
+			}
+
+			unitCost := utils.FormottedDecimalToString(delivery.UnitCost)
+			line, err = q.ReceivePurchaseOrderLine(ctx, ReceivePurchaseOrderLineParams{
+				PurchaseOrderID:  purchaseOrder.ID,
+				ID:               line.ID,
+				QuantityReceived: delivery.Quantity,
+				UnitCost:         unitCost,
+			})
+			if err != nil {
+				return err
+			}
+			orderLines[line.ID] = line
+			result.Lines = append(result.Lines, line)
+
+			_, err = q.UpsertStockLevel(ctx, UpsertStockLevelParams{
+				ShopName:  arg.ShopName,
+				ProductID: line.ProductID,
+				OnHand:    delivery.Quantity,
+			})
+			if err != nil {
+				return err
+			}
+
+			movement, err := q.CreateStockMovement(ctx, CreateStockMovementParams{
+				ID:           uuid.New(),
+				ShopName:     arg.ShopName,
+				ProductID:    line.ProductID,
+				MovementType: utils.MovementReceive,
+				Quantity:     delivery.Quantity,
+				Note:         fmt.Sprintf("purchase order %s", purchaseOrder.ID),
+			})
+			if err != nil {
+				return err
+			}
+			result.Movements = append(result.Movements, movement)
+
+			cost, err := q.CreateProductCost(ctx, CreateProductCostParams{
+				ID:                  uuid.New(),
+				ShopName:            arg.ShopName,
+				ProductID:           line.ProductID,
+				PurchaseOrderLineID: uuid.NullUUID{UUID: line.ID, Valid: true},
+				Quantity:            delivery.Quantity,
+				UnitCost:            unitCost,
+			})
+			if err != nil {
+				return err
+			}
+			result.Costs = append(result.Costs, cost)
+		}
+
+		status := utils.PurchaseOrderReceived
+		for _, line := range orderLines {
+			if line.QuantityReceived < line.QuantityOrdered {
+				status = utils.PurchaseOrderPartiallyReceived
+				break
+			}
+		}
+
+		result.PurchaseOrder, err = q.UpdatePurchaseOrderStatus(ctx, UpdatePurchaseOrderStatusParams{
+			ShopName: arg.ShopName,
+			ID:       purchaseOrder.ID,
+			Status:   status,
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func createRandomSupplier(t *testing.T, shop Shop) Supplier {
+	arg := CreateSupplierParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		Name:     utils.RandString(8),
+		Email:    utils.RandString(6) + "@example.com",
+		Phone:    "0123456789",
+	}
+
+	supplier, err := testQueries.CreateSupplier(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, supplier.ID)
+	require.Equal(t, arg.ShopName, supplier.ShopName)
+	require.Equal(t, arg.Name, supplier.Name)
+	require.Equal(t, arg.Email, supplier.Email)
+	require.Equal(t, arg.Phone, supplier.Phone)
+	require.NotZero(t, supplier.CreatedAt)
+
+	return supplier
+}
+
+func TestListSuppliers(t *testing.T) {
+	shop := createRandomShop(t)
+	supplier1 := createRandomSupplier(t, shop)
+	supplier2 := createRandomSupplier(t, shop)
+
+	suppliers, err := testQueries.ListSuppliers(context.Background(), shop.Name)
+	require.NoError(t, err)
+	require.Len(t, suppliers, 2)
+	require.ElementsMatch(t, []Supplier{supplier1, supplier2}, suppliers)
+}
+
+func TestReceivePurchaseOrderTx(t *testing.T) {
+	shop := createRandomShop(t)
+	supplier := createRandomSupplier(t, shop)
+	product := createRandomProduct(t, shop)
+
+	created, err := testStore.CreatePurchaseOrderTx(context.Background(), CreatePurchaseOrderTxParams{
+		ShopName:     shop.Name,
+		SupplierID:   supplier.ID,
+		ExpectedDate: "2024-01-02",
+		Lines:        []PurchaseOrderLineParams{{ProductID: product.ID, Quantity: 10, UnitCost: 2}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, utils.PurchaseOrderDraft, created.PurchaseOrder.Status)
+	require.Len(t, created.Lines, 1)
+	line := created.Lines[0]
+
+	receive := ReceivePurchaseOrderTxParams{
+		ShopName: shop.Name,
+		ID:       created.PurchaseOrder.ID,
+		Lines:    []ReceiveLineParams{{LineID: line.ID, Quantity: 4, UnitCost: 2.5}},
+	}
+
+	// draft orders have not been sent yet
+	_, err = testStore.ReceivePurchaseOrderTx(context.Background(), receive)
+	require.ErrorIs(t, err, ErrNotReceivable)
+
+	_, err = testQueries.UpdatePurchaseOrderStatus(context.Background(), UpdatePurchaseOrderStatusParams{
+		ShopName: shop.Name,
+		ID:       created.PurchaseOrder.ID,
+		Status:   utils.PurchaseOrderSent,
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.ReceivePurchaseOrderTx(context.Background(), receive)
+	require.NoError(t, err)
+	require.Equal(t, utils.PurchaseOrderPartiallyReceived, result.PurchaseOrder.Status)
+	require.Equal(t, int32(4), result.Lines[0].QuantityReceived)
+	require.Equal(t, "2.50", result.Lines[0].UnitCost)
+	require.Len(t, result.Movements, 1)
+	require.Equal(t, utils.MovementReceive, result.Movements[0].MovementType)
+	require.Equal(t, int32(4), result.Movements[0].Quantity)
+	require.Len(t, result.Costs, 1)
+
+	receive.Lines[0].Quantity = 7
+	_, err = testStore.ReceivePurchaseOrderTx(context.Background(), receive)
+	require.ErrorIs(t, err, ErrOverReceived)
+
+	receive.Lines[0].Quantity = 6
+	result, err = testStore.ReceivePurchaseOrderTx(context.Background(), receive)
+	require.NoError(t, err)
+	require.Equal(t, utils.PurchaseOrderReceived, result.PurchaseOrder.Status)
+
+	stockLevel, err := testQueries.GetStockLevel(context.Background(), GetStockLevelParams{
+		ShopName:  shop.Name,
+		ProductID: product.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(10), stockLevel.OnHand)
+
+	costs, err := testQueries.ListProductCosts(context.Background(), ListProductCostsParams{
+		ShopName:  shop.Name,
+		ProductID: product.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, costs, 2)
+}
@@ -6,29 +6,229 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AddCheckLine(ctx context.Context, arg AddCheckLineParams) error
+	AddIngredientStock(ctx context.Context, arg AddIngredientStockParams) (Ingredient, error)
+	AddKitchenTicketItem(ctx context.Context, arg AddKitchenTicketItemParams) error
 	AddMenuItem(ctx context.Context, arg AddMenuItemParams) (Menu, error)
+	AddOpeningHours(ctx context.Context, arg AddOpeningHoursParams) (OpeningHour, error)
+	AddOrganisationMember(ctx context.Context, arg AddOrganisationMemberParams) (OrganisationMember, error)
+	AddPriceListPrice(ctx context.Context, arg AddPriceListPriceParams) (PriceListPrice, error)
+	AddPriceListRule(ctx context.Context, arg AddPriceListRuleParams) (PriceListRule, error)
+	AddStockLevel(ctx context.Context, arg AddStockLevelParams) (StockLevel, error)
+	BookOrderSlot(ctx context.Context, arg BookOrderSlotParams) (OrderSlot, error)
+	ClaimPrintJobs(ctx context.Context, batchSize int32) ([]PrintJob, error)
+	CloseTab(ctx context.Context, arg CloseTabParams) (Tab, error)
+	CountCheckPayments(ctx context.Context, arg CountCheckPaymentsParams) (int64, error)
+	CountCustomerRedemptions(ctx context.Context, arg CountCustomerRedemptionsParams) (int64, error)
+	CountOpenKitchenTickets(ctx context.Context, arg CountOpenKitchenTicketsParams) (int64, error)
+	CreateCheck(ctx context.Context, arg CreateCheckParams) (Check, error)
+	CreateCoupon(ctx context.Context, arg CreateCouponParams) (Coupon, error)
+	CreateCouponRedemption(ctx context.Context, arg CreateCouponRedemptionParams) (CouponRedemption, error)
+	CreateDelayedPrintJob(ctx context.Context, arg CreateDelayedPrintJobParams) (PrintJob, error)
+	CreateDiningTable(ctx context.Context, arg CreateDiningTableParams) (DiningTable, error)
+	CreateFloorArea(ctx context.Context, arg CreateFloorAreaParams) (FloorArea, error)
+	CreateIngredient(ctx context.Context, arg CreateIngredientParams) (Ingredient, error)
+	CreateIngredientMovement(ctx context.Context, arg CreateIngredientMovementParams) (IngredientMovement, error)
+	CreateKitchenTicket(ctx context.Context, arg CreateKitchenTicketParams) (KitchenTicket, error)
+	CreateOpeningException(ctx context.Context, arg CreateOpeningExceptionParams) (OpeningException, error)
+	CreateOrderFulfilment(ctx context.Context, arg CreateOrderFulfilmentParams) (OrderFulfilment, error)
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (Order, error)
+	CreateOrderTypeFee(ctx context.Context, arg CreateOrderTypeFeeParams) (OrderTypeFee, error)
+	CreateOrganisation(ctx context.Context, arg CreateOrganisationParams) (Organisation, error)
+	CreatePayment(ctx context.Context, arg CreatePaymentParams) (Payment, error)
+	CreatePriceList(ctx context.Context, arg CreatePriceListParams) (PriceList, error)
+	CreatePrintJob(ctx context.Context, arg CreatePrintJobParams) (PrintJob, error)
+	CreatePrinter(ctx context.Context, arg CreatePrinterParams) (Printer, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	CreateProductCost(ctx context.Context, arg CreateProductCostParams) (ProductCost, error)
+	CreatePromotion(ctx context.Context, arg CreatePromotionParams) (Promotion, error)
+	CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrderParams) (PurchaseOrder, error)
+	CreatePurchaseOrderLine(ctx context.Context, arg CreatePurchaseOrderLineParams) (PurchaseOrderLine, error)
+	CreateRecipeItem(ctx context.Context, arg CreateRecipeItemParams) (RecipeItem, error)
+	CreateShop(ctx context.Context, arg CreateShopParams) (Shop, error)
+	CreateStation(ctx context.Context, arg CreateStationParams) (Station, error)
+	CreateStockAlert(ctx context.Context, arg CreateStockAlertParams) (StockAlert, error)
+	CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) (StockMovement, error)
+	CreateSupplier(ctx context.Context, arg CreateSupplierParams) (Supplier, error)
+	CreateTab(ctx context.Context, arg CreateTabParams) (Tab, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateZReport(ctx context.Context, arg CreateZReportParams) (ZReport, error)
+	DeleteChecks(ctx context.Context, arg DeleteChecksParams) (int64, error)
+	DeleteDiningTable(ctx context.Context, arg DeleteDiningTableParams) (int64, error)
+	DeleteFloorArea(ctx context.Context, arg DeleteFloorAreaParams) (int64, error)
 	DeleteMenuItem(ctx context.Context, arg DeleteMenuItemParams) error
+	DeleteOpeningException(ctx context.Context, arg DeleteOpeningExceptionParams) (int64, error)
+	DeleteOpeningHours(ctx context.Context, shopName string) error
 	DeleteOrderItem(ctx context.Context, arg DeleteOrderItemParams) error
+	DeleteOrderTypeFee(ctx context.Context, arg DeleteOrderTypeFeeParams) error
+	DeleteOrganisationMember(ctx context.Context, arg DeleteOrganisationMemberParams) (int64, error)
+	DeletePriceList(ctx context.Context, arg DeletePriceListParams) error
+	DeletePriceListPrices(ctx context.Context, priceListID uuid.UUID) error
+	DeletePriceListRules(ctx context.Context, priceListID uuid.UUID) error
+	DeletePrinter(ctx context.Context, arg DeletePrinterParams) (int64, error)
 	DeleteProduct(ctx context.Context, arg DeleteProductParams) error
+	DeletePromotion(ctx context.Context, arg DeletePromotionParams) error
+	DeleteRecipeItems(ctx context.Context, arg DeleteRecipeItemsParams) error
+	DeleteStation(ctx context.Context, arg DeleteStationParams) (int64, error)
+	DeleteStationRoute(ctx context.Context, arg DeleteStationRouteParams) (int64, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	GetAccountMapping(ctx context.Context, shopName string) (AccountMapping, error)
 	GetAllMenuItems(ctx context.Context, shopName string) ([]Menu, error)
-	GetAllProducts(ctx context.Context, userID uuid.UUID) ([]Product, error)
+	GetAllProducts(ctx context.Context, organisationID uuid.UUID) ([]Product, error)
+	GetCategoryMix(ctx context.Context, arg GetCategoryMixParams) ([]GetCategoryMixRow, error)
+	GetCouponByCode(ctx context.Context, arg GetCouponByCodeParams) (Coupon, error)
+	GetCouponStats(ctx context.Context, arg GetCouponStatsParams) (GetCouponStatsRow, error)
+	GetDailySales(ctx context.Context, arg GetDailySalesParams) (GetDailySalesRow, error)
+	GetDailySalesByTaxRate(ctx context.Context, arg GetDailySalesByTaxRateParams) ([]GetDailySalesByTaxRateRow, error)
+	GetDailyTenders(ctx context.Context, arg GetDailyTendersParams) ([]GetDailyTendersRow, error)
+	GetDiningTable(ctx context.Context, arg GetDiningTableParams) (DiningTable, error)
+	GetDiningTableForUpdate(ctx context.Context, arg GetDiningTableForUpdateParams) (DiningTable, error)
+	GetFloorArea(ctx context.Context, arg GetFloorAreaParams) (FloorArea, error)
+	GetIngredient(ctx context.Context, arg GetIngredientParams) (Ingredient, error)
+	GetIngredientForUpdate(ctx context.Context, arg GetIngredientForUpdateParams) (Ingredient, error)
+	GetIngredientUsageReport(ctx context.Context, arg GetIngredientUsageReportParams) ([]GetIngredientUsageReportRow, error)
+	GetMemberShop(ctx context.Context, arg GetMemberShopParams) (Shop, error)
+	GetMenuItem(ctx context.Context, arg GetMenuItemParams) (Menu, error)
+	GetNextZReportNumber(ctx context.Context, shopName string) (int32, error)
+	GetOpenTabByTable(ctx context.Context, arg GetOpenTabByTableParams) (Tab, error)
+	GetOrderFulfilment(ctx context.Context, arg GetOrderFulfilmentParams) (OrderFulfilment, error)
+	GetOrderFulfilmentForUpdate(ctx context.Context, arg GetOrderFulfilmentForUpdateParams) (OrderFulfilment, error)
+	GetOrderItem(ctx context.Context, arg GetOrderItemParams) (Order, error)
+	GetOrderItemForUpdate(ctx context.Context, arg GetOrderItemForUpdateParams) (Order, error)
+	GetOrderSchedule(ctx context.Context, shopName string) (OrderSchedule, error)
+	GetOrderTypeMix(ctx context.Context, arg GetOrderTypeMixParams) ([]GetOrderTypeMixRow, error)
 	GetOrdersByDay(ctx context.Context, arg GetOrdersByDayParams) ([]Order, error)
 	GetOrdersByOrderID(ctx context.Context, arg GetOrdersByOrderIDParams) ([]Order, error)
+	GetOrganisation(ctx context.Context, id uuid.UUID) (Organisation, error)
+	GetOrganisationMember(ctx context.Context, arg GetOrganisationMemberParams) (OrganisationMember, error)
+	GetPriceList(ctx context.Context, arg GetPriceListParams) (PriceList, error)
+	GetPrintJob(ctx context.Context, arg GetPrintJobParams) (PrintJob, error)
+	GetPrinter(ctx context.Context, arg GetPrinterParams) (Printer, error)
 	GetProduct(ctx context.Context, arg GetProductParams) (Product, error)
+	GetProductMarginReport(ctx context.Context, arg GetProductMarginReportParams) ([]GetProductMarginReportRow, error)
+	GetProductMix(ctx context.Context, arg GetProductMixParams) ([]GetProductMixRow, error)
 	GetProductsByName(ctx context.Context, arg GetProductsByNameParams) ([]Product, error)
+	GetPromotion(ctx context.Context, arg GetPromotionParams) (Promotion, error)
+	GetPurchaseOrder(ctx context.Context, arg GetPurchaseOrderParams) (PurchaseOrder, error)
+	GetPurchaseOrderForUpdate(ctx context.Context, arg GetPurchaseOrderForUpdateParams) (PurchaseOrder, error)
+	GetPurchaseSuggestions(ctx context.Context, arg GetPurchaseSuggestionsParams) ([]GetPurchaseSuggestionsRow, error)
+	GetRecipeUsage(ctx context.Context, arg GetRecipeUsageParams) ([]GetRecipeUsageRow, error)
+	GetSalesComparison(ctx context.Context, arg GetSalesComparisonParams) (GetSalesComparisonRow, error)
+	GetSalesHeatmap(ctx context.Context, arg GetSalesHeatmapParams) ([]GetSalesHeatmapRow, error)
+	GetShop(ctx context.Context, id uuid.UUID) (Shop, error)
+	GetShopByName(ctx context.Context, name string) (Shop, error)
+	GetShopForShare(ctx context.Context, name string) (Shop, error)
+	GetShopForUpdate(ctx context.Context, name string) (Shop, error)
+	GetShopProduct(ctx context.Context, arg GetShopProductParams) (Product, error)
+	GetStation(ctx context.Context, arg GetStationParams) (Station, error)
+	GetStockLevel(ctx context.Context, arg GetStockLevelParams) (StockLevel, error)
+	GetStockLevelForUpdate(ctx context.Context, arg GetStockLevelForUpdateParams) (StockLevel, error)
+	GetSupplier(ctx context.Context, arg GetSupplierParams) (Supplier, error)
+	GetTab(ctx context.Context, arg GetTabParams) (Tab, error)
+	GetTabForUpdate(ctx context.Context, arg GetTabForUpdateParams) (Tab, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetZReport(ctx context.Context, arg GetZReportParams) (ZReport, error)
+	IsDayClosed(ctx context.Context, arg IsDayClosedParams) (bool, error)
+	ListActiveFulfilments(ctx context.Context, arg ListActiveFulfilmentsParams) ([]OrderFulfilment, error)
+	ListActivePriceListRules(ctx context.Context, shopName string) ([]PriceListRule, error)
+	ListActivePromotions(ctx context.Context, arg ListActivePromotionsParams) ([]Promotion, error)
+	ListCheckLines(ctx context.Context, arg ListCheckLinesParams) ([]CheckLine, error)
+	ListChecks(ctx context.Context, arg ListChecksParams) ([]Check, error)
+	ListChecksForUpdate(ctx context.Context, arg ListChecksForUpdateParams) ([]Check, error)
+	ListCoupons(ctx context.Context, arg ListCouponsParams) ([]Coupon, error)
+	ListDailySales(ctx context.Context, arg ListDailySalesParams) ([]ListDailySalesRow, error)
+	ListDiningTables(ctx context.Context, shopName string) ([]DiningTable, error)
+	ListFloorAreas(ctx context.Context, shopName string) ([]FloorArea, error)
+	ListIngredientMovements(ctx context.Context, arg ListIngredientMovementsParams) ([]IngredientMovement, error)
+	ListIngredientSalesByOrderItem(ctx context.Context, orderItemID uuid.NullUUID) ([]IngredientMovement, error)
+	ListIngredients(ctx context.Context, shopName string) ([]Ingredient, error)
+	ListKitchenTicketItems(ctx context.Context, ticketIds []uuid.UUID) ([]ListKitchenTicketItemsRow, error)
+	ListKitchenTickets(ctx context.Context, arg ListKitchenTicketsParams) ([]KitchenTicket, error)
+	ListKitchenTicketsByOrderID(ctx context.Context, arg ListKitchenTicketsByOrderIDParams) ([]KitchenTicket, error)
+	ListMemberShops(ctx context.Context, username string) ([]Shop, error)
+	ListMenuPrices(ctx context.Context, shopName string) ([]ListMenuPricesRow, error)
+	ListOpenKitchenTicketPrep(ctx context.Context, shopName string) ([]ListOpenKitchenTicketPrepRow, error)
+	ListOpenStockAlerts(ctx context.Context, shopName string) ([]StockAlert, error)
+	ListOpenTabs(ctx context.Context, shopName string) ([]Tab, error)
+	ListOpeningExceptions(ctx context.Context, arg ListOpeningExceptionsParams) ([]OpeningException, error)
+	ListOpeningHours(ctx context.Context, shopName string) ([]OpeningHour, error)
+	ListOrderHistoryByAmountAsc(ctx context.Context, arg ListOrderHistoryByAmountAscParams) ([]Order, error)
+	ListOrderHistoryByAmountDesc(ctx context.Context, arg ListOrderHistoryByAmountDescParams) ([]Order, error)
+	ListOrderHistoryByCreatedAtAsc(ctx context.Context, arg ListOrderHistoryByCreatedAtAscParams) ([]Order, error)
+	ListOrderHistoryByCreatedAtDesc(ctx context.Context, arg ListOrderHistoryByCreatedAtDescParams) ([]Order, error)
+	ListOrderPrices(ctx context.Context, priceListIds []uuid.UUID) ([]ListOrderPricesRow, error)
+	ListOrderSlots(ctx context.Context, arg ListOrderSlotsParams) ([]OrderSlot, error)
+	ListOrderTypeFees(ctx context.Context, shopName string) ([]OrderTypeFee, error)
+	ListOrderTypeFeesByType(ctx context.Context, arg ListOrderTypeFeesByTypeParams) ([]OrderTypeFee, error)
+	ListOrganisationMembers(ctx context.Context, organisationID uuid.UUID) ([]ListOrganisationMembersRow, error)
+	ListOrganisationShops(ctx context.Context, organisationID uuid.UUID) ([]Shop, error)
+	ListPaymentsByOrderID(ctx context.Context, arg ListPaymentsByOrderIDParams) ([]Payment, error)
+	ListPriceListPrices(ctx context.Context, priceListID uuid.UUID) ([]PriceListPrice, error)
+	ListPriceListRules(ctx context.Context, shopName string) ([]PriceListRule, error)
+	ListPriceLists(ctx context.Context, shopName string) ([]PriceList, error)
+	ListPrintJobs(ctx context.Context, arg ListPrintJobsParams) ([]PrintJob, error)
+	ListPrinters(ctx context.Context, shopName string) ([]Printer, error)
+	ListProductCosts(ctx context.Context, arg ListProductCostsParams) ([]ProductCost, error)
+	ListPromotions(ctx context.Context, shopName string) ([]Promotion, error)
+	ListPurchaseOrderLines(ctx context.Context, purchaseOrderID uuid.UUID) ([]PurchaseOrderLine, error)
+	ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrdersParams) ([]PurchaseOrder, error)
+	ListRecipeItems(ctx context.Context, arg ListRecipeItemsParams) ([]RecipeItem, error)
+	ListStationRoutes(ctx context.Context, shopName string) ([]StationRoute, error)
+	ListStations(ctx context.Context, shopName string) ([]Station, error)
+	ListStockLevels(ctx context.Context, shopName string) ([]StockLevel, error)
+	ListStockLevelsBelowReorderPoint(ctx context.Context) ([]StockLevel, error)
+	ListStockMovements(ctx context.Context, arg ListStockMovementsParams) ([]StockMovement, error)
+	ListSuppliers(ctx context.Context, shopName string) ([]Supplier, error)
+	ListUnavailableMenuItems(ctx context.Context, arg ListUnavailableMenuItemsParams) ([]Menu, error)
+	ListZReports(ctx context.Context, arg ListZReportsParams) ([]ZReport, error)
+	ListZReportsByBusinessDay(ctx context.Context, arg ListZReportsByBusinessDayParams) ([]ZReport, error)
+	LockCouponCustomer(ctx context.Context, arg LockCouponCustomerParams) error
+	MarkProductSoldOut(ctx context.Context, arg MarkProductSoldOutParams) error
+	MoveKitchenTickets(ctx context.Context, arg MoveKitchenTicketsParams) (int64, error)
+	MoveOrderItems(ctx context.Context, arg MoveOrderItemsParams) (int64, error)
+	MovePayments(ctx context.Context, arg MovePaymentsParams) (int64, error)
+	MoveTab(ctx context.Context, arg MoveTabParams) (Tab, error)
+	ReceivePurchaseOrderLine(ctx context.Context, arg ReceivePurchaseOrderLineParams) (PurchaseOrderLine, error)
+	RedeemCoupon(ctx context.Context, arg RedeemCouponParams) (Coupon, error)
+	ReleaseDueKitchenTickets(ctx context.Context, now time.Time) ([]KitchenTicket, error)
+	RequeueInterruptedPrintJobs(ctx context.Context) (int64, error)
+	ResolveStockAlerts(ctx context.Context) ([]StockAlert, error)
+	RotateDiningTableLink(ctx context.Context, arg RotateDiningTableLinkParams) (DiningTable, error)
+	SetCouponActive(ctx context.Context, arg SetCouponActiveParams) (Coupon, error)
+	SetCouponBatchActive(ctx context.Context, arg SetCouponBatchActiveParams) (int64, error)
+	SetDiningTableStatus(ctx context.Context, arg SetDiningTableStatusParams) (DiningTable, error)
+	SetIngredientStock(ctx context.Context, arg SetIngredientStockParams) (Ingredient, error)
+	SetKitchenTicketStatus(ctx context.Context, arg SetKitchenTicketStatusParams) (KitchenTicket, error)
+	SetMenuItemAvailability(ctx context.Context, arg SetMenuItemAvailabilityParams) (Menu, error)
+	SetOrderFulfilmentStatus(ctx context.Context, arg SetOrderFulfilmentStatusParams) (OrderFulfilment, error)
+	SetPrintJobFailed(ctx context.Context, arg SetPrintJobFailedParams) (PrintJob, error)
+	SetPrintJobPrinted(ctx context.Context, id uuid.UUID) error
+	SetPromotionActive(ctx context.Context, arg SetPromotionActiveParams) (Promotion, error)
+	SetStockLevel(ctx context.Context, arg SetStockLevelParams) (StockLevel, error)
+	SetStockReorderLevels(ctx context.Context, arg SetStockReorderLevelsParams) (StockLevel, error)
 	UpdateMenuItem(ctx context.Context, arg UpdateMenuItemParams) (Menu, error)
 	UpdateOrderItem(ctx context.Context, arg UpdateOrderItemParams) (Order, error)
+	UpdateOrderItemPrice(ctx context.Context, arg UpdateOrderItemPriceParams) (Order, error)
+	UpdatePriceList(ctx context.Context, arg UpdatePriceListParams) (PriceList, error)
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error)
+	UpdateProductPrepTime(ctx context.Context, arg UpdateProductPrepTimeParams) (Product, error)
+	UpdatePurchaseOrderStatus(ctx context.Context, arg UpdatePurchaseOrderStatusParams) (PurchaseOrder, error)
+	UpdateShopBusinessDayCutoff(ctx context.Context, arg UpdateShopBusinessDayCutoffParams) (Shop, error)
+	UpdateShopLocale(ctx context.Context, arg UpdateShopLocaleParams) (Shop, error)
+	UpdateShopProfile(ctx context.Context, arg UpdateShopProfileParams) (Shop, error)
+	UpdateShopReceipt(ctx context.Context, arg UpdateShopReceiptParams) (Shop, error)
+	UpdateShopTimezone(ctx context.Context, arg UpdateShopTimezoneParams) (Shop, error)
+	UpsertAccountMapping(ctx context.Context, arg UpsertAccountMappingParams) (AccountMapping, error)
+	UpsertCatalogRoute(ctx context.Context, arg UpsertCatalogRouteParams) (StationRoute, error)
+	UpsertOrderSchedule(ctx context.Context, arg UpsertOrderScheduleParams) (OrderSchedule, error)
+	UpsertProductRoute(ctx context.Context, arg UpsertProductRouteParams) (StationRoute, error)
+	UpsertStockLevel(ctx context.Context, arg UpsertStockLevelParams) (StockLevel, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,202 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type RecipeItemParams struct {
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	Quantity     float64   `json:"quantity"`
+	Unit         string    `json:"unit"`
+}
+
+type SetRecipeTxParams struct {
+	ShopName  string             `json:"shop_name"`
+	ProductID uuid.UUID          `json:"product_id"`
+	Items     []RecipeItemParams `json:"items"`
+}
+
+// replace the shop's recipe of a product, every ingredient must belong to the shop
+// and be measured in a unit convertible to the ingredient's unit
+func (store *SQLStore) SetRecipeTx(ctx context.Context, arg SetRecipeTxParams) ([]RecipeItem, error) {
+	recipe := []RecipeItem{}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := q.DeleteRecipeItems(ctx, DeleteRecipeItemsParams{
+			ProductID: arg.ProductID,
+			ShopName:  arg.ShopName,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, item := range arg.Items {
+			ingredient, err := q.GetIngredient(ctx, GetIngredientParams{
+				ShopName: arg.ShopName,
+				ID:       item.IngredientID,
+			})
+			if err != nil {
+				return err
+			}
+
+			if _, err := utils.ConvertUnit(item.Quantity, item.Unit, ingredient.Unit); err != nil {
+				return ErrIncompatibleUnit
+			}
+
+			recipeItem, err := q.CreateRecipeItem(ctx, CreateRecipeItemParams{
+				ID:           uuid.New(),
+				ProductID:    arg.ProductID,
+				IngredientID: item.IngredientID,
+				Quantity:     utils.FormattedQuantityToString(item.Quantity),
+				Unit:         item.Unit,
+			})
+			if err != nil {
+				return err
+			}
+			recipe = append(recipe, recipeItem)
+		}
+
+		return nil
+	})
+
+	return recipe, err
+}
+
+type IngredientMovementTxParams struct {
+	ShopName     string    `json:"shop_name"`
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	MovementType string    `json:"movement_type"`
+	Quantity     float64   `json:"quantity"` // in the ingredient's unit
+	Note         string    `json:"note"`
+}
+
+type IngredientMovementTxResult struct {
+	Ingredient         Ingredient         `json:"ingredient"`
+	IngredientMovement IngredientMovement `json:"ingredient_movement"`
+}
+
+// ingredient counterpart of StockMovementTx
+func (store *SQLStore) IngredientMovementTx(ctx context.Context, arg IngredientMovementTxParams) (IngredientMovementTxResult, error) {
+	var result IngredientMovementTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		ingredient, err := q.GetIngredientForUpdate(ctx, GetIngredientForUpdateParams{
+			ShopName: arg.ShopName,
+			ID:       arg.IngredientID,
+		})
+		if err != nil {
+			return err
+		}
+
+		delta := arg.Quantity
+		switch arg.MovementType {
+		case utils.MovementReceive, utils.MovementAdjustment:
+		case utils.MovementWaste:
+			delta = -arg.Quantity
+		case utils.MovementCount:
+			onHand, err := strconv.ParseFloat(ingredient.OnHand, 64)
+			if err != nil {
+				return err
+			}
+			delta = arg.Quantity - onHand
+		default:
+			return ErrInvalidMovementType
+		}
+
+		result.Ingredient, err = q.AddIngredientStock(ctx, AddIngredientStockParams{
+			ShopName: arg.ShopName,
+			ID:       arg.IngredientID,
+			OnHand:   utils.FormattedQuantityToString(delta),
+		})
+		if err != nil {
+			return err
+		}
+
+		result.IngredientMovement, err = q.CreateIngredientMovement(ctx, CreateIngredientMovementParams{
+			ID:           uuid.New(),
+			ShopName:     arg.ShopName,
+			IngredientID: arg.IngredientID,
+			MovementType: arg.MovementType,
+			Quantity:     utils.FormattedQuantityToString(delta),
+			Note:         arg.Note,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type ingredientUsage struct {
+	orderItem    Order
+	ingredientID uuid.UUID
+	quantity     float64 // in the ingredient's unit
+}
+
+// expand order items into the ingredients used by their product recipes,
+// sorted by ingredient so concurrent orders lock ingredient rows in the same order
+func orderIngredientUsage(ctx context.Context, q *Queries, orderItems []Order) ([]ingredientUsage, error) {
+	var usage []ingredientUsage
+
+	for _, orderItem := range orderItems {
+		if !orderItem.ProductID.Valid {
+			continue
+		}
+
+		recipe, err := q.GetRecipeUsage(ctx, GetRecipeUsageParams{
+			ProductID: orderItem.ProductID.UUID,
+			ShopName:  orderItem.ShopName,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range recipe {
+			perItem, err := strconv.ParseFloat(r.Quantity, 64)
+			if err != nil {
+				return nil, err
+			}
+			converted, err := utils.ConvertUnit(perItem, r.Unit, r.IngredientUnit)
+			if err != nil {
+				return nil, err
+			}
+
+			usage = append(usage, ingredientUsage{
+				orderItem:    orderItem,
+				ingredientID: r.IngredientID,
+				quantity:     converted * float64(orderItem.Amount),
+			})
+		}
+	}
+
+	sort.SliceStable(usage, func(i, j int) bool {
+		return bytes.Compare(usage[i].ingredientID[:], usage[j].ingredientID[:]) < 0
+	})
+
+	return usage, nil
+}
+
+func addIngredientOrderMovement(ctx context.Context, q *Queries, shopName string, ingredientID uuid.UUID, orderItemID uuid.UUID, movementType string, quantity string) (IngredientMovement, error) {
+	_, err := q.AddIngredientStock(ctx, AddIngredientStockParams{
+		ShopName: shopName,
+		ID:       ingredientID,
+		OnHand:   quantity,
+	})
+	if err != nil {
+		return IngredientMovement{}, err
+	}
+
+	return q.CreateIngredientMovement(ctx, CreateIngredientMovementParams{
+		ID:           uuid.New(),
+		ShopName:     shopName,
+		IngredientID: ingredientID,
+		MovementType: movementType,
+		Quantity:     quantity,
+		OrderItemID:  uuid.NullUUID{UUID: orderItemID, Valid: true},
+	})
+}
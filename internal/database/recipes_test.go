@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func createRandomRecipeItem(t *testing.T, product Product, ingredient Ingredient, quantity string, unit string) RecipeItem {
+	arg := CreateRecipeItemParams{
+		ID:           uuid.New(),
+		ProductID:    product.ID,
+		IngredientID: ingredient.ID,
+		Quantity:     quantity,
+		Unit:         unit,
+	}
+
+	recipeItem, err := testQueries.CreateRecipeItem(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, recipeItem)
+
+	require.Equal(t, arg.ID, recipeItem.ID)
+	require.Equal(t, arg.ProductID, recipeItem.ProductID)
+	require.Equal(t, arg.IngredientID, recipeItem.IngredientID)
+	require.Equal(t, arg.Quantity, recipeItem.Quantity)
+	require.Equal(t, arg.Unit, recipeItem.Unit)
+
+	return recipeItem
+}
+
+func TestGetRecipeUsage(t *testing.T) {
+	shop := createRandomShop(t)
+	product := createRandomProduct(t, shop)
+	milk := createRandomIngredient(t, shop, utils.UnitLiter)
+	createRandomRecipeItem(t, product, milk, "200.000", utils.UnitMilliliter)
+
+	usage, err := testQueries.GetRecipeUsage(context.Background(), GetRecipeUsageParams{
+		ProductID: product.ID,
+		ShopName:  shop.Name,
+	})
+	require.NoError(t, err)
+	require.Len(t, usage, 1)
+
+	require.Equal(t, milk.ID, usage[0].IngredientID)
+	require.Equal(t, utils.UnitMilliliter, usage[0].Unit)
+	require.Equal(t, utils.UnitLiter, usage[0].IngredientUnit)
+}
+
+func TestDeleteRecipeItems(t *testing.T) {
+	shop := createRandomShop(t)
+	product := createRandomProduct(t, shop)
+	createRandomRecipeItem(t, product, createRandomIngredient(t, shop, utils.UnitGram), "18.000", utils.UnitGram)
+
+	err := testQueries.DeleteRecipeItems(context.Background(), DeleteRecipeItemsParams{
+		ProductID: product.ID,
+		ShopName:  shop.Name,
+	})
+	require.NoError(t, err)
+
+	recipe, err := testQueries.ListRecipeItems(context.Background(), ListRecipeItemsParams{
+		ProductID: product.ID,
+		ShopName:  shop.Name,
+	})
+	require.NoError(t, err)
+	require.Empty(t, recipe)
+}
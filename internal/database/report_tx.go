@@ -0,0 +1,203 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type DailySalesSummaryParams struct {
+	ShopName    string `json:"shop_name"`
+	BusinessDay string `json:"business_day"`
+}
+
+type DailySalesSummary struct {
+	ShopName      string               `json:"shop_name"`
+	BusinessDay   string               `json:"business_day"`
+	GrossSales    string               `json:"gross_sales"`
+	Discounts     string               `json:"discounts"`
+	Refunds       string               `json:"refunds"`
+	NetSales      string               `json:"net_sales"`
+	Tax           string               `json:"tax"`
+	Tenders       []GetDailyTendersRow `json:"tenders"`
+	OrderCount    int64                `json:"order_count"`
+	AverageTicket string               `json:"average_ticket"`
+}
+
+func (store *SQLStore) DailySalesSummary(ctx context.Context, arg DailySalesSummaryParams) (DailySalesSummary, error) {
+	return dailySalesSummary(ctx, store.Queries, arg)
+}
+
+func dailySalesSummary(ctx context.Context, q *Queries, arg DailySalesSummaryParams) (DailySalesSummary, error) {
+	sales, err := q.GetDailySales(ctx, GetDailySalesParams{
+		ShopName: arg.ShopName,
+		OrderDay: arg.BusinessDay,
+	})
+	if err != nil {
+		return DailySalesSummary{}, err
+	}
+
+	summary, err := SummarizeDailySales(arg.ShopName, arg.BusinessDay, sales)
+	if err != nil {
+		return summary, err
+	}
+
+	summary.Tenders, err = q.GetDailyTenders(ctx, GetDailyTendersParams{
+		ShopName: arg.ShopName,
+		OrderDay: arg.BusinessDay,
+	})
+	return summary, err
+}
+
+// net sales are gross sales less discounts and refunds, tax is reported
+// separately and is not part of the sales figures
+func SummarizeDailySales(shopName, businessDay string, sales GetDailySalesRow) (DailySalesSummary, error) {
+	summary := DailySalesSummary{
+		ShopName:    shopName,
+		BusinessDay: businessDay,
+		Tenders:     []GetDailyTendersRow{},
+	}
+
+	gross, err := strconv.ParseFloat(sales.GrossSales, 64)
+	if err != nil {
+		return summary, err
+	}
+	refunds, err := strconv.ParseFloat(sales.Refunds, 64)
+	if err != nil {
+		return summary, err
+	}
+	tax, err := strconv.ParseFloat(sales.Tax, 64)
+	if err != nil {
+		return summary, err
+	}
+
+	discounts, err := strconv.ParseFloat(sales.Discounts, 64)
+	if err != nil {
+		return summary, err
+	}
+
+	net := gross - discounts - refunds
+
+	var averageTicket float64
+	if sales.OrderCount > 0 {
+		averageTicket = net / float64(sales.OrderCount)
+	}
+
+	summary.GrossSales = utils.FormottedDecimalToString(gross)
+	summary.Discounts = utils.FormottedDecimalToString(discounts)
+	summary.Refunds = utils.FormottedDecimalToString(refunds)
+	summary.NetSales = utils.FormottedDecimalToString(net)
+	summary.Tax = utils.FormottedDecimalToString(tax)
+	summary.OrderCount = sales.OrderCount
+	summary.AverageTicket = utils.FormottedDecimalToString(averageTicket)
+
+	return summary, nil
+}
+
+// freeze the summary of a business day into the next numbered z report,
+// a day can only be closed once
+func (store *SQLStore) CloseDayTx(ctx context.Context, arg DailySalesSummaryParams) (ZReport, error) {
+	var report ZReport
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// serialise closings of the shop so report numbers stay sequential
+		if _, err := q.GetShopForUpdate(ctx, arg.ShopName); err != nil {
+			return err
+		}
+
+		closed, err := q.IsDayClosed(ctx, IsDayClosedParams(arg))
+		if err != nil {
+			return err
+		}
+		if closed {
+			return ErrDayClosed
+		}
+
+		summary, err := dailySalesSummary(ctx, q, arg)
+		if err != nil {
+			return err
+		}
+
+		tenders, err := json.Marshal(summary.Tenders)
+		if err != nil {
+			return err
+		}
+
+		number, err := q.GetNextZReportNumber(ctx, arg.ShopName)
+		if err != nil {
+			return err
+		}
+
+		report, err = q.CreateZReport(ctx, CreateZReportParams{
+			ID:            uuid.New(),
+			ShopName:      arg.ShopName,
+			Number:        number,
+			BusinessDay:   arg.BusinessDay,
+			GrossSales:    summary.GrossSales,
+			Discounts:     summary.Discounts,
+			Refunds:       summary.Refunds,
+			NetSales:      summary.NetSales,
+			Tax:           summary.Tax,
+			OrderCount:    int32(summary.OrderCount),
+			AverageTicket: summary.AverageTicket,
+			Tenders:       tenders,
+		})
+		return err
+	})
+
+	return report, err
+}
+
+// record a payment against an order, refused once the business day of the
+// order is closed
+func (store *SQLStore) CreatePaymentTx(ctx context.Context, arg CreatePaymentParams) (Payment, error) {
+	var payment Payment
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		orders, err := q.GetOrdersByOrderID(ctx, GetOrdersByOrderIDParams{
+			ShopName: arg.ShopName,
+			OrderID:  arg.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			return sql.ErrNoRows
+		}
+
+		if err := checkDayOpen(ctx, q, arg.ShopName, orders[0].OrderDay); err != nil {
+			return err
+		}
+
+		payment, err = q.CreatePayment(ctx, arg)
+		return err
+	})
+
+	return payment, err
+}
+
+// refuse changes to a closed business day. the shop is share locked, so a
+// change waits for a closing of the shop in progress and the closing waits
+// for the change, and the z report never misses it
+func checkDayOpen(ctx context.Context, q *Queries, shopName, businessDay string) error {
+	if _, err := q.GetShopForShare(ctx, shopName); err != nil {
+		return err
+	}
+
+	closed, err := q.IsDayClosed(ctx, IsDayClosedParams{
+		ShopName:    shopName,
+		BusinessDay: businessDay,
+	})
+	if err != nil {
+		return err
+	}
+	if closed {
+		return ErrDayClosed
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func TestCloseDayTx(t *testing.T) {
+	shop := createRandomShop(t)
+	orderDay := "2024-01-02"
+	orderItem := createRandomOrderItem(t, shop, utils.RandOrderID(), orderDay)
+
+	paymentArg := CreatePaymentParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		OrderID:  orderItem.OrderID,
+		Method:   "cash",
+		Amount:   "1.00",
+		Tendered: "5.00",
+	}
+	payment, err := testStore.CreatePaymentTx(context.Background(), paymentArg)
+	require.NoError(t, err)
+	require.Equal(t, "cash", payment.Method)
+
+	_, err = testStore.CreatePaymentTx(context.Background(), CreatePaymentParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		OrderID:  uuid.New(),
+		Method:   "cash",
+		Amount:   "1.00",
+		Tendered: "1.00",
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg := DailySalesSummaryParams{
+		ShopName:    shop.Name,
+		BusinessDay: orderDay,
+	}
+
+	summary, err := testStore.DailySalesSummary(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), summary.OrderCount)
+	require.Len(t, summary.Tenders, 1)
+	require.Equal(t, "1.00", summary.Tenders[0].Amount)
+
+	report, err := testStore.CloseDayTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), report.Number)
+	require.Equal(t, summary.GrossSales, report.GrossSales)
+	require.Equal(t, summary.NetSales, report.NetSales)
+
+	closed, err := testQueries.IsDayClosed(context.Background(), IsDayClosedParams(arg))
+	require.NoError(t, err)
+	require.True(t, closed)
+
+	_, err = testStore.CloseDayTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrDayClosed)
+
+	_, err = testStore.RefundOrderItemTx(context.Background(), RefundOrderItemTxParams{ShopName: shop.Name, ID: orderItem.ID})
+	require.ErrorIs(t, err, ErrDayClosed)
+	_, err = testStore.UpdateOrderItemTx(context.Background(), UpdateOrderItemParams{ShopName: shop.Name, ID: orderItem.ID, Amount: 5, Status: orderItem.Status})
+	require.ErrorIs(t, err, ErrDayClosed)
+	err = testStore.DeleteOrderItemTx(context.Background(), DeleteOrderItemParams{ShopName: shop.Name, ID: orderItem.ID})
+	require.ErrorIs(t, err, ErrDayClosed)
+	paymentArg.ID = uuid.New()
+	_, err = testStore.CreatePaymentTx(context.Background(), paymentArg)
+	require.ErrorIs(t, err, ErrDayClosed)
+
+	// nothing more is ordered into the closed day
+	item := tabOrderItem(shop, addRandomMenuItem(t, shop))
+	item.OrderID = uuid.New()
+	item.OrderDay = orderDay
+	_, err = testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{Items: []CreateOrderItemParams{item}})
+	require.ErrorIs(t, err, ErrDayClosed)
+
+	// the next day gets the next report number
+	next, err := testStore.CloseDayTx(context.Background(), DailySalesSummaryParams{ShopName: shop.Name, BusinessDay: "2024-01-03"})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), next.Number)
+	require.Equal(t, int32(0), next.OrderCount)
+}
+
+func TestListDailySales(t *testing.T) {
+	shop := createRandomShop(t)
+	createRandomOrderItem(t, shop, utils.RandOrderID(), "2024-01-02")
+	createRandomOrderItem(t, shop, utils.RandOrderID(), "2024-01-03")
+	createRandomOrderItem(t, shop, utils.RandOrderID(), "2024-02-01")
+
+	days, err := testQueries.ListDailySales(context.Background(), ListDailySalesParams{
+		ShopName: shop.Name,
+		FromDay:  "2024-01-01",
+		ToDay:    "2024-01-31",
+	})
+	require.NoError(t, err)
+	require.Len(t, days, 2)
+	require.Equal(t, "2024-01-02", days[0].OrderDay)
+	require.Equal(t, int64(1), days[0].OrderCount)
+	require.Equal(t, "2024-01-03", days[1].OrderDay)
+}
+
+func TestUpdateShopLocale(t *testing.T) {
+	shop := createRandomShop(t)
+	require.Equal(t, utils.DefaultLocale, shop.Locale)
+
+	updated, err := testQueries.UpdateShopLocale(context.Background(), UpdateShopLocaleParams{
+		ID:     shop.ID,
+		Locale: "de-DE",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "de-DE", updated.Locale)
+}
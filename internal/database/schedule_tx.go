@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// settings of a shop that never saved its order schedule, the same as the
+// column defaults
+func DefaultOrderSchedule(shopName string) OrderSchedule {
+	return OrderSchedule{
+		ShopName:     shopName,
+		LeadMinutes:  20,
+		MaxDaysAhead: 7,
+	}
+}
+
+type OpeningHoursParams struct {
+	Weekday  int32 `json:"weekday"`
+	OpensAt  int32 `json:"opens_at"`
+	ClosesAt int32 `json:"closes_at"`
+}
+
+type SetOpeningHoursTxParams struct {
+	ShopName string               `json:"shop_name"`
+	Hours    []OpeningHoursParams `json:"hours"`
+}
+
+// replace the weekly opening hours of a shop, no hours leaves the shop open
+// around the clock
+func (store *SQLStore) SetOpeningHoursTx(ctx context.Context, arg SetOpeningHoursTxParams) ([]OpeningHour, error) {
+	hours := []OpeningHour{}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.DeleteOpeningHours(ctx, arg.ShopName); err != nil {
+			return err
+		}
+
+		for _, h := range arg.Hours {
+			opening, err := q.AddOpeningHours(ctx, AddOpeningHoursParams{
+				ID:       uuid.New(),
+				ShopName: arg.ShopName,
+				Weekday:  h.Weekday,
+				OpensAt:  h.OpensAt,
+				ClosesAt: h.ClosesAt,
+			})
+			if err != nil {
+				return err
+			}
+			hours = append(hours, opening)
+		}
+
+		return nil
+	})
+
+	return hours, err
+}
+
+// book the round into the slot the order is scheduled for and return when its
+// kitchen tickets are released. the slot row stays locked until the order is
+// committed, so concurrent orders for the slot queue up behind it and cannot
+// overbook. the order counts once, on its first round, its items on every
+// round. an order that is not scheduled is released right away.
+func reserveOrderSlot(ctx context.Context, q *Queries, fulfilment OrderFulfilment, items []CreateOrderItemParams, first bool) (time.Time, error) {
+	now := time.Now().UTC()
+	if !fulfilment.ScheduledFor.Valid {
+		return now, nil
+	}
+
+	schedule, err := q.GetOrderSchedule(ctx, fulfilment.ShopName)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return now, err
+		}
+		schedule = DefaultOrderSchedule(fulfilment.ShopName)
+	}
+
+	var orders, amount int32
+	if first {
+		orders = 1
+	}
+	for _, item := range items {
+		amount += item.Amount
+	}
+
+	slot, err := q.BookOrderSlot(ctx, BookOrderSlotParams{
+		ShopName:  fulfilment.ShopName,
+		SlotStart: fulfilment.ScheduledFor.Time,
+		Orders:    orders,
+		Items:     amount,
+	})
+	if err != nil {
+		return now, err
+	}
+
+	if schedule.MaxOrdersPerSlot > 0 && slot.Orders > schedule.MaxOrdersPerSlot {
+		return now, ErrSlotFull
+	}
+	if schedule.MaxItemsPerSlot > 0 && slot.Items > schedule.MaxItemsPerSlot {
+		return now, ErrSlotFull
+	}
+
+	return fulfilment.ScheduledFor.Time.Add(-time.Duration(schedule.LeadMinutes) * time.Minute), nil
+}
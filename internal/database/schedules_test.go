@@ -0,0 +1,218 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func TestSetOpeningHoursTx(t *testing.T) {
+	shop := createRandomShop(t)
+
+	hours, err := testStore.SetOpeningHoursTx(context.Background(), SetOpeningHoursTxParams{
+		ShopName: shop.Name,
+		Hours: []OpeningHoursParams{
+			{Weekday: 2, OpensAt: 18 * 60, ClosesAt: 22 * 60},
+			{Weekday: 2, OpensAt: 11 * 60, ClosesAt: 14 * 60},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, hours, 2)
+
+	// the new hours replace the old ones
+	_, err = testStore.SetOpeningHoursTx(context.Background(), SetOpeningHoursTxParams{
+		ShopName: shop.Name,
+		Hours:    []OpeningHoursParams{{Weekday: 6, OpensAt: 9 * 60, ClosesAt: 24 * 60}},
+	})
+	require.NoError(t, err)
+
+	listed, err := testQueries.ListOpeningHours(context.Background(), shop.Name)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, int32(6), listed[0].Weekday)
+	require.Equal(t, int32(1440), listed[0].ClosesAt)
+}
+
+func TestOpeningExceptions(t *testing.T) {
+	shop := createRandomShop(t)
+
+	closed, err := testQueries.CreateOpeningException(context.Background(), CreateOpeningExceptionParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		Day:      "2024-12-25",
+		Closed:   true,
+		Note:     "Christmas",
+	})
+	require.NoError(t, err)
+	require.True(t, closed.Closed)
+
+	_, err = testQueries.CreateOpeningException(context.Background(), CreateOpeningExceptionParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		Day:      "2024-12-24",
+		OpensAt:  10 * 60,
+		ClosesAt: 15 * 60,
+	})
+	require.NoError(t, err)
+
+	// special hours have to be a valid range
+	_, err = testQueries.CreateOpeningException(context.Background(), CreateOpeningExceptionParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		Day:      "2024-12-31",
+		OpensAt:  15 * 60,
+		ClosesAt: 10 * 60,
+	})
+	require.Error(t, err)
+
+	listed, err := testQueries.ListOpeningExceptions(context.Background(), ListOpeningExceptionsParams{
+		ShopName: shop.Name,
+		FromDay:  "2024-12-01",
+		ToDay:    "2024-12-31",
+	})
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	require.Equal(t, "2024-12-24", listed[0].Day)
+	require.Equal(t, "2024-12-25", listed[1].Day)
+
+	rows, err := testQueries.DeleteOpeningException(context.Background(), DeleteOpeningExceptionParams{ShopName: shop.Name, ID: closed.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	rows, err = testQueries.DeleteOpeningException(context.Background(), DeleteOpeningExceptionParams{ShopName: shop.Name, ID: closed.ID})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
+
+func scheduledOrder(shop Shop, menuItem Menu, slot time.Time) CreateOrderTxParams {
+	item := tabOrderItem(shop, menuItem)
+	item.OrderID = uuid.New()
+
+	return CreateOrderTxParams{
+		Items:      []CreateOrderItemParams{item},
+		OrderType:  utils.OrderTakeaway,
+		Fulfilment: OrderFulfilmentParams{ScheduledFor: sql.NullTime{Time: slot, Valid: true}},
+	}
+}
+
+func TestCreateOrderTxScheduled(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	createRandomStation(t, shop, true)
+	slot := utils.SlotStart(time.Now().Add(3 * time.Hour)).UTC()
+
+	_, err := testQueries.UpsertOrderSchedule(context.Background(), UpsertOrderScheduleParams{
+		ShopName:        shop.Name,
+		MaxItemsPerSlot: 3,
+		LeadMinutes:     30,
+		MaxDaysAhead:    7,
+	})
+	require.NoError(t, err)
+
+	arg := scheduledOrder(shop, menuItem, slot)
+	arg.Items[0].Amount = 2
+	result, err := testStore.CreateOrderTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result.Fulfilment.ScheduledFor.Time.Equal(slot))
+
+	// the kitchen gets the tickets 30 minutes before the slot
+	require.Len(t, result.Tickets, 1)
+	require.False(t, result.Tickets[0].Released)
+	require.True(t, result.Tickets[0].ReleaseAt.Equal(slot.Add(-30*time.Minute)))
+
+	tickets, err := testQueries.ListKitchenTickets(context.Background(), ListKitchenTicketsParams{
+		ShopName: shop.Name,
+		Status:   utils.TicketOpen,
+		PageSize: 10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, tickets)
+
+	// a later round of the order is booked into its slot too
+	round := tabOrderItem(shop, menuItem)
+	round.OrderID = arg.Items[0].OrderID
+	_, err = testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:     []CreateOrderItemParams{round},
+		OrderType: utils.OrderTakeaway,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.CreateOrderTx(context.Background(), scheduledOrder(shop, menuItem, slot))
+	require.ErrorIs(t, err, ErrSlotFull)
+
+	released, err := testQueries.ReleaseDueKitchenTickets(context.Background(), slot.Add(-29*time.Minute))
+	require.NoError(t, err)
+	var ids []uuid.UUID
+	for _, ticket := range released {
+		ids = append(ids, ticket.ID)
+	}
+	require.Contains(t, ids, result.Tickets[0].ID)
+
+	tickets, err = testQueries.ListKitchenTickets(context.Background(), ListKitchenTicketsParams{
+		ShopName: shop.Name,
+		Status:   utils.TicketOpen,
+		PageSize: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, tickets, 2)
+
+	// the held ticket arrives at the station when it is released
+	prep, err := testQueries.ListOpenKitchenTicketPrep(context.Background(), shop.Name)
+	require.NoError(t, err)
+	for _, row := range prep {
+		if row.ID == result.Tickets[0].ID {
+			require.True(t, row.ReleaseAt.Equal(slot.Add(-30*time.Minute)))
+		}
+	}
+}
+
+func TestCreateOrderTxSlotCapacity(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	slot := utils.SlotStart(time.Now().Add(3 * time.Hour)).UTC()
+
+	_, err := testQueries.UpsertOrderSchedule(context.Background(), UpsertOrderScheduleParams{
+		ShopName:         shop.Name,
+		MaxOrdersPerSlot: 3,
+		LeadMinutes:      20,
+		MaxDaysAhead:     7,
+	})
+	require.NoError(t, err)
+
+	// concurrent orders for one slot cannot overbook it
+	n := 6
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		arg := scheduledOrder(shop, menuItem, slot)
+		go func() {
+			_, err := testStore.CreateOrderTx(context.Background(), arg)
+			errs <- err
+		}()
+	}
+
+	var booked int
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			booked++
+			continue
+		}
+		require.ErrorIs(t, err, ErrSlotFull)
+	}
+	require.Equal(t, 3, booked)
+
+	slots, err := testQueries.ListOrderSlots(context.Background(), ListOrderSlotsParams{
+		ShopName: shop.Name,
+		FromTime: slot,
+		ToTime:   slot.Add(utils.SlotLength),
+	})
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	// refused orders are rolled back with their booking
+	require.Equal(t, int32(3), slots[0].Orders)
+}
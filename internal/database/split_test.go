@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitOrderTx(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+
+	orderID := uuid.New()
+	var items []CreateOrderItemParams
+	for seat := int32(1); seat <= 2; seat++ {
+		item := tabOrderItem(shop, menuItem)
+		item.OrderID = orderID
+		item.Seat = seat
+		items = append(items, item)
+	}
+	created, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{Items: items})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), created.Orders[1].Seat)
+
+	arg := SplitOrderTxParams{
+		ShopName: shop.Name,
+		OrderID:  orderID,
+		Checks: []SplitCheckParams{
+			{Label: "Seat 1", Lines: []AddCheckLineParams{{OrderItemID: items[0].ID, Parts: 1}}},
+			{Label: "Seat 2", Lines: []AddCheckLineParams{{OrderItemID: items[1].ID, Parts: 1}}},
+		},
+	}
+	result, err := testStore.SplitOrderTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Checks, 2)
+	require.Equal(t, int32(2), result.Checks[1].Number)
+
+	// splitting again replaces the checks
+	arg.Checks = arg.Checks[:1]
+	arg.Checks[0].Lines = append(arg.Checks[0].Lines, AddCheckLineParams{OrderItemID: items[1].ID, Parts: 1})
+	result, err = testStore.SplitOrderTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	checks, err := testQueries.ListChecks(context.Background(), ListChecksParams{ShopName: shop.Name, OrderID: orderID})
+	require.NoError(t, err)
+	require.Len(t, checks, 1)
+	lines, err := testQueries.ListCheckLines(context.Background(), ListCheckLinesParams{ShopName: shop.Name, OrderID: orderID})
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+
+	_, err = testQueries.CreatePayment(context.Background(), CreatePaymentParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		OrderID:  orderID,
+		Method:   "cash",
+		Amount:   "5.00",
+		Tendered: "5.00",
+		CheckID:  uuid.NullUUID{UUID: result.Checks[0].ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.SplitOrderTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrCheckPaid)
+}
+
+func TestPayCheckTx(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+
+	orderID := uuid.New()
+	item := tabOrderItem(shop, menuItem)
+	item.OrderID = orderID
+	created, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{Items: []CreateOrderItemParams{item}})
+	require.NoError(t, err)
+
+	split, err := testStore.SplitOrderTx(context.Background(), SplitOrderTxParams{
+		ShopName: shop.Name,
+		OrderID:  orderID,
+		Checks:   []SplitCheckParams{{Lines: []AddCheckLineParams{{OrderItemID: item.ID, Parts: 1}}}},
+	})
+	require.NoError(t, err)
+
+	// a single check owes the whole untaxed order
+	due := func(items []Order, checks []Check, lines []CheckLine, payments []Payment) ([]int64, error) {
+		total, err := orderDue(items, payments)
+		return []int64{total}, err
+	}
+	payment := func() PayCheckTxParams {
+		return PayCheckTxParams{
+			Payment: CreatePaymentParams{
+				ID:       uuid.New(),
+				ShopName: shop.Name,
+				OrderID:  orderID,
+				Method:   "cash",
+				Amount:   created.Orders[0].ProductPrice,
+				Tendered: created.Orders[0].ProductPrice,
+				CheckID:  uuid.NullUUID{UUID: split.Checks[0].ID, Valid: true},
+			},
+			Due: due,
+		}
+	}
+
+	// two tills paying the same check at once, only one of them gets through
+	n := 2
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := testStore.PayCheckTx(context.Background(), payment())
+			errs <- err
+		}()
+	}
+
+	var paid, overpaid int
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			paid++
+			continue
+		}
+		require.ErrorIs(t, err, ErrCheckOverpaid)
+		overpaid++
+	}
+	require.Equal(t, 1, paid)
+	require.Equal(t, 1, overpaid)
+
+	other := payment()
+	other.Payment.CheckID = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	_, err = testStore.PayCheckTx(context.Background(), other)
+	require.ErrorIs(t, err, ErrCheckNotInSplit)
+}
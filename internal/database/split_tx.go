@@ -0,0 +1,170 @@
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type SplitCheckParams struct {
+	Label string               `json:"label"`
+	Lines []AddCheckLineParams `json:"lines"` // check ids are filled in by the split
+}
+
+type SplitOrderTxParams struct {
+	ShopName string             `json:"shop_name"`
+	OrderID  uuid.UUID          `json:"order_id"`
+	Checks   []SplitCheckParams `json:"checks"` // numbered from 1 in this order
+}
+
+type SplitOrderTxResult struct {
+	Checks []Check     `json:"checks"`
+	Lines  []CheckLine `json:"lines"`
+}
+
+// replace the checks of an order with a new split. an order is not split
+// again once one of its checks took a payment, the checks are locked first so
+// a payment either lands before the split or waits for it.
+func (store *SQLStore) SplitOrderTx(ctx context.Context, arg SplitOrderTxParams) (SplitOrderTxResult, error) {
+	var result SplitOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.ListChecksForUpdate(ctx, ListChecksForUpdateParams{
+			ShopName: arg.ShopName,
+			OrderID:  arg.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+
+		paid, err := q.CountCheckPayments(ctx, CountCheckPaymentsParams{
+			ShopName: arg.ShopName,
+			OrderID:  arg.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+		if paid > 0 {
+			return ErrCheckPaid
+		}
+
+		_, err = q.DeleteChecks(ctx, DeleteChecksParams{
+			ShopName: arg.ShopName,
+			OrderID:  arg.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, c := range arg.Checks {
+			check, err := q.CreateCheck(ctx, CreateCheckParams{
+				ID:       uuid.New(),
+				ShopName: arg.ShopName,
+				OrderID:  arg.OrderID,
+				Number:   int32(i + 1),
+				Label:    c.Label,
+			})
+			if err != nil {
+				return err
+			}
+			result.Checks = append(result.Checks, check)
+
+			for _, line := range c.Lines {
+				line.CheckID = check.ID
+				if err := q.AddCheckLine(ctx, line); err != nil {
+					return err
+				}
+				result.Lines = append(result.Lines, CheckLine(line))
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// what is due on every check of a split in cents, in the order of the checks,
+// worked out from the items, check lines and payments of the order
+type ChecksDueFunc func(items []Order, checks []Check, lines []CheckLine, payments []Payment) ([]int64, error)
+
+// shop name, order id and check id of the payment name the check it pays
+type PayCheckTxParams struct {
+	Payment CreatePaymentParams `json:"payment"`
+	Due     ChecksDueFunc       `json:"-"`
+}
+
+// pay one check of a split, at most what is still due on it. the checks of
+// the order are locked before what is due is worked out, so payments of the
+// same check wait for each other and a new split waits for the payment.
+// payments are refused once the business day of the order is closed.
+func (store *SQLStore) PayCheckTx(ctx context.Context, arg PayCheckTxParams) (Payment, error) {
+	var result Payment
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		checks, err := q.ListChecksForUpdate(ctx, ListChecksForUpdateParams{
+			ShopName: arg.Payment.ShopName,
+			OrderID:  arg.Payment.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+
+		index := -1
+		for i, check := range checks {
+			if arg.Payment.CheckID.Valid && check.ID == arg.Payment.CheckID.UUID {
+				index = i
+			}
+		}
+		if index < 0 {
+			return ErrCheckNotInSplit
+		}
+
+		items, err := q.GetOrdersByOrderID(ctx, GetOrdersByOrderIDParams{
+			ShopName: arg.Payment.ShopName,
+			OrderID:  arg.Payment.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return ErrCheckNotInSplit
+		}
+		lines, err := q.ListCheckLines(ctx, ListCheckLinesParams{
+			ShopName: arg.Payment.ShopName,
+			OrderID:  arg.Payment.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+		payments, err := q.ListPaymentsByOrderID(ctx, ListPaymentsByOrderIDParams{
+			ShopName: arg.Payment.ShopName,
+			OrderID:  arg.Payment.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+
+		due, err := arg.Due(items, checks, lines, payments)
+		if err != nil {
+			return err
+		}
+		amount, err := utils.ParseCents(arg.Payment.Amount)
+		if err != nil {
+			return err
+		}
+		if amount > due[index] {
+			return ErrCheckOverpaid
+		}
+
+		if err := checkDayOpen(ctx, q, arg.Payment.ShopName, items[0].OrderDay); err != nil {
+			return err
+		}
+
+		result, err = q.CreatePayment(ctx, arg.Payment)
+		return err
+	})
+
+	return result, err
+}
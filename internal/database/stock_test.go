@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func createRandomStockLevel(t *testing.T, shop Shop, product Product) StockLevel {
+	arg := UpsertStockLevelParams{
+		ShopName:  shop.Name,
+		ProductID: product.ID,
+		OnHand:    utils.RandomInt32(10, 100),
+	}
+
+	stockLevel, err := testQueries.UpsertStockLevel(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, stockLevel)
+
+	require.Equal(t, arg.ShopName, stockLevel.ShopName)
+	require.Equal(t, arg.ProductID, stockLevel.ProductID)
+	require.Equal(t, arg.OnHand, stockLevel.OnHand)
+	require.NotZero(t, stockLevel.UpdatedAt)
+
+	return stockLevel
+}
+
+func TestUpsertStockLevel(t *testing.T) {
+	shop := createRandomShop(t)
+	product := createRandomProduct(t, shop)
+	stockLevel := createRandomStockLevel(t, shop, product)
+
+	arg := UpsertStockLevelParams{
+		ShopName:  shop.Name,
+		ProductID: product.ID,
+		OnHand:    5,
+	}
+
+	updated, err := testQueries.UpsertStockLevel(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, stockLevel.OnHand+5, updated.OnHand)
+}
+
+func TestSetStockLevel(t *testing.T) {
+	shop := createRandomShop(t)
+	product := createRandomProduct(t, shop)
+	createRandomStockLevel(t, shop, product)
+
+	arg := SetStockLevelParams{
+		ShopName:  shop.Name,
+		ProductID: product.ID,
+		OnHand:    3,
+	}
+
+	updated, err := testQueries.SetStockLevel(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), updated.OnHand)
+}
+
+func TestAddStockLevelUntracked(t *testing.T) {
+	shop := createRandomShop(t)
+	product := createRandomProduct(t, shop)
+
+	arg := AddStockLevelParams{
+		ShopName:  shop.Name,
+		ProductID: product.ID,
+		OnHand:    -1,
+	}
+
+	_, err := testQueries.AddStockLevel(context.Background(), arg)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestListStockMovements(t *testing.T) {
+	shop := createRandomShop(t)
+	product := createRandomProduct(t, shop)
+
+	for i := 0; i < 3; i++ {
+		arg := CreateStockMovementParams{
+			ID:           uuid.New(),
+			ShopName:     shop.Name,
+			ProductID:    product.ID,
+			MovementType: utils.MovementReceive,
+			Quantity:     utils.RandomInt32(1, 10),
+		}
+
+		movement, err := testQueries.CreateStockMovement(context.Background(), arg)
+		require.NoError(t, err)
+		require.Equal(t, arg.ID, movement.ID)
+		require.Equal(t, arg.MovementType, movement.MovementType)
+		require.Equal(t, arg.Quantity, movement.Quantity)
+		require.False(t, movement.OrderItemID.Valid)
+	}
+
+	arg := ListStockMovementsParams{
+		ShopName:  shop.Name,
+		ProductID: product.ID,
+		Limit:     2,
+		Offset:    1,
+	}
+
+	movements, err := testQueries.ListStockMovements(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, movements, 2)
+
+	for _, movement := range movements {
+		require.Equal(t, shop.Name, movement.ShopName)
+		require.Equal(t, product.ID, movement.ProductID)
+	}
+}
+
+func TestStockAlerts(t *testing.T) {
+	shop := createRandomShop(t)
+	product := createRandomProduct(t, shop)
+
+	stockLevel, err := testQueries.SetStockReorderLevels(context.Background(), SetStockReorderLevelsParams{
+		ShopName:     shop.Name,
+		ProductID:    product.ID,
+		ReorderPoint: 5,
+		ParLevel:     20,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(0), stockLevel.OnHand)
+	require.Equal(t, int32(5), stockLevel.ReorderPoint)
+	require.Equal(t, int32(20), stockLevel.ParLevel)
+
+	levels, err := testQueries.ListStockLevelsBelowReorderPoint(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, levels, stockLevel)
+
+	alert, err := testQueries.CreateStockAlert(context.Background(), CreateStockAlertParams{
+		ID:           uuid.New(),
+		ShopName:     shop.Name,
+		ProductID:    product.ID,
+		OnHand:       stockLevel.OnHand,
+		ReorderPoint: stockLevel.ReorderPoint,
+	})
+	require.NoError(t, err)
+	require.False(t, alert.ResolvedAt.Valid)
+
+	// an open alert is not raised twice
+	levels, err = testQueries.ListStockLevelsBelowReorderPoint(context.Background())
+	require.NoError(t, err)
+	require.NotContains(t, levels, stockLevel)
+
+	alerts, err := testQueries.ListOpenStockAlerts(context.Background(), shop.Name)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+
+	_, err = testQueries.UpsertStockLevel(context.Background(), UpsertStockLevelParams{
+		ShopName:  shop.Name,
+		ProductID: product.ID,
+		OnHand:    10,
+	})
+	require.NoError(t, err)
+
+	resolved, err := testQueries.ResolveStockAlerts(context.Background())
+	require.NoError(t, err)
+
+	var resolvedIDs []uuid.UUID
+	for _, a := range resolved {
+		require.True(t, a.ResolvedAt.Valid)
+		resolvedIDs = append(resolvedIDs, a.ID)
+	}
+	require.Contains(t, resolvedIDs, alert.ID)
+
+	alerts, err = testQueries.ListOpenStockAlerts(context.Background(), shop.Name)
+	require.NoError(t, err)
+	require.Empty(t, alerts)
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type StockMovementTxParams struct {
+	ShopName     string    `json:"shop_name"`
+	ProductID    uuid.UUID `json:"product_id"`
+	MovementType string    `json:"movement_type"`
+	Quantity     int32     `json:"quantity"`
+	Note         string    `json:"note"`
+}
+
+type StockMovementTxResult struct {
+	StockLevel    StockLevel    `json:"stock_level"`
+	StockMovement StockMovement `json:"stock_movement"`
+}
+
+// record a staff movement and update the on-hand level within a single transaction.
+// receive adds, waste removes, adjustment applies a signed quantity
+// and count replaces the on-hand level with the counted quantity.
+// the product is marked sold out on the menu when its level drops to zero.
+func (store *SQLStore) StockMovementTx(ctx context.Context, arg StockMovementTxParams) (StockMovementTxResult, error) {
+	var result StockMovementTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		delta := arg.Quantity
+
+		switch arg.MovementType {
+		case utils.MovementReceive, utils.MovementAdjustment:
+		case utils.MovementWaste:
+			delta = -arg.Quantity
+		case utils.MovementCount:
+			level, err := q.GetStockLevelForUpdate(ctx, GetStockLevelForUpdateParams{
+				ShopName:  arg.ShopName,
+				ProductID: arg.ProductID,
+			})
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			delta = arg.Quantity - level.OnHand
+		default:
+			return ErrInvalidMovementType
+		}
+
+		if arg.MovementType == utils.MovementCount {
+			result.StockLevel, err = q.SetStockLevel(ctx, SetStockLevelParams{
+				ShopName:  arg.ShopName,
+				ProductID: arg.ProductID,
+				OnHand:    arg.Quantity,
+			})
+		} else {
+			result.StockLevel, err = q.UpsertStockLevel(ctx, UpsertStockLevelParams{
+				ShopName:  arg.ShopName,
+				ProductID: arg.ProductID,
+				OnHand:    delta,
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := markSoldOut(ctx, q, result.StockLevel); err != nil {
+			return err
+		}
+
+		result.StockMovement, err = q.CreateStockMovement(ctx, CreateStockMovementParams{
+			ID:           uuid.New(),
+			ShopName:     arg.ShopName,
+			ProductID:    arg.ProductID,
+			MovementType: arg.MovementType,
+			Quantity:     delta,
+			Note:         arg.Note,
+		})
+		return err
+	})
+
+	return result, err
+}
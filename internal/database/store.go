@@ -1,12 +1,39 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 )
 
 // build interface for mockDB
 type Store interface {
 	Querier
+	CloseDayTx(ctx context.Context, arg DailySalesSummaryParams) (ZReport, error)
+	CloseTabTx(ctx context.Context, arg CloseTabTxParams) (CloseTabTxResult, error)
+	CreateCouponBatchTx(ctx context.Context, arg CreateCouponBatchTxParams) (CouponBatchTxResult, error)
+	CreateOrderTx(ctx context.Context, arg CreateOrderTxParams) (CreateOrderTxResult, error)
+	CreateOrganisationTx(ctx context.Context, arg CreateOrganisationTxParams) (CreateOrganisationTxResult, error)
+	CreatePaymentTx(ctx context.Context, arg CreatePaymentParams) (Payment, error)
+	CreatePriceListTx(ctx context.Context, arg SetPriceListTxParams) (PriceListTxResult, error)
+	CreatePurchaseOrderTx(ctx context.Context, arg CreatePurchaseOrderTxParams) (PurchaseOrderTxResult, error)
+	DailySalesSummary(ctx context.Context, arg DailySalesSummaryParams) (DailySalesSummary, error)
+	DeleteOrderItemTx(ctx context.Context, arg DeleteOrderItemParams) error
+	IngredientMovementTx(ctx context.Context, arg IngredientMovementTxParams) (IngredientMovementTxResult, error)
+	ListOrderHistory(ctx context.Context, arg OrderHistoryParams) ([]Order, error)
+	MergeTabsTx(ctx context.Context, arg MergeTabsTxParams) (Tab, error)
+	MoveTabTx(ctx context.Context, arg MoveTabTxParams) (Tab, error)
+	OpenTabTx(ctx context.Context, arg OpenTabTxParams) (Tab, error)
+	PayCheckTx(ctx context.Context, arg PayCheckTxParams) (Payment, error)
+	ReceivePurchaseOrderTx(ctx context.Context, arg ReceivePurchaseOrderTxParams) (ReceivePurchaseOrderTxResult, error)
+	RefundOrderItemTx(ctx context.Context, arg RefundOrderItemTxParams) (RefundOrderItemTxResult, error)
+	SetOpeningHoursTx(ctx context.Context, arg SetOpeningHoursTxParams) ([]OpeningHour, error)
+	SetPriceListPricesTx(ctx context.Context, arg SetPriceListPricesTxParams) ([]PriceListPrice, error)
+	SetRecipeTx(ctx context.Context, arg SetRecipeTxParams) ([]RecipeItem, error)
+	SplitOrderTx(ctx context.Context, arg SplitOrderTxParams) (SplitOrderTxResult, error)
+	StockMovementTx(ctx context.Context, arg StockMovementTxParams) (StockMovementTxResult, error)
+	UpdateOrderItemTx(ctx context.Context, arg UpdateOrderItemParams) (Order, error)
+	UpdatePriceListTx(ctx context.Context, arg SetPriceListTxParams) (PriceListTxResult, error)
 }
 
 // real implement of store interface
@@ -25,21 +52,21 @@ func NewStore(db *sql.DB) Store {
 }
 
 // execute database transaction
-// func (store *Store) execTx(ctx context.Context, fn func(*Queries) error) error {
-// 	tx, err := store.db.BeginTx(ctx, nil)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	q := New(tx) // pass tx as parameter instead of db connection
-// 	err = fn(q)
-// 	if err != nil {
-// 		if rbErr := tx.Rollback(); rbErr != nil {
-// 			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
-// 		}
-// 		return err
-// 	}
-
-// 	return tx.Commit()
-
-// }
+func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	q := New(tx) // pass tx as parameter instead of db connection
+	err = fn(q)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+
+}
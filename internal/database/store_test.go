@@ -0,0 +1,268 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func TestCreateOrderTx(t *testing.T) {
+	shop := createRandomShop(t)
+	tracked := createRandomProduct(t, shop)
+	untracked := createRandomProduct(t, shop)
+	stockLevel := createRandomStockLevel(t, shop, tracked)
+
+	orderID := utils.RandOrderID()
+	newItem := func(product Product, amount int32) CreateOrderItemParams {
+		return CreateOrderItemParams{
+			ID:           uuid.New(),
+			ShopName:     shop.Name,
+			OrderID:      orderID,
+			OrderDay:     utils.FormattedDateNow(),
+			ProductName:  product.Name,
+			ProductPrice: product.Price,
+			Amount:       amount,
+			Status:       "pending",
+			ProductID:    uuid.NullUUID{UUID: product.ID, Valid: true},
+			TaxRate:      "0.00",
+		}
+	}
+
+	// the products are not on the menu, staff ring them up as open items
+	arg := CreateOrderTxParams{
+		Items:     []CreateOrderItemParams{newItem(tracked, 2), newItem(untracked, 1)},
+		OpenItems: true,
+	}
+
+	result, err := testStore.CreateOrderTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Orders, 2)
+
+	// only the tracked product writes a sale movement
+	require.Len(t, result.Movements, 1)
+	movement := result.Movements[0]
+	require.Equal(t, utils.MovementSale, movement.MovementType)
+	require.Equal(t, int32(-2), movement.Quantity)
+	require.Equal(t, arg.Items[0].ID, movement.OrderItemID.UUID)
+
+	updated, err := testQueries.GetStockLevel(context.Background(), GetStockLevelParams{
+		ShopName:  shop.Name,
+		ProductID: tracked.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, stockLevel.OnHand-2, updated.OnHand)
+}
+
+func TestCreateOrderTxSoldOut(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+
+	_, err := testQueries.UpsertStockLevel(context.Background(), UpsertStockLevelParams{
+		ShopName:  shop.Name,
+		ProductID: menuItem.ProductID,
+		OnHand:    2,
+	})
+	require.NoError(t, err)
+
+	newOrder := func() CreateOrderTxParams {
+		return CreateOrderTxParams{
+			Items: []CreateOrderItemParams{
+				{
+					ID:           uuid.New(),
+					ShopName:     shop.Name,
+					OrderID:      utils.RandOrderID(),
+					OrderDay:     utils.FormattedDateNow(),
+					ProductName:  menuItem.ProductName,
+					ProductPrice: menuItem.ProductPrice,
+					Amount:       2,
+					Status:       "pending",
+					ProductID:    uuid.NullUUID{UUID: menuItem.ProductID, Valid: true},
+					TaxRate:      "0.00",
+				},
+			},
+		}
+	}
+
+	// selling the last units marks the item sold out
+	_, err = testStore.CreateOrderTx(context.Background(), newOrder())
+	require.NoError(t, err)
+
+	allMenuItems, err := testQueries.GetAllMenuItems(context.Background(), shop.Name)
+	require.NoError(t, err)
+	require.Len(t, allMenuItems, 1)
+	require.False(t, allMenuItems[0].Available)
+
+	_, err = testStore.CreateOrderTx(context.Background(), newOrder())
+	require.ErrorIs(t, err, ErrItemUnavailable)
+}
+
+func TestCreateOrderTxRefundedStatus(t *testing.T) {
+	shop := createRandomShop(t)
+	product := createRandomProduct(t, shop)
+	stockLevel := createRandomStockLevel(t, shop, product)
+
+	arg := CreateOrderTxParams{
+		Items: []CreateOrderItemParams{
+			{
+				ID:           uuid.New(),
+				ShopName:     shop.Name,
+				OrderID:      utils.RandOrderID(),
+				OrderDay:     utils.FormattedDateNow(),
+				ProductName:  product.Name,
+				ProductPrice: product.Price,
+				Amount:       1,
+				Status:       utils.StatusRefunded,
+				ProductID:    uuid.NullUUID{UUID: product.ID, Valid: true},
+				TaxRate:      "0.00",
+			},
+		},
+		OpenItems: true,
+	}
+
+	_, err := testStore.CreateOrderTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrRefundStatus)
+
+	// nothing is taken from stock
+	level, err := testQueries.GetStockLevel(context.Background(), GetStockLevelParams{
+		ShopName:  shop.Name,
+		ProductID: product.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, stockLevel.OnHand, level.OnHand)
+}
+
+func TestRefundOrderItemTx(t *testing.T) {
+	shop := createRandomShop(t)
+	orderItem := createRandomOrderItem(t, shop, utils.RandOrderID(), utils.FormattedDateNow())
+	product := Product{ID: orderItem.ProductID.UUID}
+	stockLevel := createRandomStockLevel(t, shop, product)
+
+	arg := RefundOrderItemTxParams{
+		ShopName: shop.Name,
+		ID:       orderItem.ID,
+	}
+
+	result, err := testStore.RefundOrderItemTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, utils.StatusRefunded, result.Order.Status)
+	require.Len(t, result.Movements, 1)
+	require.Equal(t, utils.MovementRefund, result.Movements[0].MovementType)
+	require.Equal(t, orderItem.Amount, result.Movements[0].Quantity)
+
+	updated, err := testQueries.GetStockLevel(context.Background(), GetStockLevelParams{
+		ShopName:  shop.Name,
+		ProductID: product.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, stockLevel.OnHand+orderItem.Amount, updated.OnHand)
+
+	_, err = testStore.RefundOrderItemTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrAlreadyRefunded)
+}
+
+func TestUpdateAndDeleteOrderItemTxStock(t *testing.T) {
+	shop := createRandomShop(t)
+	orderItem := createRandomOrderItem(t, shop, utils.RandOrderID(), utils.FormattedDateNow())
+	product := Product{ID: orderItem.ProductID.UUID}
+	stockLevel := createRandomStockLevel(t, shop, product)
+
+	onHand := func() int32 {
+		level, err := testQueries.GetStockLevel(context.Background(), GetStockLevelParams{
+			ShopName:  shop.Name,
+			ProductID: product.ID,
+		})
+		require.NoError(t, err)
+		return level.OnHand
+	}
+
+	// two more units are sold
+	updated, err := testStore.UpdateOrderItemTx(context.Background(), UpdateOrderItemParams{
+		ShopName: shop.Name,
+		ID:       orderItem.ID,
+		Amount:   orderItem.Amount + 2,
+		Status:   "accepted",
+	})
+	require.NoError(t, err)
+	require.Equal(t, orderItem.Amount+2, updated.Amount)
+	require.Equal(t, stockLevel.OnHand-2, onHand())
+
+	// refunds go through the refund, which returns the stock
+	_, err = testStore.UpdateOrderItemTx(context.Background(), UpdateOrderItemParams{
+		ShopName: shop.Name,
+		ID:       orderItem.ID,
+		Amount:   updated.Amount,
+		Status:   utils.StatusRefunded,
+	})
+	require.ErrorIs(t, err, ErrRefundStatus)
+
+	// a deleted item returns all of its units
+	err = testStore.DeleteOrderItemTx(context.Background(), DeleteOrderItemParams{ShopName: shop.Name, ID: orderItem.ID})
+	require.NoError(t, err)
+	require.Equal(t, stockLevel.OnHand+orderItem.Amount, onHand())
+
+	err = testStore.DeleteOrderItemTx(context.Background(), DeleteOrderItemParams{ShopName: shop.Name, ID: orderItem.ID})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestStockMovementTxCount(t *testing.T) {
+	shop := createRandomShop(t)
+	product := createRandomProduct(t, shop)
+	stockLevel := createRandomStockLevel(t, shop, product)
+
+	arg := StockMovementTxParams{
+		ShopName:     shop.Name,
+		ProductID:    product.ID,
+		MovementType: utils.MovementCount,
+		Quantity:     4,
+	}
+
+	result, err := testStore.StockMovementTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(4), result.StockLevel.OnHand)
+	require.Equal(t, 4-stockLevel.OnHand, result.StockMovement.Quantity)
+}
+
+func TestCreateOrderTxDepletesIngredients(t *testing.T) {
+	shop := createRandomShop(t)
+	product := createRandomProduct(t, shop)
+	beans := createRandomIngredient(t, shop, utils.UnitKilogram)
+	createRandomRecipeItem(t, product, beans, "18.000", utils.UnitGram)
+
+	_, err := testStore.IngredientMovementTx(context.Background(), IngredientMovementTxParams{
+		ShopName:     shop.Name,
+		IngredientID: beans.ID,
+		MovementType: utils.MovementReceive,
+		Quantity:     1,
+	})
+	require.NoError(t, err)
+
+	item := CreateOrderItemParams{
+		ID:           uuid.New(),
+		ShopName:     shop.Name,
+		OrderID:      utils.RandOrderID(),
+		OrderDay:     utils.FormattedDateNow(),
+		ProductName:  product.Name,
+		ProductPrice: product.Price,
+		Amount:       2,
+		Status:       "pending",
+		ProductID:    uuid.NullUUID{UUID: product.ID, Valid: true},
+		TaxRate:      "0.00",
+	}
+
+	result, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{Items: []CreateOrderItemParams{item}, OpenItems: true})
+	require.NoError(t, err)
+	require.Len(t, result.IngredientMovements, 1)
+	require.Equal(t, "-0.036", result.IngredientMovements[0].Quantity)
+
+	updated, err := testQueries.GetIngredient(context.Background(), GetIngredientParams{ShopName: shop.Name, ID: beans.ID})
+	require.NoError(t, err)
+	require.Equal(t, "0.964", updated.OnHand)
+
+	refund, err := testStore.RefundOrderItemTx(context.Background(), RefundOrderItemTxParams{ShopName: shop.Name, ID: item.ID})
+	require.NoError(t, err)
+	require.Len(t, refund.IngredientMovements, 1)
+	require.Equal(t, "0.036", refund.IngredientMovements[0].Quantity)
+}
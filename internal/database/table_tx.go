@@ -0,0 +1,455 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type OpenTabTxParams struct {
+	ShopName string    `json:"shop_name"`
+	TableID  uuid.UUID `json:"table_id"`
+	OrderID  uuid.UUID `json:"order_id"`
+	Guests   int32     `json:"guests"`
+}
+
+// open a tab on a free table and mark the table occupied
+func (store *SQLStore) OpenTabTx(ctx context.Context, arg OpenTabTxParams) (Tab, error) {
+	var tab Tab
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		tab, err = openTab(ctx, q, arg, false)
+		return err
+	})
+
+	return tab, err
+}
+
+// lock the table and open a tab on it, when reuse is set an already open
+// tab of the table is returned instead of refusing the table
+func openTab(ctx context.Context, q *Queries, arg OpenTabTxParams, reuse bool) (Tab, error) {
+	tableID := uuid.NullUUID{UUID: arg.TableID, Valid: true}
+
+	_, err := q.GetDiningTableForUpdate(ctx, GetDiningTableForUpdateParams{
+		ShopName: arg.ShopName,
+		ID:       arg.TableID,
+	})
+	if err != nil {
+		return Tab{}, err
+	}
+
+	tab, err := q.GetOpenTabByTable(ctx, GetOpenTabByTableParams{
+		ShopName: arg.ShopName,
+		TableID:  tableID,
+	})
+	if err == nil {
+		if reuse {
+			// a round on the open tab, locked like rounds sent to the tab itself
+			return getOpenTabForUpdate(ctx, q, arg.ShopName, tab.ID)
+		}
+		return Tab{}, ErrTableOccupied
+	}
+	if err != sql.ErrNoRows {
+		return Tab{}, err
+	}
+
+	tab, err = q.CreateTab(ctx, CreateTabParams{
+		ID:       uuid.New(),
+		ShopName: arg.ShopName,
+		TableID:  tableID,
+		OrderID:  arg.OrderID,
+		Guests:   arg.Guests,
+	})
+	if err != nil {
+		return Tab{}, err
+	}
+
+	_, err = q.SetDiningTableStatus(ctx, SetDiningTableStatusParams{
+		Status:   utils.TableOccupied,
+		ShopName: arg.ShopName,
+		ID:       arg.TableID,
+	})
+	return tab, err
+}
+
+// resolve the tab targeted by an order and point its items at the order of
+// the tab, a table target opens a tab on the table unless one is open already
+func orderTab(ctx context.Context, q *Queries, arg CreateOrderTxParams) (Tab, error) {
+	if len(arg.Items) == 0 {
+		return Tab{}, nil
+	}
+	shopName := arg.Items[0].ShopName
+
+	var tab Tab
+	var err error
+	switch {
+	case arg.TabID != uuid.Nil:
+		tab, err = q.GetTabForUpdate(ctx, GetTabForUpdateParams{
+			ShopName: shopName,
+			ID:       arg.TabID,
+		})
+		if err != nil {
+			return Tab{}, err
+		}
+		if tab.Status != utils.TabOpen {
+			return Tab{}, ErrTabNotOpen
+		}
+	case arg.TableID != uuid.Nil:
+		orderID := arg.Items[0].OrderID
+		if orderID == uuid.Nil {
+			orderID = uuid.New()
+		}
+		tab, err = openTab(ctx, q, OpenTabTxParams{
+			ShopName: shopName,
+			TableID:  arg.TableID,
+			OrderID:  orderID,
+			Guests:   1,
+		}, true)
+		if err != nil {
+			return Tab{}, err
+		}
+	default:
+		return Tab{}, nil
+	}
+
+	for i := range arg.Items {
+		arg.Items[i].OrderID = tab.OrderID
+	}
+	return tab, nil
+}
+
+// free a table of its tab, the table needs cleaning before the next guests
+func leaveTable(ctx context.Context, q *Queries, tab Tab) error {
+	if !tab.TableID.Valid {
+		return nil
+	}
+
+	_, err := q.SetDiningTableStatus(ctx, SetDiningTableStatusParams{
+		Status:   utils.TableDirty,
+		ShopName: tab.ShopName,
+		ID:       tab.TableID.UUID,
+	})
+	return err
+}
+
+func getOpenTabForUpdate(ctx context.Context, q *Queries, shopName string, id uuid.UUID) (Tab, error) {
+	tab, err := q.GetTabForUpdate(ctx, GetTabForUpdateParams{
+		ShopName: shopName,
+		ID:       id,
+	})
+	if err != nil {
+		return Tab{}, err
+	}
+	if tab.Status != utils.TabOpen {
+		return Tab{}, ErrTabNotOpen
+	}
+	return tab, nil
+}
+
+type MoveTabTxParams struct {
+	ShopName string    `json:"shop_name"`
+	ID       uuid.UUID `json:"id"`
+	TableID  uuid.UUID `json:"table_id"`
+}
+
+// move an open tab to a table without a tab, the table left behind turns dirty
+func (store *SQLStore) MoveTabTx(ctx context.Context, arg MoveTabTxParams) (Tab, error) {
+	var tab Tab
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		from, err := getOpenTabForUpdate(ctx, q, arg.ShopName, arg.ID)
+		if err != nil {
+			return err
+		}
+		if from.TableID.Valid && from.TableID.UUID == arg.TableID {
+			tab = from
+			return nil
+		}
+
+		tableID := uuid.NullUUID{UUID: arg.TableID, Valid: true}
+		_, err = q.GetDiningTableForUpdate(ctx, GetDiningTableForUpdateParams{
+			ShopName: arg.ShopName,
+			ID:       arg.TableID,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.GetOpenTabByTable(ctx, GetOpenTabByTableParams{
+			ShopName: arg.ShopName,
+			TableID:  tableID,
+		})
+		if err == nil {
+			return ErrTableOccupied
+		}
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		tab, err = q.MoveTab(ctx, MoveTabParams{
+			TableID:  tableID,
+			ShopName: arg.ShopName,
+			ID:       arg.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.SetDiningTableStatus(ctx, SetDiningTableStatusParams{
+			Status:   utils.TableOccupied,
+			ShopName: arg.ShopName,
+			ID:       arg.TableID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return leaveTable(ctx, q, from)
+	})
+
+	return tab, err
+}
+
+type MergeTabsTxParams struct {
+	ShopName string    `json:"shop_name"`
+	ID       uuid.UUID `json:"id"`      // tab that stays open
+	FromID   uuid.UUID `json:"from_id"` // tab merged into it
+}
+
+// move the items, payments and kitchen tickets of one open tab onto another
+// and close the emptied tab as merged, its table turns dirty. the tabs lose
+// their splits.
+func (store *SQLStore) MergeTabsTx(ctx context.Context, arg MergeTabsTxParams) (Tab, error) {
+	var tab Tab
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if arg.ID == arg.FromID {
+			return ErrSameTab
+		}
+
+		// lock both tabs in id order so opposite merges cannot deadlock
+		ids := []uuid.UUID{arg.ID, arg.FromID}
+		if bytes.Compare(ids[0][:], ids[1][:]) > 0 {
+			ids[0], ids[1] = ids[1], ids[0]
+		}
+		tabs := map[uuid.UUID]Tab{}
+		for _, id := range ids {
+			t, err := getOpenTabForUpdate(ctx, q, arg.ShopName, id)
+			if err != nil {
+				return err
+			}
+			tabs[id] = t
+		}
+		tab = tabs[arg.ID]
+		from := tabs[arg.FromID]
+
+		// a split of either tab no longer covers the merged items, payments
+		// taken on its checks stay on the order
+		for _, t := range []Tab{tab, from} {
+			_, err := q.DeleteChecks(ctx, DeleteChecksParams{
+				ShopName: arg.ShopName,
+				OrderID:  t.OrderID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := q.MoveOrderItems(ctx, MoveOrderItemsParams{
+			ToOrderID:   tab.OrderID,
+			ShopName:    arg.ShopName,
+			FromOrderID: from.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = q.MovePayments(ctx, MovePaymentsParams{
+			ToOrderID:   tab.OrderID,
+			ShopName:    arg.ShopName,
+			FromOrderID: from.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = q.MoveKitchenTickets(ctx, MoveKitchenTicketsParams{
+			ToOrderID:   tab.OrderID,
+			ShopName:    arg.ShopName,
+			FromOrderID: from.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+
+		from, err = q.CloseTab(ctx, CloseTabParams{
+			Status:   utils.TabMerged,
+			ShopName: arg.ShopName,
+			ID:       from.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return leaveTable(ctx, q, from)
+	})
+
+	return tab, err
+}
+
+type CloseTabTxParams struct {
+	ShopName string                `json:"shop_name"`
+	ID       uuid.UUID             `json:"id"`
+	Payments []CreatePaymentParams `json:"payments"` // settle the bill, order ids are taken from the tab
+}
+
+type CloseTabTxResult struct {
+	Tab      Tab       `json:"tab"`
+	Payments []Payment `json:"payments"`
+}
+
+// record the final payments of an open tab, close it and leave its table
+// dirty. the payments must cover what is still due on the tab, which is
+// worked out once the tab is locked so no round slips in before it closes.
+// payments are refused once the business day of the tab is closed.
+func (store *SQLStore) CloseTabTx(ctx context.Context, arg CloseTabTxParams) (CloseTabTxResult, error) {
+	var result CloseTabTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		tab, err := lockOpenTab(ctx, q, arg.ShopName, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		orders, err := q.GetOrdersByOrderID(ctx, GetOrdersByOrderIDParams{
+			ShopName: arg.ShopName,
+			OrderID:  tab.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+		payments, err := q.ListPaymentsByOrderID(ctx, ListPaymentsByOrderIDParams{
+			ShopName: arg.ShopName,
+			OrderID:  tab.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+
+		due, err := orderDue(orders, payments)
+		if err != nil {
+			return err
+		}
+		var paid int64
+		for _, p := range arg.Payments {
+			amount, err := utils.ParseCents(p.Amount)
+			if err != nil {
+				return err
+			}
+			paid += amount
+		}
+		if paid < due {
+			return ErrTabNotPaid
+		}
+
+		if len(arg.Payments) > 0 && len(orders) > 0 {
+			if err := checkDayOpen(ctx, q, arg.ShopName, orders[0].OrderDay); err != nil {
+				return err
+			}
+		}
+
+		for _, p := range arg.Payments {
+			p.ShopName = arg.ShopName
+			p.OrderID = tab.OrderID
+			payment, err := q.CreatePayment(ctx, p)
+			if err != nil {
+				return err
+			}
+			result.Payments = append(result.Payments, payment)
+		}
+
+		result.Tab, err = q.CloseTab(ctx, CloseTabParams{
+			Status:   utils.TabClosed,
+			ShopName: arg.ShopName,
+			ID:       tab.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return leaveTable(ctx, q, result.Tab)
+	})
+
+	return result, err
+}
+
+// lock an open tab after its table, in the order rounds placed on the table
+// lock them, so a round ordered on the table either lands before the tab is
+// locked or waits for it
+func lockOpenTab(ctx context.Context, q *Queries, shopName string, id uuid.UUID) (Tab, error) {
+	for {
+		tab, err := q.GetTab(ctx, GetTabParams{
+			ShopName: shopName,
+			ID:       id,
+		})
+		if err != nil {
+			return Tab{}, err
+		}
+
+		if tab.TableID.Valid {
+			_, err := q.GetDiningTableForUpdate(ctx, GetDiningTableForUpdateParams{
+				ShopName: shopName,
+				ID:       tab.TableID.UUID,
+			})
+			if err != nil {
+				return Tab{}, err
+			}
+		}
+
+		locked, err := getOpenTabForUpdate(ctx, q, shopName, id)
+		if err != nil {
+			return Tab{}, err
+		}
+		// otherwise the tab moved to another table in the meantime
+		if locked.TableID == tab.TableID {
+			return locked, nil
+		}
+	}
+}
+
+// what is still due on an order, the net of every tax rate is taxed with the
+// same rounding as the receipt
+func orderDue(orders []Order, payments []Payment) (int64, error) {
+	net := make(map[string]int64)
+	for _, order := range orders {
+		if order.Status == utils.StatusRefunded {
+			continue
+		}
+		price, err := utils.ParseCents(order.ProductPrice)
+		if err != nil {
+			return 0, err
+		}
+		net[order.TaxRate] += price * int64(order.Amount)
+	}
+
+	var total int64
+	for rate, amount := range net {
+		bp, err := utils.ParseCents(rate)
+		if err != nil {
+			return 0, err
+		}
+		total += amount + (amount*bp+5000)/10000
+	}
+
+	for _, payment := range payments {
+		amount, err := utils.ParseCents(payment.Amount)
+		if err != nil {
+			return 0, err
+		}
+		total -= amount
+	}
+
+	return total, nil
+}
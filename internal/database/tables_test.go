@@ -0,0 +1,265 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func createRandomDiningTable(t *testing.T, shop Shop) DiningTable {
+	area, err := testQueries.CreateFloorArea(context.Background(), CreateFloorAreaParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		Name:     utils.RandString(6),
+	})
+	require.NoError(t, err)
+
+	arg := CreateDiningTableParams{
+		ID:       uuid.New(),
+		ShopName: shop.Name,
+		AreaID:   area.ID,
+		Name:     utils.RandString(6),
+		Capacity: 4,
+		PosX:     1,
+		PosY:     2,
+	}
+
+	table, err := testQueries.CreateDiningTable(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, table.Name)
+	require.Equal(t, arg.Capacity, table.Capacity)
+	require.Equal(t, utils.TableFree, table.Status)
+
+	return table
+}
+
+func tabOrderItem(shop Shop, menuItem Menu) CreateOrderItemParams {
+	return CreateOrderItemParams{
+		ID:           uuid.New(),
+		ShopName:     shop.Name,
+		OrderDay:     utils.FormattedDateNow(),
+		ProductName:  menuItem.ProductName,
+		ProductPrice: "5.00",
+		Amount:       1,
+		Status:       "pending",
+		ProductID:    uuid.NullUUID{UUID: menuItem.ProductID, Valid: true},
+		TaxRate:      "0.00",
+	}
+}
+
+func TestOpenTabTx(t *testing.T) {
+	shop := createRandomShop(t)
+	table := createRandomDiningTable(t, shop)
+
+	tab, err := testStore.OpenTabTx(context.Background(), OpenTabTxParams{
+		ShopName: shop.Name,
+		TableID:  table.ID,
+		OrderID:  uuid.New(),
+		Guests:   3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, utils.TabOpen, tab.Status)
+	require.Equal(t, int32(3), tab.Guests)
+
+	table, err = testQueries.GetDiningTable(context.Background(), GetDiningTableParams{ShopName: shop.Name, ID: table.ID})
+	require.NoError(t, err)
+	require.Equal(t, utils.TableOccupied, table.Status)
+
+	_, err = testStore.OpenTabTx(context.Background(), OpenTabTxParams{
+		ShopName: shop.Name,
+		TableID:  table.ID,
+		OrderID:  uuid.New(),
+		Guests:   1,
+	})
+	require.ErrorIs(t, err, ErrTableOccupied)
+}
+
+func TestCreateOrderTxRounds(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	table := createRandomDiningTable(t, shop)
+
+	// the first round opens a tab on the table
+	first, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:   []CreateOrderItemParams{tabOrderItem(shop, menuItem)},
+		TableID: table.ID,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, uuid.Nil, first.Tab.ID)
+	require.Equal(t, first.Tab.OrderID, first.Orders[0].OrderID)
+
+	// later rounds on the table or the tab join the same order
+	second, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:   []CreateOrderItemParams{tabOrderItem(shop, menuItem)},
+		TableID: table.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, first.Tab.ID, second.Tab.ID)
+
+	third, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items: []CreateOrderItemParams{tabOrderItem(shop, menuItem)},
+		TabID: first.Tab.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, first.Tab.OrderID, third.Orders[0].OrderID)
+
+	orders, err := testQueries.GetOrdersByOrderID(context.Background(), GetOrdersByOrderIDParams{
+		ShopName: shop.Name,
+		OrderID:  first.Tab.OrderID,
+	})
+	require.NoError(t, err)
+	require.Len(t, orders, 3)
+}
+
+func TestMoveAndMergeTabsTx(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	table1 := createRandomDiningTable(t, shop)
+	table2 := createRandomDiningTable(t, shop)
+	table3 := createRandomDiningTable(t, shop)
+
+	first, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:   []CreateOrderItemParams{tabOrderItem(shop, menuItem)},
+		TableID: table1.ID,
+	})
+	require.NoError(t, err)
+	second, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:   []CreateOrderItemParams{tabOrderItem(shop, menuItem)},
+		TableID: table2.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.MoveTabTx(context.Background(), MoveTabTxParams{
+		ShopName: shop.Name,
+		ID:       first.Tab.ID,
+		TableID:  table2.ID,
+	})
+	require.ErrorIs(t, err, ErrTableOccupied)
+
+	moved, err := testStore.MoveTabTx(context.Background(), MoveTabTxParams{
+		ShopName: shop.Name,
+		ID:       first.Tab.ID,
+		TableID:  table3.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, table3.ID, moved.TableID.UUID)
+
+	table1, err = testQueries.GetDiningTable(context.Background(), GetDiningTableParams{ShopName: shop.Name, ID: table1.ID})
+	require.NoError(t, err)
+	require.Equal(t, utils.TableDirty, table1.Status)
+
+	merged, err := testStore.MergeTabsTx(context.Background(), MergeTabsTxParams{
+		ShopName: shop.Name,
+		ID:       first.Tab.ID,
+		FromID:   second.Tab.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, first.Tab.ID, merged.ID)
+
+	orders, err := testQueries.GetOrdersByOrderID(context.Background(), GetOrdersByOrderIDParams{
+		ShopName: shop.Name,
+		OrderID:  first.Tab.OrderID,
+	})
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+
+	from, err := testQueries.GetTab(context.Background(), GetTabParams{ShopName: shop.Name, ID: second.Tab.ID})
+	require.NoError(t, err)
+	require.Equal(t, utils.TabMerged, from.Status)
+	require.True(t, from.ClosedAt.Valid)
+
+	_, err = testStore.MergeTabsTx(context.Background(), MergeTabsTxParams{
+		ShopName: shop.Name,
+		ID:       first.Tab.ID,
+		FromID:   second.Tab.ID,
+	})
+	require.ErrorIs(t, err, ErrTabNotOpen)
+}
+
+func TestCloseTabTx(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	table := createRandomDiningTable(t, shop)
+
+	created, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:   []CreateOrderItemParams{tabOrderItem(shop, menuItem)},
+		TableID: table.ID,
+	})
+	require.NoError(t, err)
+
+	// a second round ordered on the table is due as well
+	_, err = testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:   []CreateOrderItemParams{tabOrderItem(shop, menuItem)},
+		TableID: table.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.CloseTabTx(context.Background(), CloseTabTxParams{
+		ShopName: shop.Name,
+		ID:       created.Tab.ID,
+		Payments: []CreatePaymentParams{
+			{ID: uuid.New(), Method: "cash", Amount: "5.00", Tendered: "10.00"},
+		},
+	})
+	require.ErrorIs(t, err, ErrTabNotPaid)
+
+	result, err := testStore.CloseTabTx(context.Background(), CloseTabTxParams{
+		ShopName: shop.Name,
+		ID:       created.Tab.ID,
+		Payments: []CreatePaymentParams{
+			{ID: uuid.New(), Method: "cash", Amount: "10.00", Tendered: "10.00"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, utils.TabClosed, result.Tab.Status)
+	require.Len(t, result.Payments, 1)
+	require.Equal(t, created.Tab.OrderID, result.Payments[0].OrderID)
+
+	table, err = testQueries.GetDiningTable(context.Background(), GetDiningTableParams{ShopName: shop.Name, ID: table.ID})
+	require.NoError(t, err)
+	require.Equal(t, utils.TableDirty, table.Status)
+
+	_, err = testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items: []CreateOrderItemParams{tabOrderItem(shop, menuItem)},
+		TabID: created.Tab.ID,
+	})
+	require.ErrorIs(t, err, ErrTabNotOpen)
+}
+
+func TestCloseTabTxDayClosed(t *testing.T) {
+	shop := createRandomShop(t)
+	menuItem := addRandomMenuItem(t, shop)
+	table := createRandomDiningTable(t, shop)
+
+	created, err := testStore.CreateOrderTx(context.Background(), CreateOrderTxParams{
+		Items:   []CreateOrderItemParams{tabOrderItem(shop, menuItem)},
+		TableID: table.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.CloseDayTx(context.Background(), DailySalesSummaryParams{
+		ShopName:    shop.Name,
+		BusinessDay: created.Orders[0].OrderDay,
+	})
+	require.NoError(t, err)
+
+	// the z report is out, payments no longer go into its day
+	_, err = testStore.CloseTabTx(context.Background(), CloseTabTxParams{
+		ShopName: shop.Name,
+		ID:       created.Tab.ID,
+		Payments: []CreatePaymentParams{
+			{ID: uuid.New(), Method: "cash", Amount: "5.00", Tendered: "5.00"},
+		},
+	})
+	require.ErrorIs(t, err, ErrDayClosed)
+
+	payments, err := testQueries.ListPaymentsByOrderID(context.Background(), ListPaymentsByOrderIDParams{
+		ShopName: shop.Name,
+		OrderID:  created.Tab.OrderID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, payments)
+}
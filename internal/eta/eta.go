@@ -0,0 +1,76 @@
+package eta
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+)
+
+// an open kitchen ticket and the time its station needs for it. a ticket
+// arrives at its station when it is released, tickets of scheduled orders
+// are held back until shortly before their slot.
+type Ticket struct {
+	ID        uuid.UUID
+	OrderID   uuid.UUID
+	StationID uuid.UUID
+	ArrivedAt time.Time
+	Prep      time.Duration
+}
+
+func NewTickets(rows []db.ListOpenKitchenTicketPrepRow) []Ticket {
+	tickets := make([]Ticket, len(rows))
+	for i, row := range rows {
+		tickets[i] = Ticket{
+			ID:        row.ID,
+			OrderID:   row.OrderID,
+			StationID: row.StationID,
+			ArrivedAt: row.ReleaseAt,
+			Prep:      time.Duration(row.PrepSeconds) * time.Second,
+		}
+	}
+	return tickets
+}
+
+// Estimate when every order with open tickets will be ready.
+//
+// stations work in parallel, each one on its own tickets in the order they
+// came in. a ticket starts when it arrives or when the ticket before it is
+// done, whichever is later. a ticket that is still open after its estimated
+// finish is due any moment, so it finishes now and pushes back the tickets
+// behind it. an order is ready when its last ticket is.
+func Estimate(now time.Time, tickets []Ticket) map[uuid.UUID]time.Time {
+	byStation := make(map[uuid.UUID][]Ticket)
+	for _, ticket := range tickets {
+		byStation[ticket.StationID] = append(byStation[ticket.StationID], ticket)
+	}
+
+	ready := make(map[uuid.UUID]time.Time)
+	for _, queue := range byStation {
+		sort.Slice(queue, func(i, j int) bool {
+			if queue[i].ArrivedAt.Equal(queue[j].ArrivedAt) {
+				return queue[i].ID.String() < queue[j].ID.String()
+			}
+			return queue[i].ArrivedAt.Before(queue[j].ArrivedAt)
+		})
+
+		var free time.Time // when the station finishes the ticket before
+		for _, ticket := range queue {
+			start := ticket.ArrivedAt
+			if free.After(start) {
+				start = free
+			}
+			free = start.Add(ticket.Prep)
+			if free.Before(now) {
+				free = now
+			}
+
+			if free.After(ready[ticket.OrderID]) {
+				ready[ticket.OrderID] = free
+			}
+		}
+	}
+
+	return ready
+}
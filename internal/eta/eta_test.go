@@ -0,0 +1,59 @@
+package eta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimate(t *testing.T) {
+	now := time.Date(2024, time.March, 2, 12, 0, 0, 0, time.UTC)
+	bar, grill := uuid.New(), uuid.New()
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+
+	tickets := []Ticket{
+		// the grill is 2 minutes into a 5 minute ticket
+		{ID: uuid.New(), OrderID: first, StationID: grill, ArrivedAt: now.Add(-2 * time.Minute), Prep: 5 * time.Minute},
+		{ID: uuid.New(), OrderID: first, StationID: bar, ArrivedAt: now.Add(-2 * time.Minute), Prep: time.Minute},
+		// queued behind the first order at the grill
+		{ID: uuid.New(), OrderID: second, StationID: grill, ArrivedAt: now, Prep: 4 * time.Minute},
+		{ID: uuid.New(), OrderID: second, StationID: bar, ArrivedAt: now, Prep: 2 * time.Minute},
+		// only the bar, right after the second order
+		{ID: uuid.New(), OrderID: third, StationID: bar, ArrivedAt: now.Add(time.Second), Prep: 30 * time.Second},
+	}
+
+	ready := Estimate(now, tickets)
+	require.Len(t, ready, 3)
+	require.Equal(t, now.Add(3*time.Minute), ready[first])
+	require.Equal(t, now.Add(7*time.Minute), ready[second])
+	require.Equal(t, now.Add(2*time.Minute+30*time.Second), ready[third])
+}
+
+func TestEstimateOverdue(t *testing.T) {
+	now := time.Date(2024, time.March, 2, 12, 0, 0, 0, time.UTC)
+	station := uuid.New()
+	late, next := uuid.New(), uuid.New()
+
+	tickets := []Ticket{
+		// next is listed first, the queue follows the arrival order
+		{ID: uuid.New(), OrderID: next, StationID: station, ArrivedAt: now.Add(-5 * time.Minute), Prep: 2 * time.Minute},
+		{ID: uuid.New(), OrderID: late, StationID: station, ArrivedAt: now.Add(-20 * time.Minute), Prep: 10 * time.Minute},
+	}
+
+	ready := Estimate(now, tickets)
+	// the late ticket should have been done 10 minutes ago and is due any moment
+	require.Equal(t, now, ready[late])
+	require.Equal(t, now.Add(2*time.Minute), ready[next])
+}
+
+func TestEstimateUnknownPrep(t *testing.T) {
+	now := time.Date(2024, time.March, 2, 12, 0, 0, 0, time.UTC)
+	order := uuid.New()
+
+	ready := Estimate(now, []Ticket{{ID: uuid.New(), OrderID: order, StationID: uuid.New(), ArrivedAt: now}})
+	require.Equal(t, now, ready[order])
+
+	require.Empty(t, Estimate(now, nil))
+}
@@ -0,0 +1,83 @@
+package event
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	TypeStockLow       = "stock.low"
+	TypeStockRecovered = "stock.recovered"
+	TypeTicketCreated  = "kitchen.ticket_created"
+	TypeTicketBumped   = "kitchen.ticket_bumped"
+	TypeTicketRecalled = "kitchen.ticket_recalled"
+	TypeOrderReady     = "kitchen.order_ready"
+	TypeETAUpdated     = "kitchen.eta_updated"
+	TypePrintJobFailed = "print.job_failed"
+	TypeOrderStatus    = "order.status_changed"
+)
+
+// buffered events per subscriber, slow subscribers miss events instead of blocking publishers
+const subscriberBuffer = 16
+
+type Event struct {
+	Type      string      `json:"type"`
+	ShopName  string      `json:"shop_name"`
+	Data      interface{} `json:"data"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Hub fans out published events to the subscribers of the event's shop
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan Event]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[string]map[chan Event]struct{}),
+	}
+}
+
+// subscribe to the events of a shop, the returned function must be called to unsubscribe
+func (hub *Hub) Subscribe(shopName string) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	hub.mu.Lock()
+	if hub.subscribers[shopName] == nil {
+		hub.subscribers[shopName] = make(map[chan Event]struct{})
+	}
+	hub.subscribers[shopName][ch] = struct{}{}
+	hub.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			hub.mu.Lock()
+			delete(hub.subscribers[shopName], ch)
+			if len(hub.subscribers[shopName]) == 0 {
+				delete(hub.subscribers, shopName)
+			}
+			hub.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+func (hub *Hub) Publish(event Event) {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	hub.mu.RLock()
+	defer hub.mu.RUnlock()
+
+	for ch := range hub.subscribers[event.ShopName] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHubPublish(t *testing.T) {
+	hub := NewHub()
+
+	events, unsubscribe := hub.Subscribe("shop")
+	defer unsubscribe()
+
+	other, unsubscribeOther := hub.Subscribe("other")
+	defer unsubscribeOther()
+
+	hub.Publish(Event{Type: TypeStockLow, ShopName: "shop", Data: "data"})
+
+	event := <-events
+	require.Equal(t, TypeStockLow, event.Type)
+	require.Equal(t, "shop", event.ShopName)
+	require.Equal(t, "data", event.Data)
+	require.NotZero(t, event.CreatedAt)
+
+	// events are only delivered to subscribers of the same shop
+	require.Empty(t, other)
+}
+
+func TestHubUnsubscribe(t *testing.T) {
+	hub := NewHub()
+
+	events, unsubscribe := hub.Subscribe("shop")
+	unsubscribe()
+	unsubscribe()
+
+	_, ok := <-events
+	require.False(t, ok)
+
+	// publishing without subscribers must not block
+	hub.Publish(Event{Type: TypeStockLow, ShopName: "shop"})
+	require.Empty(t, hub.subscribers)
+}
+
+func TestHubSlowSubscriber(t *testing.T) {
+	hub := NewHub()
+
+	events, unsubscribe := hub.Subscribe("shop")
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBuffer*2; i++ {
+		hub.Publish(Event{Type: TypeStockLow, ShopName: "shop"})
+	}
+
+	require.Len(t, events, subscriberBuffer)
+}
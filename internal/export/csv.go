@@ -0,0 +1,59 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+type csvWriter struct {
+	w      *csv.Writer
+	locale utils.Locale
+}
+
+// locales with a decimal comma use semicolons between fields, as spreadsheet
+// applications in those locales expect
+func NewCSVWriter(w io.Writer, locale utils.Locale) Writer {
+	writer := csv.NewWriter(w)
+	if locale.DecimalSeparator == "," {
+		writer.Comma = ';'
+	}
+
+	return &csvWriter{w: writer, locale: locale}
+}
+
+func (writer *csvWriter) WriteRow(cells ...Cell) error {
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		record[i] = cell.format(writer.locale)
+		if cell.kind == kindText {
+			record[i] = escapeFormula(record[i])
+		}
+	}
+	return writer.w.Write(record)
+}
+
+// spreadsheet applications evaluate a field starting with one of these as a
+// formula. text such as product names typed in by customers is prefixed with
+// a quote so it stays text, numbers are written as they are
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func (writer *csvWriter) Flush() error {
+	writer.w.Flush()
+	return writer.w.Error()
+}
+
+func (writer *csvWriter) Close() error {
+	return writer.Flush()
+}
+
+func (writer *csvWriter) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// row oriented file writer, rows are written through to the underlying
+// io.Writer so large exports are never held in memory
+type Writer interface {
+	WriteRow(cells ...Cell) error
+	// flush buffered rows to the underlying writer
+	Flush() error
+	// finish the file, the writer must not be used afterwards
+	Close() error
+	ContentType() string
+}
+
+type cellKind int
+
+const (
+	kindText cellKind = iota
+	kindNumber
+	kindDate
+	kindDateTime
+)
+
+type Cell struct {
+	kind  cellKind
+	value string
+	time  time.Time
+}
+
+func Text(s string) Cell {
+	return Cell{kind: kindText, value: s}
+}
+
+// decimal as stored in the database, e.g. "12.50"
+func Decimal(d string) Cell {
+	return Cell{kind: kindNumber, value: d}
+}
+
+func Int(n int64) Cell {
+	return Cell{kind: kindNumber, value: strconv.FormatInt(n, 10)}
+}
+
+func Date(t time.Time) Cell {
+	return Cell{kind: kindDate, time: t}
+}
+
+func DateTime(t time.Time) Cell {
+	return Cell{kind: kindDateTime, time: t}
+}
+
+// text of the cell in the locale, numbers keep their precision
+func (cell Cell) format(locale utils.Locale) string {
+	switch cell.kind {
+	case kindNumber:
+		return locale.FormatDecimal(cell.value)
+	case kindDate:
+		return cell.time.Format(locale.DateLayout)
+	case kindDateTime:
+		return cell.time.Format(locale.DateTimeLayout)
+	default:
+		return cell.value
+	}
+}
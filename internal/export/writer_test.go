@@ -0,0 +1,104 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+var testDay = time.Date(2024, time.March, 2, 9, 30, 0, 0, time.UTC)
+
+func TestCSVWriter(t *testing.T) {
+	testCases := []struct {
+		name   string
+		locale string
+		want   string
+	}{
+		{
+			name:   "DecimalPoint",
+			locale: "en-US",
+			want:   "day,product,total\n03/02/2024,\"latte, oat\",12.50\n",
+		},
+		{
+			name:   "DecimalComma",
+			locale: "de-DE",
+			want:   "day;product;total\n02.03.2024;latte, oat;12,50\n",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer := NewCSVWriter(&buf, utils.LookupLocale(tc.locale))
+
+			require.NoError(t, writer.WriteRow(Text("day"), Text("product"), Text("total")))
+			require.NoError(t, writer.WriteRow(Date(testDay), Text("latte, oat"), Decimal("12.50")))
+			require.NoError(t, writer.Close())
+
+			require.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestCSVWriterFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewCSVWriter(&buf, utils.LookupLocale("en-US"))
+
+	// text that a spreadsheet would evaluate stays text, negative numbers are
+	// numbers
+	require.NoError(t, writer.WriteRow(Text("=HYPERLINK(\"http://x\")"), Text("@SUM(A1)"), Text("-1+2"), Decimal("-3.00")))
+	require.NoError(t, writer.Close())
+
+	require.Equal(t, "\"'=HYPERLINK(\"\"http://x\"\")\",'@SUM(A1),'-1+2,-3.00\n", buf.String())
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewXLSXWriter(&buf, "orders", utils.LookupLocale("de-DE"))
+	require.NoError(t, err)
+
+	require.NoError(t, writer.WriteRow(Text("day"), Text("product <special> & co"), Text("total")))
+	require.NoError(t, writer.WriteRow(Date(testDay), Text("latte"), Decimal("12.50")))
+	require.NoError(t, writer.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	parts := map[string][]byte{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+
+		// every part is well formed xml
+		decoder := xml.NewDecoder(bytes.NewReader(data))
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err, f.Name)
+		}
+		parts[f.Name] = data
+	}
+
+	require.Contains(t, parts, "[Content_Types].xml")
+	require.Contains(t, parts, "xl/workbook.xml")
+	require.Contains(t, string(parts["xl/workbook.xml"]), `name="orders"`)
+
+	sheet := string(parts["xl/worksheets/sheet1.xml"])
+	require.Contains(t, sheet, "product &lt;special&gt; &amp; co")
+	require.Contains(t, sheet, "<t xml:space=\"preserve\">02.03.2024</t>")
+	// numbers stay numeric regardless of the locale
+	require.Contains(t, sheet, `<c t="n"><v>12.50</v></c>`)
+}
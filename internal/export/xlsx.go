@@ -0,0 +1,129 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+// the smallest package spreadsheet applications open: one workbook with a
+// single worksheet, no shared strings and no styles
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+const xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+type xlsxWriter struct {
+	zip    *zip.Writer
+	sheet  *bufio.Writer
+	locale utils.Locale
+}
+
+// numbers are written as numeric cells and displayed in the reader's
+// locale, dates are written as text in the shop's locale
+func NewXLSXWriter(w io.Writer, sheetName string, locale utils.Locale) (Writer, error) {
+	archive := zip.NewWriter(w)
+
+	for _, part := range xlsxParts {
+		f, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := archive.Create("xl/workbook.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(f, fmt.Sprintf(xlsxWorkbook, escapeXML(sheetName))); err != nil {
+		return nil, err
+	}
+
+	// the worksheet is the last part, rows stream into it until Close
+	f, err = archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(f)
+	_, err = sheet.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	if err != nil {
+		return nil, err
+	}
+
+	return &xlsxWriter{zip: archive, sheet: sheet, locale: locale}, nil
+}
+
+func (writer *xlsxWriter) WriteRow(cells ...Cell) error {
+	if _, err := writer.sheet.WriteString("<row>"); err != nil {
+		return err
+	}
+
+	for _, cell := range cells {
+		var err error
+		if cell.kind == kindNumber {
+			_, err = writer.sheet.WriteString(`<c t="n"><v>` + escapeXML(cell.value) + `</v></c>`)
+		} else {
+			// inline strings are never evaluated as formulas, text needs no escaping
+			_, err = writer.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">` + escapeXML(cell.format(writer.locale)) + `</t></is></c>`)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := writer.sheet.WriteString("</row>")
+	return err
+}
+
+func (writer *xlsxWriter) Flush() error {
+	if err := writer.sheet.Flush(); err != nil {
+		return err
+	}
+	return writer.zip.Flush()
+}
+
+func (writer *xlsxWriter) Close() error {
+	if _, err := writer.sheet.WriteString("</sheetData></worksheet>"); err != nil {
+		return err
+	}
+	if err := writer.sheet.Flush(); err != nil {
+		return err
+	}
+	return writer.zip.Close()
+}
+
+func (writer *xlsxWriter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	// EscapeText only fails when the writer does
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
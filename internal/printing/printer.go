@@ -0,0 +1,81 @@
+package printing
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DefaultPort is the raw printing port network thermal printers listen on
+const DefaultPort = "9100"
+
+const sendTimeout = 10 * time.Second
+
+// raw printing ports, printers with several ports take jobs on the ones
+// after the default
+var printerPorts = map[string]bool{
+	DefaultPort: true,
+	"9101":      true,
+	"9102":      true,
+}
+
+var (
+	ErrPrinterPort = errors.New("printer port must be 9100, 9101 or 9102")
+	ErrPrinterHost = errors.New("printer address must not be a loopback, link-local, multicast or unspecified address")
+)
+
+// CheckAddress refuses a host:port that cannot be a network printer of the
+// shop, so print jobs are never written to services of the server itself or
+// its cloud metadata endpoint. a host name is checked again once it is
+// resolved, when a job is sent
+func CheckAddress(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if !printerPorts[port] {
+		return ErrPrinterPort
+	}
+	if host == "" || strings.EqualFold(host, "localhost") {
+		return ErrPrinterHost
+	}
+
+	ip := net.ParseIP(host)
+	if ip != nil && (ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()) {
+		return ErrPrinterHost
+	}
+
+	return nil
+}
+
+// Send writes an ESC/POS payload to a network printer. the printer prints
+// whatever arrives on the connection, so a completed write is a printed job.
+// the resolved address is checked before connecting, see CheckAddress
+func Send(ctx context.Context, address string, payload []byte) error {
+	return send(ctx, address, payload, func(network, address string, _ syscall.RawConn) error {
+		return CheckAddress(address)
+	})
+}
+
+func send(ctx context.Context, address string, payload []byte, control func(network, address string, c syscall.RawConn) error) error {
+	dialer := net.Dialer{Timeout: sendTimeout, Control: control}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetWriteDeadline(time.Now().Add(sendTimeout)); err != nil {
+		return err
+	}
+	_, err = conn.Write(payload)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
@@ -0,0 +1,148 @@
+package printing
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/event"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+const (
+	DefaultInterval = 2 * time.Second
+	MaxAttempts     = 8
+	batchSize       = 20
+	firstRetry      = 5 * time.Second
+	maxRetry        = 5 * time.Minute
+)
+
+// Worker sends queued print jobs to their printers. a job that cannot be
+// sent is retried with a doubling delay, after MaxAttempts it is marked
+// failed and a print.job_failed event tells the shop
+type Worker struct {
+	store    db.Store
+	hub      *event.Hub
+	interval time.Duration
+	send     func(ctx context.Context, address string, payload []byte) error
+}
+
+func NewWorker(store db.Store, hub *event.Hub, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	return &Worker{
+		store:    store,
+		hub:      hub,
+		interval: interval,
+		send:     Send,
+	}
+}
+
+// process the queue on every tick until the context is cancelled
+func (worker *Worker) Run(ctx context.Context) {
+	// only one worker runs, so jobs still marked printing were cut off by a restart
+	if _, err := worker.store.RequeueInterruptedPrintJobs(ctx); err != nil {
+		log.Println("cannot requeue interrupted print jobs:", err)
+	}
+
+	ticker := time.NewTicker(worker.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := worker.Process(ctx); err != nil {
+			log.Println("cannot process print jobs:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// send the jobs that are due. printers are served in parallel so an offline
+// printer does not hold up the others, the jobs of one printer go out in order
+func (worker *Worker) Process(ctx context.Context) error {
+	jobs, err := worker.store.ClaimPrintJobs(ctx, batchSize)
+	if err != nil {
+		return err
+	}
+
+	byPrinter := make(map[uuid.UUID][]db.PrintJob)
+	for _, job := range jobs {
+		byPrinter[job.PrinterID] = append(byPrinter[job.PrinterID], job)
+	}
+
+	var wg sync.WaitGroup
+	for _, printerJobs := range byPrinter {
+		wg.Add(1)
+		go func(jobs []db.PrintJob) {
+			defer wg.Done()
+			for _, job := range jobs {
+				if err := worker.print(ctx, job); err != nil {
+					log.Println("cannot update print job:", err)
+				}
+			}
+		}(printerJobs)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (worker *Worker) print(ctx context.Context, job db.PrintJob) error {
+	printer, sendErr := worker.store.GetPrinter(ctx, db.GetPrinterParams{
+		ShopName: job.ShopName,
+		ID:       job.PrinterID,
+	})
+	if sendErr == nil {
+		sendErr = worker.send(ctx, printer.Address, job.Payload)
+	}
+	if sendErr == nil {
+		return worker.store.SetPrintJobPrinted(ctx, job.ID)
+	}
+
+	status := utils.PrintJobQueued
+	if job.Attempts >= MaxAttempts {
+		status = utils.PrintJobFailed
+	}
+
+	failed, err := worker.store.SetPrintJobFailed(ctx, db.SetPrintJobFailedParams{
+		Status:         status,
+		LastError:      sendErr.Error(),
+		RetryInSeconds: int32(retryDelay(job.Attempts) / time.Second),
+		ID:             job.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed.Status == utils.PrintJobFailed {
+		failed.Payload = nil // the raw commands are of no use to subscribers
+		worker.hub.Publish(event.Event{
+			Type:     event.TypePrintJobFailed,
+			ShopName: failed.ShopName,
+			Data:     failed,
+		})
+	}
+
+	return nil
+}
+
+// wait before the next attempt, doubling from firstRetry up to maxRetry
+func retryDelay(attempts int32) time.Duration {
+	delay := firstRetry
+	for i := int32(1); i < attempts && delay < maxRetry; i++ {
+		delay *= 2
+	}
+	if delay > maxRetry {
+		delay = maxRetry
+	}
+	return delay
+}
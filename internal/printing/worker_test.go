@@ -0,0 +1,208 @@
+package printing
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/internal/event"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+// fakePrinter accepts raw printing connections like a network thermal
+// printer and hands over everything written to it
+func fakePrinter(t *testing.T) (string, <-chan []byte) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	printed := make(chan []byte, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			data, _ := io.ReadAll(conn)
+			conn.Close()
+			printed <- data
+		}
+	}()
+
+	return listener.Addr().String(), printed
+}
+
+// an address nothing listens on
+func offlineAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	return address
+}
+
+// the fake printers listen on loopback, which Send refuses
+func sendAnywhere(ctx context.Context, address string, payload []byte) error {
+	return send(ctx, address, payload, nil)
+}
+
+func randomPrintJob(printer db.Printer, attempts int32) db.PrintJob {
+	return db.PrintJob{
+		ID:          uuid.New(),
+		ShopName:    printer.ShopName,
+		PrinterID:   printer.ID,
+		Kind:        utils.PrintKindKitchenTicket,
+		ReferenceID: uuid.New(),
+		Payload:     []byte("\x1b@" + utils.RandString(12) + "\n"),
+		Status:      utils.PrintJobPrinting,
+		Attempts:    attempts,
+	}
+}
+
+func TestSend(t *testing.T) {
+	address, printed := fakePrinter(t)
+
+	err := sendAnywhere(context.Background(), address, []byte("hello\n"))
+	require.NoError(t, err)
+
+	select {
+	case data := <-printed:
+		require.Equal(t, "hello\n", string(data))
+	case <-time.After(time.Second):
+		t.Fatal("nothing printed")
+	}
+
+	err = sendAnywhere(context.Background(), offlineAddress(t), []byte("hello\n"))
+	require.Error(t, err)
+
+	// the server's own services are not printers
+	err = Send(context.Background(), address, []byte("hello\n"))
+	require.ErrorIs(t, err, ErrPrinterPort)
+	err = Send(context.Background(), "localhost:9100", []byte("hello\n"))
+	require.ErrorIs(t, err, ErrPrinterHost)
+}
+
+func TestCheckAddress(t *testing.T) {
+	require.NoError(t, CheckAddress("192.168.1.50:9100"))
+	require.NoError(t, CheckAddress("kitchen-printer.local:9101"))
+
+	require.ErrorIs(t, CheckAddress("192.168.1.50:22"), ErrPrinterPort)
+	require.ErrorIs(t, CheckAddress("127.0.0.1:9100"), ErrPrinterHost)
+	require.ErrorIs(t, CheckAddress("[::1]:9100"), ErrPrinterHost)
+	require.ErrorIs(t, CheckAddress("169.254.169.254:9100"), ErrPrinterHost)
+	require.ErrorIs(t, CheckAddress("0.0.0.0:9100"), ErrPrinterHost)
+	require.Error(t, CheckAddress("192.168.1.50"))
+}
+
+func TestProcessPrinted(t *testing.T) {
+	address, printed := fakePrinter(t)
+	printer := db.Printer{ID: uuid.New(), ShopName: utils.RandString(6), Address: address}
+	first := randomPrintJob(printer, 1)
+	second := randomPrintJob(printer, 3)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ClaimPrintJobs(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.PrintJob{first, second}, nil)
+	store.EXPECT().
+		GetPrinter(gomock.Any(), gomock.Eq(db.GetPrinterParams{ShopName: printer.ShopName, ID: printer.ID})).
+		Times(2).
+		Return(printer, nil)
+	store.EXPECT().SetPrintJobPrinted(gomock.Any(), gomock.Eq(first.ID)).Times(1).Return(nil)
+	store.EXPECT().SetPrintJobPrinted(gomock.Any(), gomock.Eq(second.ID)).Times(1).Return(nil)
+	store.EXPECT().SetPrintJobFailed(gomock.Any(), gomock.Any()).Times(0)
+
+	worker := NewWorker(store, event.NewHub(), 0)
+	worker.send = sendAnywhere
+	require.NoError(t, worker.Process(context.Background()))
+
+	// jobs of one printer go out in the order they were claimed
+	require.Equal(t, first.Payload, <-printed)
+	require.Equal(t, second.Payload, <-printed)
+}
+
+func TestProcessRetry(t *testing.T) {
+	printer := db.Printer{ID: uuid.New(), ShopName: utils.RandString(6), Address: offlineAddress(t)}
+
+	testCases := []struct {
+		name     string
+		attempts int32
+		status   string
+		retryIn  int32
+	}{
+		{"FirstAttempt", 1, utils.PrintJobQueued, 5},
+		{"ThirdAttempt", 3, utils.PrintJobQueued, 20},
+		{"OutOfAttempts", MaxAttempts, utils.PrintJobFailed, 300},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			job := randomPrintJob(printer, tc.attempts)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				ClaimPrintJobs(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return([]db.PrintJob{job}, nil)
+			store.EXPECT().
+				GetPrinter(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(printer, nil)
+			store.EXPECT().SetPrintJobPrinted(gomock.Any(), gomock.Any()).Times(0)
+			store.EXPECT().
+				SetPrintJobFailed(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, arg db.SetPrintJobFailedParams) (db.PrintJob, error) {
+					require.Equal(t, job.ID, arg.ID)
+					require.Equal(t, tc.status, arg.Status)
+					require.Equal(t, tc.retryIn, arg.RetryInSeconds)
+					require.NotEmpty(t, arg.LastError)
+
+					job.Status = arg.Status
+					job.LastError = arg.LastError
+					return job, nil
+				})
+
+			hub := event.NewHub()
+			events, unsubscribe := hub.Subscribe(printer.ShopName)
+			defer unsubscribe()
+
+			worker := NewWorker(store, hub, 0)
+			worker.send = sendAnywhere
+			require.NoError(t, worker.Process(context.Background()))
+
+			if tc.status != utils.PrintJobFailed {
+				require.Empty(t, events)
+				return
+			}
+			e := <-events
+			require.Equal(t, event.TypePrintJobFailed, e.Type)
+			require.Nil(t, e.Data.(db.PrintJob).Payload)
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	require.Equal(t, 5*time.Second, retryDelay(0))
+	require.Equal(t, 5*time.Second, retryDelay(1))
+	require.Equal(t, 10*time.Second, retryDelay(2))
+	require.Equal(t, 160*time.Second, retryDelay(6))
+	require.Equal(t, 5*time.Minute, retryDelay(7))
+	require.Equal(t, 5*time.Minute, retryDelay(100))
+}
@@ -0,0 +1,75 @@
+package receipt
+
+import (
+	"bufio"
+	"io"
+)
+
+// ESC/POS commands understood by Epson compatible thermal printers
+var (
+	escInit         = []byte{0x1b, 0x40}       // ESC @
+	escAlignLeft    = []byte{0x1b, 0x61, 0x00} // ESC a 0
+	escAlignCenter  = []byte{0x1b, 0x61, 0x01} // ESC a 1
+	escBoldOn       = []byte{0x1b, 0x45, 0x01} // ESC E 1
+	escBoldOff      = []byte{0x1b, 0x45, 0x00} // ESC E 0
+	escDoubleHeight = []byte{0x1d, 0x21, 0x01} // GS ! 1
+	escNormalSize   = []byte{0x1d, 0x21, 0x00} // GS ! 0
+	escFeedLines    = []byte{0x1b, 0x64, 0x04} // ESC d 4, clear the cutter
+	escPartialCut   = []byte{0x1d, 0x56, 0x01} // GS V 1
+)
+
+// raw command bytes for a thermal printer, text is sent in the printer's
+// default code page so characters outside ASCII are printed as '?'
+func WriteESCPOS(w io.Writer, receipt Receipt, width Width) error {
+	return writeESCPOS(w, receipt.rows(width.Columns()))
+}
+
+func writeESCPOS(w io.Writer, rows []row) error {
+	buf := bufio.NewWriter(w)
+	write := func(b []byte) {
+		buf.Write(b) // errors are kept by the buffer and returned by Flush
+	}
+
+	write(escInit)
+	for _, row := range rows {
+		if row.centered {
+			write(escAlignCenter)
+		}
+		switch row.style {
+		case styleBold:
+			write(escBoldOn)
+		case styleTitle:
+			write(escBoldOn)
+			write(escDoubleHeight)
+		}
+
+		write(ascii(row.text))
+		write([]byte{'\n'})
+
+		switch row.style {
+		case styleBold:
+			write(escBoldOff)
+		case styleTitle:
+			write(escNormalSize)
+			write(escBoldOff)
+		}
+		if row.centered {
+			write(escAlignLeft)
+		}
+	}
+	write(escFeedLines)
+	write(escPartialCut)
+
+	return buf.Flush()
+}
+
+func ascii(s string) []byte {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r < 0x20 || r > 0x7e {
+			r = '?'
+		}
+		b = append(b, byte(r))
+	}
+	return b
+}
@@ -0,0 +1,142 @@
+package receipt
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+)
+
+var htmlTemplate = template.Must(template.New("receipt").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Receipt {{.Number}}</title>
+<style>
+@page { size: {{.Paper}}mm auto; margin: 0; }
+body { width: {{.Printable}}mm; margin: 0 auto; font-family: monospace; font-size: 12px; }
+h1 { font-size: 18px; margin: 0; }
+header, footer { text-align: center; }
+table { width: 100%; border-collapse: collapse; }
+td { vertical-align: top; padding: 1px 0; }
+td.amount { text-align: right; white-space: nowrap; }
+tr.detail td { padding-left: 2em; }
+tr.total td { font-weight: bold; border-top: 1px dashed #000; }
+hr { border: none; border-top: 1px dashed #000; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Shop}}</h1>
+{{- range .Header}}
+<div>{{.}}</div>
+{{- end}}
+</header>
+<hr>
+<div>Order {{.Number}}</div>
+<div>{{.Time}}</div>
+<hr>
+<table>
+{{- range .Lines}}
+<tr><td>{{.Label}}</td><td class="amount">{{.Amount}}</td></tr>
+{{- range .Details}}
+<tr class="detail"><td>{{.Label}}</td><td class="amount">{{.Amount}}</td></tr>
+{{- end}}
+{{- end}}
+</table>
+<hr>
+<table>
+{{- range .Totals}}
+<tr><td>{{.Label}}</td><td class="amount">{{.Amount}}</td></tr>
+{{- end}}
+<tr class="total"><td>TOTAL</td><td class="amount">{{.Total}}</td></tr>
+</table>
+{{- if .Payments}}
+<hr>
+<table>
+{{- range .Payments}}
+<tr><td>{{.Label}}</td><td class="amount">{{.Amount}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Footer}}
+<hr>
+<footer>
+{{- range .Footer}}
+<div>{{.}}</div>
+{{- end}}
+</footer>
+{{- end}}
+</body>
+</html>
+`))
+
+type htmlRow struct {
+	Label   string
+	Amount  string
+	Details []htmlRow
+}
+
+// printable HTML page sized for the paper roll, meant for the browser's print
+// dialog when no thermal printer is attached
+func WriteHTML(w io.Writer, receipt Receipt, width Width) error {
+	data := struct {
+		Paper     int
+		Printable int
+		Shop      string
+		Header    []string
+		Footer    []string
+		Number    string
+		Time      string
+		Lines     []htmlRow
+		Totals    []htmlRow
+		Total     string
+		Payments  []htmlRow
+	}{
+		Paper:     int(width),
+		Printable: width.printable(),
+		Shop:      receipt.Shop.Name,
+		Header:    receipt.Shop.Header,
+		Footer:    receipt.Shop.Footer,
+		Number:    receipt.number(),
+		Time:      receipt.time(),
+		Total:     receipt.money(receipt.Total),
+	}
+
+	for _, line := range receipt.Lines {
+		row := htmlRow{
+			Label:  fmt.Sprintf("%d x %s", line.Quantity, line.Name),
+			Amount: receipt.money(line.Amount),
+		}
+		if line.Quantity > 1 {
+			row.Details = append(row.Details, htmlRow{Label: "@ " + receipt.money(line.UnitPrice)})
+		}
+		for _, modifier := range line.Modifiers {
+			detail := htmlRow{Label: "+ " + modifier.Name}
+			if modifier.Amount != 0 {
+				detail.Amount = receipt.money(modifier.Amount * int64(line.Quantity))
+			}
+			row.Details = append(row.Details, detail)
+		}
+		data.Lines = append(data.Lines, row)
+	}
+
+	data.Totals = append(data.Totals, htmlRow{Label: "Subtotal", Amount: receipt.money(receipt.Subtotal)})
+	for _, tax := range receipt.Taxes {
+		data.Totals = append(data.Totals, htmlRow{
+			Label:  fmt.Sprintf("Tax %s on %s", receipt.rate(tax.Rate), receipt.money(tax.Net)),
+			Amount: receipt.money(tax.Tax),
+		})
+	}
+
+	for _, payment := range receipt.Payments {
+		data.Payments = append(data.Payments, htmlRow{Label: methodLabel(payment.Method), Amount: receipt.money(payment.Tendered)})
+	}
+	if receipt.Change > 0 {
+		data.Payments = append(data.Payments, htmlRow{Label: "Change", Amount: receipt.money(receipt.Change)})
+	}
+	if receipt.Due > 0 {
+		data.Payments = append(data.Payments, htmlRow{Label: "Due", Amount: receipt.money(receipt.Due)})
+	}
+
+	return htmlTemplate.Execute(w, data)
+}
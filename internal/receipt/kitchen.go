@@ -0,0 +1,60 @@
+package receipt
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+)
+
+// the slip a station prepares an order from, items without prices
+type KitchenTicket struct {
+	Station string
+	OrderID uuid.UUID
+	Time    time.Time // in the shop timezone
+	Items   []KitchenItem
+}
+
+type KitchenItem struct {
+	Name     string
+	Quantity int32
+}
+
+func NewKitchenTicket(shop Shop, station string, ticket db.KitchenTicket, items []db.Order) KitchenTicket {
+	kitchenTicket := KitchenTicket{
+		Station: station,
+		OrderID: ticket.OrderID,
+		Time:    ticket.CreatedAt.In(shop.Location),
+	}
+	for _, item := range items {
+		kitchenTicket.Items = append(kitchenTicket.Items, KitchenItem{
+			Name:     item.ProductName,
+			Quantity: item.Amount,
+		})
+	}
+
+	return kitchenTicket
+}
+
+func (ticket KitchenTicket) rows(columns int) []row {
+	rows := []row{
+		{text: truncate(ticket.Station, columns), centered: true, style: styleTitle},
+		{text: strings.Repeat("-", columns)},
+		{text: twoColumns("Order "+strings.ToUpper(ticket.OrderID.String()[:8]), ticket.Time.Format("15:04"), columns), style: styleBold},
+		{text: strings.Repeat("-", columns)},
+	}
+	for _, item := range ticket.Items {
+		text := fmt.Sprintf("%d x %s", item.Quantity, item.Name)
+		rows = append(rows, row{text: truncate(text, columns), style: styleBold})
+	}
+
+	return rows
+}
+
+// kitchen printers only ever receive raw commands, so there is no text or HTML form
+func WriteKitchenTicketESCPOS(w io.Writer, ticket KitchenTicket, width Width) error {
+	return writeESCPOS(w, ticket.rows(width.Columns()))
+}
@@ -0,0 +1,118 @@
+package receipt
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type style int
+
+const (
+	styleNormal style = iota
+	styleBold
+	styleTitle // bold and double height
+)
+
+// one printed line, text is padded to the full width unless centered
+type row struct {
+	text     string
+	centered bool
+	style    style
+}
+
+// lay the receipt out in fixed width rows, shared by the plain text and the
+// ESC/POS renderers so both print the same thing
+func (receipt Receipt) rows(columns int) []row {
+	var rows []row
+	center := func(text string, style style) {
+		rows = append(rows, row{text: truncate(text, columns), centered: true, style: style})
+	}
+	pair := func(left, right string, style style) {
+		rows = append(rows, row{text: twoColumns(left, right, columns), style: style})
+	}
+	rule := func() {
+		rows = append(rows, row{text: strings.Repeat("-", columns)})
+	}
+
+	center(receipt.Shop.Name, styleTitle)
+	for _, line := range receipt.Shop.Header {
+		center(line, styleNormal)
+	}
+	rule()
+	pair("Order "+receipt.number(), "", styleNormal)
+	pair(receipt.time(), "", styleNormal)
+	rule()
+
+	for _, line := range receipt.Lines {
+		pair(fmt.Sprintf("%d x %s", line.Quantity, line.Name), receipt.money(line.Amount), styleNormal)
+		if line.Quantity > 1 {
+			pair("    @ "+receipt.money(line.UnitPrice), "", styleNormal)
+		}
+		for _, modifier := range line.Modifiers {
+			var amount string
+			if modifier.Amount != 0 {
+				amount = receipt.money(modifier.Amount * int64(line.Quantity))
+			}
+			pair("  + "+modifier.Name, amount, styleNormal)
+		}
+	}
+	rule()
+
+	pair("Subtotal", receipt.money(receipt.Subtotal), styleNormal)
+	for _, tax := range receipt.Taxes {
+		label := fmt.Sprintf("Tax %s on %s", receipt.rate(tax.Rate), receipt.money(tax.Net))
+		pair(label, receipt.money(tax.Tax), styleNormal)
+	}
+	pair("TOTAL", receipt.money(receipt.Total), styleBold)
+
+	if len(receipt.Payments) > 0 || receipt.Due > 0 {
+		rule()
+	}
+	for _, payment := range receipt.Payments {
+		pair(methodLabel(payment.Method), receipt.money(payment.Tendered), styleNormal)
+	}
+	if receipt.Change > 0 {
+		pair("Change", receipt.money(receipt.Change), styleNormal)
+	}
+	if receipt.Due > 0 {
+		pair("Due", receipt.money(receipt.Due), styleBold)
+	}
+
+	if len(receipt.Shop.Footer) > 0 {
+		rule()
+	}
+	for _, line := range receipt.Shop.Footer {
+		center(line, styleNormal)
+	}
+
+	return rows
+}
+
+// left aligned label and right aligned amount, the label is cut short when
+// both do not fit on one line
+func twoColumns(left, right string, columns int) string {
+	width := columns - utf8.RuneCountInString(right)
+	if right != "" {
+		width-- // keep a space between the columns
+	}
+	left = truncate(left, width)
+
+	padding := columns - utf8.RuneCountInString(left) - utf8.RuneCountInString(right)
+	if right == "" {
+		return left
+	}
+	return left + strings.Repeat(" ", padding) + right
+}
+
+func truncate(s string, columns int) string {
+	if utf8.RuneCountInString(s) <= columns {
+		return s
+	}
+	return string([]rune(s)[:columns])
+}
+
+func centered(s string, columns int) string {
+	padding := (columns - utf8.RuneCountInString(s)) / 2
+	return strings.Repeat(" ", padding) + s
+}
@@ -0,0 +1,232 @@
+package receipt
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+var ErrEmptyOrder = errors.New("order has no items to print")
+
+// thermal paper roll the receipt is printed on
+type Width int
+
+const (
+	Width58mm Width = 58
+	Width80mm Width = 80
+)
+
+// characters per line in the printer's standard font
+func (w Width) Columns() int {
+	if w == Width58mm {
+		return 32
+	}
+	return 48
+}
+
+// printable area in millimeters, the printer keeps a margin on each side
+func (w Width) printable() int {
+	if w == Width58mm {
+		return 48
+	}
+	return 72
+}
+
+type Shop struct {
+	Name     string
+	Header   []string
+	Footer   []string
+	Location *time.Location
+	Locale   utils.Locale
+}
+
+func NewShop(shop db.Shop) (Shop, error) {
+	loc, err := time.LoadLocation(shop.Timezone)
+	if err != nil {
+		return Shop{}, err
+	}
+
+	name := shop.DisplayName
+	if name == "" {
+		name = shop.Name
+	}
+
+	return Shop{
+		Name:     name,
+		Header:   splitLines(shop.ReceiptHeader),
+		Footer:   splitLines(shop.ReceiptFooter),
+		Location: loc,
+		Locale:   utils.LookupLocale(shop.Locale),
+	}, nil
+}
+
+// amounts are in cents
+type Modifier struct {
+	Name   string
+	Amount int64
+}
+
+type Line struct {
+	Name      string
+	Quantity  int32
+	UnitPrice int64
+	Modifiers []Modifier // priced per unit, order items do not record any yet
+	Amount    int64      // quantity times the unit price and modifiers
+	TaxRate   string
+}
+
+type TaxLine struct {
+	Rate string
+	Net  int64
+	Tax  int64
+}
+
+type Payment struct {
+	Method   string
+	Amount   int64
+	Tendered int64
+}
+
+type Receipt struct {
+	Shop     Shop
+	OrderID  uuid.UUID
+	Time     time.Time // in the shop timezone
+	Lines    []Line
+	Taxes    []TaxLine // one per non-zero rate
+	Subtotal int64
+	Tax      int64
+	Total    int64
+	Payments []Payment
+	Change   int64 // handed back on tenders above the paid amount
+	Due      int64 // still to be paid
+}
+
+// build the receipt of one order, refunded items are left out as they were
+// neither charged nor taxed
+func Build(shop Shop, items []db.Order, payments []db.Payment) (Receipt, error) {
+	if len(items) == 0 {
+		return Receipt{}, ErrEmptyOrder
+	}
+
+	receipt := Receipt{
+		Shop:    shop,
+		OrderID: items[0].OrderID,
+		Time:    items[0].CreatedAt,
+	}
+
+	net := make(map[string]int64)
+	for _, item := range items {
+		if item.CreatedAt.Before(receipt.Time) {
+			receipt.Time = item.CreatedAt
+		}
+		if item.Status == utils.StatusRefunded {
+			continue
+		}
+
+		price, err := utils.ParseCents(item.ProductPrice)
+		if err != nil {
+			return Receipt{}, err
+		}
+
+		line := Line{
+			Name:      item.ProductName,
+			Quantity:  item.Amount,
+			UnitPrice: price,
+			Amount:    price * int64(item.Amount),
+			TaxRate:   item.TaxRate,
+		}
+		receipt.Lines = append(receipt.Lines, line)
+		receipt.Subtotal += line.Amount
+		net[line.TaxRate] += line.Amount
+	}
+	receipt.Time = receipt.Time.In(shop.Location)
+
+	// the rate is a percentage with two decimals, so in cents it is the rate
+	// in hundredths of a percent
+	basisPoints := make(map[string]int64, len(net))
+	for rate := range net {
+		bp, err := utils.ParseCents(rate)
+		if err != nil {
+			return Receipt{}, err
+		}
+		if bp != 0 {
+			basisPoints[rate] = bp
+			receipt.Taxes = append(receipt.Taxes, TaxLine{Rate: rate, Net: net[rate]})
+		}
+	}
+	sort.Slice(receipt.Taxes, func(i, j int) bool {
+		return basisPoints[receipt.Taxes[i].Rate] < basisPoints[receipt.Taxes[j].Rate]
+	})
+	for i := range receipt.Taxes {
+		tax := &receipt.Taxes[i]
+		tax.Tax = (tax.Net*basisPoints[tax.Rate] + 5000) / 10000
+		receipt.Tax += tax.Tax
+	}
+	receipt.Total = receipt.Subtotal + receipt.Tax
+
+	var paid int64
+	for _, payment := range payments {
+		amount, err := utils.ParseCents(payment.Amount)
+		if err != nil {
+			return Receipt{}, err
+		}
+		tendered, err := utils.ParseCents(payment.Tendered)
+		if err != nil {
+			return Receipt{}, err
+		}
+
+		receipt.Payments = append(receipt.Payments, Payment{
+			Method:   payment.Method,
+			Amount:   amount,
+			Tendered: tendered,
+		})
+		paid += amount
+		receipt.Change += tendered - amount
+	}
+	if paid < receipt.Total {
+		receipt.Due = receipt.Total - paid
+	}
+
+	return receipt, nil
+}
+
+// format cents with the decimal separator of the shop locale
+func (receipt Receipt) money(cents int64) string {
+	return receipt.Shop.Locale.FormatDecimal(utils.FormatCents(cents))
+}
+
+func (receipt Receipt) rate(rate string) string {
+	return receipt.Shop.Locale.FormatDecimal(rate) + "%"
+}
+
+func (receipt Receipt) number() string {
+	return strings.ToUpper(receipt.OrderID.String()[:8])
+}
+
+func (receipt Receipt) time() string {
+	return receipt.Time.Format(receipt.Shop.Locale.DateTimeLayout)
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+func methodLabel(method string) string {
+	switch method {
+	case utils.PaymentCash:
+		return "Cash"
+	case utils.PaymentCard:
+		return "Card"
+	default:
+		return "Other"
+	}
+}
@@ -0,0 +1,152 @@
+package receipt
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func testShop(t *testing.T, locale string) Shop {
+	loc, err := time.LoadLocation("Asia/Hong_Kong")
+	require.NoError(t, err)
+
+	return Shop{
+		Name:     "harbourcafe",
+		Header:   []string{"12 Harbour Road", "Tel 2345 6789"},
+		Footer:   []string{"Thank you!", "Wi-Fi: harbour / espresso"},
+		Location: loc,
+		Locale:   utils.LookupLocale(locale),
+	}
+}
+
+func testReceipt(t *testing.T, locale string) Receipt {
+	orderID := uuid.MustParse("1f3c9a2b-5d4e-4f6a-8b7c-9d0e1f2a3b4c")
+	createdAt := time.Date(2024, time.March, 2, 0, 15, 0, 0, time.UTC)
+
+	items := []db.Order{
+		{OrderID: orderID, ProductName: "Flat white", ProductPrice: "4.50", Amount: 2, Status: "done", TaxRate: "5.00", CreatedAt: createdAt},
+		{OrderID: orderID, ProductName: "Sourdough toast with smashed avocado and feta", ProductPrice: "12.00", Amount: 1, Status: "done", TaxRate: "5.00", CreatedAt: createdAt},
+		{OrderID: orderID, ProductName: "Bottled water", ProductPrice: "2.00", Amount: 1, Status: "done", TaxRate: "0.00", CreatedAt: createdAt},
+		{OrderID: orderID, ProductName: "Croissant", ProductPrice: "3.80", Amount: 1, Status: utils.StatusRefunded, TaxRate: "5.00", CreatedAt: createdAt},
+	}
+	payments := []db.Payment{
+		{OrderID: orderID, Method: utils.PaymentCard, Amount: "10.00", Tendered: "10.00"},
+		{OrderID: orderID, Method: utils.PaymentCash, Amount: "14.05", Tendered: "20.00"},
+	}
+
+	receipt, err := Build(testShop(t, locale), items, payments)
+	require.NoError(t, err)
+
+	// order items carry no modifiers, free ones leave the totals untouched
+	receipt.Lines[0].Modifiers = []Modifier{{Name: "Oat milk"}, {Name: "Extra hot"}}
+
+	return receipt
+}
+
+func TestBuild(t *testing.T) {
+	receipt := testReceipt(t, "en-US")
+
+	// the refunded croissant is left out
+	require.Len(t, receipt.Lines, 3)
+	require.Equal(t, int64(2300), receipt.Subtotal)
+	require.Equal(t, []TaxLine{{Rate: "5.00", Net: 2100, Tax: 105}}, receipt.Taxes)
+	require.Equal(t, int64(2405), receipt.Total)
+	require.Equal(t, int64(595), receipt.Change)
+	require.Zero(t, receipt.Due)
+	require.Equal(t, "Asia/Hong_Kong", receipt.Time.Location().String())
+	require.Equal(t, 8, receipt.Time.Hour())
+}
+
+func TestBuildUnpaid(t *testing.T) {
+	items := []db.Order{
+		{ProductName: "Tea", ProductPrice: "3.00", Amount: 1, TaxRate: "10.00"},
+		{ProductName: "Cake", ProductPrice: "5.00", Amount: 1, TaxRate: "2.50"},
+	}
+
+	receipt, err := Build(testShop(t, "en-US"), items, nil)
+	require.NoError(t, err)
+	// sorted by rate, not by their text
+	require.Equal(t, []TaxLine{{Rate: "2.50", Net: 500, Tax: 13}, {Rate: "10.00", Net: 300, Tax: 30}}, receipt.Taxes)
+	require.Equal(t, int64(843), receipt.Due)
+
+	_, err = Build(testShop(t, "en-US"), nil, nil)
+	require.ErrorIs(t, err, ErrEmptyOrder)
+}
+
+func TestGolden(t *testing.T) {
+	testCases := []struct {
+		golden string
+		locale string
+		width  Width
+		write  func(buf *bytes.Buffer, receipt Receipt, width Width) error
+	}{
+		{"receipt_58mm.txt", "en-US", Width58mm, func(buf *bytes.Buffer, r Receipt, w Width) error { return WriteText(buf, r, w) }},
+		{"receipt_80mm.txt", "de-DE", Width80mm, func(buf *bytes.Buffer, r Receipt, w Width) error { return WriteText(buf, r, w) }},
+		{"receipt_80mm.html", "en-US", Width80mm, func(buf *bytes.Buffer, r Receipt, w Width) error { return WriteHTML(buf, r, w) }},
+		{"receipt_58mm.escpos", "en-US", Width58mm, func(buf *bytes.Buffer, r Receipt, w Width) error { return WriteESCPOS(buf, r, w) }},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.golden, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, tc.write(&buf, testReceipt(t, tc.locale), tc.width))
+
+			path := filepath.Join("testdata", tc.golden)
+			if *update {
+				require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+			}
+
+			golden, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, string(golden), buf.String())
+		})
+	}
+}
+
+func TestKitchenTicketGolden(t *testing.T) {
+	shop := testShop(t, "en-US")
+	ticket := db.KitchenTicket{
+		OrderID:   uuid.MustParse("1f3c9a2b-5d4e-4f6a-8b7c-9d0e1f2a3b4c"),
+		CreatedAt: time.Date(2024, time.March, 2, 0, 15, 0, 0, time.UTC),
+	}
+	items := []db.Order{
+		{ProductName: "Flat white", Amount: 2},
+		{ProductName: "Sourdough toast with smashed avocado and feta", Amount: 1},
+	}
+
+	var buf bytes.Buffer
+	err := WriteKitchenTicketESCPOS(&buf, NewKitchenTicket(shop, "Bar", ticket, items), Width58mm)
+	require.NoError(t, err)
+
+	path := filepath.Join("testdata", "kitchen_58mm.escpos")
+	if *update {
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	}
+
+	golden, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(golden), buf.String())
+	require.Contains(t, buf.String(), "08:15") // printed in the shop timezone
+}
+
+func TestTwoColumns(t *testing.T) {
+	require.Equal(t, "Tea        3.00", twoColumns("Tea", "3.00", 15))
+	require.Equal(t, "Sourdough 12.00", twoColumns("Sourdough toast", "12.00", 15))
+	require.Equal(t, "Order 1F3C", twoColumns("Order 1F3C", "", 15))
+}
+
+func TestASCII(t *testing.T) {
+	require.Equal(t, []byte("Caf? ??"), ascii("Café 茶點"))
+}
@@ -0,0 +1,163 @@
+package receipt
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+var ErrInvalidShare = errors.New("share must name a check of the split and at least one part")
+
+// part of an order item charged to a check, an item is divided between its
+// checks in proportion to their parts
+type Share struct {
+	OrderItemID uuid.UUID
+	Check       int // index of the check in the split
+	Parts       int64
+}
+
+// amounts in cents of one check of a split
+type CheckTotal struct {
+	Subtotal int64
+	Tax      int64
+	Total    int64
+}
+
+// split the shared items of an order between checks. the net of every tax
+// rate and the tax charged on it are divided with the largest remainder
+// method, so the checks always add up to the cent to the receipt of the
+// shared items. leftover cents go to the checks with the largest fraction,
+// the first check on ties. refunded items and items without shares are
+// left out.
+func Split(items []db.Order, checks int, shares []Share) ([]CheckTotal, error) {
+	totals := make([]CheckTotal, checks)
+
+	byID := make(map[uuid.UUID]db.Order, len(items))
+	for _, item := range items {
+		if item.Status != utils.StatusRefunded {
+			byID[item.ID] = item
+		}
+	}
+
+	parts := make(map[uuid.UUID]int64)
+	for _, share := range shares {
+		if share.Check < 0 || share.Check >= checks || share.Parts <= 0 {
+			return nil, ErrInvalidShare
+		}
+		if _, ok := byID[share.OrderItemID]; ok {
+			parts[share.OrderItemID] += share.Parts
+		}
+	}
+
+	// exact share of every check in the net of each rate
+	ideal := make(map[string][]*big.Rat)
+	net := make(map[string]int64)
+	var rates []string
+	for _, share := range shares {
+		item, ok := byID[share.OrderItemID]
+		if !ok {
+			continue
+		}
+
+		price, err := utils.ParseCents(item.ProductPrice)
+		if err != nil {
+			return nil, err
+		}
+		amount := price * int64(item.Amount)
+
+		if _, ok := ideal[item.TaxRate]; !ok {
+			ideal[item.TaxRate] = newRats(checks)
+			rates = append(rates, item.TaxRate)
+		}
+		ideal[item.TaxRate][share.Check].Add(ideal[item.TaxRate][share.Check], big.NewRat(amount*share.Parts, parts[item.ID]))
+	}
+	for id, item := range byID {
+		if _, ok := parts[id]; !ok {
+			continue
+		}
+		price, err := utils.ParseCents(item.ProductPrice)
+		if err != nil {
+			return nil, err
+		}
+		net[item.TaxRate] += price * int64(item.Amount)
+	}
+	sort.Strings(rates)
+
+	for _, rate := range rates {
+		bp, err := utils.ParseCents(rate)
+		if err != nil {
+			return nil, err
+		}
+
+		nets := largestRemainder(net[rate], ideal[rate])
+		// same rounding as the receipt, on the net of the rate
+		tax := (net[rate]*bp + 5000) / 10000
+
+		taxShares := newRats(checks)
+		if net[rate] != 0 {
+			for i, n := range nets {
+				taxShares[i].SetFrac(big.NewInt(tax*n), big.NewInt(net[rate]))
+			}
+		}
+		taxes := largestRemainder(tax, taxShares)
+
+		for i := range totals {
+			totals[i].Subtotal += nets[i]
+			totals[i].Tax += taxes[i]
+		}
+	}
+
+	for i := range totals {
+		totals[i].Total = totals[i].Subtotal + totals[i].Tax
+	}
+	return totals, nil
+}
+
+func newRats(n int) []*big.Rat {
+	rats := make([]*big.Rat, n)
+	for i := range rats {
+		rats[i] = new(big.Rat)
+	}
+	return rats
+}
+
+// round shares down to cents and hand the cents left of total to the
+// largest fractions, the lowest index first on ties. shares of discount lines
+// are negative, they round down as well. should the rounded shares exceed
+// total, the cents are taken back from the smallest fractions, the highest
+// index first.
+func largestRemainder(total int64, shares []*big.Rat) []int64 {
+	cents := make([]int64, len(shares))
+	remainders := make([]*big.Rat, len(shares))
+
+	left := total
+	for i, share := range shares {
+		// the denominator is positive, so euclidean division is floor division
+		floor := new(big.Int).Div(share.Num(), share.Denom())
+		cents[i] = floor.Int64()
+		remainders[i] = new(big.Rat).Sub(share, new(big.Rat).SetInt(floor))
+		left -= cents[i]
+	}
+
+	order := make([]int, len(shares))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+
+	for i := 0; left > 0 && len(order) > 0; i = (i + 1) % len(order) {
+		cents[order[i]]++
+		left--
+	}
+	for i := len(order) - 1; left < 0 && len(order) > 0; i = (i + len(order) - 1) % len(order) {
+		cents[order[i]]--
+		left++
+	}
+	return cents
+}
@@ -0,0 +1,136 @@
+package receipt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func TestSplitEvenly(t *testing.T) {
+	items := []db.Order{
+		{ID: uuid.New(), ProductName: "Pizza", ProductPrice: "10.00", Amount: 1, TaxRate: "5.00"},
+		{ID: uuid.New(), ProductName: "Water", ProductPrice: "2.00", Amount: 1, TaxRate: "0.00"},
+		{ID: uuid.New(), ProductName: "Wine", ProductPrice: "30.00", Amount: 1, TaxRate: "5.00", Status: utils.StatusRefunded},
+	}
+
+	var shares []Share
+	for _, item := range items {
+		for check := 0; check < 3; check++ {
+			shares = append(shares, Share{OrderItemID: item.ID, Check: check, Parts: 1})
+		}
+	}
+
+	totals, err := Split(items, 3, shares)
+	require.NoError(t, err)
+	// 12.50 three ways, the first checks take the leftover cents
+	require.Equal(t, []CheckTotal{
+		{Subtotal: 401, Tax: 17, Total: 418},
+		{Subtotal: 400, Tax: 17, Total: 417},
+		{Subtotal: 399, Tax: 16, Total: 415},
+	}, totals)
+}
+
+func TestSplitByItem(t *testing.T) {
+	items := []db.Order{
+		{ID: uuid.New(), ProductName: "Burger", ProductPrice: "12.00", Amount: 1, TaxRate: "10.00"},
+		{ID: uuid.New(), ProductName: "Fries", ProductPrice: "4.00", Amount: 1, TaxRate: "10.00"},
+		{ID: uuid.New(), ProductName: "Extra fries", ProductPrice: "4.00", Amount: 1, TaxRate: "10.00"},
+	}
+
+	// the burger goes to the first check, the fries to the second and the
+	// extra fries are shared one part to two
+	totals, err := Split(items, 2, []Share{
+		{OrderItemID: items[0].ID, Check: 0, Parts: 1},
+		{OrderItemID: items[1].ID, Check: 1, Parts: 1},
+		{OrderItemID: items[2].ID, Check: 0, Parts: 1},
+		{OrderItemID: items[2].ID, Check: 1, Parts: 2},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []CheckTotal{
+		{Subtotal: 1333, Tax: 133, Total: 1466},
+		{Subtotal: 667, Tax: 67, Total: 734},
+	}, totals)
+}
+
+func TestSplitDiscountUneven(t *testing.T) {
+	items := []db.Order{
+		{ID: uuid.New(), ProductName: "Pizza", ProductPrice: "10.00", Amount: 1, TaxRate: "5.00"},
+		{ID: uuid.New(), ProductName: "Lunch deal", ProductPrice: "-1.01", Amount: 1, TaxRate: "5.00", PromotionID: uuid.NullUUID{UUID: uuid.New(), Valid: true}},
+	}
+
+	// the pizza goes to the first check and the discount is shared three
+	// ways, so the shares of the other checks are negative cents and a bit
+	totals, err := Split(items, 3, []Share{
+		{OrderItemID: items[0].ID, Check: 0, Parts: 1},
+		{OrderItemID: items[1].ID, Check: 0, Parts: 1},
+		{OrderItemID: items[1].ID, Check: 1, Parts: 1},
+		{OrderItemID: items[1].ID, Check: 2, Parts: 1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []CheckTotal{
+		{Subtotal: 967, Tax: 49, Total: 1016},
+		{Subtotal: -34, Tax: -2, Total: -36},
+		{Subtotal: -34, Tax: -2, Total: -36},
+	}, totals)
+
+	receipt, err := Build(testShop(t, "en-US"), items, nil)
+	require.NoError(t, err)
+	require.Equal(t, receipt.Total, totals[0].Total+totals[1].Total+totals[2].Total)
+}
+
+func TestSplitSumsToReceipt(t *testing.T) {
+	rates := []string{"0.00", "2.50", "5.00", "12.50"}
+
+	for n := 0; n < 50; n++ {
+		var items []db.Order
+		for i := 0; i < utils.RandomInt(1, 6); i++ {
+			items = append(items, db.Order{
+				ID:           uuid.New(),
+				ProductName:  utils.RandString(6),
+				ProductPrice: fmt.Sprintf("%d.%02d", utils.RandomInt(0, 40), utils.RandomInt(0, 99)),
+				Amount:       utils.RandomInt32(1, 4),
+				TaxRate:      rates[utils.RandomInt(0, len(rates)-1)],
+			})
+		}
+
+		checks := utils.RandomInt(1, 7)
+		var shares []Share
+		for _, item := range items {
+			// every item lands on at least one check
+			shares = append(shares, Share{OrderItemID: item.ID, Check: utils.RandomInt(0, checks-1), Parts: int64(utils.RandomInt(1, 3))})
+			if utils.RandomInt(0, 1) == 1 {
+				shares = append(shares, Share{OrderItemID: item.ID, Check: utils.RandomInt(0, checks-1), Parts: int64(utils.RandomInt(1, 3))})
+			}
+		}
+
+		totals, err := Split(items, checks, shares)
+		require.NoError(t, err)
+
+		receipt, err := Build(testShop(t, "en-US"), items, nil)
+		require.NoError(t, err)
+
+		var subtotal, tax, total int64
+		for _, check := range totals {
+			subtotal += check.Subtotal
+			tax += check.Tax
+			total += check.Total
+		}
+		require.Equal(t, receipt.Subtotal, subtotal)
+		require.Equal(t, receipt.Tax, tax)
+		require.Equal(t, receipt.Total, total)
+	}
+}
+
+func TestSplitInvalidShare(t *testing.T) {
+	items := []db.Order{{ID: uuid.New(), ProductPrice: "1.00", Amount: 1, TaxRate: "0.00"}}
+
+	_, err := Split(items, 2, []Share{{OrderItemID: items[0].ID, Check: 2, Parts: 1}})
+	require.ErrorIs(t, err, ErrInvalidShare)
+
+	_, err = Split(items, 2, []Share{{OrderItemID: items[0].ID, Check: 0, Parts: 0}})
+	require.ErrorIs(t, err, ErrInvalidShare)
+}
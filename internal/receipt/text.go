@@ -0,0 +1,23 @@
+package receipt
+
+import (
+	"bufio"
+	"io"
+)
+
+// plain text receipt for printers driven as a text device or for email
+func WriteText(w io.Writer, receipt Receipt, width Width) error {
+	buf := bufio.NewWriter(w)
+
+	for _, row := range receipt.rows(width.Columns()) {
+		text := row.text
+		if row.centered {
+			text = centered(text, width.Columns())
+		}
+		if _, err := buf.WriteString(text + "\n"); err != nil {
+			return err
+		}
+	}
+
+	return buf.Flush()
+}
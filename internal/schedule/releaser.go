@@ -0,0 +1,94 @@
+package schedule
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/event"
+)
+
+const DefaultInterval = 30 * time.Second
+
+// ReleasedTicket is the kitchen.ticket_created event of a held ticket
+type ReleasedTicket struct {
+	db.KitchenTicket
+	Items []db.ListKitchenTicketItemsRow `json:"items"`
+}
+
+// Releaser hands the kitchen tickets of scheduled orders to the kitchen once
+// the lead time before their slot is reached, the kitchen screens learn of
+// them through a kitchen.ticket_created event like any new ticket
+type Releaser struct {
+	store    db.Store
+	hub      *event.Hub
+	interval time.Duration
+}
+
+func NewReleaser(store db.Store, hub *event.Hub, interval time.Duration) *Releaser {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	return &Releaser{
+		store:    store,
+		hub:      hub,
+		interval: interval,
+	}
+}
+
+// release due tickets on every tick until the context is cancelled
+func (releaser *Releaser) Run(ctx context.Context) {
+	ticker := time.NewTicker(releaser.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := releaser.Release(ctx, time.Now().UTC()); err != nil {
+			log.Println("cannot release kitchen tickets:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (releaser *Releaser) Release(ctx context.Context, now time.Time) error {
+	tickets, err := releaser.store.ReleaseDueKitchenTickets(ctx, now)
+	if err != nil || len(tickets) == 0 {
+		return err
+	}
+
+	ids := make([]uuid.UUID, len(tickets))
+	for i, ticket := range tickets {
+		ids[i] = ticket.ID
+	}
+	items, err := releaser.store.ListKitchenTicketItems(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	byTicket := make(map[uuid.UUID][]db.ListKitchenTicketItemsRow, len(tickets))
+	for _, item := range items {
+		byTicket[item.TicketID] = append(byTicket[item.TicketID], item)
+	}
+
+	for _, ticket := range tickets {
+		released := ReleasedTicket{KitchenTicket: ticket, Items: byTicket[ticket.ID]}
+		if released.Items == nil {
+			released.Items = []db.ListKitchenTicketItemsRow{}
+		}
+
+		releaser.hub.Publish(event.Event{
+			Type:     event.TypeTicketCreated,
+			ShopName: ticket.ShopName,
+			Data:     released,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/toml5566/go_pos_backend/internal/database"
+	mockdb "github.com/toml5566/go_pos_backend/internal/database/mock"
+	"github.com/toml5566/go_pos_backend/internal/event"
+	"github.com/toml5566/go_pos_backend/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRelease(t *testing.T) {
+	shopName := utils.RandString(6)
+	now := time.Date(2024, time.March, 4, 12, 10, 0, 0, time.UTC)
+	grill := db.KitchenTicket{ID: uuid.New(), ShopName: shopName, StationID: uuid.New(), Released: true, ReleaseAt: now}
+	bar := db.KitchenTicket{ID: uuid.New(), ShopName: shopName, StationID: uuid.New(), Released: true, ReleaseAt: now}
+	burger := db.ListKitchenTicketItemsRow{TicketID: grill.ID, OrderItemID: uuid.New(), ProductName: "Burger", Amount: 2}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ReleaseDueKitchenTickets(gomock.Any(), gomock.Eq(now)).
+		Times(1).
+		Return([]db.KitchenTicket{grill, bar}, nil)
+	store.EXPECT().
+		ListKitchenTicketItems(gomock.Any(), gomock.Eq([]uuid.UUID{grill.ID, bar.ID})).
+		Times(1).
+		Return([]db.ListKitchenTicketItemsRow{burger}, nil)
+
+	hub := event.NewHub()
+	events, unsubscribe := hub.Subscribe(shopName)
+	defer unsubscribe()
+
+	releaser := NewReleaser(store, hub, 0)
+	require.NoError(t, releaser.Release(context.Background(), now))
+
+	e := <-events
+	require.Equal(t, event.TypeTicketCreated, e.Type)
+	require.Equal(t, ReleasedTicket{KitchenTicket: grill, Items: []db.ListKitchenTicketItemsRow{burger}}, e.Data)
+	e = <-events
+	require.Equal(t, bar.ID, e.Data.(ReleasedTicket).ID)
+	require.Empty(t, e.Data.(ReleasedTicket).Items)
+}
+
+func TestReleaseNothingDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ReleaseDueKitchenTickets(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.KitchenTicket{}, nil)
+	store.EXPECT().
+		ListKitchenTicketItems(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	releaser := NewReleaser(store, event.NewHub(), 0)
+	require.NoError(t, releaser.Release(context.Background(), time.Now()))
+}
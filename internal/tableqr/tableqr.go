@@ -0,0 +1,47 @@
+package tableqr
+
+import (
+	"bytes"
+	"fmt"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// PNG image of the link, size pixels wide and high
+func PNG(link string, size int) ([]byte, error) {
+	code, err := qrcode.New(link, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	return code.PNG(size)
+}
+
+// SVG image of the link. every dark module run of a row is one rect on a
+// grid of one unit per module, so the image scales without blurring.
+func SVG(link string) ([]byte, error) {
+	code, err := qrcode.New(link, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	bitmap := code.Bitmap()
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, len(bitmap), len(bitmap))
+	buf.WriteString(`<rect width="100%" height="100%" fill="#fff"/>`)
+	for y, row := range bitmap {
+		for x := 0; x < len(row); {
+			if !row[x] {
+				x++
+				continue
+			}
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="1"/>`, start, y, x-start)
+		}
+	}
+	buf.WriteString("</svg>\n")
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,37 @@
+package tableqr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testLink = "https://order.example.com/harbourcafe/order?table_token=abc.1.def"
+
+func TestPNG(t *testing.T) {
+	data, err := PNG(testLink, 256)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, 256, img.Bounds().Dx())
+	require.Equal(t, 256, img.Bounds().Dy())
+}
+
+func TestSVG(t *testing.T) {
+	data, err := SVG(testLink)
+	require.NoError(t, err)
+
+	svg := string(data)
+	require.True(t, strings.HasPrefix(svg, "<svg "))
+	require.True(t, strings.HasSuffix(svg, "</svg>\n"))
+	require.Contains(t, svg, `height="1"`)
+
+	// the same link always draws the same code
+	again, err := SVG(testLink)
+	require.NoError(t, err)
+	require.Equal(t, data, again)
+}
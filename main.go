@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 
 	"github.com/toml5566/go_pos_backend/api"
+	"github.com/toml5566/go_pos_backend/internal/alert"
 	db "github.com/toml5566/go_pos_backend/internal/database"
+	"github.com/toml5566/go_pos_backend/internal/event"
+	"github.com/toml5566/go_pos_backend/internal/printing"
+	"github.com/toml5566/go_pos_backend/internal/schedule"
 	"github.com/toml5566/go_pos_backend/utils"
 
 	_ "github.com/lib/pq"
@@ -23,7 +28,18 @@ func main() {
 	}
 
 	store := db.NewStore(conn)
-	server, err := api.NewServer(config, store)
+	hub := event.NewHub()
+
+	evaluator := alert.NewEvaluator(store, hub, alert.LogNotifier{}, config.AlertInterval)
+	go evaluator.Run(context.Background())
+
+	printWorker := printing.NewWorker(store, hub, config.PrintInterval)
+	go printWorker.Run(context.Background())
+
+	releaser := schedule.NewReleaser(store, hub, config.ReleaseInterval)
+	go releaser.Run(context.Background())
+
+	server, err := api.NewServer(config, store, hub)
 	if err != nil {
 		log.Fatal("cannot start server", err)
 	}
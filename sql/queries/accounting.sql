@@ -0,0 +1,39 @@
+-- name: GetAccountMapping :one
+SELECT * FROM account_mappings
+WHERE shop_name = $1 LIMIT 1;
+
+-- name: UpsertAccountMapping :one
+INSERT INTO account_mappings (
+  shop_name, cash_clearing, card_clearing, other_clearing, sales,
+  sales_by_tax_rate, tax_payable, refund_clearing, receivable
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (shop_name) DO UPDATE
+SET cash_clearing = EXCLUDED.cash_clearing,
+    card_clearing = EXCLUDED.card_clearing,
+    other_clearing = EXCLUDED.other_clearing,
+    sales = EXCLUDED.sales,
+    sales_by_tax_rate = EXCLUDED.sales_by_tax_rate,
+    tax_payable = EXCLUDED.tax_payable,
+    refund_clearing = EXCLUDED.refund_clearing,
+    receivable = EXCLUDED.receivable,
+    updated_at = now()
+RETURNING *;
+
+-- name: ListZReportsByBusinessDay :many
+SELECT * FROM z_reports
+WHERE shop_name = sqlc.arg(shop_name)
+AND business_day >= sqlc.arg(from_day) AND business_day <= sqlc.arg(to_day)
+ORDER BY business_day;
+
+-- name: GetDailySalesByTaxRate :many
+SELECT
+  tax_rate,
+  SUM(product_price * amount)::numeric AS sales,
+  COALESCE(SUM(product_price * amount) FILTER (WHERE status = 'refunded'), 0)::numeric AS refunds,
+  ROUND(SUM(product_price * amount * tax_rate / 100), 2)::numeric AS tax,
+  ROUND(COALESCE(SUM(product_price * amount * tax_rate / 100) FILTER (WHERE status = 'refunded'), 0), 2)::numeric AS refunded_tax
+FROM orders
+WHERE shop_name = $1 AND order_day = $2
+GROUP BY tax_rate
+ORDER BY tax_rate;
@@ -0,0 +1,75 @@
+-- name: GetProductMix :many
+SELECT
+  o.product_name,
+  COALESCE(m.catalog, '')::varchar AS category,
+  SUM(o.amount)::bigint AS quantity,
+  SUM(o.product_price * o.amount)::numeric AS revenue,
+  ROUND(COALESCE(SUM(o.product_price * o.amount) / NULLIF(SUM(SUM(o.product_price * o.amount)) OVER (), 0), 0), 4)::numeric AS share
+FROM orders o
+LEFT JOIN LATERAL (
+  SELECT catalog FROM menus
+  WHERE menus.shop_name = o.shop_name AND menus.product_id = o.product_id
+  ORDER BY created_at
+  LIMIT 1
+) m ON true
+WHERE o.shop_name = sqlc.arg(shop_name) AND o.status <> 'refunded' AND o.promotion_id IS NULL AND o.fee_id IS NULL
+AND o.order_day >= sqlc.arg(from_day) AND o.order_day <= sqlc.arg(to_day)
+GROUP BY o.product_name, m.catalog
+ORDER BY revenue DESC, o.product_name;
+
+-- name: GetCategoryMix :many
+SELECT
+  COALESCE(m.catalog, '')::varchar AS category,
+  SUM(o.amount)::bigint AS quantity,
+  SUM(o.product_price * o.amount)::numeric AS revenue,
+  ROUND(COALESCE(SUM(o.product_price * o.amount) / NULLIF(SUM(SUM(o.product_price * o.amount)) OVER (), 0), 0), 4)::numeric AS share
+FROM orders o
+LEFT JOIN LATERAL (
+  SELECT catalog FROM menus
+  WHERE menus.shop_name = o.shop_name AND menus.product_id = o.product_id
+  ORDER BY created_at
+  LIMIT 1
+) m ON true
+WHERE o.shop_name = sqlc.arg(shop_name) AND o.status <> 'refunded' AND o.promotion_id IS NULL AND o.fee_id IS NULL
+AND o.order_day >= sqlc.arg(from_day) AND o.order_day <= sqlc.arg(to_day)
+GROUP BY m.catalog
+ORDER BY revenue DESC, category;
+
+-- name: GetSalesHeatmap :many
+SELECT
+  EXTRACT(ISODOW FROM o.order_day::date)::integer AS day_of_week,
+  EXTRACT(HOUR FROM (COALESCE(f.scheduled_for, o.created_at) AT TIME ZONE 'UTC') AT TIME ZONE sqlc.arg(timezone)::varchar)::integer AS hour,
+  COUNT(DISTINCT o.order_id) AS order_count,
+  SUM(o.amount)::bigint AS quantity,
+  SUM(o.product_price * o.amount)::numeric AS revenue
+FROM orders o
+LEFT JOIN order_fulfilments f ON f.shop_name = o.shop_name AND f.order_id = o.order_id
+WHERE o.shop_name = sqlc.arg(shop_name) AND o.status <> 'refunded' AND o.promotion_id IS NULL AND o.fee_id IS NULL
+AND o.order_day >= sqlc.arg(from_day) AND o.order_day <= sqlc.arg(to_day)
+GROUP BY day_of_week, hour
+ORDER BY day_of_week, hour;
+
+-- name: GetSalesComparison :one
+SELECT
+  COALESCE(SUM(product_price * amount) FILTER (WHERE order_day >= sqlc.arg(from_day)), 0)::numeric AS current_revenue,
+  COALESCE(SUM(amount) FILTER (WHERE order_day >= sqlc.arg(from_day)), 0)::bigint AS current_quantity,
+  COUNT(DISTINCT order_id) FILTER (WHERE order_day >= sqlc.arg(from_day)) AS current_order_count,
+  COALESCE(SUM(product_price * amount) FILTER (WHERE order_day < sqlc.arg(from_day)), 0)::numeric AS previous_revenue,
+  COALESCE(SUM(amount) FILTER (WHERE order_day < sqlc.arg(from_day)), 0)::bigint AS previous_quantity,
+  COUNT(DISTINCT order_id) FILTER (WHERE order_day < sqlc.arg(from_day)) AS previous_order_count
+FROM orders
+WHERE shop_name = sqlc.arg(shop_name) AND status <> 'refunded' AND promotion_id IS NULL AND fee_id IS NULL
+AND order_day >= sqlc.arg(previous_from_day) AND order_day <= sqlc.arg(to_day);
+
+-- name: GetOrderTypeMix :many
+SELECT
+  order_type,
+  COUNT(DISTINCT order_id) AS order_count,
+  SUM(amount)::bigint AS quantity,
+  SUM(product_price * amount)::numeric AS revenue,
+  ROUND(COALESCE(SUM(product_price * amount) / NULLIF(SUM(SUM(product_price * amount)) OVER (), 0), 0), 4)::numeric AS share
+FROM orders
+WHERE shop_name = sqlc.arg(shop_name) AND status <> 'refunded' AND promotion_id IS NULL AND fee_id IS NULL
+AND order_day >= sqlc.arg(from_day) AND order_day <= sqlc.arg(to_day)
+GROUP BY order_type
+ORDER BY revenue DESC, order_type;
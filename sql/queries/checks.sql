@@ -0,0 +1,33 @@
+-- name: CreateCheck :one
+INSERT INTO checks (id, shop_name, order_id, number, label)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: AddCheckLine :exec
+INSERT INTO check_lines (check_id, order_item_id, parts)
+VALUES ($1, $2, $3);
+
+-- name: ListChecks :many
+SELECT * FROM checks
+WHERE shop_name = $1 AND order_id = $2
+ORDER BY number;
+
+-- name: ListChecksForUpdate :many
+SELECT * FROM checks
+WHERE shop_name = $1 AND order_id = $2
+ORDER BY number
+FOR UPDATE;
+
+-- name: ListCheckLines :many
+SELECT check_lines.* FROM check_lines
+JOIN checks ON checks.id = check_lines.check_id
+WHERE checks.shop_name = $1 AND checks.order_id = $2
+ORDER BY checks.number, check_lines.order_item_id;
+
+-- name: DeleteChecks :execrows
+DELETE FROM checks
+WHERE shop_name = $1 AND order_id = $2;
+
+-- name: CountCheckPayments :one
+SELECT COUNT(*) FROM payments
+WHERE shop_name = $1 AND order_id = $2 AND check_id IS NOT NULL;
@@ -0,0 +1,71 @@
+-- name: CreateCoupon :one
+INSERT INTO coupons (
+  id, shop_name, promotion_id, code, batch_id, max_redemptions,
+  max_per_customer, expires_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (shop_name, code) DO NOTHING
+RETURNING *;
+
+-- name: GetCouponByCode :one
+SELECT * FROM coupons
+WHERE shop_name = $1 AND code = $2 LIMIT 1;
+
+-- name: ListCoupons :many
+SELECT * FROM coupons
+WHERE shop_name = $1 AND promotion_id = $2
+ORDER BY created_at, code;
+
+-- name: SetCouponActive :one
+UPDATE coupons
+SET active = $3
+WHERE shop_name = $1 AND id = $2
+RETURNING *;
+
+-- name: SetCouponBatchActive :execrows
+UPDATE coupons
+SET active = $3
+WHERE shop_name = $1 AND batch_id = $2;
+
+-- name: RedeemCoupon :one
+UPDATE coupons
+SET redemptions = redemptions + 1
+WHERE id = sqlc.arg(id) AND active
+AND (expires_at IS NULL OR expires_at > sqlc.arg(at))
+AND (max_redemptions = 0 OR redemptions < max_redemptions)
+RETURNING *;
+
+-- name: LockCouponCustomer :exec
+SELECT pg_advisory_xact_lock(hashtextextended(sqlc.arg(batch_id)::uuid::text || ':' || sqlc.arg(customer)::text, 0));
+
+-- name: CountCustomerRedemptions :one
+SELECT count(*) FROM coupon_redemptions r
+JOIN coupons c ON c.id = r.coupon_id
+WHERE r.customer = sqlc.arg(customer)
+AND (c.id = sqlc.arg(coupon_id) OR c.batch_id = sqlc.narg(batch_id));
+
+-- name: CreateCouponRedemption :one
+INSERT INTO coupon_redemptions (
+  id, coupon_id, promotion_id, order_id, customer
+)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetCouponStats :one
+SELECT
+  count(*) AS codes,
+  count(*) FILTER (WHERE c.active) AS active_codes,
+  count(*) FILTER (WHERE c.redemptions > 0) AS redeemed_codes,
+  COALESCE(SUM(c.redemptions), 0)::bigint AS redemptions,
+  (
+    SELECT count(DISTINCT r.customer) FROM coupon_redemptions r
+    WHERE r.promotion_id = sqlc.arg(promotion_id) AND r.customer <> ''
+  ) AS customers,
+  (
+    SELECT COALESCE(-SUM(o.product_price * o.amount), 0)::DECIMAL(12,2) FROM coupon_redemptions r
+    JOIN orders o ON o.order_id = r.order_id AND o.promotion_id = r.promotion_id
+    WHERE r.promotion_id = sqlc.arg(promotion_id) AND o.shop_name = sqlc.arg(shop_name)
+    AND o.status <> 'refunded'
+  ) AS discounts
+FROM coupons c
+WHERE c.shop_name = sqlc.arg(shop_name) AND c.promotion_id = sqlc.arg(promotion_id);
@@ -0,0 +1,45 @@
+-- name: CreateOrderFulfilment :one
+INSERT INTO order_fulfilments (id, shop_name, order_id, order_type, pickup_at, delivery_address, contact_name, contact_phone, scheduled_for)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetOrderFulfilment :one
+SELECT * FROM order_fulfilments
+WHERE shop_name = $1 AND order_id = $2 LIMIT 1;
+
+-- name: GetOrderFulfilmentForUpdate :one
+SELECT * FROM order_fulfilments
+WHERE shop_name = $1 AND order_id = $2 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: SetOrderFulfilmentStatus :one
+UPDATE order_fulfilments
+SET status = sqlc.arg(status), updated_at = now()
+WHERE shop_name = sqlc.arg(shop_name) AND order_id = sqlc.arg(order_id) AND status = sqlc.arg(from_status)
+RETURNING *;
+
+-- name: ListActiveFulfilments :many
+SELECT * FROM order_fulfilments
+WHERE shop_name = sqlc.arg(shop_name)
+AND (sqlc.arg(order_type)::varchar = '' OR order_type = sqlc.arg(order_type))
+AND status NOT IN ('served', 'picked_up', 'delivered', 'cancelled')
+ORDER BY COALESCE(pickup_at, created_at), created_at;
+
+-- name: CreateOrderTypeFee :one
+INSERT INTO order_type_fees (id, shop_name, order_type, name, kind, amount, tax_rate)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListOrderTypeFees :many
+SELECT * FROM order_type_fees
+WHERE shop_name = $1
+ORDER BY order_type, name;
+
+-- name: ListOrderTypeFeesByType :many
+SELECT * FROM order_type_fees
+WHERE shop_name = $1 AND order_type = $2
+ORDER BY created_at, name;
+
+-- name: DeleteOrderTypeFee :exec
+DELETE FROM order_type_fees
+WHERE shop_name = $1 AND id = $2;
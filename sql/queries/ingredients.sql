@@ -0,0 +1,64 @@
+-- name: CreateIngredient :one
+INSERT INTO ingredients (id, shop_name, name, unit)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetIngredient :one
+SELECT * FROM ingredients
+WHERE shop_name = $1 AND id = $2 LIMIT 1;
+
+-- name: GetIngredientForUpdate :one
+SELECT * FROM ingredients
+WHERE shop_name = $1 AND id = $2 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListIngredients :many
+SELECT * FROM ingredients
+WHERE shop_name = $1
+ORDER BY name;
+
+-- name: AddIngredientStock :one
+UPDATE ingredients
+SET on_hand = on_hand + $3
+WHERE shop_name = $1 AND id = $2
+RETURNING *;
+
+-- name: SetIngredientStock :one
+UPDATE ingredients
+SET on_hand = $3
+WHERE shop_name = $1 AND id = $2
+RETURNING *;
+
+-- name: CreateIngredientMovement :one
+INSERT INTO ingredient_movements (id, shop_name, ingredient_id, movement_type, quantity, order_item_id, note)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListIngredientMovements :many
+SELECT * FROM ingredient_movements
+WHERE shop_name = $1 AND ingredient_id = $2
+ORDER BY created_at DESC
+LIMIT $3
+OFFSET $4;
+
+-- name: ListIngredientSalesByOrderItem :many
+SELECT * FROM ingredient_movements
+WHERE order_item_id = $1 AND movement_type = 'sale'
+ORDER BY ingredient_id;
+
+-- name: GetIngredientUsageReport :many
+SELECT
+  i.id AS ingredient_id,
+  i.name,
+  i.unit,
+  (-COALESCE(SUM(m.quantity) FILTER (WHERE m.movement_type IN ('sale', 'refund')), 0))::numeric AS theoretical_usage,
+  (-COALESCE(SUM(m.quantity) FILTER (WHERE m.movement_type <> 'receive'), 0))::numeric AS actual_usage,
+  (-COALESCE(SUM(m.quantity) FILTER (WHERE m.movement_type IN ('waste', 'adjustment', 'count')), 0))::numeric AS variance
+FROM ingredients i
+LEFT JOIN ingredient_movements m
+  ON m.ingredient_id = i.id
+  AND m.created_at >= sqlc.arg(from_time)
+  AND m.created_at < sqlc.arg(to_time)
+WHERE i.shop_name = sqlc.arg(shop_name)
+GROUP BY i.id
+ORDER BY i.name;
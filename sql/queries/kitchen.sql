@@ -0,0 +1,100 @@
+-- name: CreateStation :one
+INSERT INTO stations (id, shop_name, name, is_default)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListStations :many
+SELECT * FROM stations
+WHERE shop_name = $1
+ORDER BY name;
+
+-- name: DeleteStation :execrows
+DELETE FROM stations
+WHERE shop_name = $1 AND id = $2;
+
+-- name: UpsertCatalogRoute :one
+INSERT INTO station_routes (id, shop_name, station_id, catalog)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (shop_name, catalog) DO UPDATE
+SET station_id = EXCLUDED.station_id
+RETURNING *;
+
+-- name: UpsertProductRoute :one
+INSERT INTO station_routes (id, shop_name, station_id, product_id)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (shop_name, product_id) DO UPDATE
+SET station_id = EXCLUDED.station_id
+RETURNING *;
+
+-- name: ListStationRoutes :many
+SELECT * FROM station_routes
+WHERE shop_name = $1
+ORDER BY created_at, id;
+
+-- name: DeleteStationRoute :execrows
+DELETE FROM station_routes
+WHERE shop_name = $1 AND id = $2;
+
+-- name: CreateKitchenTicket :one
+INSERT INTO kitchen_tickets (id, shop_name, order_id, station_id, release_at, released)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: AddKitchenTicketItem :exec
+INSERT INTO kitchen_ticket_items (ticket_id, order_item_id)
+VALUES ($1, $2);
+
+-- name: ListKitchenTickets :many
+SELECT * FROM kitchen_tickets
+WHERE shop_name = sqlc.arg(shop_name) AND status = sqlc.arg(status) AND released
+AND (sqlc.arg(station_id)::uuid = '00000000-0000-0000-0000-000000000000' OR station_id = sqlc.arg(station_id))
+ORDER BY created_at, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListKitchenTicketsByOrderID :many
+SELECT * FROM kitchen_tickets
+WHERE shop_name = $1 AND order_id = $2
+ORDER BY created_at, id;
+
+-- name: ListKitchenTicketItems :many
+SELECT i.ticket_id, o.id AS order_item_id, o.product_name, o.amount, o.status
+FROM kitchen_ticket_items i
+JOIN orders o ON o.id = i.order_item_id
+WHERE i.ticket_id = ANY(sqlc.arg(ticket_ids)::uuid[])
+ORDER BY o.created_at, o.id;
+
+-- name: SetKitchenTicketStatus :one
+UPDATE kitchen_tickets
+SET status = sqlc.arg(status),
+    bumped_at = CASE WHEN sqlc.arg(status)::varchar = 'bumped' THEN COALESCE(bumped_at, now()) END
+WHERE shop_name = sqlc.arg(shop_name) AND id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ReleaseDueKitchenTickets :many
+UPDATE kitchen_tickets
+SET released = true, created_at = release_at
+WHERE NOT released AND release_at <= sqlc.arg(now)
+RETURNING *;
+
+-- name: CountOpenKitchenTickets :one
+SELECT COUNT(*) FROM kitchen_tickets
+WHERE shop_name = $1 AND order_id = $2 AND status = 'open';
+
+-- name: GetStation :one
+SELECT * FROM stations
+WHERE shop_name = $1 AND id = $2 LIMIT 1;
+
+-- name: ListOpenKitchenTicketPrep :many
+SELECT t.id, t.order_id, t.station_id, t.release_at,
+       COALESCE(SUM(p.prep_seconds * o.amount), 0)::bigint AS prep_seconds
+FROM kitchen_tickets t
+LEFT JOIN kitchen_ticket_items i ON i.ticket_id = t.id
+LEFT JOIN orders o ON o.id = i.order_item_id AND o.status <> 'refunded'
+LEFT JOIN products p ON p.id = COALESCE(o.product_id, (
+  SELECT m.product_id FROM menus m
+  WHERE m.shop_name = o.shop_name AND m.product_name = o.product_name
+  LIMIT 1
+))
+WHERE t.shop_name = $1 AND t.status = 'open' AND t.released
+GROUP BY t.id
+ORDER BY t.release_at, t.id;
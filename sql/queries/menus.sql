@@ -1,19 +1,46 @@
 -- name: AddMenuItem :one
-INSERT INTO menus (id, user_id, shop_name, product_id, product_name, product_price, catalog, description)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO menus (id, shop_name, product_id, product_name, product_price, catalog, description)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
 -- name: UpdateMenuItem :one
 UPDATE menus
 SET product_name = $3, product_price = $4, catalog = $5, description = $6
-WHERE user_id = $1 AND id = $2
+WHERE shop_name = $1 AND id = $2
 RETURNING *;
 
 -- name: DeleteMenuItem :exec
 DELETE FROM menus
-WHERE user_id = $1 AND id = $2;
+WHERE shop_name = $1 AND id = $2;
+
+-- name: GetMenuItem :one
+SELECT * FROM menus
+WHERE shop_name = $1 AND id = $2 LIMIT 1;
 
 -- name: GetAllMenuItems :many
 SELECT * FROM menus 
 WHERE shop_name = $1;
 
+
+-- name: SetMenuItemAvailability :one
+UPDATE menus
+SET available = $3
+WHERE shop_name = $1 AND id = $2
+RETURNING *;
+
+-- name: MarkProductSoldOut :exec
+UPDATE menus
+SET available = false
+WHERE shop_name = $1 AND product_id = $2;
+
+-- name: ListUnavailableMenuItems :many
+SELECT * FROM menus
+WHERE shop_name = sqlc.arg(shop_name) AND available = false
+AND (product_id = ANY(sqlc.arg(product_ids)::uuid[]) OR product_name = ANY(sqlc.arg(product_names)::varchar[]));
+
+-- name: ListMenuPrices :many
+SELECT m.product_id, m.product_name, m.product_price, COALESCE(p.tax_rate, 0.00)::numeric AS tax_rate
+FROM menus m
+LEFT JOIN products p ON p.id = m.product_id
+WHERE m.shop_name = $1
+ORDER BY m.created_at, m.id;
@@ -1,6 +1,6 @@
 -- name: CreateOrderItem :one
-INSERT INTO orders (id, shop_name, order_id, order_day, product_name, product_price, amount, status)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO orders (id, shop_name, order_id, order_day, product_name, product_price, amount, status, product_id, tax_rate, seat, order_type, price_list_id, promotion_id, fee_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 RETURNING *;
 
 -- name: UpdateOrderItem :one
@@ -9,6 +9,21 @@ SET amount = $3, status = $4
 WHERE shop_name = $1 AND id = $2
 RETURNING *;
 
+-- name: UpdateOrderItemPrice :one
+UPDATE orders
+SET product_price = $3
+WHERE shop_name = $1 AND id = $2
+RETURNING *;
+
+-- name: GetOrderItem :one
+SELECT * FROM orders
+WHERE shop_name = $1 AND id = $2 LIMIT 1;
+
+-- name: GetOrderItemForUpdate :one
+SELECT * FROM orders
+WHERE shop_name = $1 AND id = $2 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: DeleteOrderItem :exec
 DELETE FROM orders
 WHERE shop_name = $1 AND id = $2;
@@ -19,4 +34,48 @@ WHERE shop_name = $1 AND order_day = $2;
 
 -- name: GetOrdersByOrderID :many
 SELECT * FROM orders
-WHERE shop_name = $1 AND order_id = $2;
\ No newline at end of file
+WHERE shop_name = $1 AND order_id = $2;
+
+-- name: ListOrderHistoryByCreatedAtAsc :many
+SELECT * FROM orders
+WHERE shop_name = sqlc.arg(shop_name)
+AND order_day >= sqlc.arg(from_day) AND order_day <= sqlc.arg(to_day)
+AND (sqlc.arg(status)::varchar = '' OR status = sqlc.arg(status))
+AND (sqlc.arg(product_name)::varchar = '' OR product_name ILIKE '%' || sqlc.arg(product_name) || '%')
+AND amount >= sqlc.arg(min_amount) AND amount <= sqlc.arg(max_amount)
+AND (created_at, id) > (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::uuid)
+ORDER BY created_at ASC, id ASC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListOrderHistoryByCreatedAtDesc :many
+SELECT * FROM orders
+WHERE shop_name = sqlc.arg(shop_name)
+AND order_day >= sqlc.arg(from_day) AND order_day <= sqlc.arg(to_day)
+AND (sqlc.arg(status)::varchar = '' OR status = sqlc.arg(status))
+AND (sqlc.arg(product_name)::varchar = '' OR product_name ILIKE '%' || sqlc.arg(product_name) || '%')
+AND amount >= sqlc.arg(min_amount) AND amount <= sqlc.arg(max_amount)
+AND (created_at, id) < (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListOrderHistoryByAmountAsc :many
+SELECT * FROM orders
+WHERE shop_name = sqlc.arg(shop_name)
+AND order_day >= sqlc.arg(from_day) AND order_day <= sqlc.arg(to_day)
+AND (sqlc.arg(status)::varchar = '' OR status = sqlc.arg(status))
+AND (sqlc.arg(product_name)::varchar = '' OR product_name ILIKE '%' || sqlc.arg(product_name) || '%')
+AND amount >= sqlc.arg(min_amount) AND amount <= sqlc.arg(max_amount)
+AND (amount, id) > (sqlc.arg(cursor_amount)::integer, sqlc.arg(cursor_id)::uuid)
+ORDER BY amount ASC, id ASC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListOrderHistoryByAmountDesc :many
+SELECT * FROM orders
+WHERE shop_name = sqlc.arg(shop_name)
+AND order_day >= sqlc.arg(from_day) AND order_day <= sqlc.arg(to_day)
+AND (sqlc.arg(status)::varchar = '' OR status = sqlc.arg(status))
+AND (sqlc.arg(product_name)::varchar = '' OR product_name ILIKE '%' || sqlc.arg(product_name) || '%')
+AND amount >= sqlc.arg(min_amount) AND amount <= sqlc.arg(max_amount)
+AND (amount, id) < (sqlc.arg(cursor_amount)::integer, sqlc.arg(cursor_id)::uuid)
+ORDER BY amount DESC, id DESC
+LIMIT sqlc.arg(page_size);
\ No newline at end of file
@@ -0,0 +1,104 @@
+-- name: CreateOrganisation :one
+INSERT INTO organisations (id, name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetOrganisation :one
+SELECT * FROM organisations
+WHERE id = $1 LIMIT 1;
+
+-- name: CreateShop :one
+INSERT INTO shops (id, organisation_id, name, display_name, currency, timezone, locale)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetShop :one
+SELECT * FROM shops
+WHERE id = $1 LIMIT 1;
+
+-- name: GetShopByName :one
+SELECT * FROM shops
+WHERE name = $1 LIMIT 1;
+
+-- name: GetShopForUpdate :one
+SELECT * FROM shops
+WHERE name = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetShopForShare :one
+SELECT * FROM shops
+WHERE name = $1 LIMIT 1
+FOR SHARE;
+
+-- name: ListOrganisationShops :many
+SELECT * FROM shops
+WHERE organisation_id = $1
+ORDER BY name;
+
+-- name: GetMemberShop :one
+SELECT s.* FROM shops s
+JOIN organisation_members m ON m.organisation_id = s.organisation_id
+JOIN users u ON u.id = m.user_id
+WHERE s.id = sqlc.arg(shop_id) AND u.username = sqlc.arg(username)
+AND (m.shop_id IS NULL OR m.shop_id = s.id)
+LIMIT 1;
+
+-- name: ListMemberShops :many
+SELECT s.* FROM shops s
+JOIN organisation_members m ON m.organisation_id = s.organisation_id
+JOIN users u ON u.id = m.user_id
+WHERE u.username = sqlc.arg(username)
+AND (m.shop_id IS NULL OR m.shop_id = s.id)
+ORDER BY s.name;
+
+-- name: AddOrganisationMember :one
+INSERT INTO organisation_members (organisation_id, user_id, shop_id, role)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetOrganisationMember :one
+SELECT m.organisation_id, m.user_id, m.shop_id, m.role, m.created_at FROM organisation_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.organisation_id = sqlc.arg(organisation_id) AND u.username = sqlc.arg(username)
+LIMIT 1;
+
+-- name: ListOrganisationMembers :many
+SELECT m.user_id, u.username, m.shop_id, m.role, m.created_at FROM organisation_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.organisation_id = $1
+ORDER BY u.username;
+
+-- name: DeleteOrganisationMember :execrows
+DELETE FROM organisation_members
+WHERE organisation_id = $1 AND user_id = $2 AND role <> 'owner';
+
+-- name: UpdateShopTimezone :one
+UPDATE shops
+SET timezone = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateShopLocale :one
+UPDATE shops
+SET locale = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateShopBusinessDayCutoff :one
+UPDATE shops
+SET business_day_cutoff = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateShopReceipt :one
+UPDATE shops
+SET receipt_header = $2, receipt_footer = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateShopProfile :one
+UPDATE shops
+SET display_name = $2, address = $3, phone = $4, currency = $5, timezone = $6,
+    logo_url = $7, receipt_header = $8, receipt_footer = $9
+WHERE id = $1
+RETURNING *;
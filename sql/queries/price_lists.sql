@@ -0,0 +1,68 @@
+-- name: CreatePriceList :one
+INSERT INTO price_lists (id, shop_name, name, priority, active)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: UpdatePriceList :one
+UPDATE price_lists
+SET name = $3, priority = $4, active = $5
+WHERE shop_name = $1 AND id = $2
+RETURNING *;
+
+-- name: GetPriceList :one
+SELECT * FROM price_lists
+WHERE shop_name = $1 AND id = $2 LIMIT 1;
+
+-- name: ListPriceLists :many
+SELECT * FROM price_lists
+WHERE shop_name = $1
+ORDER BY priority DESC;
+
+-- name: DeletePriceList :exec
+DELETE FROM price_lists
+WHERE shop_name = $1 AND id = $2;
+
+-- name: AddPriceListRule :one
+INSERT INTO price_list_rules (id, price_list_id, channel, order_type, weekday, starts_at, ends_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: DeletePriceListRules :exec
+DELETE FROM price_list_rules
+WHERE price_list_id = $1;
+
+-- name: ListPriceListRules :many
+SELECT r.* FROM price_list_rules r
+JOIN price_lists l ON l.id = r.price_list_id
+WHERE l.shop_name = $1
+ORDER BY r.price_list_id, r.weekday, r.starts_at, r.id;
+
+-- name: ListActivePriceListRules :many
+SELECT r.* FROM price_list_rules r
+JOIN price_lists l ON l.id = r.price_list_id
+WHERE l.shop_name = $1 AND l.active
+ORDER BY l.priority DESC, r.weekday, r.starts_at, r.id;
+
+-- name: AddPriceListPrice :one
+INSERT INTO price_list_prices (id, price_list_id, product_id, menu_item_id, price)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: DeletePriceListPrices :exec
+DELETE FROM price_list_prices
+WHERE price_list_id = $1;
+
+-- name: ListPriceListPrices :many
+SELECT * FROM price_list_prices
+WHERE price_list_id = $1
+ORDER BY menu_item_id, product_id;
+
+-- name: ListOrderPrices :many
+SELECT p.price_list_id, p.price, COALESCE(p.product_id, m.product_id)::uuid AS product_id,
+       COALESCE(m.product_name, pr.name, '')::varchar AS product_name, (p.menu_item_id IS NOT NULL)::boolean AS menu_item,
+       COALESCE(pr.tax_rate, 0.00)::numeric AS tax_rate
+FROM price_list_prices p
+LEFT JOIN menus m ON m.id = p.menu_item_id
+LEFT JOIN products pr ON pr.id = COALESCE(p.product_id, m.product_id)
+WHERE p.price_list_id = ANY(sqlc.arg(price_list_ids)::uuid[])
+ORDER BY p.price_list_id, p.menu_item_id IS NULL, m.created_at, p.id;
@@ -0,0 +1,67 @@
+-- name: CreatePrinter :one
+INSERT INTO printers (id, shop_name, name, address, paper_width, station_id, prints_receipts)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetPrinter :one
+SELECT * FROM printers
+WHERE shop_name = $1 AND id = $2 LIMIT 1;
+
+-- name: ListPrinters :many
+SELECT * FROM printers
+WHERE shop_name = $1
+ORDER BY name;
+
+-- name: DeletePrinter :execrows
+DELETE FROM printers
+WHERE shop_name = $1 AND id = $2;
+
+-- name: CreatePrintJob :one
+INSERT INTO print_jobs (id, shop_name, printer_id, kind, reference_id, payload)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: CreateDelayedPrintJob :one
+INSERT INTO print_jobs (id, shop_name, printer_id, kind, reference_id, payload, next_attempt_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetPrintJob :one
+SELECT * FROM print_jobs
+WHERE shop_name = $1 AND id = $2 LIMIT 1;
+
+-- name: ListPrintJobs :many
+SELECT * FROM print_jobs
+WHERE shop_name = sqlc.arg(shop_name)
+AND (sqlc.arg(status)::varchar = '' OR status = sqlc.arg(status))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: ClaimPrintJobs :many
+UPDATE print_jobs
+SET status = 'printing', attempts = attempts + 1
+WHERE id IN (
+  SELECT id FROM print_jobs
+  WHERE status = 'queued' AND next_attempt_at <= now()
+  ORDER BY next_attempt_at, created_at
+  LIMIT sqlc.arg(batch_size)
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: SetPrintJobPrinted :exec
+UPDATE print_jobs
+SET status = 'printed', last_error = '', printed_at = now()
+WHERE id = $1;
+
+-- name: SetPrintJobFailed :one
+UPDATE print_jobs
+SET status = sqlc.arg(status), last_error = sqlc.arg(last_error),
+    next_attempt_at = now() + sqlc.arg(retry_in_seconds)::int * interval '1 second'
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: RequeueInterruptedPrintJobs :execrows
+UPDATE print_jobs
+SET status = 'queued'
+WHERE status = 'printing';
@@ -1,26 +1,38 @@
 -- name: CreateProduct :one
-INSERT INTO products (id, user_id, name, price, description)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO products (id, organisation_id, name, price, description, tax_rate)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetProductsByName :many
 SELECT * FROM products
-WHERE user_id = $1 AND name = $2;
+WHERE organisation_id = $1 AND name = $2;
 
 -- name: GetAllProducts :many
 SELECT * FROM products
-WHERE user_id = $1;
+WHERE organisation_id = $1;
 
 -- name: GetProduct :one
 SELECT * FROM products
-WHERE user_id = $1 AND id = $2 LIMIT 1;
+WHERE organisation_id = $1 AND id = $2 LIMIT 1;
 
 -- name: UpdateProduct :one
 UPDATE products
-SET name = $3, price = $4, description = $5
-WHERE user_id = $1 AND id = $2
+SET name = $3, price = $4, description = $5, tax_rate = $6
+WHERE organisation_id = $1 AND id = $2
 RETURNING *;
 
 -- name: DeleteProduct :exec
 DELETE FROM products
-WHERE user_id = $1 AND id = $2;
\ No newline at end of file
+WHERE organisation_id = $1 AND id = $2;
+
+-- name: UpdateProductPrepTime :one
+UPDATE products
+SET prep_seconds = $3
+WHERE organisation_id = $1 AND id = $2
+RETURNING *;
+
+-- name: GetShopProduct :one
+SELECT p.* FROM products p
+JOIN shops s ON s.organisation_id = p.organisation_id
+WHERE s.name = sqlc.arg(shop_name) AND p.id = sqlc.arg(id)
+LIMIT 1;
@@ -0,0 +1,35 @@
+-- name: CreatePromotion :one
+INSERT INTO promotions (
+  id, shop_name, name, target, kind, amount, product_id, catalog, buy_quantity,
+  get_quantity, min_spend, starts_at, ends_at, priority, stackable, active,
+  requires_code
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+RETURNING *;
+
+-- name: GetPromotion :one
+SELECT * FROM promotions
+WHERE shop_name = $1 AND id = $2 LIMIT 1;
+
+-- name: ListPromotions :many
+SELECT * FROM promotions
+WHERE shop_name = $1
+ORDER BY priority DESC, created_at, id;
+
+-- name: ListActivePromotions :many
+SELECT * FROM promotions
+WHERE shop_name = sqlc.arg(shop_name) AND active
+AND (starts_at IS NULL OR starts_at <= sqlc.arg(at))
+AND (ends_at IS NULL OR ends_at > sqlc.arg(at))
+AND (NOT requires_code OR id = sqlc.narg(coupon_promotion_id))
+ORDER BY priority DESC, created_at, id;
+
+-- name: SetPromotionActive :one
+UPDATE promotions
+SET active = $3
+WHERE shop_name = $1 AND id = $2
+RETURNING *;
+
+-- name: DeletePromotion :exec
+DELETE FROM promotions
+WHERE shop_name = $1 AND id = $2;
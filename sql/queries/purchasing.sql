@@ -0,0 +1,96 @@
+-- name: CreateSupplier :one
+INSERT INTO suppliers (id, shop_name, name, email, phone)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetSupplier :one
+SELECT * FROM suppliers
+WHERE shop_name = $1 AND id = $2 LIMIT 1;
+
+-- name: ListSuppliers :many
+SELECT * FROM suppliers
+WHERE shop_name = $1
+ORDER BY name;
+
+-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (id, shop_name, supplier_id, expected_date, note)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetPurchaseOrder :one
+SELECT * FROM purchase_orders
+WHERE shop_name = $1 AND id = $2 LIMIT 1;
+
+-- name: GetPurchaseOrderForUpdate :one
+SELECT * FROM purchase_orders
+WHERE shop_name = $1 AND id = $2 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListPurchaseOrders :many
+SELECT * FROM purchase_orders
+WHERE shop_name = $1
+ORDER BY created_at DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdatePurchaseOrderStatus :one
+UPDATE purchase_orders
+SET status = $3, updated_at = now()
+WHERE shop_name = $1 AND id = $2
+RETURNING *;
+
+-- name: CreatePurchaseOrderLine :one
+INSERT INTO purchase_order_lines (id, purchase_order_id, product_id, quantity_ordered, unit_cost)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListPurchaseOrderLines :many
+SELECT * FROM purchase_order_lines
+WHERE purchase_order_id = $1
+ORDER BY product_id;
+
+-- name: ReceivePurchaseOrderLine :one
+UPDATE purchase_order_lines
+SET quantity_received = quantity_received + $3, unit_cost = $4
+WHERE purchase_order_id = $1 AND id = $2
+RETURNING *;
+
+-- name: CreateProductCost :one
+INSERT INTO product_costs (id, shop_name, product_id, purchase_order_line_id, quantity, unit_cost)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListProductCosts :many
+SELECT * FROM product_costs
+WHERE shop_name = $1 AND product_id = $2
+ORDER BY created_at DESC;
+
+-- name: GetProductMarginReport :many
+WITH costs AS (
+  SELECT product_id, SUM(unit_cost * quantity) / SUM(quantity) AS average_cost
+  FROM product_costs
+  WHERE shop_name = sqlc.arg(shop_name) AND created_at < sqlc.arg(to_time)
+  GROUP BY product_id
+), sales AS (
+  SELECT product_id, SUM(amount) AS sold, SUM(product_price * amount) AS revenue
+  FROM orders
+  WHERE shop_name = sqlc.arg(shop_name) AND status <> 'refunded'
+  AND created_at >= sqlc.arg(from_time) AND created_at < sqlc.arg(to_time)
+  GROUP BY product_id
+)
+SELECT
+  p.id AS product_id,
+  p.name,
+  p.price,
+  ROUND(COALESCE(c.average_cost, 0), 2)::numeric AS average_cost,
+  (p.price - ROUND(COALESCE(c.average_cost, 0), 2))::numeric AS unit_margin,
+  COALESCE(s.sold, 0)::bigint AS sold,
+  COALESCE(s.revenue, 0)::numeric AS revenue,
+  ROUND(COALESCE(s.sold, 0) * COALESCE(c.average_cost, 0), 2)::numeric AS cost_of_goods_sold,
+  (COALESCE(s.revenue, 0) - ROUND(COALESCE(s.sold, 0) * COALESCE(c.average_cost, 0), 2))::numeric AS margin
+FROM products p
+JOIN shops sh ON sh.organisation_id = p.organisation_id
+LEFT JOIN costs c ON c.product_id = p.id
+LEFT JOIN sales s ON s.product_id = p.id
+WHERE sh.name = sqlc.arg(shop_name)
+ORDER BY p.name;
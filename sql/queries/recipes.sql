@@ -0,0 +1,22 @@
+-- name: CreateRecipeItem :one
+INSERT INTO recipe_items (id, product_id, ingredient_id, quantity, unit)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListRecipeItems :many
+SELECT r.* FROM recipe_items r
+JOIN ingredients i ON i.id = r.ingredient_id
+WHERE r.product_id = $1 AND i.shop_name = $2
+ORDER BY r.ingredient_id;
+
+-- name: DeleteRecipeItems :exec
+DELETE FROM recipe_items r
+USING ingredients i
+WHERE i.id = r.ingredient_id AND r.product_id = $1 AND i.shop_name = $2;
+
+-- name: GetRecipeUsage :many
+SELECT r.ingredient_id, r.quantity, r.unit, i.unit AS ingredient_unit
+FROM recipe_items r
+JOIN ingredients i ON i.id = r.ingredient_id
+WHERE r.product_id = $1 AND i.shop_name = $2
+ORDER BY r.ingredient_id;
@@ -0,0 +1,71 @@
+-- name: CreatePayment :one
+INSERT INTO payments (id, shop_name, order_id, method, amount, tendered, check_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListPaymentsByOrderID :many
+SELECT * FROM payments
+WHERE shop_name = $1 AND order_id = $2
+ORDER BY created_at;
+
+-- name: GetDailySales :one
+SELECT
+  COALESCE(SUM(product_price * amount) FILTER (WHERE promotion_id IS NULL AND (fee_id IS NULL OR product_price >= 0)), 0)::numeric AS gross_sales,
+  COALESCE(-SUM(product_price * amount) FILTER (WHERE (promotion_id IS NOT NULL OR (fee_id IS NOT NULL AND product_price < 0)) AND status <> 'refunded'), 0)::numeric AS discounts,
+  COALESCE(SUM(product_price * amount) FILTER (WHERE promotion_id IS NULL AND (fee_id IS NULL OR product_price >= 0) AND status = 'refunded'), 0)::numeric AS refunds,
+  ROUND(COALESCE(SUM(product_price * amount * tax_rate / 100) FILTER (WHERE status <> 'refunded'), 0), 2)::numeric AS tax,
+  COUNT(DISTINCT order_id) AS order_count
+FROM orders
+WHERE shop_name = $1 AND order_day = $2;
+
+-- name: ListDailySales :many
+SELECT
+  order_day,
+  COALESCE(SUM(product_price * amount) FILTER (WHERE promotion_id IS NULL AND (fee_id IS NULL OR product_price >= 0)), 0)::numeric AS gross_sales,
+  COALESCE(-SUM(product_price * amount) FILTER (WHERE (promotion_id IS NOT NULL OR (fee_id IS NOT NULL AND product_price < 0)) AND status <> 'refunded'), 0)::numeric AS discounts,
+  COALESCE(SUM(product_price * amount) FILTER (WHERE promotion_id IS NULL AND (fee_id IS NULL OR product_price >= 0) AND status = 'refunded'), 0)::numeric AS refunds,
+  ROUND(COALESCE(SUM(product_price * amount * tax_rate / 100) FILTER (WHERE status <> 'refunded'), 0), 2)::numeric AS tax,
+  COUNT(DISTINCT order_id) AS order_count
+FROM orders
+WHERE shop_name = sqlc.arg(shop_name) AND order_day >= sqlc.arg(from_day) AND order_day <= sqlc.arg(to_day)
+GROUP BY order_day
+ORDER BY order_day;
+
+-- name: GetDailyTenders :many
+SELECT method, SUM(amount)::numeric AS amount, COUNT(*) AS payments
+FROM payments
+WHERE shop_name = $1 AND order_id IN (
+  SELECT order_id FROM orders WHERE shop_name = $1 AND order_day = $2
+)
+GROUP BY method
+ORDER BY method;
+
+-- name: IsDayClosed :one
+SELECT EXISTS (
+  SELECT 1 FROM z_reports
+  WHERE shop_name = $1 AND business_day = $2
+);
+
+-- name: GetNextZReportNumber :one
+SELECT (COALESCE(MAX(number), 0) + 1)::integer
+FROM z_reports
+WHERE shop_name = $1;
+
+-- name: CreateZReport :one
+INSERT INTO z_reports (
+  id, shop_name, number, business_day, gross_sales, discounts, refunds,
+  net_sales, tax, order_count, average_ticket, tenders
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: GetZReport :one
+SELECT * FROM z_reports
+WHERE shop_name = $1 AND number = $2 LIMIT 1;
+
+-- name: ListZReports :many
+SELECT * FROM z_reports
+WHERE shop_name = $1
+ORDER BY number DESC
+LIMIT $2
+OFFSET $3;
@@ -0,0 +1,57 @@
+-- name: AddOpeningHours :one
+INSERT INTO opening_hours (id, shop_name, weekday, opens_at, closes_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListOpeningHours :many
+SELECT * FROM opening_hours
+WHERE shop_name = $1
+ORDER BY weekday, opens_at;
+
+-- name: DeleteOpeningHours :exec
+DELETE FROM opening_hours
+WHERE shop_name = $1;
+
+-- name: GetOrderSchedule :one
+SELECT * FROM order_schedules
+WHERE shop_name = $1 LIMIT 1;
+
+-- name: UpsertOrderSchedule :one
+INSERT INTO order_schedules (shop_name, max_orders_per_slot, max_items_per_slot, lead_minutes, max_days_ahead)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (shop_name) DO UPDATE
+SET max_orders_per_slot = EXCLUDED.max_orders_per_slot,
+    max_items_per_slot = EXCLUDED.max_items_per_slot,
+    lead_minutes = EXCLUDED.lead_minutes,
+    max_days_ahead = EXCLUDED.max_days_ahead,
+    updated_at = now()
+RETURNING *;
+
+-- name: BookOrderSlot :one
+INSERT INTO order_slots (shop_name, slot_start, orders, items)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (shop_name, slot_start) DO UPDATE
+SET orders = order_slots.orders + EXCLUDED.orders,
+    items = order_slots.items + EXCLUDED.items
+RETURNING *;
+
+-- name: ListOrderSlots :many
+SELECT * FROM order_slots
+WHERE shop_name = sqlc.arg(shop_name)
+AND slot_start >= sqlc.arg(from_time) AND slot_start < sqlc.arg(to_time)
+ORDER BY slot_start;
+
+-- name: CreateOpeningException :one
+INSERT INTO opening_exceptions (id, shop_name, day, closed, opens_at, closes_at, note)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListOpeningExceptions :many
+SELECT * FROM opening_exceptions
+WHERE shop_name = sqlc.arg(shop_name)
+AND day >= sqlc.arg(from_day) AND day <= sqlc.arg(to_day)
+ORDER BY day, opens_at;
+
+-- name: DeleteOpeningException :execrows
+DELETE FROM opening_exceptions
+WHERE shop_name = $1 AND id = $2;
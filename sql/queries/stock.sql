@@ -0,0 +1,90 @@
+-- name: GetStockLevel :one
+SELECT * FROM stock_levels
+WHERE shop_name = $1 AND product_id = $2 LIMIT 1;
+
+-- name: GetStockLevelForUpdate :one
+SELECT * FROM stock_levels
+WHERE shop_name = $1 AND product_id = $2 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListStockLevels :many
+SELECT * FROM stock_levels
+WHERE shop_name = $1
+ORDER BY product_id;
+
+-- name: UpsertStockLevel :one
+INSERT INTO stock_levels (shop_name, product_id, on_hand)
+VALUES ($1, $2, $3)
+ON CONFLICT (shop_name, product_id) DO UPDATE
+SET on_hand = stock_levels.on_hand + EXCLUDED.on_hand, updated_at = now()
+RETURNING *;
+
+-- name: SetStockLevel :one
+INSERT INTO stock_levels (shop_name, product_id, on_hand)
+VALUES ($1, $2, $3)
+ON CONFLICT (shop_name, product_id) DO UPDATE
+SET on_hand = EXCLUDED.on_hand, updated_at = now()
+RETURNING *;
+
+-- name: AddStockLevel :one
+UPDATE stock_levels
+SET on_hand = on_hand + $3, updated_at = now()
+WHERE shop_name = $1 AND product_id = $2
+RETURNING *;
+
+-- name: CreateStockMovement :one
+INSERT INTO stock_movements (id, shop_name, product_id, movement_type, quantity, order_item_id, note)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListStockMovements :many
+SELECT * FROM stock_movements
+WHERE shop_name = $1 AND product_id = $2
+ORDER BY created_at DESC
+LIMIT $3
+OFFSET $4;
+
+-- name: SetStockReorderLevels :one
+INSERT INTO stock_levels (shop_name, product_id, reorder_point, par_level)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (shop_name, product_id) DO UPDATE
+SET reorder_point = EXCLUDED.reorder_point, par_level = EXCLUDED.par_level, updated_at = now()
+RETURNING *;
+
+-- name: ListStockLevelsBelowReorderPoint :many
+SELECT shop_name, product_id, on_hand, updated_at, reorder_point, par_level FROM stock_levels s
+WHERE s.reorder_point > 0 AND s.on_hand < s.reorder_point
+AND NOT EXISTS (
+  SELECT 1 FROM stock_alerts a
+  WHERE a.shop_name = s.shop_name AND a.product_id = s.product_id AND a.resolved_at IS NULL
+)
+ORDER BY s.shop_name, s.product_id;
+
+-- name: CreateStockAlert :one
+INSERT INTO stock_alerts (id, shop_name, product_id, on_hand, reorder_point)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ResolveStockAlerts :many
+UPDATE stock_alerts a
+SET resolved_at = now()
+FROM stock_levels s
+WHERE a.shop_name = s.shop_name AND a.product_id = s.product_id
+AND a.resolved_at IS NULL AND s.on_hand >= s.reorder_point
+RETURNING a.id, a.shop_name, a.product_id, a.on_hand, a.reorder_point, a.created_at, a.resolved_at;
+
+-- name: ListOpenStockAlerts :many
+SELECT * FROM stock_alerts
+WHERE shop_name = $1 AND resolved_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: GetPurchaseSuggestions :many
+SELECT s.product_id, p.name AS product_name, s.on_hand, s.reorder_point, s.par_level,
+  COALESCE(SUM(o.amount), 0)::bigint AS sold
+FROM stock_levels s
+JOIN products p ON p.id = s.product_id
+LEFT JOIN orders o ON o.shop_name = s.shop_name AND o.product_id = s.product_id
+  AND o.status <> 'refunded' AND o.created_at >= sqlc.arg(since)
+WHERE s.shop_name = sqlc.arg(shop_name) AND s.par_level > 0
+GROUP BY s.product_id, p.name, s.on_hand, s.reorder_point, s.par_level
+ORDER BY p.name;
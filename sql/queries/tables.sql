@@ -0,0 +1,102 @@
+-- name: CreateFloorArea :one
+INSERT INTO floor_areas (id, shop_name, name)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetFloorArea :one
+SELECT * FROM floor_areas
+WHERE shop_name = $1 AND id = $2 LIMIT 1;
+
+-- name: ListFloorAreas :many
+SELECT * FROM floor_areas
+WHERE shop_name = $1
+ORDER BY name;
+
+-- name: DeleteFloorArea :execrows
+DELETE FROM floor_areas
+WHERE shop_name = $1 AND id = $2;
+
+-- name: CreateDiningTable :one
+INSERT INTO dining_tables (id, shop_name, area_id, name, capacity, pos_x, pos_y)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetDiningTable :one
+SELECT * FROM dining_tables
+WHERE shop_name = $1 AND id = $2 LIMIT 1;
+
+-- name: GetDiningTableForUpdate :one
+SELECT * FROM dining_tables
+WHERE shop_name = $1 AND id = $2 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListDiningTables :many
+SELECT * FROM dining_tables
+WHERE shop_name = $1
+ORDER BY area_id, name;
+
+-- name: SetDiningTableStatus :one
+UPDATE dining_tables
+SET status = sqlc.arg(status)
+WHERE shop_name = sqlc.arg(shop_name) AND id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteDiningTable :execrows
+DELETE FROM dining_tables
+WHERE shop_name = $1 AND id = $2;
+
+-- name: CreateTab :one
+INSERT INTO tabs (id, shop_name, table_id, order_id, guests)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetTab :one
+SELECT * FROM tabs
+WHERE shop_name = $1 AND id = $2 LIMIT 1;
+
+-- name: GetTabForUpdate :one
+SELECT * FROM tabs
+WHERE shop_name = $1 AND id = $2 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetOpenTabByTable :one
+SELECT * FROM tabs
+WHERE shop_name = $1 AND table_id = $2 AND status = 'open' LIMIT 1;
+
+-- name: ListOpenTabs :many
+SELECT * FROM tabs
+WHERE shop_name = $1 AND status = 'open'
+ORDER BY opened_at, id;
+
+-- name: MoveTab :one
+UPDATE tabs
+SET table_id = sqlc.arg(table_id)
+WHERE shop_name = sqlc.arg(shop_name) AND id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CloseTab :one
+UPDATE tabs
+SET status = sqlc.arg(status), closed_at = now()
+WHERE shop_name = sqlc.arg(shop_name) AND id = sqlc.arg(id)
+RETURNING *;
+
+-- name: MoveOrderItems :execrows
+UPDATE orders
+SET order_id = sqlc.arg(to_order_id)
+WHERE shop_name = sqlc.arg(shop_name) AND order_id = sqlc.arg(from_order_id);
+
+-- name: MovePayments :execrows
+UPDATE payments
+SET order_id = sqlc.arg(to_order_id)
+WHERE shop_name = sqlc.arg(shop_name) AND order_id = sqlc.arg(from_order_id);
+
+-- name: MoveKitchenTickets :execrows
+UPDATE kitchen_tickets
+SET order_id = sqlc.arg(to_order_id)
+WHERE shop_name = sqlc.arg(shop_name) AND order_id = sqlc.arg(from_order_id);
+
+-- name: RotateDiningTableLink :one
+UPDATE dining_tables
+SET link_version = link_version + 1
+WHERE shop_name = $1 AND id = $2
+RETURNING *;
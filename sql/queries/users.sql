@@ -8,4 +8,4 @@ SELECT * FROM users
 WHERE username = $1 LIMIT 1;
 
 -- name: DeleteUser :exec
-DELETE FROM users WHERE id = $1;
\ No newline at end of file
+DELETE FROM users WHERE id = $1;
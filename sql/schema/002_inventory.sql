@@ -0,0 +1,37 @@
+-- +goose Up
+
+ALTER TABLE "orders" ADD COLUMN "product_id" UUID;
+
+CREATE TABLE "stock_levels" (
+  "shop_name" varchar NOT NULL,
+  "product_id" UUID NOT NULL,
+  "on_hand" INTEGER NOT NULL DEFAULT 0,
+  "updated_at" timestamp NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("shop_name", "product_id")
+);
+
+CREATE TABLE "stock_movements" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "product_id" UUID NOT NULL,
+  "movement_type" varchar NOT NULL CHECK (movement_type IN ('receive', 'sale', 'refund', 'waste', 'adjustment', 'count')),
+  "quantity" INTEGER NOT NULL,
+  "order_item_id" UUID,
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "stock_movements" ("shop_name", "product_id", "created_at");
+
+ALTER TABLE "orders" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE SET NULL;
+ALTER TABLE "stock_levels" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "stock_levels" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+ALTER TABLE "stock_movements" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "stock_movements" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+ALTER TABLE "stock_movements" ADD FOREIGN KEY ("order_item_id") REFERENCES "orders" ("id") ON DELETE SET NULL;
+
+
+-- +goose Down
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS stock_levels;
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "product_id";
@@ -0,0 +1,48 @@
+-- +goose Up
+
+CREATE TABLE "ingredients" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "name" varchar NOT NULL CHECK (name <> ''),
+  "unit" varchar NOT NULL CHECK (unit IN ('g', 'kg', 'ml', 'l', 'pcs')),
+  "on_hand" DECIMAL(12,3) NOT NULL DEFAULT 0,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "recipe_items" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "product_id" UUID NOT NULL,
+  "ingredient_id" UUID NOT NULL,
+  "quantity" DECIMAL(12,3) NOT NULL CHECK (quantity > 0),
+  "unit" varchar NOT NULL CHECK (unit IN ('g', 'kg', 'ml', 'l', 'pcs')),
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "ingredient_movements" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "ingredient_id" UUID NOT NULL,
+  "movement_type" varchar NOT NULL CHECK (movement_type IN ('receive', 'sale', 'refund', 'waste', 'adjustment', 'count')),
+  "quantity" DECIMAL(12,3) NOT NULL,
+  "order_item_id" UUID,
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "ingredients" ("shop_name");
+CREATE UNIQUE INDEX ON "recipe_items" ("product_id", "ingredient_id");
+CREATE INDEX ON "ingredient_movements" ("shop_name", "ingredient_id", "created_at");
+CREATE INDEX ON "ingredient_movements" ("order_item_id");
+
+ALTER TABLE "ingredients" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "recipe_items" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+ALTER TABLE "recipe_items" ADD FOREIGN KEY ("ingredient_id") REFERENCES "ingredients" ("id") ON DELETE CASCADE;
+ALTER TABLE "ingredient_movements" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "ingredient_movements" ADD FOREIGN KEY ("ingredient_id") REFERENCES "ingredients" ("id") ON DELETE CASCADE;
+ALTER TABLE "ingredient_movements" ADD FOREIGN KEY ("order_item_id") REFERENCES "orders" ("id") ON DELETE SET NULL;
+
+
+-- +goose Down
+DROP TABLE IF EXISTS ingredient_movements;
+DROP TABLE IF EXISTS recipe_items;
+DROP TABLE IF EXISTS ingredients;
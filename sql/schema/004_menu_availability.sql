@@ -0,0 +1,7 @@
+-- +goose Up
+
+ALTER TABLE "menus" ADD COLUMN "available" BOOLEAN NOT NULL DEFAULT true;
+
+
+-- +goose Down
+ALTER TABLE "menus" DROP COLUMN IF EXISTS "available";
@@ -0,0 +1,26 @@
+-- +goose Up
+
+ALTER TABLE "stock_levels" ADD COLUMN "reorder_point" INTEGER NOT NULL DEFAULT 0 CHECK (reorder_point >= 0);
+ALTER TABLE "stock_levels" ADD COLUMN "par_level" INTEGER NOT NULL DEFAULT 0 CHECK (par_level >= 0);
+
+CREATE TABLE "stock_alerts" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "product_id" UUID NOT NULL,
+  "on_hand" INTEGER NOT NULL,
+  "reorder_point" INTEGER NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "resolved_at" timestamp
+);
+
+-- at most one open alert per product
+CREATE UNIQUE INDEX ON "stock_alerts" ("shop_name", "product_id") WHERE resolved_at IS NULL;
+
+ALTER TABLE "stock_alerts" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "stock_alerts" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+
+
+-- +goose Down
+DROP TABLE IF EXISTS stock_alerts;
+ALTER TABLE "stock_levels" DROP COLUMN IF EXISTS "par_level";
+ALTER TABLE "stock_levels" DROP COLUMN IF EXISTS "reorder_point";
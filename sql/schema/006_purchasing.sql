@@ -0,0 +1,62 @@
+-- +goose Up
+
+CREATE TABLE "suppliers" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "name" varchar NOT NULL CHECK (name <> ''),
+  "email" varchar NOT NULL DEFAULT '',
+  "phone" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("shop_name", "name")
+);
+
+CREATE TABLE "purchase_orders" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "supplier_id" UUID NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'sent', 'partially_received', 'received')),
+  "expected_date" varchar NOT NULL DEFAULT '',
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "purchase_order_lines" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "purchase_order_id" UUID NOT NULL,
+  "product_id" UUID NOT NULL,
+  "quantity_ordered" INTEGER NOT NULL CHECK (quantity_ordered > 0),
+  "quantity_received" INTEGER NOT NULL DEFAULT 0 CHECK (quantity_received >= 0),
+  "unit_cost" DECIMAL(10,2) NOT NULL CHECK (unit_cost >= 0),
+  UNIQUE ("purchase_order_id", "product_id")
+);
+
+-- one row per delivery, the history used to cost the goods sold
+CREATE TABLE "product_costs" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "product_id" UUID NOT NULL,
+  "purchase_order_line_id" UUID,
+  "quantity" INTEGER NOT NULL CHECK (quantity > 0),
+  "unit_cost" DECIMAL(10,2) NOT NULL CHECK (unit_cost >= 0),
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "purchase_orders" ("shop_name", "created_at");
+CREATE INDEX ON "product_costs" ("shop_name", "product_id", "created_at");
+
+ALTER TABLE "suppliers" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "purchase_orders" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "purchase_orders" ADD FOREIGN KEY ("supplier_id") REFERENCES "suppliers" ("id");
+ALTER TABLE "purchase_order_lines" ADD FOREIGN KEY ("purchase_order_id") REFERENCES "purchase_orders" ("id") ON DELETE CASCADE;
+ALTER TABLE "purchase_order_lines" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "product_costs" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "product_costs" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+ALTER TABLE "product_costs" ADD FOREIGN KEY ("purchase_order_line_id") REFERENCES "purchase_order_lines" ("id") ON DELETE SET NULL;
+
+
+-- +goose Down
+DROP TABLE IF EXISTS product_costs;
+DROP TABLE IF EXISTS purchase_order_lines;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS suppliers;
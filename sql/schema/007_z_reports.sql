@@ -0,0 +1,46 @@
+-- +goose Up
+
+-- percentage charged on top of the line price
+ALTER TABLE "orders" ADD COLUMN "tax_rate" DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (tax_rate >= 0 AND tax_rate <= 100);
+
+CREATE TABLE "payments" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "order_id" UUID NOT NULL,
+  "method" varchar NOT NULL CHECK (method IN ('cash', 'card', 'other')),
+  "amount" DECIMAL(10,2) NOT NULL CHECK (amount > 0),
+  "tendered" DECIMAL(10,2) NOT NULL CHECK (tendered >= amount),
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+-- z reports are written once when a business day is closed and never updated
+CREATE TABLE "z_reports" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "number" INTEGER NOT NULL,
+  "business_day" varchar NOT NULL,
+  "gross_sales" DECIMAL(12,2) NOT NULL,
+  "discounts" DECIMAL(12,2) NOT NULL,
+  "refunds" DECIMAL(12,2) NOT NULL,
+  "net_sales" DECIMAL(12,2) NOT NULL,
+  "tax" DECIMAL(12,2) NOT NULL,
+  "order_count" INTEGER NOT NULL,
+  "average_ticket" DECIMAL(12,2) NOT NULL,
+  "tenders" JSONB NOT NULL,
+  "closed_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("shop_name", "number"),
+  UNIQUE ("shop_name", "business_day")
+);
+
+CREATE INDEX ON "payments" ("shop_name", "order_id");
+CREATE INDEX ON "orders" ("shop_name", "order_day");
+
+ALTER TABLE "payments" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "z_reports" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+
+-- +goose Down
+DROP TABLE IF EXISTS z_reports;
+DROP TABLE IF EXISTS payments;
+DROP INDEX IF EXISTS orders_shop_name_order_day_idx;
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "tax_rate";
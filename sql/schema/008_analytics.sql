@@ -0,0 +1,10 @@
+-- +goose Up
+
+ALTER TABLE "users" ADD COLUMN "timezone" varchar NOT NULL DEFAULT 'UTC';
+
+CREATE INDEX ON "orders" ("shop_name", "created_at");
+
+
+-- +goose Down
+DROP INDEX IF EXISTS orders_shop_name_created_at_idx;
+ALTER TABLE "users" DROP COLUMN IF EXISTS "timezone";
@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- keyset pagination of the order history walks (sort column, id)
+DROP INDEX IF EXISTS orders_shop_name_created_at_idx;
+CREATE INDEX ON "orders" ("shop_name", "created_at", "id");
+CREATE INDEX ON "orders" ("shop_name", "amount", "id");
+
+
+-- +goose Down
+DROP INDEX IF EXISTS orders_shop_name_amount_id_idx;
+DROP INDEX IF EXISTS orders_shop_name_created_at_id_idx;
+CREATE INDEX ON "orders" ("shop_name", "created_at");
@@ -0,0 +1,7 @@
+-- +goose Up
+
+ALTER TABLE "users" ADD COLUMN "locale" varchar NOT NULL DEFAULT 'en-US';
+
+
+-- +goose Down
+ALTER TABLE "users" DROP COLUMN IF EXISTS "locale";
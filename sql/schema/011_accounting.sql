@@ -0,0 +1,23 @@
+-- +goose Up
+
+-- ledger accounts the journal export posts to, shops without a row use the
+-- defaults of internal/accounting
+CREATE TABLE "account_mappings" (
+  "shop_name" varchar PRIMARY KEY NOT NULL,
+  "cash_clearing" varchar NOT NULL CHECK (cash_clearing <> ''),
+  "card_clearing" varchar NOT NULL CHECK (card_clearing <> ''),
+  "other_clearing" varchar NOT NULL CHECK (other_clearing <> ''),
+  "sales" varchar NOT NULL CHECK (sales <> ''),
+  -- tax rate, e.g. "5.00", to sales account, rates not listed post to sales
+  "sales_by_tax_rate" JSONB NOT NULL DEFAULT '{}',
+  "tax_payable" varchar NOT NULL CHECK (tax_payable <> ''),
+  "refund_clearing" varchar NOT NULL CHECK (refund_clearing <> ''),
+  "receivable" varchar NOT NULL CHECK (receivable <> ''),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "account_mappings" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+
+-- +goose Down
+DROP TABLE IF EXISTS account_mappings;
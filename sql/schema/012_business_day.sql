@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- minutes after local midnight at which the shop's business day rolls over,
+-- e.g. 240 keeps sales until 04:00 on the previous day
+ALTER TABLE "users" ADD COLUMN "business_day_cutoff" INTEGER NOT NULL DEFAULT 0 CHECK (business_day_cutoff >= 0 AND business_day_cutoff < 1440);
+
+
+-- +goose Down
+ALTER TABLE "users" DROP COLUMN IF EXISTS "business_day_cutoff";
@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- free text printed above and below every receipt, one line per newline
+ALTER TABLE "users" ADD COLUMN "receipt_header" TEXT NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "receipt_footer" TEXT NOT NULL DEFAULT '';
+
+
+-- +goose Down
+ALTER TABLE "users" DROP COLUMN IF EXISTS "receipt_footer";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "receipt_header";
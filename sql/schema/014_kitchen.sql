@@ -0,0 +1,63 @@
+-- +goose Up
+
+-- preparation stations of a shop, e.g. bar and grill. order items that match
+-- no route go to the default station, or print nowhere if there is none
+CREATE TABLE "stations" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "name" varchar NOT NULL CHECK (name <> ''),
+  "is_default" BOOLEAN NOT NULL DEFAULT false,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("shop_name", "name")
+);
+
+-- send a menu catalog or a single product to a station, a product route
+-- wins over the route of its catalog
+CREATE TABLE "station_routes" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "station_id" UUID NOT NULL,
+  "catalog" varchar,
+  "product_id" UUID,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  CHECK ((catalog IS NULL) <> (product_id IS NULL)),
+  UNIQUE ("shop_name", "catalog"),
+  UNIQUE ("shop_name", "product_id")
+);
+
+-- the part of an order one station prepares, bumped once it is done
+CREATE TABLE "kitchen_tickets" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "order_id" UUID NOT NULL,
+  "station_id" UUID NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'bumped')),
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "bumped_at" timestamp
+);
+
+CREATE TABLE "kitchen_ticket_items" (
+  "ticket_id" UUID NOT NULL,
+  "order_item_id" UUID NOT NULL,
+  PRIMARY KEY ("ticket_id", "order_item_id")
+);
+
+CREATE UNIQUE INDEX ON "stations" ("shop_name") WHERE is_default;
+CREATE INDEX ON "kitchen_tickets" ("shop_name", "status", "created_at");
+CREATE INDEX ON "kitchen_tickets" ("shop_name", "order_id");
+
+ALTER TABLE "stations" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "station_routes" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "station_routes" ADD FOREIGN KEY ("station_id") REFERENCES "stations" ("id") ON DELETE CASCADE;
+ALTER TABLE "station_routes" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+ALTER TABLE "kitchen_tickets" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "kitchen_tickets" ADD FOREIGN KEY ("station_id") REFERENCES "stations" ("id") ON DELETE CASCADE;
+ALTER TABLE "kitchen_ticket_items" ADD FOREIGN KEY ("ticket_id") REFERENCES "kitchen_tickets" ("id") ON DELETE CASCADE;
+ALTER TABLE "kitchen_ticket_items" ADD FOREIGN KEY ("order_item_id") REFERENCES "orders" ("id") ON DELETE CASCADE;
+
+
+-- +goose Down
+DROP TABLE IF EXISTS kitchen_ticket_items;
+DROP TABLE IF EXISTS kitchen_tickets;
+DROP TABLE IF EXISTS station_routes;
+DROP TABLE IF EXISTS stations;
@@ -0,0 +1,45 @@
+-- +goose Up
+
+-- network thermal printers of a shop, reached on their raw TCP port (usually 9100).
+-- a printer bound to a station prints its kitchen tickets
+CREATE TABLE "printers" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "name" varchar NOT NULL CHECK (name <> ''),
+  "address" varchar NOT NULL,
+  "paper_width" INT NOT NULL DEFAULT 80 CHECK (paper_width IN (58, 80)),
+  "station_id" UUID,
+  "prints_receipts" BOOLEAN NOT NULL DEFAULT false,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("shop_name", "name")
+);
+
+-- ESC/POS payloads waiting for their printer, retried with a growing delay
+-- until they print or run out of attempts
+CREATE TABLE "print_jobs" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "printer_id" UUID NOT NULL,
+  "kind" varchar NOT NULL CHECK (kind IN ('receipt', 'kitchen_ticket')),
+  "reference_id" UUID NOT NULL, -- order of a receipt, ticket of a kitchen ticket
+  "payload" BYTEA NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'printing', 'printed', 'failed')),
+  "attempts" INT NOT NULL DEFAULT 0,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "next_attempt_at" timestamp NOT NULL DEFAULT (now()),
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "printed_at" timestamp
+);
+
+CREATE INDEX ON "print_jobs" ("status", "next_attempt_at");
+CREATE INDEX ON "print_jobs" ("shop_name", "created_at");
+
+ALTER TABLE "printers" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "printers" ADD FOREIGN KEY ("station_id") REFERENCES "stations" ("id") ON DELETE SET NULL;
+ALTER TABLE "print_jobs" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "print_jobs" ADD FOREIGN KEY ("printer_id") REFERENCES "printers" ("id") ON DELETE CASCADE;
+
+
+-- +goose Down
+DROP TABLE IF EXISTS print_jobs;
+DROP TABLE IF EXISTS printers;
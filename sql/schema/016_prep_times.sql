@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- seconds a station needs to prepare one unit of the product, 0 when unknown
+ALTER TABLE "products" ADD COLUMN "prep_seconds" INT NOT NULL DEFAULT 0 CHECK (prep_seconds >= 0);
+
+
+-- +goose Down
+ALTER TABLE "products" DROP COLUMN IF EXISTS "prep_seconds";
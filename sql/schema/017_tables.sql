@@ -0,0 +1,53 @@
+-- +goose Up
+
+-- rooms or zones of a dining floor, e.g. terrace and main room
+CREATE TABLE "floor_areas" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "name" varchar NOT NULL CHECK (name <> ''),
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("shop_name", "name")
+);
+
+-- pos_x and pos_y place the table on its area's floor plan grid
+CREATE TABLE "dining_tables" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "area_id" UUID NOT NULL,
+  "name" varchar NOT NULL CHECK (name <> ''),
+  "capacity" INT NOT NULL CHECK (capacity > 0),
+  "pos_x" INT NOT NULL DEFAULT 0,
+  "pos_y" INT NOT NULL DEFAULT 0,
+  "status" varchar NOT NULL DEFAULT 'free' CHECK (status IN ('free', 'occupied', 'reserved', 'dirty')),
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("shop_name", "name")
+);
+
+-- a running bill on a table. every round ordered on the tab shares its
+-- order_id, so receipts, payments and kitchen tickets see one order
+CREATE TABLE "tabs" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "table_id" UUID,
+  "order_id" UUID NOT NULL,
+  "guests" INT NOT NULL DEFAULT 1 CHECK (guests > 0),
+  "status" varchar NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed', 'merged')),
+  "opened_at" timestamp NOT NULL DEFAULT (now()),
+  "closed_at" timestamp,
+  UNIQUE ("shop_name", "order_id")
+);
+
+CREATE UNIQUE INDEX ON "tabs" ("table_id") WHERE status = 'open';
+CREATE INDEX ON "tabs" ("shop_name", "status");
+
+ALTER TABLE "floor_areas" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "dining_tables" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "dining_tables" ADD FOREIGN KEY ("area_id") REFERENCES "floor_areas" ("id") ON DELETE CASCADE;
+ALTER TABLE "tabs" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "tabs" ADD FOREIGN KEY ("table_id") REFERENCES "dining_tables" ("id") ON DELETE SET NULL;
+
+
+-- +goose Down
+DROP TABLE IF EXISTS tabs;
+DROP TABLE IF EXISTS dining_tables;
+DROP TABLE IF EXISTS floor_areas;
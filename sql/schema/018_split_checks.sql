@@ -0,0 +1,38 @@
+-- +goose Up
+
+-- seat of the guest who ordered the item, 0 for items shared by the table
+ALTER TABLE "orders" ADD COLUMN "seat" INT NOT NULL DEFAULT 0 CHECK (seat >= 0);
+
+-- sub-checks of a split order, numbered from 1
+CREATE TABLE "checks" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "order_id" UUID NOT NULL,
+  "number" INT NOT NULL CHECK (number > 0),
+  "label" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("shop_name", "order_id", "number")
+);
+
+-- an order item is shared between checks in proportion to their parts,
+-- an item on a single check is charged to it in full
+CREATE TABLE "check_lines" (
+  "check_id" UUID NOT NULL,
+  "order_item_id" UUID NOT NULL,
+  "parts" INT NOT NULL CHECK (parts > 0),
+  PRIMARY KEY ("check_id", "order_item_id")
+);
+
+ALTER TABLE "payments" ADD COLUMN "check_id" UUID;
+
+ALTER TABLE "checks" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "check_lines" ADD FOREIGN KEY ("check_id") REFERENCES "checks" ("id") ON DELETE CASCADE;
+ALTER TABLE "check_lines" ADD FOREIGN KEY ("order_item_id") REFERENCES "orders" ("id") ON DELETE CASCADE;
+ALTER TABLE "payments" ADD FOREIGN KEY ("check_id") REFERENCES "checks" ("id") ON DELETE SET NULL;
+
+
+-- +goose Down
+ALTER TABLE "payments" DROP COLUMN IF EXISTS "check_id";
+DROP TABLE IF EXISTS check_lines;
+DROP TABLE IF EXISTS checks;
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "seat";
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- version signed into the table's QR link, bumped to revoke printed links
+ALTER TABLE "dining_tables" ADD COLUMN "link_version" INT NOT NULL DEFAULT 1;
+
+
+-- +goose Down
+ALTER TABLE "dining_tables" DROP COLUMN IF EXISTS "link_version";
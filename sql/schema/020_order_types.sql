@@ -0,0 +1,47 @@
+-- +goose Up
+
+ALTER TABLE "orders" ADD COLUMN "order_type" varchar NOT NULL DEFAULT 'dine_in' CHECK (order_type IN ('dine_in', 'takeaway', 'pickup', 'delivery'));
+
+-- type specific data and fulfilment status of an order, one row per order id.
+-- pickup orders carry the time the customer collects them, delivery orders
+-- the address and who to contact
+CREATE TABLE "order_fulfilments" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "order_id" UUID NOT NULL,
+  "order_type" varchar NOT NULL CHECK (order_type IN ('dine_in', 'takeaway', 'pickup', 'delivery')),
+  "status" varchar NOT NULL DEFAULT 'received',
+  "pickup_at" timestamp,
+  "delivery_address" varchar NOT NULL DEFAULT '',
+  "contact_name" varchar NOT NULL DEFAULT '',
+  "contact_phone" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("shop_name", "order_id")
+);
+
+-- price adjustments of an order type, e.g. a packaging fee on takeaway.
+-- a flat fee is charged once per order, a percent fee on every round,
+-- negative amounts are discounts
+CREATE TABLE "order_type_fees" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "order_type" varchar NOT NULL CHECK (order_type IN ('dine_in', 'takeaway', 'pickup', 'delivery')),
+  "name" varchar NOT NULL CHECK (name <> ''),
+  "kind" varchar NOT NULL CHECK (kind IN ('flat', 'percent')),
+  "amount" DECIMAL(10,2) NOT NULL CHECK (amount <> 0 AND (kind = 'flat' OR (amount >= -100 AND amount <= 100))),
+  "tax_rate" DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (tax_rate >= 0 AND tax_rate <= 100),
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("shop_name", "order_type", "name")
+);
+
+CREATE INDEX ON "order_fulfilments" ("shop_name", "status");
+
+ALTER TABLE "order_fulfilments" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "order_type_fees" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+
+-- +goose Down
+DROP TABLE IF EXISTS order_type_fees;
+DROP TABLE IF EXISTS order_fulfilments;
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "order_type";
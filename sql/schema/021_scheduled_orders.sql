@@ -0,0 +1,54 @@
+-- +goose Up
+
+-- when a shop takes orders, in minutes after local midnight. weekday is
+-- ISO 8601 (1 is Monday), a day can have several ranges, e.g. lunch and dinner
+CREATE TABLE "opening_hours" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "weekday" INT NOT NULL CHECK (weekday >= 1 AND weekday <= 7),
+  "opens_at" INT NOT NULL CHECK (opens_at >= 0 AND opens_at < 1440),
+  "closes_at" INT NOT NULL CHECK (closes_at > opens_at AND closes_at <= 1440),
+  UNIQUE ("shop_name", "weekday", "opens_at")
+);
+
+-- limits of the 15 minute slots customers schedule orders for, 0 is unlimited.
+-- kitchen tickets of a scheduled order reach the kitchen lead_minutes before its slot
+CREATE TABLE "order_schedules" (
+  "shop_name" varchar PRIMARY KEY NOT NULL,
+  "max_orders_per_slot" INT NOT NULL DEFAULT 0 CHECK (max_orders_per_slot >= 0),
+  "max_items_per_slot" INT NOT NULL DEFAULT 0 CHECK (max_items_per_slot >= 0),
+  "lead_minutes" INT NOT NULL DEFAULT 20 CHECK (lead_minutes >= 0),
+  "max_days_ahead" INT NOT NULL DEFAULT 7 CHECK (max_days_ahead > 0),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+-- orders and items booked into a slot, the row is locked while an order is
+-- booked so concurrent orders cannot overbook the slot
+CREATE TABLE "order_slots" (
+  "shop_name" varchar NOT NULL,
+  "slot_start" timestamp NOT NULL,
+  "orders" INT NOT NULL DEFAULT 0,
+  "items" INT NOT NULL DEFAULT 0,
+  PRIMARY KEY ("shop_name", "slot_start")
+);
+
+ALTER TABLE "order_fulfilments" ADD COLUMN "scheduled_for" timestamp;
+
+-- tickets of scheduled orders are held back until release_at
+ALTER TABLE "kitchen_tickets" ADD COLUMN "release_at" timestamp NOT NULL DEFAULT (now());
+ALTER TABLE "kitchen_tickets" ADD COLUMN "released" boolean NOT NULL DEFAULT true;
+
+CREATE INDEX ON "kitchen_tickets" ("release_at") WHERE NOT released;
+
+ALTER TABLE "opening_hours" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "order_schedules" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "order_slots" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+
+-- +goose Down
+ALTER TABLE "kitchen_tickets" DROP COLUMN IF EXISTS "released";
+ALTER TABLE "kitchen_tickets" DROP COLUMN IF EXISTS "release_at";
+ALTER TABLE "order_fulfilments" DROP COLUMN IF EXISTS "scheduled_for";
+DROP TABLE IF EXISTS order_slots;
+DROP TABLE IF EXISTS order_schedules;
+DROP TABLE IF EXISTS opening_hours;
@@ -0,0 +1,35 @@
+-- +goose Up
+
+-- public profile of the shop, display_name falls back to the username
+ALTER TABLE "users" ADD COLUMN "display_name" varchar NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "address" TEXT NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "phone" varchar NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "currency" varchar(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE "users" ADD COLUMN "logo_url" varchar NOT NULL DEFAULT '';
+
+-- dated changes to the weekly opening hours, e.g. a public holiday or late
+-- opening. the ranges of a day replace its weekly hours, a closed row closes
+-- the whole day. day is a calendar day on the shop's clock, like order_day
+CREATE TABLE "opening_exceptions" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "day" varchar NOT NULL,
+  "closed" boolean NOT NULL DEFAULT false,
+  "opens_at" INT NOT NULL DEFAULT 0,
+  "closes_at" INT NOT NULL DEFAULT 0,
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  CHECK (closed OR (opens_at >= 0 AND closes_at > opens_at AND closes_at <= 1440)),
+  UNIQUE ("shop_name", "day", "opens_at")
+);
+
+ALTER TABLE "opening_exceptions" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+
+-- +goose Down
+DROP TABLE IF EXISTS opening_exceptions;
+ALTER TABLE "users" DROP COLUMN IF EXISTS "logo_url";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "currency";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "phone";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "address";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "display_name";
@@ -0,0 +1,228 @@
+-- +goose Up
+
+-- a login used to be a shop. organisations now own the shops and the product
+-- catalogue, and users work at them as members
+CREATE TABLE "organisations" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "name" varchar NOT NULL CHECK (name <> ''),
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+-- name is the shop_name of every shop scoped table and of the public routes,
+-- the settings moved over from users
+CREATE TABLE "shops" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "organisation_id" UUID NOT NULL,
+  "name" varchar UNIQUE NOT NULL CHECK (name <> ''),
+  "display_name" varchar NOT NULL DEFAULT '',
+  "address" TEXT NOT NULL DEFAULT '',
+  "phone" varchar NOT NULL DEFAULT '',
+  "currency" varchar(3) NOT NULL DEFAULT 'USD',
+  "timezone" varchar NOT NULL DEFAULT 'UTC',
+  "locale" varchar NOT NULL DEFAULT 'en-US',
+  "business_day_cutoff" INTEGER NOT NULL DEFAULT 0 CHECK (business_day_cutoff >= 0 AND business_day_cutoff < 1440),
+  "receipt_header" TEXT NOT NULL DEFAULT '',
+  "receipt_footer" TEXT NOT NULL DEFAULT '',
+  "logo_url" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+-- members without a shop work at every shop of the organisation, the others
+-- only at theirs. owners always cover the whole organisation
+CREATE TABLE "organisation_members" (
+  "organisation_id" UUID NOT NULL,
+  "user_id" UUID NOT NULL,
+  "shop_id" UUID,
+  "role" varchar NOT NULL CHECK (role IN ('owner', 'manager', 'staff')),
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("organisation_id", "user_id"),
+  CHECK (role <> 'owner' OR shop_id IS NULL)
+);
+
+CREATE INDEX ON "shops" ("organisation_id");
+CREATE INDEX ON "organisation_members" ("user_id");
+
+-- every login becomes an organisation owning a single shop of the same name,
+-- the ids are reused so products keep pointing at their owner
+INSERT INTO organisations (id, name, created_at)
+SELECT id, username, created_at FROM users;
+
+INSERT INTO shops (id, organisation_id, name, display_name, address, phone, currency, timezone, locale, business_day_cutoff, receipt_header, receipt_footer, logo_url, created_at)
+SELECT id, id, username, display_name, address, phone, currency, timezone, locale, business_day_cutoff, receipt_header, receipt_footer, logo_url, created_at FROM users;
+
+INSERT INTO organisation_members (organisation_id, user_id, role, created_at)
+SELECT id, id, 'owner', created_at FROM users;
+
+ALTER TABLE "shops" ADD FOREIGN KEY ("organisation_id") REFERENCES "organisations" ("id") ON DELETE CASCADE;
+ALTER TABLE "organisation_members" ADD FOREIGN KEY ("organisation_id") REFERENCES "organisations" ("id") ON DELETE CASCADE;
+ALTER TABLE "organisation_members" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+ALTER TABLE "organisation_members" ADD FOREIGN KEY ("shop_id") REFERENCES "shops" ("id") ON DELETE CASCADE;
+
+-- products are shared by the shops of an organisation, each shop prices them
+-- on its own menu
+ALTER TABLE "products" DROP CONSTRAINT "products_user_id_fkey";
+ALTER TABLE "products" RENAME COLUMN "user_id" TO "organisation_id";
+ALTER TABLE "products" ADD FOREIGN KEY ("organisation_id") REFERENCES "organisations" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "menus" DROP COLUMN "user_id";
+ALTER TABLE "menus" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+CREATE INDEX ON "menus" ("shop_name");
+
+ALTER TABLE "orders" DROP CONSTRAINT "orders_shop_name_fkey";
+ALTER TABLE "orders" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "stock_levels" DROP CONSTRAINT "stock_levels_shop_name_fkey";
+ALTER TABLE "stock_levels" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "stock_movements" DROP CONSTRAINT "stock_movements_shop_name_fkey";
+ALTER TABLE "stock_movements" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "ingredients" DROP CONSTRAINT "ingredients_shop_name_fkey";
+ALTER TABLE "ingredients" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "ingredient_movements" DROP CONSTRAINT "ingredient_movements_shop_name_fkey";
+ALTER TABLE "ingredient_movements" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "stock_alerts" DROP CONSTRAINT "stock_alerts_shop_name_fkey";
+ALTER TABLE "stock_alerts" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "suppliers" DROP CONSTRAINT "suppliers_shop_name_fkey";
+ALTER TABLE "suppliers" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "purchase_orders" DROP CONSTRAINT "purchase_orders_shop_name_fkey";
+ALTER TABLE "purchase_orders" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "product_costs" DROP CONSTRAINT "product_costs_shop_name_fkey";
+ALTER TABLE "product_costs" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "payments" DROP CONSTRAINT "payments_shop_name_fkey";
+ALTER TABLE "payments" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "z_reports" DROP CONSTRAINT "z_reports_shop_name_fkey";
+ALTER TABLE "z_reports" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "account_mappings" DROP CONSTRAINT "account_mappings_shop_name_fkey";
+ALTER TABLE "account_mappings" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "stations" DROP CONSTRAINT "stations_shop_name_fkey";
+ALTER TABLE "stations" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "station_routes" DROP CONSTRAINT "station_routes_shop_name_fkey";
+ALTER TABLE "station_routes" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "kitchen_tickets" DROP CONSTRAINT "kitchen_tickets_shop_name_fkey";
+ALTER TABLE "kitchen_tickets" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "printers" DROP CONSTRAINT "printers_shop_name_fkey";
+ALTER TABLE "printers" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "print_jobs" DROP CONSTRAINT "print_jobs_shop_name_fkey";
+ALTER TABLE "print_jobs" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "floor_areas" DROP CONSTRAINT "floor_areas_shop_name_fkey";
+ALTER TABLE "floor_areas" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "dining_tables" DROP CONSTRAINT "dining_tables_shop_name_fkey";
+ALTER TABLE "dining_tables" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "tabs" DROP CONSTRAINT "tabs_shop_name_fkey";
+ALTER TABLE "tabs" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "checks" DROP CONSTRAINT "checks_shop_name_fkey";
+ALTER TABLE "checks" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "order_fulfilments" DROP CONSTRAINT "order_fulfilments_shop_name_fkey";
+ALTER TABLE "order_fulfilments" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "order_type_fees" DROP CONSTRAINT "order_type_fees_shop_name_fkey";
+ALTER TABLE "order_type_fees" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "opening_hours" DROP CONSTRAINT "opening_hours_shop_name_fkey";
+ALTER TABLE "opening_hours" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "order_schedules" DROP CONSTRAINT "order_schedules_shop_name_fkey";
+ALTER TABLE "order_schedules" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "order_slots" DROP CONSTRAINT "order_slots_shop_name_fkey";
+ALTER TABLE "order_slots" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "opening_exceptions" DROP CONSTRAINT "opening_exceptions_shop_name_fkey";
+ALTER TABLE "opening_exceptions" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+
+ALTER TABLE "users" DROP COLUMN "display_name";
+ALTER TABLE "users" DROP COLUMN "address";
+ALTER TABLE "users" DROP COLUMN "phone";
+ALTER TABLE "users" DROP COLUMN "currency";
+ALTER TABLE "users" DROP COLUMN "timezone";
+ALTER TABLE "users" DROP COLUMN "locale";
+ALTER TABLE "users" DROP COLUMN "business_day_cutoff";
+ALTER TABLE "users" DROP COLUMN "receipt_header";
+ALTER TABLE "users" DROP COLUMN "receipt_footer";
+ALTER TABLE "users" DROP COLUMN "logo_url";
+
+
+-- +goose Down
+ALTER TABLE "users" ADD COLUMN "display_name" varchar NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "address" TEXT NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "phone" varchar NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "currency" varchar(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE "users" ADD COLUMN "timezone" varchar NOT NULL DEFAULT 'UTC';
+ALTER TABLE "users" ADD COLUMN "locale" varchar NOT NULL DEFAULT 'en-US';
+ALTER TABLE "users" ADD COLUMN "business_day_cutoff" INTEGER NOT NULL DEFAULT 0 CHECK (business_day_cutoff >= 0 AND business_day_cutoff < 1440);
+ALTER TABLE "users" ADD COLUMN "receipt_header" TEXT NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "receipt_footer" TEXT NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "logo_url" varchar NOT NULL DEFAULT '';
+
+UPDATE users u
+SET display_name = s.display_name, address = s.address, phone = s.phone, currency = s.currency, timezone = s.timezone, locale = s.locale, business_day_cutoff = s.business_day_cutoff, receipt_header = s.receipt_header, receipt_footer = s.receipt_footer, logo_url = s.logo_url
+FROM shops s
+WHERE s.name = u.username;
+
+-- shops and organisations that are not also a login cannot be kept
+DELETE FROM shops WHERE name NOT IN (SELECT username FROM users);
+DELETE FROM organisations WHERE id NOT IN (SELECT id FROM users);
+
+ALTER TABLE "orders" DROP CONSTRAINT "orders_shop_name_fkey";
+ALTER TABLE "orders" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "stock_levels" DROP CONSTRAINT "stock_levels_shop_name_fkey";
+ALTER TABLE "stock_levels" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "stock_movements" DROP CONSTRAINT "stock_movements_shop_name_fkey";
+ALTER TABLE "stock_movements" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "ingredients" DROP CONSTRAINT "ingredients_shop_name_fkey";
+ALTER TABLE "ingredients" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "ingredient_movements" DROP CONSTRAINT "ingredient_movements_shop_name_fkey";
+ALTER TABLE "ingredient_movements" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "stock_alerts" DROP CONSTRAINT "stock_alerts_shop_name_fkey";
+ALTER TABLE "stock_alerts" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "suppliers" DROP CONSTRAINT "suppliers_shop_name_fkey";
+ALTER TABLE "suppliers" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "purchase_orders" DROP CONSTRAINT "purchase_orders_shop_name_fkey";
+ALTER TABLE "purchase_orders" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "product_costs" DROP CONSTRAINT "product_costs_shop_name_fkey";
+ALTER TABLE "product_costs" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "payments" DROP CONSTRAINT "payments_shop_name_fkey";
+ALTER TABLE "payments" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "z_reports" DROP CONSTRAINT "z_reports_shop_name_fkey";
+ALTER TABLE "z_reports" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "account_mappings" DROP CONSTRAINT "account_mappings_shop_name_fkey";
+ALTER TABLE "account_mappings" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "stations" DROP CONSTRAINT "stations_shop_name_fkey";
+ALTER TABLE "stations" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "station_routes" DROP CONSTRAINT "station_routes_shop_name_fkey";
+ALTER TABLE "station_routes" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "kitchen_tickets" DROP CONSTRAINT "kitchen_tickets_shop_name_fkey";
+ALTER TABLE "kitchen_tickets" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "printers" DROP CONSTRAINT "printers_shop_name_fkey";
+ALTER TABLE "printers" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "print_jobs" DROP CONSTRAINT "print_jobs_shop_name_fkey";
+ALTER TABLE "print_jobs" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "floor_areas" DROP CONSTRAINT "floor_areas_shop_name_fkey";
+ALTER TABLE "floor_areas" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "dining_tables" DROP CONSTRAINT "dining_tables_shop_name_fkey";
+ALTER TABLE "dining_tables" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "tabs" DROP CONSTRAINT "tabs_shop_name_fkey";
+ALTER TABLE "tabs" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "checks" DROP CONSTRAINT "checks_shop_name_fkey";
+ALTER TABLE "checks" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "order_fulfilments" DROP CONSTRAINT "order_fulfilments_shop_name_fkey";
+ALTER TABLE "order_fulfilments" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "order_type_fees" DROP CONSTRAINT "order_type_fees_shop_name_fkey";
+ALTER TABLE "order_type_fees" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "opening_hours" DROP CONSTRAINT "opening_hours_shop_name_fkey";
+ALTER TABLE "opening_hours" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "order_schedules" DROP CONSTRAINT "order_schedules_shop_name_fkey";
+ALTER TABLE "order_schedules" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "order_slots" DROP CONSTRAINT "order_slots_shop_name_fkey";
+ALTER TABLE "order_slots" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "opening_exceptions" DROP CONSTRAINT "opening_exceptions_shop_name_fkey";
+ALTER TABLE "opening_exceptions" ADD FOREIGN KEY ("shop_name") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "menus" ADD COLUMN "user_id" UUID;
+UPDATE menus m SET user_id = u.id FROM users u WHERE u.username = m.shop_name;
+DELETE FROM menus WHERE user_id IS NULL;
+ALTER TABLE "menus" ALTER COLUMN "user_id" SET NOT NULL;
+ALTER TABLE "menus" DROP CONSTRAINT "menus_shop_name_fkey";
+ALTER TABLE "menus" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+CREATE INDEX ON "menus" ("user_id");
+
+ALTER TABLE "products" DROP CONSTRAINT "products_organisation_id_fkey";
+ALTER TABLE "products" RENAME COLUMN "organisation_id" TO "user_id";
+ALTER TABLE "products" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+DROP TABLE IF EXISTS organisation_members;
+DROP TABLE IF EXISTS shops;
+DROP TABLE IF EXISTS organisations;
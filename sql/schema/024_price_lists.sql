@@ -0,0 +1,62 @@
+-- +goose Up
+
+-- named prices of a shop that override the menu for some orders, e.g. a
+-- delivery markup or happy hour. priority orders the lists of a shop, the
+-- highest priority list that applies and prices an item wins
+CREATE TABLE "price_lists" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "name" varchar NOT NULL CHECK (name <> ''),
+  "priority" INT NOT NULL,
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("shop_name", "name"),
+  UNIQUE ("shop_name", "priority")
+);
+
+-- a list applies to an order matching any of its rules, a list without rules
+-- never applies. an empty channel or order type matches every one and weekday
+-- 0 every day. starts_at and ends_at are minutes after local midnight like
+-- opening hours
+CREATE TABLE "price_list_rules" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "price_list_id" UUID NOT NULL,
+  "channel" varchar NOT NULL DEFAULT '' CHECK (channel IN ('', 'pos', 'qr', 'online')),
+  "order_type" varchar NOT NULL DEFAULT '' CHECK (order_type IN ('', 'dine_in', 'takeaway', 'pickup', 'delivery')),
+  "weekday" INT NOT NULL DEFAULT 0 CHECK (weekday >= 0 AND weekday <= 7),
+  "starts_at" INT NOT NULL DEFAULT 0 CHECK (starts_at >= 0 AND starts_at < 1440),
+  "ends_at" INT NOT NULL DEFAULT 1440 CHECK (ends_at > starts_at AND ends_at <= 1440)
+);
+
+-- a price overrides either a product wherever it is sold or a single menu
+-- item, a menu item price beats a product price of the same list
+CREATE TABLE "price_list_prices" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "price_list_id" UUID NOT NULL,
+  "product_id" UUID,
+  "menu_item_id" UUID,
+  "price" DECIMAL(10,2) NOT NULL CHECK (price >= 0),
+  CHECK ((product_id IS NULL) <> (menu_item_id IS NULL)),
+  UNIQUE ("price_list_id", "product_id"),
+  UNIQUE ("price_list_id", "menu_item_id")
+);
+
+-- the list that priced the line, null when it kept the price it was ordered at
+ALTER TABLE "orders" ADD COLUMN "price_list_id" UUID;
+
+CREATE INDEX ON "price_list_rules" ("price_list_id");
+
+ALTER TABLE "price_lists" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "price_list_rules" ADD FOREIGN KEY ("price_list_id") REFERENCES "price_lists" ("id") ON DELETE CASCADE;
+ALTER TABLE "price_list_prices" ADD FOREIGN KEY ("price_list_id") REFERENCES "price_lists" ("id") ON DELETE CASCADE;
+ALTER TABLE "price_list_prices" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+ALTER TABLE "price_list_prices" ADD FOREIGN KEY ("menu_item_id") REFERENCES "menus" ("id") ON DELETE CASCADE;
+-- a list that priced orders cannot be deleted, it is deactivated instead
+ALTER TABLE "orders" ADD FOREIGN KEY ("price_list_id") REFERENCES "price_lists" ("id");
+
+
+-- +goose Down
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "price_list_id";
+DROP TABLE IF EXISTS price_list_prices;
+DROP TABLE IF EXISTS price_list_rules;
+DROP TABLE IF EXISTS price_lists;
@@ -0,0 +1,52 @@
+-- +goose Up
+
+-- discounts of a shop, evaluated on every round of an order when it is
+-- created. target is what the promotion discounts: the whole round, the
+-- items of a product or the items of a menu catalog. percent and fixed take
+-- amount off, fixed amounts are per unit on items and once per order on the
+-- order. buy_x_get_y takes amount percent off get_quantity of every
+-- buy_quantity + get_quantity units, the cheapest ones.
+-- a promotion applies while active, within starts_at and ends_at and when
+-- the round reaches min_spend. promotions are evaluated by priority, highest
+-- first, each on what earlier ones left of the items. a promotion that is not
+-- stackable only applies when no other did and stops the evaluation.
+CREATE TABLE "promotions" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "name" varchar NOT NULL CHECK (name <> ''),
+  "target" varchar NOT NULL CHECK (target IN ('order', 'product', 'catalog')),
+  "kind" varchar NOT NULL CHECK (kind IN ('percent', 'fixed', 'buy_x_get_y')),
+  "amount" DECIMAL(10,2) NOT NULL CHECK (amount > 0 AND (kind = 'fixed' OR amount <= 100)),
+  "product_id" UUID,
+  "catalog" varchar NOT NULL DEFAULT '',
+  "buy_quantity" INT NOT NULL DEFAULT 0 CHECK (buy_quantity >= 0),
+  "get_quantity" INT NOT NULL DEFAULT 0 CHECK (get_quantity >= 0),
+  "min_spend" DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (min_spend >= 0),
+  "starts_at" timestamp,
+  "ends_at" timestamp,
+  "priority" INT NOT NULL DEFAULT 0,
+  "stackable" boolean NOT NULL DEFAULT true,
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("shop_name", "name"),
+  CHECK ((target = 'product') = (product_id IS NOT NULL)),
+  CHECK ((target = 'catalog') = (catalog <> '')),
+  CHECK (kind <> 'buy_x_get_y' OR (target <> 'order' AND buy_quantity > 0 AND get_quantity > 0)),
+  CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at)
+);
+
+-- discount lines carry the promotion they apply, one line per promotion and
+-- tax rate of a round with a negative price
+ALTER TABLE "orders" ADD COLUMN "promotion_id" UUID;
+
+CREATE INDEX ON "promotions" ("shop_name", "active");
+
+ALTER TABLE "promotions" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "promotions" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+-- a promotion that discounted orders cannot be deleted, it is deactivated instead
+ALTER TABLE "orders" ADD FOREIGN KEY ("promotion_id") REFERENCES "promotions" ("id");
+
+
+-- +goose Down
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "promotion_id";
+DROP TABLE IF EXISTS promotions;
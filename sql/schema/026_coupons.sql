@@ -0,0 +1,52 @@
+-- +goose Up
+
+-- promotions that only apply to orders redeeming one of their coupon codes
+ALTER TABLE "promotions" ADD COLUMN "requires_code" boolean NOT NULL DEFAULT false;
+
+-- codes that apply a promotion to an order. max_redemptions limits the
+-- redemptions of the code over all customers and max_per_customer those of
+-- one customer, 0 is unlimited. codes generated in bulk share a batch, are
+-- single use and their per customer limit counts over the whole batch.
+-- redemptions is raised with the limit checked in the same statement so
+-- concurrent orders cannot redeem a code past its limit.
+CREATE TABLE "coupons" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "shop_name" varchar NOT NULL,
+  "promotion_id" UUID NOT NULL,
+  "code" varchar NOT NULL CHECK (code <> ''),
+  "batch_id" UUID,
+  "max_redemptions" INT NOT NULL DEFAULT 0 CHECK (max_redemptions >= 0),
+  "max_per_customer" INT NOT NULL DEFAULT 0 CHECK (max_per_customer >= 0),
+  "redemptions" INT NOT NULL DEFAULT 0 CHECK (redemptions >= 0 AND (max_redemptions = 0 OR redemptions <= max_redemptions)),
+  "expires_at" timestamp,
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("shop_name", "code")
+);
+
+-- a promotion is redeemed with a code at most once per order. customer is
+-- who redeemed it, e.g. a phone number, empty when unknown
+CREATE TABLE "coupon_redemptions" (
+  "id" UUID UNIQUE PRIMARY KEY NOT NULL,
+  "coupon_id" UUID NOT NULL,
+  "promotion_id" UUID NOT NULL,
+  "order_id" UUID NOT NULL,
+  "customer" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("promotion_id", "order_id")
+);
+
+CREATE INDEX ON "coupons" ("shop_name", "promotion_id");
+CREATE INDEX ON "coupons" ("batch_id");
+CREATE INDEX ON "coupon_redemptions" ("coupon_id", "customer");
+
+ALTER TABLE "coupons" ADD FOREIGN KEY ("shop_name") REFERENCES "shops" ("name") ON DELETE CASCADE;
+ALTER TABLE "coupons" ADD FOREIGN KEY ("promotion_id") REFERENCES "promotions" ("id") ON DELETE CASCADE;
+ALTER TABLE "coupon_redemptions" ADD FOREIGN KEY ("coupon_id") REFERENCES "coupons" ("id") ON DELETE CASCADE;
+ALTER TABLE "coupon_redemptions" ADD FOREIGN KEY ("promotion_id") REFERENCES "promotions" ("id") ON DELETE CASCADE;
+
+
+-- +goose Down
+DROP TABLE IF EXISTS coupon_redemptions;
+DROP TABLE IF EXISTS coupons;
+ALTER TABLE "promotions" DROP COLUMN IF EXISTS "requires_code";
@@ -0,0 +1,15 @@
+-- +goose Up
+
+-- fee lines carry the order type fee they charge, like discount lines carry
+-- their promotion, so sales reports tell them apart from products. the id is
+-- kept when the fee is deleted, the lines are history.
+ALTER TABLE "orders" ADD COLUMN "fee_id" UUID;
+
+UPDATE "orders" o SET "fee_id" = f."id"
+FROM "order_type_fees" f
+WHERE o."shop_name" = f."shop_name" AND o."order_type" = f."order_type" AND o."product_name" = f."name"
+AND o."product_id" IS NULL AND o."promotion_id" IS NULL;
+
+
+-- +goose Down
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "fee_id";
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- percentage charged on top of the price of the product, items are taxed at
+-- the rate of their product and never at a rate sent with the order
+ALTER TABLE "products" ADD COLUMN "tax_rate" DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (tax_rate >= 0 AND tax_rate <= 100);
+
+
+-- +goose Down
+ALTER TABLE "products" DROP COLUMN IF EXISTS "tax_rate";
@@ -0,0 +1,57 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/toml5566/go_pos_backend/utils"
+)
+
+func TestTableSigner(t *testing.T) {
+	signer, err := NewTableSigner(utils.RandString(32))
+	require.NoError(t, err)
+
+	claims := TableClaims{
+		ShopName: utils.RandString(6),
+		TableID:  uuid.New(),
+		Version:  3,
+	}
+
+	tableToken := signer.Sign(claims)
+	require.NotEmpty(t, tableToken)
+
+	verified, err := signer.Verify(claims.ShopName, tableToken)
+	require.NoError(t, err)
+	require.Equal(t, claims, verified)
+}
+
+func TestInvalidTableToken(t *testing.T) {
+	signer, err := NewTableSigner(utils.RandString(32))
+	require.NoError(t, err)
+
+	claims := TableClaims{ShopName: "harbourcafe", TableID: uuid.New(), Version: 1}
+	tableToken := signer.Sign(claims)
+
+	// the link of one shop does not work at another
+	_, err = signer.Verify("othershop", tableToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	// a bumped version needs a new signature
+	forged := claims.TableID.String() + ".2." + tableToken[len(tableToken)-22:]
+	_, err = signer.Verify(claims.ShopName, forged)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	other, err := NewTableSigner(utils.RandString(32))
+	require.NoError(t, err)
+	_, err = other.Verify(claims.ShopName, tableToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = signer.Verify(claims.ShopName, "not-a-token")
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestTableSignerKeySize(t *testing.T) {
+	_, err := NewTableSigner(utils.RandString(16))
+	require.ErrorIs(t, err, ErrInvalidKeySize)
+}
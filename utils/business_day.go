@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// business day, formatted like order_day, that the instant t belongs to in a
+// shop whose day rolls over cutoff after local midnight
+func BusinessDay(t time.Time, loc *time.Location, cutoff time.Duration) string {
+	local := t.In(loc)
+
+	// compare wall clock times, subtracting the cutoff would be off by the
+	// shift on days with a daylight saving change
+	wall := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second + time.Duration(local.Nanosecond())
+	if wall < cutoff {
+		local = local.AddDate(0, 0, -1)
+	}
+
+	return local.Format("2006-01-02")
+}
+
+// first instant of a business day, the day is written like order_day
+func BusinessDayStart(day string, loc *time.Location, cutoff time.Duration) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// build the wall clock time so the cutoff holds across daylight saving changes
+	hour, minute := int(cutoff/time.Hour), int(cutoff%time.Hour/time.Minute)
+	return time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, loc), nil
+}
+
+// parse a cutoff written as HH:MM, it must fall before the next midnight
+func ParseCutoff(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("cutoff %q must be written as HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
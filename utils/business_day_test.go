@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusinessDay(t *testing.T) {
+	hongKong, err := time.LoadLocation("Asia/Hong_Kong")
+	require.NoError(t, err)
+
+	// 2024-03-01 18:30 UTC is 02:30 on the 2nd in Hong Kong
+	instant := time.Date(2024, time.March, 1, 18, 30, 0, 0, time.UTC)
+
+	require.Equal(t, "2024-03-01", BusinessDay(instant, time.UTC, 0))
+	require.Equal(t, "2024-03-02", BusinessDay(instant, hongKong, 0))
+	// a 04:00 cutoff keeps the late sale on the previous business day
+	require.Equal(t, "2024-03-01", BusinessDay(instant, hongKong, 4*time.Hour))
+	require.Equal(t, "2024-03-02", BusinessDay(instant.Add(2*time.Hour), hongKong, 4*time.Hour))
+}
+
+func TestBusinessDayStart(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+
+	start, err := BusinessDayStart("2024-03-31", london, 4*time.Hour)
+	require.NoError(t, err)
+	// british summer time starts at 01:00 UTC that morning
+	require.Equal(t, time.Date(2024, time.March, 31, 3, 0, 0, 0, time.UTC), start.UTC())
+	require.Equal(t, "2024-03-31", BusinessDay(start, london, 4*time.Hour))
+	require.Equal(t, "2024-03-30", BusinessDay(start.Add(-time.Second), london, 4*time.Hour))
+
+	_, err = BusinessDayStart("31/03/2024", london, 0)
+	require.Error(t, err)
+}
+
+func TestParseCutoff(t *testing.T) {
+	cutoff, err := ParseCutoff("04:30")
+	require.NoError(t, err)
+	require.Equal(t, 4*time.Hour+30*time.Minute, cutoff)
+
+	for _, s := range []string{"24:00", "4am", ""} {
+		_, err := ParseCutoff(s)
+		require.Error(t, err, s)
+	}
+}
@@ -15,6 +15,10 @@ type Config struct {
 	ServerAddress       string        `mapstructure:"SERVER_ADDRESS"`
 	TokenSecretKey      string        `mapstructure:"TOKEN_SECRET_KEY"`
 	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	AlertInterval       time.Duration `mapstructure:"ALERT_INTERVAL"`
+	PrintInterval       time.Duration `mapstructure:"PRINT_INTERVAL"`
+	ReleaseInterval     time.Duration `mapstructure:"RELEASE_INTERVAL"` // how often held tickets of scheduled orders are released
+	PublicOrderURL      string        `mapstructure:"PUBLIC_ORDER_URL"` // base of the table QR links, no links are made when empty
 }
 
 func LoadConfig(path string) (config Config, err error) {
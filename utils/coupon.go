@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+// letters and digits of generated coupon codes, without the ones easily
+// misread on a printed flyer (0/O, 1/I/L)
+const couponAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// coupon codes are matched case insensitively and without surrounding spaces
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// customers are matched the same way, so a phone number or email address
+// typed differently still counts against the same limit
+func NormalizeCustomer(customer string) string {
+	return strings.ToLower(strings.Join(strings.Fields(customer), ""))
+}
+
+// NewCouponCode returns the prefix followed by n random characters. the
+// characters come from crypto/rand since codes of a batch must not be
+// guessable from one another
+func NewCouponCode(prefix string, n int) (string, error) {
+	var sb strings.Builder
+	sb.WriteString(NormalizeCouponCode(prefix))
+
+	max := big.NewInt(int64(len(couponAlphabet)))
+	for i := 0; i < n; i++ {
+		k, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(couponAlphabet[k.Int64()])
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCouponCode(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		code, err := NewCouponCode("spring-", 8)
+		require.NoError(t, err)
+		require.Len(t, code, len("SPRING-")+8)
+		require.True(t, strings.HasPrefix(code, "SPRING-"))
+		require.Equal(t, code, NormalizeCouponCode(code))
+		for _, c := range code[len("SPRING-"):] {
+			require.True(t, strings.ContainsRune(couponAlphabet, c))
+		}
+		require.False(t, seen[code])
+		seen[code] = true
+	}
+}
+
+func TestNormalizeCoupon(t *testing.T) {
+	require.Equal(t, "WELCOME10", NormalizeCouponCode("  welcome10 "))
+	require.Equal(t, "+85291234567", NormalizeCustomer(" +852 9123 4567"))
+	require.Equal(t, "ann@example.com", NormalizeCustomer("Ann@Example.com "))
+}
@@ -0,0 +1,17 @@
+package utils
+
+const DefaultCurrency = "USD"
+
+// ISO 4217 codes of the currencies a shop can price in
+var currencies = map[string]bool{
+	"AUD": true, "BRL": true, "CAD": true, "CHF": true, "CNY": true,
+	"CZK": true, "DKK": true, "EUR": true, "GBP": true, "HKD": true,
+	"IDR": true, "INR": true, "JPY": true, "KRW": true, "MXN": true,
+	"MYR": true, "NOK": true, "NZD": true, "PHP": true, "PLN": true,
+	"SEK": true, "SGD": true, "THB": true, "TWD": true, "USD": true,
+	"VND": true, "ZAR": true,
+}
+
+func IsValidCurrency(code string) bool {
+	return currencies[code]
+}
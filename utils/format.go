@@ -6,9 +6,13 @@ import (
 )
 
 func FormattedDateNow() string {
-	return time.Now().Format("2006-01-02")
+	return time.Now().UTC().Format("2006-01-02")
 }
 
 func FormottedDecimalToString(d float64) string {
 	return fmt.Sprintf("%.2f", d)
 }
+
+func FormattedQuantityToString(q float64) string {
+	return fmt.Sprintf("%.3f", q)
+}
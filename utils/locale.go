@@ -0,0 +1,45 @@
+package utils
+
+import "strings"
+
+const DefaultLocale = "en-US"
+
+type Locale struct {
+	DecimalSeparator string
+	DateLayout       string
+	DateTimeLayout   string
+}
+
+var locales = map[string]Locale{
+	"en-US": {".", "01/02/2006", "01/02/2006 15:04:05"},
+	"en-GB": {".", "02/01/2006", "02/01/2006 15:04:05"},
+	"zh-HK": {".", "2006/01/02", "2006/01/02 15:04:05"},
+	"ja-JP": {".", "2006/01/02", "2006/01/02 15:04:05"},
+	"de-DE": {",", "02.01.2006", "02.01.2006 15:04:05"},
+	"fr-FR": {",", "02/01/2006", "02/01/2006 15:04:05"},
+	"es-ES": {",", "02/01/2006", "02/01/2006 15:04:05"},
+	"it-IT": {",", "02/01/2006", "02/01/2006 15:04:05"},
+	"nl-NL": {",", "02-01-2006", "02-01-2006 15:04:05"},
+}
+
+func IsValidLocale(tag string) bool {
+	_, ok := locales[tag]
+	return ok
+}
+
+// unknown tags fall back to the default locale
+func LookupLocale(tag string) Locale {
+	if locale, ok := locales[tag]; ok {
+		return locale
+	}
+	return locales[DefaultLocale]
+}
+
+// rewrite a decimal string as stored in the database, e.g. "12.50", with the
+// decimal separator of the locale
+func (locale Locale) FormatDecimal(d string) string {
+	if locale.DecimalSeparator == "." {
+		return d
+	}
+	return strings.Replace(d, ".", locale.DecimalSeparator, 1)
+}
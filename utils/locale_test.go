@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupLocale(t *testing.T) {
+	require.True(t, IsValidLocale("de-DE"))
+	require.False(t, IsValidLocale("xx-XX"))
+
+	german := LookupLocale("de-DE")
+	require.Equal(t, "12,50", german.FormatDecimal("12.50"))
+	require.Equal(t, "-3", german.FormatDecimal("-3"))
+
+	fallback := LookupLocale("xx-XX")
+	require.Equal(t, LookupLocale(DefaultLocale), fallback)
+	require.Equal(t, "12.50", fallback.FormatDecimal("12.50"))
+}
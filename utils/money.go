@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parse a decimal string with at most two fraction digits, e.g. "-12.5",
+// into an integer number of cents so sums of money stay exact
+func ParseCents(d string) (int64, error) {
+	negative := strings.HasPrefix(d, "-")
+	d = strings.TrimPrefix(d, "-")
+
+	whole, fraction, _ := strings.Cut(d, ".")
+	if len(fraction) > 2 {
+		return 0, fmt.Errorf("more than two decimal places: %s", d)
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	cents, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid decimal %q: %w", d, err)
+	}
+	if negative {
+		cents = -cents
+	}
+	return cents, nil
+}
+
+func FormatCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// percent of an amount of cents, with the percentage in hundredths as parsed
+// by ParseCents, rounded half away from zero to whole cents
+func PercentOfCents(cents, percent int64) int64 {
+	product := cents * percent
+	if product < 0 {
+		return -((-product + 5000) / 10000)
+	}
+	return (product + 5000) / 10000
+}
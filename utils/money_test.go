@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCents(t *testing.T) {
+	for s, want := range map[string]int64{"12.50": 1250, "12.5": 1250, "0": 0, "-3.05": -305, "7": 700} {
+		cents, err := ParseCents(s)
+		require.NoError(t, err)
+		require.Equal(t, want, cents, s)
+	}
+
+	_, err := ParseCents("1.005")
+	require.Error(t, err)
+
+	require.Equal(t, "-3.05", FormatCents(-305))
+	require.Equal(t, "0.00", FormatCents(0))
+}
+
+func TestPercentOfCents(t *testing.T) {
+	// 10% of 12.35 is 1.235, rounded up to 1.24
+	require.Equal(t, int64(124), PercentOfCents(1235, 1000))
+	require.Equal(t, int64(-124), PercentOfCents(1235, -1000))
+	require.Equal(t, int64(31), PercentOfCents(250, 1250))
+	require.Equal(t, int64(0), PercentOfCents(0, 1000))
+}
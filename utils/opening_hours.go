@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// customers schedule orders for slots of this length, slots start on the
+// quarter hour
+const SlotLength = 15 * time.Minute
+
+// a range of a weekday the shop is open, in minutes after local midnight.
+// weekday is ISO 8601, 1 is Monday and 7 is Sunday
+type OpeningRange struct {
+	Weekday  int32
+	OpensAt  int32
+	ClosesAt int32
+}
+
+func ISOWeekday(t time.Time) int32 {
+	weekday := int32(t.Weekday())
+	if weekday == 0 {
+		return 7
+	}
+	return weekday
+}
+
+// start of the slot the instant t falls in
+func SlotStart(t time.Time) time.Time {
+	return t.Truncate(SlotLength)
+}
+
+// a dated change to the weekly hours, day is written like order_day. the
+// ranges of a day replace its weekly hours and a closed exception closes the
+// whole day
+type OpeningException struct {
+	Day      string
+	Closed   bool
+	OpensAt  int32
+	ClosesAt int32
+}
+
+// when a shop is open, a shop without weekly hours is open around the clock
+// except on the days it has exceptions for
+type OpeningHours struct {
+	Weekly     []OpeningRange
+	Exceptions []OpeningException
+}
+
+// reports whether the shop is open at the instant t, read on the wall clock of loc
+func (hours OpeningHours) IsOpen(t time.Time, loc *time.Location) bool {
+	return hours.covers(t, time.Minute, loc)
+}
+
+// reports whether the whole slot starting at start lies within one opening range
+func (hours OpeningHours) IsSlotOpen(start time.Time, loc *time.Location) bool {
+	return hours.covers(start, SlotLength, loc)
+}
+
+func (hours OpeningHours) covers(start time.Time, length time.Duration, loc *time.Location) bool {
+	local := start.In(loc)
+	opens := int32(local.Hour()*60 + local.Minute())
+	closes := opens + int32(length/time.Minute)
+
+	day := local.Format("2006-01-02")
+	var ranges []OpeningRange
+	excepted := false
+	for _, e := range hours.Exceptions {
+		if e.Day != day {
+			continue
+		}
+		if e.Closed {
+			return false
+		}
+		excepted = true
+		ranges = append(ranges, OpeningRange{OpensAt: e.OpensAt, ClosesAt: e.ClosesAt})
+	}
+
+	if !excepted {
+		if len(hours.Weekly) == 0 {
+			return true
+		}
+		weekday := ISOWeekday(local)
+		for _, r := range hours.Weekly {
+			if r.Weekday == weekday {
+				ranges = append(ranges, r)
+			}
+		}
+	}
+
+	for _, r := range ranges {
+		if opens >= r.OpensAt && closes <= r.ClosesAt {
+			return true
+		}
+	}
+	return false
+}
+
+// parse a time of day written as HH:MM into minutes after midnight, 24:00 is
+// the end of the day
+func ParseTimeOfDay(s string) (int32, error) {
+	if s == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("time %q must be written as HH:MM", s)
+	}
+	return int32(t.Hour()*60 + t.Minute()), nil
+}
+
+func FormatTimeOfDay(minutes int32) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
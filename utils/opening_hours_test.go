@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSlotOpen(t *testing.T) {
+	hongKong, err := time.LoadLocation("Asia/Hong_Kong")
+	require.NoError(t, err)
+
+	// lunch and dinner on Mondays, all day on Sundays
+	hours := OpeningHours{Weekly: []OpeningRange{
+		{Weekday: 1, OpensAt: 11 * 60, ClosesAt: 14 * 60},
+		{Weekday: 1, OpensAt: 18 * 60, ClosesAt: 22 * 60},
+		{Weekday: 7, OpensAt: 0, ClosesAt: 24 * 60},
+	}}
+
+	// 2024-03-04 is a Monday
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2024, time.March, 4, hour, minute, 0, 0, hongKong)
+	}
+
+	require.True(t, hours.IsSlotOpen(monday(12, 30), hongKong))
+	require.True(t, hours.IsSlotOpen(monday(11, 0), hongKong))
+	// the slot must end by closing time
+	require.True(t, hours.IsSlotOpen(monday(13, 45), hongKong))
+	require.False(t, hours.IsSlotOpen(monday(14, 0), hongKong))
+	require.False(t, hours.IsSlotOpen(monday(16, 0), hongKong))
+	require.True(t, hours.IsSlotOpen(monday(18, 0), hongKong))
+	// read on the shop's wall clock, 04:30 UTC is 12:30 and 07:00 UTC is 15:00 in Hong Kong
+	require.True(t, hours.IsSlotOpen(time.Date(2024, time.March, 4, 4, 30, 0, 0, time.UTC), hongKong))
+	require.False(t, hours.IsSlotOpen(time.Date(2024, time.March, 4, 7, 0, 0, 0, time.UTC), hongKong))
+	// Sunday closes at midnight
+	require.True(t, hours.IsSlotOpen(time.Date(2024, time.March, 3, 23, 45, 0, 0, hongKong), hongKong))
+	require.False(t, hours.IsSlotOpen(time.Date(2024, time.March, 5, 12, 30, 0, 0, hongKong), hongKong))
+
+	require.True(t, OpeningHours{}.IsSlotOpen(monday(3, 0), hongKong))
+}
+
+func TestOpeningHoursExceptions(t *testing.T) {
+	hours := OpeningHours{
+		Weekly: []OpeningRange{{Weekday: 1, OpensAt: 9 * 60, ClosesAt: 17 * 60}},
+		Exceptions: []OpeningException{
+			// closed for a public holiday
+			{Day: "2024-03-04", Closed: true},
+			// a Tuesday opening for an event
+			{Day: "2024-03-05", OpensAt: 18 * 60, ClosesAt: 23 * 60},
+		},
+	}
+
+	require.False(t, hours.IsOpen(time.Date(2024, time.March, 4, 12, 0, 0, 0, time.UTC), time.UTC))
+	require.True(t, hours.IsOpen(time.Date(2024, time.March, 11, 12, 0, 0, 0, time.UTC), time.UTC))
+	require.True(t, hours.IsOpen(time.Date(2024, time.March, 5, 22, 59, 0, 0, time.UTC), time.UTC))
+	require.False(t, hours.IsOpen(time.Date(2024, time.March, 5, 23, 0, 0, 0, time.UTC), time.UTC))
+	require.False(t, hours.IsOpen(time.Date(2024, time.March, 12, 19, 0, 0, 0, time.UTC), time.UTC))
+
+	// without weekly hours the shop is only closed by its exceptions
+	hours.Weekly = nil
+	require.True(t, hours.IsOpen(time.Date(2024, time.March, 6, 3, 0, 0, 0, time.UTC), time.UTC))
+	require.False(t, hours.IsOpen(time.Date(2024, time.March, 4, 3, 0, 0, 0, time.UTC), time.UTC))
+	require.False(t, hours.IsSlotOpen(time.Date(2024, time.March, 5, 22, 50, 0, 0, time.UTC), time.UTC))
+}
+
+func TestSlotStart(t *testing.T) {
+	instant := time.Date(2024, time.March, 4, 12, 44, 59, 0, time.UTC)
+	require.Equal(t, time.Date(2024, time.March, 4, 12, 30, 0, 0, time.UTC), SlotStart(instant))
+
+	// slots start on the local quarter hour in shops with half hour offsets
+	india, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+	local := SlotStart(time.Date(2024, time.March, 4, 12, 50, 0, 0, india)).In(india)
+	require.Equal(t, 45, local.Minute())
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	minutes, err := ParseTimeOfDay("11:30")
+	require.NoError(t, err)
+	require.Equal(t, int32(690), minutes)
+	require.Equal(t, "11:30", FormatTimeOfDay(minutes))
+
+	minutes, err = ParseTimeOfDay("24:00")
+	require.NoError(t, err)
+	require.Equal(t, int32(1440), minutes)
+	require.Equal(t, "24:00", FormatTimeOfDay(minutes))
+
+	_, err = ParseTimeOfDay("11.30")
+	require.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package utils
+
+// how the customer receives the order
+const (
+	OrderDineIn   = "dine_in"
+	OrderTakeaway = "takeaway"
+	OrderPickup   = "pickup"
+	OrderDelivery = "delivery"
+)
+
+// fulfilment status of an order, the steps an order goes through depend on its type
+const (
+	FulfilmentReceived       = "received"
+	FulfilmentPreparing      = "preparing"
+	FulfilmentReady          = "ready"
+	FulfilmentServed         = "served"
+	FulfilmentPickedUp       = "picked_up"
+	FulfilmentOutForDelivery = "out_for_delivery"
+	FulfilmentDelivered      = "delivered"
+	FulfilmentCancelled      = "cancelled"
+)
+
+// kind of an order type fee, a flat fee is charged once per order and a
+// percent fee on the items of every round
+const (
+	FeeFlat    = "flat"
+	FeePercent = "percent"
+)
+
+var fulfilmentFlows = map[string][]string{
+	OrderDineIn:   {FulfilmentReceived, FulfilmentPreparing, FulfilmentServed},
+	OrderTakeaway: {FulfilmentReceived, FulfilmentPreparing, FulfilmentReady, FulfilmentPickedUp},
+	OrderPickup:   {FulfilmentReceived, FulfilmentPreparing, FulfilmentReady, FulfilmentPickedUp},
+	OrderDelivery: {FulfilmentReceived, FulfilmentPreparing, FulfilmentReady, FulfilmentOutForDelivery, FulfilmentDelivered},
+}
+
+func IsValidOrderType(orderType string) bool {
+	_, ok := fulfilmentFlows[orderType]
+	return ok
+}
+
+// the statuses an order of the type can move to from status. an order only
+// moves forward, skipping steps is allowed, and can be cancelled until it
+// reaches the last step of its flow
+func NextFulfilmentStatuses(orderType, status string) []string {
+	flow := fulfilmentFlows[orderType]
+	for i, step := range flow {
+		if step != status {
+			continue
+		}
+		if i == len(flow)-1 {
+			return nil
+		}
+		next := append([]string{}, flow[i+1:]...)
+		return append(next, FulfilmentCancelled)
+	}
+	return nil
+}
+
+func CanMoveFulfilment(orderType, from, to string) bool {
+	for _, status := range NextFulfilmentStatuses(orderType, from) {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextFulfilmentStatuses(t *testing.T) {
+	require.Equal(t,
+		[]string{FulfilmentReady, FulfilmentOutForDelivery, FulfilmentDelivered, FulfilmentCancelled},
+		NextFulfilmentStatuses(OrderDelivery, FulfilmentPreparing))
+	require.Equal(t, []string{FulfilmentServed, FulfilmentCancelled}, NextFulfilmentStatuses(OrderDineIn, FulfilmentPreparing))
+
+	// finished and cancelled orders stay where they are
+	require.Empty(t, NextFulfilmentStatuses(OrderPickup, FulfilmentPickedUp))
+	require.Empty(t, NextFulfilmentStatuses(OrderPickup, FulfilmentCancelled))
+	require.Empty(t, NextFulfilmentStatuses("drive_through", FulfilmentReceived))
+}
+
+func TestCanMoveFulfilment(t *testing.T) {
+	require.True(t, CanMoveFulfilment(OrderDelivery, FulfilmentReady, FulfilmentOutForDelivery))
+	require.True(t, CanMoveFulfilment(OrderTakeaway, FulfilmentReceived, FulfilmentPickedUp))
+	require.True(t, CanMoveFulfilment(OrderDineIn, FulfilmentReceived, FulfilmentCancelled))
+
+	// out for delivery belongs to delivery orders only
+	require.False(t, CanMoveFulfilment(OrderPickup, FulfilmentReady, FulfilmentOutForDelivery))
+	require.False(t, CanMoveFulfilment(OrderDelivery, FulfilmentReady, FulfilmentPreparing))
+	require.False(t, CanMoveFulfilment(OrderDelivery, FulfilmentDelivered, FulfilmentCancelled))
+}
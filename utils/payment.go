@@ -0,0 +1,7 @@
+package utils
+
+const (
+	PaymentCash  = "cash"
+	PaymentCard  = "card"
+	PaymentOther = "other"
+)
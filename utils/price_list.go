@@ -0,0 +1,40 @@
+package utils
+
+import "time"
+
+// where an order is placed, orders from the QR code of a table are always qr
+const (
+	ChannelPOS    = "pos"
+	ChannelQR     = "qr"
+	ChannelOnline = "online"
+)
+
+// when a price list applies. an empty channel or order type matches every
+// one and weekday 0 every day, weekday is ISO 8601 like opening hours.
+// starts_at and ends_at are minutes after local midnight, a window past
+// midnight takes a rule for each day
+type PriceRule struct {
+	Channel   string
+	OrderType string
+	Weekday   int32
+	StartsAt  int32
+	EndsAt    int32
+}
+
+// reports whether an order of the channel and type placed at the instant t
+// matches the rule, read on the wall clock of loc
+func (rule PriceRule) Matches(channel, orderType string, t time.Time, loc *time.Location) bool {
+	if rule.Channel != "" && rule.Channel != channel {
+		return false
+	}
+	if rule.OrderType != "" && rule.OrderType != orderType {
+		return false
+	}
+
+	local := t.In(loc)
+	if rule.Weekday != 0 && rule.Weekday != ISOWeekday(local) {
+		return false
+	}
+	minute := int32(local.Hour()*60 + local.Minute())
+	return minute >= rule.StartsAt && minute < rule.EndsAt
+}
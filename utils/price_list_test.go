@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceRuleMatches(t *testing.T) {
+	hongKong, err := time.LoadLocation("Asia/Hong_Kong")
+	require.NoError(t, err)
+
+	// happy hour from 17:00 to 19:00 on Fridays, 2024-03-08 is a Friday
+	happyHour := PriceRule{Weekday: 5, StartsAt: 17 * 60, EndsAt: 19 * 60}
+	friday := func(hour, minute int) time.Time {
+		return time.Date(2024, time.March, 8, hour, minute, 0, 0, hongKong)
+	}
+
+	require.True(t, happyHour.Matches(ChannelPOS, OrderDineIn, friday(17, 0), hongKong))
+	require.True(t, happyHour.Matches(ChannelQR, OrderTakeaway, friday(18, 59), hongKong))
+	// the window ends at ends_at
+	require.False(t, happyHour.Matches(ChannelPOS, OrderDineIn, friday(19, 0), hongKong))
+	require.False(t, happyHour.Matches(ChannelPOS, OrderDineIn, friday(16, 59), hongKong))
+	require.False(t, happyHour.Matches(ChannelPOS, OrderDineIn, friday(17, 30).AddDate(0, 0, 1), hongKong))
+	// read on the shop's wall clock, 09:30 UTC is 17:30 in Hong Kong
+	require.True(t, happyHour.Matches(ChannelPOS, OrderDineIn, time.Date(2024, time.March, 8, 9, 30, 0, 0, time.UTC), hongKong))
+	require.False(t, happyHour.Matches(ChannelPOS, OrderDineIn, time.Date(2024, time.March, 8, 17, 30, 0, 0, time.UTC), hongKong))
+
+	// a delivery markup all day on every channel
+	delivery := PriceRule{OrderType: OrderDelivery, EndsAt: 24 * 60}
+	require.True(t, delivery.Matches(ChannelOnline, OrderDelivery, friday(23, 59), hongKong))
+	require.True(t, delivery.Matches(ChannelPOS, OrderDelivery, friday(0, 0), hongKong))
+	require.False(t, delivery.Matches(ChannelOnline, OrderPickup, friday(12, 0), hongKong))
+
+	online := PriceRule{Channel: ChannelOnline, EndsAt: 24 * 60}
+	require.True(t, online.Matches(ChannelOnline, OrderPickup, friday(12, 0), hongKong))
+	require.False(t, online.Matches(ChannelQR, OrderDineIn, friday(12, 0), hongKong))
+}
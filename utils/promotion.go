@@ -0,0 +1,16 @@
+package utils
+
+// what a promotion discounts, the whole round or the items of a product or
+// of a menu catalog
+const (
+	PromotionOrder   = "order"
+	PromotionProduct = "product"
+	PromotionCatalog = "catalog"
+)
+
+// how a promotion discounts, buy_x_get_y only applies to items
+const (
+	PromotionPercent  = "percent"
+	PromotionFixed    = "fixed"
+	PromotionBuyXGetY = "buy_x_get_y"
+)
@@ -0,0 +1,51 @@
+package utils
+
+// order item status, the fee and discount lines the shop adds to an order
+// start pending and items are only refunded through a refund
+const (
+	StatusPending  = "pending"
+	StatusRefunded = "refunded"
+)
+
+// kitchen ticket status, a ticket is bumped once its station is done with it
+const (
+	TicketOpen   = "open"
+	TicketBumped = "bumped"
+)
+
+// print job status, a failed job ran out of attempts and only prints again on a reprint
+const (
+	PrintJobQueued   = "queued"
+	PrintJobPrinting = "printing"
+	PrintJobPrinted  = "printed"
+	PrintJobFailed   = "failed"
+)
+
+// what a print job prints
+const (
+	PrintKindReceipt       = "receipt"
+	PrintKindKitchenTicket = "kitchen_ticket"
+)
+
+// dining table status, a table turns dirty when its guests leave until staff frees it
+const (
+	TableFree     = "free"
+	TableOccupied = "occupied"
+	TableReserved = "reserved"
+	TableDirty    = "dirty"
+)
+
+// tab status, a merged tab handed its items over to another tab
+const (
+	TabOpen   = "open"
+	TabClosed = "closed"
+	TabMerged = "merged"
+)
+
+// role of an organisation member, owners manage the members and every shop,
+// managers the shops and products they cover and staff only run a shop
+const (
+	RoleOwner   = "owner"
+	RoleManager = "manager"
+	RoleStaff   = "staff"
+)
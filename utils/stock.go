@@ -0,0 +1,20 @@
+package utils
+
+const (
+	MovementReceive    = "receive"
+	MovementSale       = "sale"
+	MovementRefund     = "refund"
+	MovementWaste      = "waste"
+	MovementAdjustment = "adjustment"
+	MovementCount      = "count"
+)
+
+// sale and refund movements are posted by the order flow,
+// only the remaining types can be recorded by staff
+func IsValidMovementType(movementType string) bool {
+	switch movementType {
+	case MovementReceive, MovementWaste, MovementAdjustment, MovementCount:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package utils
+
+import "fmt"
+
+const (
+	UnitGram       = "g"
+	UnitKilogram   = "kg"
+	UnitMilliliter = "ml"
+	UnitLiter      = "l"
+	UnitPiece      = "pcs"
+)
+
+type unitInfo struct {
+	dimension string
+	factor    float64 // multiplier to the dimension's base unit
+}
+
+var units = map[string]unitInfo{
+	UnitGram:       {"mass", 1},
+	UnitKilogram:   {"mass", 1000},
+	UnitMilliliter: {"volume", 1},
+	UnitLiter:      {"volume", 1000},
+	UnitPiece:      {"count", 1},
+}
+
+func IsValidUnit(unit string) bool {
+	_, ok := units[unit]
+	return ok
+}
+
+// convert quantity between two units of the same dimension, e.g. kg to g
+func ConvertUnit(quantity float64, from, to string) (float64, error) {
+	fromUnit, ok := units[from]
+	if !ok {
+		return 0, fmt.Errorf("unsupported unit: %s", from)
+	}
+	toUnit, ok := units[to]
+	if !ok {
+		return 0, fmt.Errorf("unsupported unit: %s", to)
+	}
+	if fromUnit.dimension != toUnit.dimension {
+		return 0, fmt.Errorf("cannot convert %s to %s", from, to)
+	}
+
+	return quantity * fromUnit.factor / toUnit.factor, nil
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertUnit(t *testing.T) {
+	testCases := []struct {
+		quantity float64
+		from     string
+		to       string
+		expected float64
+	}{
+		{1.5, UnitKilogram, UnitGram, 1500},
+		{250, UnitGram, UnitKilogram, 0.25},
+		{2, UnitLiter, UnitMilliliter, 2000},
+		{330, UnitMilliliter, UnitLiter, 0.33},
+		{3, UnitPiece, UnitPiece, 3},
+	}
+
+	for _, tc := range testCases {
+		converted, err := ConvertUnit(tc.quantity, tc.from, tc.to)
+		require.NoError(t, err)
+		require.InDelta(t, tc.expected, converted, 1e-9)
+	}
+
+	_, err := ConvertUnit(1, UnitGram, UnitLiter)
+	require.Error(t, err)
+
+	_, err = ConvertUnit(1, "oz", UnitGram)
+	require.Error(t, err)
+}